@@ -0,0 +1,973 @@
+// Code generated by cmd/openapi from api/openapi.json; DO NOT EDIT.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// APIVersion is the version of the API contract this client was generated from
+const APIVersion = "1.0.0"
+
+// BusinessType mirrors the BusinessType schema: Kind of business
+type BusinessType string
+
+const (
+	BusinessTypeRetail  BusinessType = "retail"
+	BusinessTypeService BusinessType = "service"
+	BusinessTypeDigital BusinessType = "digital"
+)
+
+// ConfidenceLevel mirrors the ConfidenceLevel schema: high from a confidence score of 0.75, medium from 0.5, otherwise low
+type ConfidenceLevel string
+
+const (
+	ConfidenceLevelHigh   ConfidenceLevel = "high"
+	ConfidenceLevelMedium ConfidenceLevel = "medium"
+	ConfidenceLevelLow    ConfidenceLevel = "low"
+)
+
+// ConversationStatus mirrors the ConversationStatus schema: Where a clarifying conversation stands
+type ConversationStatus string
+
+const (
+	ConversationStatusAsking   ConversationStatus = "asking"
+	ConversationStatusReady    ConversationStatus = "ready"
+	ConversationStatusComplete ConversationStatus = "complete"
+)
+
+// EmojiPolicy mirrors the EmojiPolicy schema: How freely content may use emoji: none, at most one (sparing) or freely (generous)
+type EmojiPolicy string
+
+const (
+	EmojiPolicyNone     EmojiPolicy = "none"
+	EmojiPolicySparing  EmojiPolicy = "sparing"
+	EmojiPolicyGenerous EmojiPolicy = "generous"
+)
+
+// Layout mirrors the Layout schema: CSV columns: generic, Buffer's bulk upload or Hootsuite's bulk composer
+type Layout string
+
+const (
+	LayoutGeneric   Layout = "generic"
+	LayoutBuffer    Layout = "buffer"
+	LayoutHootsuite Layout = "hootsuite"
+)
+
+// Locale mirrors the Locale schema: Language the consultation is written in: English, Spanish, Portuguese, Swahili or Hindi
+type Locale string
+
+const (
+	LocaleEn Locale = "en"
+	LocaleEs Locale = "es"
+	LocalePt Locale = "pt"
+	LocaleSw Locale = "sw"
+	LocaleHi Locale = "hi"
+)
+
+// MarketingGoal mirrors the MarketingGoal schema: Primary marketing goal
+type MarketingGoal string
+
+const (
+	MarketingGoalAwareness MarketingGoal = "awareness"
+	MarketingGoalSales     MarketingGoal = "sales"
+)
+
+// Parameter mirrors the Parameter schema: A number behind a platform's score: a metadata number on the 1-10 scale or a constraint penalty from 0 to 1
+type Parameter string
+
+const (
+	ParameterReachPotential  Parameter = "reach_potential"
+	ParameterConversionFocus Parameter = "conversion_focus"
+	ParameterBudgetPenalty   Parameter = "budget_penalty"
+	ParameterEffortPenalty   Parameter = "effort_penalty"
+	ParameterVisualPenalty   Parameter = "visual_penalty"
+	ParameterGoalPenalty     Parameter = "goal_penalty"
+)
+
+// Pillar mirrors the Pillar schema: Kind of post: educational, promotional, behind-the-scenes or user-generated content
+type Pillar string
+
+const (
+	PillarEducational     Pillar = "educational"
+	PillarPromotional     Pillar = "promotional"
+	PillarBehindTheScenes Pillar = "behind-the-scenes"
+	PillarUgc             Pillar = "ugc"
+)
+
+// Platform mirrors the Platform schema: Marketing platform
+type Platform string
+
+const (
+	PlatformInstagram        Platform = "Instagram"
+	PlatformFacebook         Platform = "Facebook"
+	PlatformTikTok           Platform = "TikTok"
+	PlatformGoogleMyBusiness Platform = "Google My Business"
+	PlatformWhatsAppBusiness Platform = "WhatsApp Business"
+	PlatformEmailNewsletter  Platform = "Email/Newsletter"
+	PlatformLinkedIn         Platform = "LinkedIn"
+	PlatformYouTube          Platform = "YouTube"
+)
+
+// PolicyAction mirrors the PolicyAction schema: What the content policy checker did about a finding
+type PolicyAction string
+
+const (
+	PolicyActionRewritten PolicyAction = "rewritten"
+	PolicyActionRemoved   PolicyAction = "removed"
+	PolicyActionFlagged   PolicyAction = "flagged"
+)
+
+// Status mirrors the Status schema: Lifecycle state of an asynchronous consultation
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// AnalyzeRequest mirrors the AnalyzeRequest schema. A business whose platform scores to perturb, and how
+type AnalyzeRequest struct {
+	Business BusinessInput `json:"business"`
+	// Monte Carlo runs; defaults to 1000, at most 20000
+	Runs int `json:"runs,omitempty"`
+	// Largest relative perturbation of each parameter, above 0 and at most 1; defaults to 0.2 for ±20%
+	Spread float64 `json:"spread,omitempty"`
+	// Random seed; the same seed gives the same report. Defaults to 1
+	Seed int64 `json:"seed,omitempty"`
+}
+
+// AnswerRequest mirrors the AnswerRequest schema. Answers to a conversation's pending questions
+type AnswerRequest struct {
+	// Answers keyed by question field; a pending question left out is skipped
+	Answers map[string]string `json:"answers"`
+}
+
+// BrandVoice mirrors the BrandVoice schema. How the owner wants generated content to sound
+type BrandVoice struct {
+	Tone *Tone `json:"tone,omitempty"`
+	// Words generated content must never use; sentences and hashtags with them are dropped
+	BannedWords []string    `json:"banned_words,omitempty"`
+	Emoji       EmojiPolicy `json:"emoji,omitempty"`
+	// The owner's recurring lines, worked into content where they fit
+	SignaturePhrases []string `json:"signature_phrases,omitempty"`
+	// Past posts that show the voice; sliders left at 0 take their tone from these
+	SamplePosts []string `json:"sample_posts,omitempty"`
+}
+
+// BusinessInput mirrors the BusinessInput schema. Details of the business to consult for. The legacy field names business_type and monthly_budget are accepted as aliases for type and budget.
+type BusinessInput struct {
+	Type BusinessType `json:"type"`
+	// What the business sells and to whom
+	Description string `json:"description"`
+	// City or region; empty or "online" for online-only businesses
+	Location string `json:"location,omitempty"`
+	// Monthly marketing budget in US dollars
+	Budget float64 `json:"budget,omitempty"`
+	// Channels the business already uses
+	Channels []string      `json:"channels,omitempty"`
+	Goal     MarketingGoal `json:"goal"`
+	// Language for reasons, advice and content; defaults to en
+	Locale Locale `json:"locale,omitempty"`
+	// Brand voice that generated content and advice should follow
+	Voice *BrandVoice `json:"voice,omitempty"`
+	// Name reported results are kept under; consultations for the profile are calibrated by them
+	Profile string `json:"profile,omitempty"`
+}
+
+// Cadence mirrors the Cadence schema. How often and when the calendar posts to a platform
+type Cadence struct {
+	Platform Platform `json:"platform"`
+	// Average posts a week; 1.5 alternates one and two
+	PostsPerWeek float64 `json:"posts_per_week"`
+	HoursPerPost float64 `json:"hours_per_post"`
+	// Local posting time, HH:MM
+	Time string `json:"time"`
+}
+
+// Calendar mirrors the Calendar schema. Weeks of posts across the recommended platforms, each with a content template
+type Calendar struct {
+	// Identifies the business the calendar was planned for; exported events are keyed by it
+	ID           string  `json:"id,omitempty"`
+	Start        string  `json:"start"`
+	End          string  `json:"end"`
+	Weeks        int     `json:"weeks"`
+	HoursPerWeek float64 `json:"hours_per_week"`
+	// Average hours a week the plan takes
+	PlannedHours float64   `json:"planned_hours"`
+	Cadence      []Cadence `json:"cadence"`
+	Slots        []Slot    `json:"slots"`
+}
+
+// Calibration mirrors the Calibration schema. Reach and conversion potential from 1 to 10 re-estimated from the results of the profile and its vertical
+type Calibration struct {
+	ReachPotential  float64 `json:"reach_potential"`
+	ConversionFocus float64 `json:"conversion_focus"`
+	Results         int     `json:"results"`
+	VerticalResults int     `json:"vertical_results"`
+}
+
+// Change mirrors the Change schema. One edited business field; from and to are empty for the brand voice
+type Change struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// CompareRequest mirrors the CompareRequest schema. Two consultations to compare, or one to run again against the current rules
+type CompareRequest struct {
+	Before Snapshot  `json:"before"`
+	After  *Snapshot `json:"after,omitempty"`
+	// Consult before's business again and compare the fresh result with before's; after must be empty
+	Rerun bool `json:"rerun,omitempty"`
+}
+
+// Comparison mirrors the Comparison schema. How the recommendations, risks and advice moved between two consultations
+type Comparison struct {
+	// Label of the earlier consultation, e.g. "revision 2"
+	From string `json:"from,omitempty"`
+	// Label of the later consultation, e.g. "current rules" for a rerun
+	To string `json:"to,omitempty"`
+	// Edited business fields; empty unless both businesses are known
+	Changes []Change `json:"changes"`
+	// Every platform recommended in either consultation, in the later rank order with dropped platforms last
+	Platforms      []PlatformDelta `json:"platforms"`
+	Added          []Platform      `json:"added"`
+	Removed        []Platform      `json:"removed"`
+	Risks          RiskChanges     `json:"risks"`
+	AdviceChanged  bool            `json:"advice_changed"`
+	PersonaChanged bool            `json:"persona_changed"`
+	// One line in the later business's locale, e.g. "Budget rose from $80 to $250 → Facebook ads now recommended"
+	Summary string `json:"summary"`
+}
+
+// Confidence mirrors the Confidence schema. How sure the pipeline is of a recommendation: a score from 0 to 1 weighing the signals, each also from 0 to 1
+type Confidence struct {
+	Score float64         `json:"score"`
+	Level ConfidenceLevel `json:"level"`
+	// How much the business input reveals: a detailed description, a location, existing channels and a budget that fits the goal
+	Completeness float64 `json:"completeness"`
+	// Score gap to the platforms ranked just above and below; a gap of 10 points or more is full
+	Margin float64 `json:"margin"`
+	// Share of LLM review samples that picked the platform too; set only when the review stage ran
+	Agreement float64 `json:"agreement,omitempty"`
+	// Reported results behind the platform's calibration; six of the business's own periods are full
+	Evidence float64 `json:"evidence"`
+}
+
+// Consultation mirrors the Consultation schema. A revision's new consultation and what changed since the profile was last consulted
+type Consultation struct {
+	Revision Revision `json:"revision"`
+	// Comparison with the closest earlier consulted revision; absent on the first consultation
+	Diff *Comparison `json:"diff,omitempty"`
+}
+
+// ConsultationResult mirrors the ConsultationResult schema. Ranked platform recommendations with advice and risks
+type ConsultationResult struct {
+	Recommendations []Recommendation `json:"recommendations"`
+	StrategicAdvice string           `json:"strategic_advice"`
+	Risks           []string         `json:"risks"`
+	Persona         string           `json:"persona"`
+	Metadata        *ResultMetadata  `json:"metadata,omitempty"`
+	// Clarifying questions, most useful first, suggested when any recommendation has low confidence
+	Questions []Question `json:"questions,omitempty"`
+}
+
+// ContentTemplate mirrors the ContentTemplate schema
+type ContentTemplate struct {
+	Hook     string   `json:"hook"`
+	Caption  string   `json:"caption"`
+	CTA      string   `json:"cta"`
+	Hashtags []string `json:"hashtags"`
+}
+
+// Conversation mirrors the Conversation schema. A business input being clarified: follow-up questions while status is asking, the consultation result once it is complete
+type Conversation struct {
+	ID     string             `json:"id"`
+	Status ConversationStatus `json:"status"`
+	// The business input with every answer so far merged in
+	Business BusinessInput `json:"business"`
+	// Follow-ups waiting for answers; empty unless status is asking
+	Questions []Question `json:"questions"`
+	// Fields already asked about; none is asked twice
+	Asked []string `json:"asked"`
+	// Set once status is complete
+	Result    *ConsultationResult `json:"result,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// CostEstimate mirrors the CostEstimate schema. Estimated LLM spend for the consultation; cached stages cost nothing
+type CostEstimate struct {
+	TotalUSD       float64     `json:"total_usd"`
+	Calls          []ModelCall `json:"calls,omitempty"`
+	UnpricedModels []string    `json:"unpriced_models,omitempty"`
+}
+
+// ErrorResponse mirrors the ErrorResponse schema
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// ExploreRequest mirrors the ExploreRequest schema. A base business and what-if variations of it: named variations, a grid of budget, goal and weekly hours values, or both
+type ExploreRequest struct {
+	Business BusinessInput `json:"business"`
+	// The base's weekly content hours; defaults to 3
+	HoursPerWeek float64     `json:"hours_per_week,omitempty"`
+	Variations   []Variation `json:"variations,omitempty"`
+	// Budgets to sweep; tipping points are located between them
+	Budget *Range `json:"budget,omitempty"`
+	// Goals to sweep; each gets its own tipping points
+	Goals []MarketingGoal `json:"goals,omitempty"`
+	// Weekly content hours to sweep; they change the posting cadence, not the ranking
+	Hours *Range `json:"hours,omitempty"`
+	// How many places tipping points track; defaults to 3
+	Top int `json:"top,omitempty"`
+}
+
+// ExportRequest mirrors the ExportRequest schema. A consultation result or content calendar to export; set exactly one
+type ExportRequest struct {
+	Result   *ConsultationResult `json:"result,omitempty"`
+	Calendar *Calendar           `json:"calendar,omitempty"`
+	// The business a result was for; recommendations without content then get a template from the offline library
+	Business *BusinessInput `json:"business,omitempty"`
+	// Language of .ics event titles; defaults to the business's, or English
+	Locale Locale `json:"locale,omitempty"`
+	// First posting day for a result, YYYY-MM-DD; defaults to next Monday
+	Start string `json:"start,omitempty"`
+	// Export only this platform's posts
+	Platform Platform `json:"platform,omitempty"`
+	Layout   Layout   `json:"layout,omitempty"`
+	// Minutes before each post an .ics reminder goes off; 0 for none, defaults to 30
+	ReminderMinutes int `json:"reminder_minutes,omitempty"`
+}
+
+// Fallback mirrors the Fallback schema. A stage that moved from one source to the next, and why
+type Fallback struct {
+	Stage  string `json:"stage"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Reason string `json:"reason"`
+}
+
+// HealthResponse mirrors the HealthResponse schema
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+// Influence mirrors the Influence schema. What moving one parameter to either end of the spread, with the others unperturbed, does
+type Influence struct {
+	Platform  Platform  `json:"platform"`
+	Parameter Parameter `json:"parameter"`
+	// The unperturbed parameter
+	Value float64 `json:"value"`
+	// The parameter at the low end of the spread
+	Low float64 `json:"low"`
+	// The parameter at the high end of the spread
+	High float64 `json:"high"`
+	// The platform's score with the parameter at low
+	ScoreLow float64 `json:"score_low"`
+	// The platform's score with the parameter at high
+	ScoreHigh float64 `json:"score_high"`
+	// Whether either end changes which platforms fill the top places or their order
+	Reorders bool `json:"reorders"`
+}
+
+// Job mirrors the Job schema. An asynchronous consultation; result is set once status is succeeded
+type Job struct {
+	ID        string              `json:"id"`
+	Status    Status              `json:"status"`
+	Input     BusinessInput       `json:"input"`
+	Result    *ConsultationResult `json:"result,omitempty"`
+	Error     string              `json:"error,omitempty"`
+	Attempts  int                 `json:"attempts"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// Matrix mirrors the Matrix schema. Every scenario's ranking side by side, and the budgets where platforms enter or drop out of the top places
+type Matrix struct {
+	Top int `json:"top"`
+	// Every platform a scenario ranks, best rank first
+	Platforms []Platform `json:"platforms"`
+	// The base first, then the variations, then the grid in goal, budget and hours order
+	Scenarios []Scenario `json:"scenarios"`
+	// Located along the budget range for each goal; empty without a budget range
+	TippingPoints []TippingPoint `json:"tipping_points"`
+}
+
+// MetricsResponse mirrors the MetricsResponse schema
+type MetricsResponse struct {
+	// Counters keyed by cache stage: deterministic or llm
+	Cache map[string]Stats `json:"cache"`
+}
+
+// ModelCall mirrors the ModelCall schema. One request to a model with its token usage and estimated cost
+type ModelCall struct {
+	Stage            string  `json:"stage"`
+	Provider         string  `json:"provider"`
+	Model            string  `json:"model"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+	Priced           bool    `json:"priced"`
+}
+
+// PlanRequest mirrors the PlanRequest schema. A business and how many weeks, from when, and how many hours a week to plan for
+type PlanRequest struct {
+	Business BusinessInput `json:"business"`
+	// Weeks to plan, 4 to 12; defaults to 4
+	Weeks int `json:"weeks,omitempty"`
+	// First day, YYYY-MM-DD, moved forward to a Monday; defaults to next Monday
+	Start string `json:"start,omitempty"`
+	// Hours a week the owner can spend on content; defaults to 3
+	HoursPerWeek float64 `json:"hours_per_week,omitempty"`
+}
+
+// PlatformDelta mirrors the PlatformDelta schema. One platform's recommendation in both consultations
+type PlatformDelta struct {
+	Platform Platform `json:"platform"`
+	// Rank in the earlier consultation; 0 when not recommended
+	FromRank int `json:"from_rank"`
+	// Rank in the later consultation; 0 when not recommended
+	ToRank    int     `json:"to_rank"`
+	FromScore float64 `json:"from_score"`
+	ToScore   float64 `json:"to_score"`
+	// Score change, for platforms both consultations recommend
+	ScoreDelta float64 `json:"score_delta"`
+	FromAds    bool    `json:"from_ads"`
+	// Whether the later budget buys ads on the platform rather than only funding organic posts
+	ToAds bool `json:"to_ads"`
+	// Reasoning sentences only the later consultation gave
+	ReasoningAdded []string `json:"reasoning_added,omitempty"`
+	// Reasoning sentences only the earlier consultation gave
+	ReasoningRemoved []string `json:"reasoning_removed,omitempty"`
+}
+
+// PlatformStability mirrors the PlatformStability schema. How one platform's place held up across the runs
+type PlatformStability struct {
+	Platform Platform `json:"platform"`
+	Rank     int      `json:"rank"`
+	Score    float64  `json:"score"`
+	// Score lead over the next-ranked platform; 0 for the last
+	Margin float64 `json:"margin"`
+	// Share of runs in which the platform kept its rank
+	RankStability float64 `json:"rank_stability"`
+	// Share of runs in which the platform ranked within the top places
+	TopShare float64 `json:"top_share"`
+	// Lower bound of the middle 95% of the platform's perturbed scores
+	ScoreLow float64 `json:"score_low"`
+	// Upper bound of the middle 95% of the platform's perturbed scores
+	ScoreHigh float64 `json:"score_high"`
+	// solid from 95% rank stability, likely from 75%, otherwise fragile
+	Verdict string `json:"verdict"`
+}
+
+// PlatformSummary mirrors the PlatformSummary schema. One platform's reach and conversion potential before and after reported results
+type PlatformSummary struct {
+	Platform          Platform    `json:"platform"`
+	CatalogReach      int         `json:"catalog_reach"`
+	CatalogConversion int         `json:"catalog_conversion"`
+	Calibration       Calibration `json:"calibration"`
+	// The profile's own reported results
+	Totals Totals `json:"totals"`
+}
+
+// PolicyFinding mirrors the PolicyFinding schema. A content policy rule a generated template broke; flagged findings are also listed in risks
+type PolicyFinding struct {
+	Rule     string       `json:"rule"`
+	Platform Platform     `json:"platform"`
+	Field    string       `json:"field"`
+	Match    string       `json:"match"`
+	Action   PolicyAction `json:"action"`
+	Concern  string       `json:"concern"`
+}
+
+// Profile mirrors the Profile schema. A stored business and its latest revision
+type Profile struct {
+	Name string `json:"name"`
+	// Latest revision number
+	Revision    int           `json:"revision"`
+	Business    BusinessInput `json:"business"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	ConsultedAt time.Time     `json:"consulted_at,omitempty"`
+}
+
+// Question mirrors the Question schema. A clarifying question whose answer would firm up the recommendations
+type Question struct {
+	// The business input field the answer goes into
+	Field string `json:"field"`
+	Text  string `json:"text"`
+}
+
+// Range mirrors the Range schema. An inclusive sweep from from to to in steps of step; to is always included
+type Range struct {
+	From float64 `json:"from"`
+	To   float64 `json:"to"`
+	Step float64 `json:"step"`
+}
+
+// Ranking mirrors the Ranking schema. One platform's place in a scenario
+type Ranking struct {
+	Platform Platform `json:"platform"`
+	Rank     int      `json:"rank"`
+	Score    float64  `json:"score"`
+}
+
+// Recommendation mirrors the Recommendation schema
+type Recommendation struct {
+	Rank      int      `json:"rank"`
+	Platform  Platform `json:"platform"`
+	Reasoning string   `json:"reasoning"`
+	// Fit score from 0 to 100
+	Score           float64          `json:"score"`
+	ContentTemplate *ContentTemplate `json:"content_template,omitempty"`
+	// How well the content template matches the brand voice; set only with a voice
+	VoiceScore *VoiceScore `json:"voice_score,omitempty"`
+	// How sure the pipeline is of the recommendation
+	Confidence *Confidence `json:"confidence,omitempty"`
+}
+
+// Report mirrors the Report schema. How stable a business's recommendations are when the metadata numbers and constraint penalties behind their scores are perturbed
+type Report struct {
+	Runs   int     `json:"runs"`
+	Spread float64 `json:"spread"`
+	Seed   int64   `json:"seed"`
+	// How many places the recommendations fill
+	Top int `json:"top"`
+	// Share of runs whose top places match the unperturbed ranking in order
+	Stability float64 `json:"stability"`
+	// Share of runs in which the same platforms fill the top places in any order
+	SetStability float64 `json:"set_stability"`
+	// Every platform that passes the filters, in unperturbed rank order; those ranked within top are the recommendations
+	Platforms []PlatformStability `json:"platforms"`
+	// The parameters the top places depend on most, those that reorder them first
+	Influences []Influence `json:"influences"`
+}
+
+// Result mirrors the Result schema. One platform's reported performance for a period; reporting a period again replaces it
+type Result struct {
+	Platform Platform `json:"platform"`
+	Period   string   `json:"period,omitempty"`
+	Reach    int64    `json:"reach"`
+	Clicks   int64    `json:"clicks"`
+	Leads    int64    `json:"leads"`
+	Sales    int64    `json:"sales"`
+}
+
+// ResultMetadata mirrors the ResultMetadata schema. How the result was produced: prompt versions, degraded stages, policy findings and cost
+type ResultMetadata struct {
+	Degraded   bool            `json:"degraded"`
+	Fallbacks  []Fallback      `json:"fallbacks,omitempty"`
+	Prompts    []string        `json:"prompts,omitempty"`
+	Guardrails []PolicyFinding `json:"guardrails,omitempty"`
+	Cost       *CostEstimate   `json:"cost,omitempty"`
+}
+
+// Revision mirrors the Revision schema. One saved version of a profile's business and the consultation run against it
+type Revision struct {
+	Profile   string        `json:"profile"`
+	Number    int           `json:"revision"`
+	Business  BusinessInput `json:"business"`
+	Note      string        `json:"note,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	// The revision's latest consultation; omitted from revision lists
+	Result      *ConsultationResult `json:"result,omitempty"`
+	ConsultedAt time.Time           `json:"consulted_at,omitempty"`
+}
+
+// RiskChanges mirrors the RiskChanges schema. Risks only the later (added) or only the earlier (removed) consultation raised
+type RiskChanges struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// SaveRequest mirrors the SaveRequest schema. A business to store as the profile's next revision, with an optional note on what changed
+type SaveRequest struct {
+	Business BusinessInput `json:"business"`
+	Note     string        `json:"note,omitempty"`
+}
+
+// Scenario mirrors the Scenario schema. One variation of the business and how the deterministic pipeline ranks it
+type Scenario struct {
+	Name         string        `json:"name"`
+	Budget       float64       `json:"budget"`
+	BudgetTier   string        `json:"budget_tier"`
+	Goal         MarketingGoal `json:"goal"`
+	HoursPerWeek float64       `json:"hours_per_week"`
+	// Every platform that passes the filters, not just the top places
+	Rankings []Ranking `json:"rankings"`
+	// Weekly hours shared between the platforms in the top places, as the content calendar would
+	Cadence      []Cadence `json:"cadence"`
+	PlannedHours float64   `json:"planned_hours"`
+}
+
+// Slot mirrors the Slot schema. One post in the calendar
+type Slot struct {
+	Date     string   `json:"date"`
+	Time     string   `json:"time"`
+	Week     int      `json:"week"`
+	Platform Platform `json:"platform"`
+	Pillar   Pillar   `json:"pillar"`
+	// Holiday the post is built around, in the business's locale
+	Holiday    string          `json:"holiday,omitempty"`
+	Template   ContentTemplate `json:"template"`
+	VoiceScore *VoiceScore     `json:"voice_score,omitempty"`
+}
+
+// Snapshot mirrors the Snapshot schema. A consultation result and, when known, the business it was run for
+type Snapshot struct {
+	// Needed to run the consultation again, list edited fields and tell ads from organic posts
+	Business *BusinessInput     `json:"business,omitempty"`
+	Result   ConsultationResult `json:"result"`
+}
+
+// Stats mirrors the Stats schema. Cache lookup counters for one pipeline stage
+type Stats struct {
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	Errors  int64   `json:"errors"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// Summary mirrors the Summary schema. Each platform's catalog estimate next to its estimate calibrated by reported results
+type Summary struct {
+	Profile  string `json:"profile,omitempty"`
+	Vertical string `json:"vertical"`
+	// How many results the upload stored
+	Recorded  int               `json:"recorded,omitempty"`
+	Platforms []PlatformSummary `json:"platforms"`
+}
+
+// TippingPoint mirrors the TippingPoint schema. The budget at which a platform enters or drops out of the top places for one goal
+type TippingPoint struct {
+	Platform Platform      `json:"platform"`
+	Goal     MarketingGoal `json:"goal"`
+	// Lowest budget, to the cent, from which the change holds
+	Budget float64 `json:"budget"`
+	Enters bool    `json:"enters"`
+	// Rank at the tipping budget; 0 when the platform is not ranked
+	Rank int `json:"rank"`
+	// Budget tier just below the tipping budget; it differs from to_tier at a tier boundary
+	FromTier string `json:"from_tier"`
+	ToTier   string `json:"to_tier"`
+}
+
+// Tone mirrors the Tone schema. Position on the brand voice sliders, each from -1 to 1; 0 leaves the axis open
+type Tone struct {
+	// Playful (-1) to formal (1)
+	Formality float64 `json:"formality,omitempty"`
+	// Calm (-1) to enthusiastic (1)
+	Enthusiasm float64 `json:"enthusiasm,omitempty"`
+}
+
+// Totals mirrors the Totals schema. Sums of a platform's reported results
+type Totals struct {
+	Results int   `json:"results"`
+	Reach   int64 `json:"reach"`
+	Clicks  int64 `json:"clicks"`
+	Leads   int64 `json:"leads"`
+	Sales   int64 `json:"sales"`
+}
+
+// UploadRequest mirrors the UploadRequest schema. Reported results for a business profile, as the text of a CSV export or as JSON; set exactly one
+type UploadRequest struct {
+	Business BusinessInput `json:"business"`
+	// CSV with a header row: platform, reach and clicks columns, and optionally period, leads and sales
+	CSV     string   `json:"csv,omitempty"`
+	Results []Result `json:"results,omitempty"`
+}
+
+// Variation mirrors the Variation schema. One named what-if; fields left out keep the base's value
+type Variation struct {
+	// Defaults to a description of the overrides, e.g. "budget $150"
+	Name         string        `json:"name,omitempty"`
+	Budget       float64       `json:"budget,omitempty"`
+	Goal         MarketingGoal `json:"goal,omitempty"`
+	HoursPerWeek float64       `json:"hours_per_week,omitempty"`
+}
+
+// VoiceIssue mirrors the VoiceIssue schema. One way a content template strays from the brand voice, and the points it cost
+type VoiceIssue struct {
+	Check   string  `json:"check"`
+	Field   string  `json:"field,omitempty"`
+	Detail  string  `json:"detail"`
+	Penalty float64 `json:"penalty"`
+}
+
+// VoiceScore mirrors the VoiceScore schema. Brand voice score from 0 to 100 with the measured tone, each issue and what was adjusted
+type VoiceScore struct {
+	Score       float64      `json:"score"`
+	Tone        Tone         `json:"tone"`
+	Issues      []VoiceIssue `json:"issues,omitempty"`
+	Adjustments []string     `json:"adjustments,omitempty"`
+}
+
+// Client calls the consultation API
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// New creates a client for the API served at baseURL, e.g. "http://localhost:8080"
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/"), HTTPClient: http.DefaultClient}
+}
+
+// APIError is returned for non-2xx responses
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// PlanCalendar calls POST /calendar: Plan weeks of posts across the recommended platforms
+func (c *Client) PlanCalendar(ctx context.Context, body PlanRequest) (*Calendar, error) {
+	var result Calendar
+	if err := c.do(ctx, "POST", "/calendar", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateConsultation calls POST /consultations: Queue a consultation and return its job
+func (c *Client) CreateConsultation(ctx context.Context, body BusinessInput) (*Job, error) {
+	var result Job
+	if err := c.do(ctx, "POST", "/consultations", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetConsultation calls GET /consultations/{id}: Get a consultation job's status and result
+func (c *Client) GetConsultation(ctx context.Context, id string) (*Job, error) {
+	var result Job
+	if err := c.do(ctx, "GET", "/consultations/"+url.PathEscape(id), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CancelConsultation calls DELETE /consultations/{id}: Cancel a queued or running consultation job
+func (c *Client) CancelConsultation(ctx context.Context, id string) (*Job, error) {
+	var result Job
+	if err := c.do(ctx, "DELETE", "/consultations/"+url.PathEscape(id), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// StartConversation calls POST /conversations: Ask follow-up questions about missing or ambiguous business details before consulting
+func (c *Client) StartConversation(ctx context.Context, body BusinessInput) (*Conversation, error) {
+	var result Conversation
+	if err := c.do(ctx, "POST", "/conversations", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetConversation calls GET /conversations/{id}: Get a conversation's questions or result
+func (c *Client) GetConversation(ctx context.Context, id string) (*Conversation, error) {
+	var result Conversation
+	if err := c.do(ctx, "GET", "/conversations/"+url.PathEscape(id), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AnswerConversation calls POST /conversations/{id}/answers: Answer a conversation's pending questions
+func (c *Client) AnswerConversation(ctx context.Context, id string, body AnswerRequest) (*Conversation, error) {
+	var result Conversation
+	if err := c.do(ctx, "POST", "/conversations/"+url.PathEscape(id)+"/answers", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CompareConsultations calls POST /diff: Compare two consultations, or run one again against the current rules and compare
+func (c *Client) CompareConsultations(ctx context.Context, body CompareRequest) (*Comparison, error) {
+	var result Comparison
+	if err := c.do(ctx, "POST", "/diff", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetHealth calls GET /health: Check that the server is up
+func (c *Client) GetHealth(ctx context.Context) (*HealthResponse, error) {
+	var result HealthResponse
+	if err := c.do(ctx, "GET", "/health", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetMetrics calls GET /metrics: Cache hit and miss counters
+func (c *Client) GetMetrics(ctx context.Context) (*MetricsResponse, error) {
+	var result MetricsResponse
+	if err := c.do(ctx, "GET", "/metrics", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetOpenAPI calls GET /openapi.json: This OpenAPI document
+func (c *Client) GetOpenAPI(ctx context.Context) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := c.do(ctx, "GET", "/openapi.json", nil, &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// ReportPerformance calls POST /performance: Record a business profile's reported results and recalibrate its platforms
+func (c *Client) ReportPerformance(ctx context.Context, body UploadRequest) (*Summary, error) {
+	var result Summary
+	if err := c.do(ctx, "POST", "/performance", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListProfiles calls GET /profiles: List stored business profiles
+func (c *Client) ListProfiles(ctx context.Context) ([]Profile, error) {
+	var result []Profile
+	if err := c.do(ctx, "GET", "/profiles", nil, &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// GetProfile calls GET /profiles/{name}: Get a profile's latest revision, or the one named by ?revision=, with its consultation
+func (c *Client) GetProfile(ctx context.Context, name string) (*Revision, error) {
+	var result Revision
+	if err := c.do(ctx, "GET", "/profiles/"+url.PathEscape(name), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SaveProfile calls PUT /profiles/{name}: Store a business as the profile's next revision, creating the profile on first save
+func (c *Client) SaveProfile(ctx context.Context, name string, body SaveRequest) (*Revision, error) {
+	var result Revision
+	if err := c.do(ctx, "PUT", "/profiles/"+url.PathEscape(name), body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ConsultProfile calls POST /profiles/{name}/consultations: Consult a profile's latest revision, or the one named by ?revision=, and compare with its last consultation
+func (c *Client) ConsultProfile(ctx context.Context, name string) (*Consultation, error) {
+	var result Consultation
+	if err := c.do(ctx, "POST", "/profiles/"+url.PathEscape(name)+"/consultations", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DiffProfile calls GET /profiles/{name}/diff: Compare the consultations of two revisions of a profile, or one against the current rules
+func (c *Client) DiffProfile(ctx context.Context, name string) (*Comparison, error) {
+	var result Comparison
+	if err := c.do(ctx, "GET", "/profiles/"+url.PathEscape(name)+"/diff", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListProfileRevisions calls GET /profiles/{name}/revisions: List a profile's revisions, oldest first, without their consultations
+func (c *Client) ListProfileRevisions(ctx context.Context, name string) ([]Revision, error) {
+	var result []Revision
+	if err := c.do(ctx, "GET", "/profiles/"+url.PathEscape(name)+"/revisions", nil, &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// RunAgent calls POST /run-agent: Run a consultation
+func (c *Client) RunAgent(ctx context.Context, body BusinessInput) (*ConsultationResult, error) {
+	var result ConsultationResult
+	if err := c.do(ctx, "POST", "/run-agent", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExploreScenarios calls POST /scenarios: Rank what-if variations of a business's budget, goal and weekly hours side by side and find tipping points
+func (c *Client) ExploreScenarios(ctx context.Context, body ExploreRequest) (*Matrix, error) {
+	var result Matrix
+	if err := c.do(ctx, "POST", "/scenarios", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AnalyzeSensitivity calls POST /sensitivity: Perturb the numbers behind a business's platform scores and report how stable its recommendations are
+func (c *Client) AnalyzeSensitivity(ctx context.Context, body AnalyzeRequest) (*Report, error) {
+	var result Report
+	if err := c.do(ctx, "POST", "/sensitivity", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// do sends a JSON request and decodes the JSON response into out
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		raw, _ := io.ReadAll(resp.Body)
+		if json.Unmarshal(raw, &apiErr) != nil || apiErr.Error == "" {
+			apiErr.Error = strings.TrimSpace(string(raw))
+		}
+		return &APIError{StatusCode: resp.StatusCode, Message: apiErr.Error}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+// Package client is a typed Go client for the consultation API, generated
+// from api/openapi.json. Regenerate it after changing the types in
+// internal/core or the routes in internal/openapi:
+//
+//	go generate ./client
+package client
+
+//go:generate go run ../cmd/openapi -spec ../api/openapi.json -client client.gen.go
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"biz-flow/internal/batch"
+)
+
+// runBatch consults every record in a JSONL file
+func runBatch(c *cli, args []string) error {
+	fs := flag.NewFlagSet("batch", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	inputPath := fs.String("input", "", "JSONL file of BusinessInput records (\"-\" for stdin)")
+	outPath := fs.String("out", "-", "where to write JSONL results (\"-\" for stdout)")
+	summaryPath := fs.String("summary", "", "optional file for the JSON summary")
+	workers := fs.Int("workers", 4, "number of concurrent consultations")
+	pf := addPipelineFlags(fs, "memory")
+	format := formatFlag(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *inputPath == "" && fs.NArg() > 0 {
+		*inputPath = fs.Arg(0)
+	}
+	if *inputPath == "" {
+		return usageErrorf("batch requires -input")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var in io.Reader = c.stdin
+	if *inputPath != "-" {
+		file, err := os.Open(*inputPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	var out io.Writer = c.stdout
+	if *outPath != "-" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	consultant, stageCache, err := pf.newAgent()
+	if err != nil {
+		return err
+	}
+	summary, err := batch.Run(ctx, in, out, consultant.Consult, batch.Options{Workers: *workers})
+	if summary != nil {
+		// The summary goes to stderr so stdout stays pure JSONL
+		writeOutput(c.stderr, *format, summary, summary.WriteText, nil)
+		if *format == formatText {
+			writeCacheStats(c.stderr, stageCache)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if *summaryPath != "" {
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*summaryPath, append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+
+	if summary.Failed > 0 {
+		return &exitError{code: exitPartial, err: fmt.Errorf("%d of %d records failed", summary.Failed, summary.Total)}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"biz-flow/internal/cache"
+)
+
+// cacheFlags configures the stage result cache for a command
+type cacheFlags struct {
+	backend string
+	dir     string
+	size    int
+	options cache.Options
+}
+
+// addCacheFlags registers the cache flags on fs with the given default backend
+func addCacheFlags(fs *flag.FlagSet, backend string) *cacheFlags {
+	cf := &cacheFlags{options: cache.DefaultOptions()}
+	fs.StringVar(&cf.backend, "cache", backend, "stage result cache: off, memory or disk")
+	fs.StringVar(&cf.dir, "cache-dir", defaultCacheDir(), "directory for the disk cache")
+	fs.IntVar(&cf.size, "cache-size", cache.DefaultCapacity, "maximum entries in the memory cache")
+	fs.DurationVar(&cf.options.DeterministicTTL, "cache-ttl", cf.options.DeterministicTTL, "how long filter and score results are reused (0 disables)")
+	fs.DurationVar(&cf.options.LLMTTL, "cache-llm-ttl", cf.options.LLMTTL, "how long persona and content results are reused (0 disables)")
+	return cf
+}
+
+// open builds the configured cache; "off" returns a nil cache
+func (cf *cacheFlags) open() (*cache.Cache, error) {
+	switch cf.backend {
+	case "off":
+		return nil, nil
+	case "memory":
+		return cache.New(cache.NewLRU(cf.size), cf.options), nil
+	case "disk":
+		store, err := cache.NewDisk(cf.dir)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := store.Prune(); err != nil {
+			return nil, err
+		}
+		return cache.New(store, cf.options), nil
+	default:
+		return nil, usageErrorf("unknown cache backend %q (want off, memory or disk)", cf.backend)
+	}
+}
+
+// defaultCacheDir places the disk cache under the user's cache directory
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ".bizflow-cache"
+	}
+	return filepath.Join(dir, "bizflow")
+}
+
+// writeCacheStats prints one line of hit/miss counters per cache stage
+func writeCacheStats(w io.Writer, c *cache.Cache) {
+	stats := c.Stats()
+	if stats == nil {
+		return
+	}
+	for _, stage := range cache.Stages() {
+		s := stats[stage]
+		fmt.Fprintf(w, "Cache %-13s %d hits, %d misses (%.0f%% hit rate)\n",
+			stage+":", s.Hits, s.Misses, s.HitRate*100)
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"biz-flow/internal/agent"
+	"biz-flow/internal/calendar"
+	"biz-flow/internal/core"
+	"biz-flow/internal/export"
+)
+
+// runCalendar plans weeks of posts across the recommended platforms
+func runCalendar(c *cli, args []string) error {
+	fs := flag.NewFlagSet("calendar", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	bf := addBusinessFlags(fs)
+	weeks := fs.Int("weeks", calendar.DefaultWeeks, fmt.Sprintf("weeks to plan (%d-%d)", calendar.MinWeeks, calendar.MaxWeeks))
+	start := fs.String("start", "", "first day, YYYY-MM-DD, moved forward to a Monday (default next Monday)")
+	hours := fs.Float64("hours", calendar.DefaultHoursPerWeek, "hours a week the owner can spend on content")
+	formatName := fs.String("format", string(formatText), "output format: text, json, yaml, markdown, csv or ics")
+	ef := addExportFlags(fs)
+	outPath := fs.String("out", "-", "where to write the calendar (\"-\" for stdout)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	var format outputFormat
+	if *formatName != "csv" && *formatName != "ics" {
+		if err := format.Set(*formatName); err != nil {
+			return usageErrorf("unknown format %q (want text, json, yaml, markdown, csv or ics)", *formatName)
+		}
+	}
+	options := calendar.Options{Weeks: *weeks, HoursPerWeek: *hours}
+	if *start != "" {
+		date, err := time.Parse(calendar.DateLayout, *start)
+		if err != nil {
+			return usageErrorf("-start must be a date like 2025-03-03")
+		}
+		options.Start = date
+	}
+
+	business, err := bf.load(c)
+	if err != nil {
+		return err
+	}
+	recommendations, err := agent.New(nil).Recommend(business)
+	if err != nil {
+		return err
+	}
+	plan, err := calendar.NewPlanner().Plan(business, recommendations, options)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = c.stdout
+	if *outPath != "-" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if *formatName == "csv" || *formatName == "ics" {
+		posts, err := export.FromCalendar(plan)
+		if err != nil {
+			return err
+		}
+		return ef.write(out, *formatName, posts, business.Language())
+	}
+	return writeOutput(out, format, plan,
+		func(w io.Writer) error { return writeCalendarText(w, business, plan) },
+		func(w io.Writer) error { return writeCalendarMarkdown(w, business, plan) },
+	)
+}
+
+// writeCalendarText prints a content calendar as plain text, week by week
+func writeCalendarText(w io.Writer, business core.BusinessInput, plan *calendar.Calendar) error {
+	fmt.Fprintf(w, "Business: %s\n", business.String())
+	fmt.Fprintf(w, "Calendar: %s to %s, %d weeks, %.1f of %.1f hours a week\n",
+		plan.Start, plan.End, plan.Weeks, plan.PlannedHours, plan.HoursPerWeek)
+
+	fmt.Fprintln(w, "\nCADENCE:")
+	for _, cadence := range plan.Cadence {
+		fmt.Fprintf(w, "- %s: %s a week at %s, about %s each\n",
+			cadence.Platform, postsPerWeek(cadence.PostsPerWeek), cadence.Time, hoursText(cadence.HoursPerPost))
+	}
+
+	week := 0
+	for _, slot := range plan.Slots {
+		if slot.Week != week {
+			week = slot.Week
+			fmt.Fprintf(w, "\nWEEK %d:\n", week)
+		}
+		fmt.Fprintf(w, "%s %s  %s · %s", weekdayOf(slot.Date), slot.Time, slot.Platform, slot.Pillar)
+		if slot.Holiday != "" {
+			fmt.Fprintf(w, " · %s", slot.Holiday)
+		}
+		fmt.Fprintln(w)
+		if slot.Template != nil {
+			writeTemplateText(w, "   ", slot.Template)
+		}
+		if slot.VoiceScore != nil {
+			fmt.Fprintf(w, "   Voice:   %s\n", voiceSummary(slot.VoiceScore))
+		}
+	}
+
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// writeCalendarMarkdown prints a content calendar as Markdown, a table per week
+func writeCalendarMarkdown(w io.Writer, business core.BusinessInput, plan *calendar.Calendar) error {
+	fmt.Fprintf(w, "# Content calendar\n\n")
+	fmt.Fprintf(w, "**Business:** %s\n\n", business.String())
+	fmt.Fprintf(w, "**Dates:** %s to %s (%d weeks), %.1f of %.1f hours a week\n\n",
+		plan.Start, plan.End, plan.Weeks, plan.PlannedHours, plan.HoursPerWeek)
+
+	fmt.Fprintf(w, "| Platform | Posts a week | Time | Hours per post |\n|---|---|---|---|\n")
+	for _, cadence := range plan.Cadence {
+		fmt.Fprintf(w, "| %s | %s | %s | %s |\n", cadence.Platform, postsPerWeek(cadence.PostsPerWeek), cadence.Time, hoursText(cadence.HoursPerPost))
+	}
+
+	week := 0
+	for _, slot := range plan.Slots {
+		if slot.Week != week {
+			week = slot.Week
+			fmt.Fprintf(w, "\n## Week %d\n\n| Day | Platform | Pillar | Post |\n|---|---|---|---|\n", week)
+		}
+		post := ""
+		if slot.Template != nil {
+			post = fmt.Sprintf("**%s** %s %s", slot.Template.Hook, slot.Template.Caption, slot.Template.CTA)
+			if len(slot.Template.Hashtags) > 0 {
+				post += " " + formatHashtags(slot.Template.Hashtags)
+			}
+		}
+		pillar := string(slot.Pillar)
+		if slot.Holiday != "" {
+			pillar += " (" + slot.Holiday + ")"
+		}
+		fmt.Fprintf(w, "| %s %s %s | %s | %s | %s |\n", weekdayOf(slot.Date), slot.Date, slot.Time, slot.Platform, pillar,
+			strings.ReplaceAll(post, "|", `\|`))
+	}
+	fmt.Fprintln(w)
+
+	return nil
+}
+
+// weekdayOf names the weekday of a YYYY-MM-DD date, e.g. "Mon"
+func weekdayOf(date string) string {
+	parsed, err := time.Parse(calendar.DateLayout, date)
+	if err != nil {
+		return date
+	}
+	return parsed.Format("Mon")
+}
+
+// postsPerWeek describes an average weekly post count, e.g. "1 post" or
+// "1.5 posts"
+func postsPerWeek(n float64) string {
+	if n == 1 {
+		return "1 post"
+	}
+	return fmt.Sprintf("%g posts", n)
+}
+
+// hoursText describes a duration in hours, e.g. "30 min" or "2.5 h"
+func hoursText(hours float64) string {
+	if hours < 1 {
+		return fmt.Sprintf("%.0f min", hours*60)
+	}
+	return fmt.Sprintf("%g h", hours)
+}
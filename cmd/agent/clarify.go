@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"biz-flow/internal/clarify"
+	"biz-flow/internal/core"
+)
+
+// clarifyBusiness asks the follow-up questions a business input calls for,
+// one line at a time, and returns the input with the answers merged in
+func clarifyBusiness(c *cli, business core.BusinessInput) (core.BusinessInput, error) {
+	conversation, err := clarify.Start(business)
+	if err != nil {
+		return business, err
+	}
+	if conversation.Status == clarify.StatusReady {
+		return conversation.Business, nil
+	}
+
+	lines := newLineReader(c.stdin)
+	fmt.Fprintln(c.stderr, "A few questions before consulting. Press Enter to skip one.")
+	for conversation.Status == clarify.StatusAsking {
+		answers := make(map[string]string, len(conversation.Questions))
+		for _, question := range conversation.Questions {
+			answer, err := askFollowUp(c, lines, conversation, question)
+			if err != nil {
+				return business, err
+			}
+			answers[question.Field] = answer
+		}
+		if err := conversation.Answer(answers); err != nil {
+			return business, err
+		}
+	}
+	return conversation.Business, nil
+}
+
+// askFollowUp repeats a follow-up question until the answer checks out
+func askFollowUp(c *cli, lines *lineReader, conversation *clarify.Conversation, question core.Question) (string, error) {
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		fmt.Fprintf(c.stderr, "\n%s\n> ", question.Text)
+		answer, err := lines.next()
+		if err != nil {
+			return "", err
+		}
+		if lastErr = conversation.Check(question.Field, answer); lastErr == nil {
+			return answer, nil
+		}
+		fmt.Fprintf(c.stderr, "  ✗ %v\n", lastErr)
+	}
+	return "", lastErr
+}
+
+// missingDescription reports whether err only says the description is empty,
+// which a clarifying conversation asks for
+func missingDescription(err error) bool {
+	var validationErr *core.ValidationError
+	return errors.As(err, &validationErr) && validationErr.Field == "description"
+}
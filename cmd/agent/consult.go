@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"biz-flow/internal/core"
+)
+
+// runConsult runs a single consultation and prints the result
+func runConsult(c *cli, args []string) error {
+	fs := flag.NewFlagSet("consult", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	bf := addBusinessFlags(fs)
+	pf := addPipelineFlags(fs, "off")
+	format := formatFlag(fs)
+	clarifyFirst := fs.Bool("clarify", false, "ask follow-up questions about missing or ambiguous details before consulting")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if *clarifyFirst && (bf.interactive || bf.inputPath == "-") {
+		return usageErrorf("-clarify reads answers from stdin, so it cannot be combined with -interactive or -input -")
+	}
+
+	business, err := bf.load(c)
+	if err != nil && !(*clarifyFirst && missingDescription(err)) {
+		return err
+	}
+	if *clarifyFirst {
+		if business, err = clarifyBusiness(c, business); err != nil {
+			return err
+		}
+	}
+
+	consultant, _, err := pf.newAgent()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	result, err := consultant.Consult(ctx, business)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(c.stdout, *format, result,
+		func(w io.Writer) error { return writeConsultationText(w, business, result) },
+		func(w io.Writer) error { return writeConsultationMarkdown(w, business, result) },
+	)
+}
+
+// writeConsultationText prints a consultation result as plain text
+func writeConsultationText(w io.Writer, business core.BusinessInput, result *core.ConsultationResult) error {
+	fmt.Fprintf(w, "Business: %s\n", business.String())
+	if result.Persona != "" {
+		fmt.Fprintf(w, "Persona:  %s\n", result.Persona)
+	}
+
+	fmt.Fprintln(w, "\nRECOMMENDATIONS:")
+	for _, rec := range result.Recommendations {
+		fmt.Fprintf(w, "%d. %s (score %.1f%s)\n", rec.Rank, rec.Platform, rec.Score, confidenceText(rec.Confidence))
+		fmt.Fprintf(w, "   %s\n", rec.Reasoning)
+		if rec.ContentTemplate != nil {
+			writeTemplateText(w, "   ", rec.ContentTemplate)
+		}
+		if rec.VoiceScore != nil {
+			fmt.Fprintf(w, "   Voice:   %s\n", voiceSummary(rec.VoiceScore))
+		}
+	}
+
+	if len(result.Questions) > 0 {
+		fmt.Fprintln(w, "\nQUESTIONS (answers would firm up these recommendations):")
+		for _, question := range result.Questions {
+			fmt.Fprintf(w, "- %s\n", question.Text)
+		}
+	}
+
+	fmt.Fprintln(w, "\nSTRATEGY:")
+	fmt.Fprintln(w, result.StrategicAdvice)
+
+	if len(result.Risks) > 0 {
+		fmt.Fprintln(w, "\nRISKS:")
+		for _, risk := range result.Risks {
+			fmt.Fprintf(w, "- %s\n", risk)
+		}
+	}
+
+	if adjusted := policyAdjustments(result); len(adjusted) > 0 {
+		fmt.Fprintln(w, "\nCONTENT ADJUSTED BY POLICY CHECKS:")
+		for _, line := range adjusted {
+			fmt.Fprintf(w, "- %s\n", line)
+		}
+	}
+
+	if cost := costSummary(result); cost != "" {
+		fmt.Fprintf(w, "\nLLM cost: %s\n", cost)
+	}
+
+	if metadata := result.Metadata; metadata != nil && metadata.Degraded {
+		fmt.Fprintln(w, "\nNOTE: some output came from fallback sources:")
+		for _, fallback := range metadata.Fallbacks {
+			fmt.Fprintf(w, "- %s: %s -> %s (%s)\n", fallback.Stage, fallback.From, fallback.To, fallback.Reason)
+		}
+	}
+
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// writeTemplateText prints a content template with the given indent
+func writeTemplateText(w io.Writer, indent string, template *core.ContentTemplate) {
+	fmt.Fprintf(w, "%sHook:    %s\n", indent, template.Hook)
+	fmt.Fprintf(w, "%sCaption: %s\n", indent, template.Caption)
+	fmt.Fprintf(w, "%sCTA:     %s\n", indent, template.CTA)
+	if len(template.Hashtags) > 0 {
+		fmt.Fprintf(w, "%sTags:    %s\n", indent, formatHashtags(template.Hashtags))
+	}
+}
+
+// writeConsultationMarkdown prints a consultation result as Markdown
+func writeConsultationMarkdown(w io.Writer, business core.BusinessInput, result *core.ConsultationResult) error {
+	fmt.Fprintf(w, "# Marketing consultation\n\n")
+	fmt.Fprintf(w, "**Business:** %s\n\n", business.String())
+	if result.Persona != "" {
+		fmt.Fprintf(w, "**Persona:** %s\n\n", result.Persona)
+	}
+
+	fmt.Fprintf(w, "## Recommendations\n\n")
+	for _, rec := range result.Recommendations {
+		fmt.Fprintf(w, "### %d. %s (score %.1f%s)\n\n%s\n\n", rec.Rank, rec.Platform, rec.Score, confidenceText(rec.Confidence), rec.Reasoning)
+		if template := rec.ContentTemplate; template != nil {
+			fmt.Fprintf(w, "- **Hook:** %s\n- **Caption:** %s\n- **CTA:** %s\n", template.Hook, template.Caption, template.CTA)
+			if len(template.Hashtags) > 0 {
+				fmt.Fprintf(w, "- **Hashtags:** %s\n", formatHashtags(template.Hashtags))
+			}
+			if rec.VoiceScore != nil {
+				fmt.Fprintf(w, "- **Brand voice:** %s\n", voiceSummary(rec.VoiceScore))
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
+	if len(result.Questions) > 0 {
+		fmt.Fprintf(w, "## Questions\n\nAnswers would firm up these recommendations.\n\n")
+		for _, question := range result.Questions {
+			fmt.Fprintf(w, "- %s\n", question.Text)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "## Strategy\n\n%s\n\n", result.StrategicAdvice)
+
+	if len(result.Risks) > 0 {
+		fmt.Fprintf(w, "## Risks\n\n")
+		for _, risk := range result.Risks {
+			fmt.Fprintf(w, "- %s\n", risk)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if adjusted := policyAdjustments(result); len(adjusted) > 0 {
+		fmt.Fprintf(w, "## Content adjusted by policy checks\n\n")
+		for _, line := range adjusted {
+			fmt.Fprintf(w, "- %s\n", line)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if cost := costSummary(result); cost != "" {
+		fmt.Fprintf(w, "_LLM cost: %s_\n\n", cost)
+	}
+
+	if metadata := result.Metadata; metadata != nil && metadata.Degraded {
+		fmt.Fprintf(w, "> **Note:** some output came from fallback sources.\n>\n")
+		for _, fallback := range metadata.Fallbacks {
+			fmt.Fprintf(w, "> - %s: %s → %s (%s)\n", fallback.Stage, fallback.From, fallback.To, fallback.Reason)
+		}
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
+
+// confidenceText describes a recommendation's confidence after its score, or
+// "" when it was not rated
+func confidenceText(confidence *core.Confidence) string {
+	if confidence == nil {
+		return ""
+	}
+	return fmt.Sprintf(", %s confidence %.2f", confidence.Level, confidence.Score)
+}
+
+// policyAdjustments describes the content the policy checker rewrote or
+// removed; flagged content is already listed under risks
+func policyAdjustments(result *core.ConsultationResult) []string {
+	if result.Metadata == nil {
+		return nil
+	}
+	var lines []string
+	for _, finding := range result.Metadata.Guardrails {
+		if finding.Action != core.PolicyFlagged {
+			lines = append(lines, fmt.Sprintf("%s %s: %s %q (%s)", finding.Platform, finding.Field, finding.Action, finding.Match, finding.Rule))
+		}
+	}
+	return lines
+}
+
+// voiceSummary describes a brand voice score and what cost it points
+func voiceSummary(score *core.VoiceScore) string {
+	summary := fmt.Sprintf("%.0f/100", score.Score)
+	details := make([]string, 0, len(score.Issues)+len(score.Adjustments))
+	for _, issue := range score.Issues {
+		details = append(details, issue.Detail)
+	}
+	details = append(details, score.Adjustments...)
+	if len(details) > 0 {
+		summary += " (" + strings.Join(details, "; ") + ")"
+	}
+	return summary
+}
+
+// costSummary describes the estimated LLM spend, or "" when no model was called
+func costSummary(result *core.ConsultationResult) string {
+	if result.Metadata == nil || result.Metadata.Cost == nil || len(result.Metadata.Cost.Calls) == 0 {
+		return ""
+	}
+	cost := result.Metadata.Cost
+	summary := fmt.Sprintf("~$%.4f over %d calls", cost.TotalUSD, len(cost.Calls))
+	if len(cost.UnpricedModels) > 0 {
+		summary += fmt.Sprintf(" (no price for %s)", strings.Join(cost.UnpricedModels, ", "))
+	}
+	return summary
+}
+
+// formatHashtags renders hashtags with a leading # each
+func formatHashtags(hashtags []string) string {
+	tags := make([]string, 0, len(hashtags))
+	for _, tag := range hashtags {
+		tags = append(tags, "#"+strings.TrimPrefix(tag, "#"))
+	}
+	return strings.Join(tags, " ")
+}
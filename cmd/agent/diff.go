@@ -0,0 +1,309 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/diff"
+)
+
+// runDiff compares two saved consultations, or runs a saved one again
+// against the current rules and compares the results
+func runDiff(c *cli, args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	beforePath := fs.String("before", "", "earlier consultation: consult, profile show or batch JSON output (\"-\" for stdin)")
+	afterPath := fs.String("after", "", "later consultation, in the same forms as -before")
+	rerun := fs.Bool("rerun", false, "consult -before's business again, with any business flags applied, and compare")
+	bf := addBusinessFlags(fs)
+	pf := addPipelineFlags(fs, "off")
+	format := formatFlag(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	switch {
+	case *beforePath == "":
+		return usageErrorf("diff requires -before")
+	case *rerun && *afterPath != "":
+		return usageErrorf("-rerun and -after cannot be combined")
+	case !*rerun && *afterPath == "":
+		return usageErrorf("diff requires -after or -rerun")
+	case *beforePath == "-" && *afterPath == "-":
+		return usageErrorf("only one of -before and -after can read stdin")
+	}
+
+	before, err := readSnapshot(c, *beforePath)
+	if err != nil {
+		return err
+	}
+
+	var comparison *diff.Comparison
+	if *rerun {
+		if comparison, err = rerunSnapshot(c, bf, pf, before); err != nil {
+			return err
+		}
+	} else {
+		after, err := readSnapshot(c, *afterPath)
+		if err != nil {
+			return err
+		}
+		if comparison, err = diff.Compare(before, after); err != nil {
+			return err
+		}
+		comparison.To = *afterPath
+	}
+	comparison.From = *beforePath
+
+	return writeOutput(c.stdout, *format, comparison,
+		func(w io.Writer) error { return writeComparisonText(w, comparison) },
+		func(w io.Writer) error { return writeComparisonMarkdown(w, comparison) },
+	)
+}
+
+// rerunSnapshot consults the snapshot's business, edited by any business
+// flags, and compares the fresh result with the snapshot's
+func rerunSnapshot(c *cli, bf *businessFlags, pf *pipelineFlags, before diff.Snapshot) (*diff.Comparison, error) {
+	var (
+		business core.BusinessInput
+		err      error
+	)
+	if before.Business != nil {
+		business, err = bf.edit(c, *before.Business)
+	} else if business, err = bf.load(c); err != nil {
+		err = fmt.Errorf("-before has no business to run again; describe it with the business flags or -input: %w", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	consultant, _, err := pf.newAgent()
+	if err != nil {
+		return nil, err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	result, err := consultant.Consult(ctx, business)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison, err := diff.Compare(before, diff.Snapshot{Business: &business, Result: result})
+	if err != nil {
+		return nil, err
+	}
+	comparison.To = "current rules"
+	return comparison, nil
+}
+
+// readSnapshot reads a consultation from a file: either a bare
+// ConsultationResult, as consult writes it, or an object with the result
+// under "result" and optionally the business under "business" or "input",
+// as profile show and batch write them
+func readSnapshot(c *cli, path string) (diff.Snapshot, error) {
+	var snapshot diff.Snapshot
+	var r io.Reader = c.stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return snapshot, err
+		}
+		defer file.Close()
+		r = file
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return snapshot, err
+	}
+	invalid := func(err error) error {
+		return &exitError{code: exitInvalidInput, err: fmt.Errorf("decoding %s: %w", path, err)}
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return snapshot, invalid(err)
+	}
+	if _, ok := fields["recommendations"]; ok {
+		fields = map[string]json.RawMessage{"result": data}
+	}
+	raw, ok := fields["result"]
+	if !ok || bytes.Equal(raw, []byte("null")) {
+		return snapshot, invalid(fmt.Errorf("no consultation result"))
+	}
+	if err := json.Unmarshal(raw, &snapshot.Result); err != nil {
+		return snapshot, invalid(err)
+	}
+	for _, key := range []string{"business", "input"} {
+		if raw, ok := fields[key]; ok {
+			business, err := core.DecodeBusinessInput(bytes.NewReader(raw))
+			if err != nil {
+				return snapshot, invalid(err)
+			}
+			snapshot.Business = &business
+			break
+		}
+	}
+	return snapshot, nil
+}
+
+// writeComparisonText prints the summary, then each platform's move, the
+// edits and the risk changes
+func writeComparisonText(w io.Writer, comparison *diff.Comparison) error {
+	fmt.Fprintln(w, comparison.Summary)
+	if comparison.From != "" || comparison.To != "" {
+		fmt.Fprintf(w, "(%s → %s)\n", comparison.From, comparison.To)
+	}
+
+	if len(comparison.Changes) > 0 {
+		fmt.Fprintln(w, "\nEDITS:")
+		for _, change := range comparison.Changes {
+			fmt.Fprintf(w, "  %s\n", changeText(change))
+		}
+	}
+
+	if platforms := changedPlatforms(comparison); len(platforms) > 0 {
+		fmt.Fprintln(w, "\nPLATFORMS:")
+		for _, platform := range platforms {
+			fmt.Fprintf(w, "  %-20s %s\n", platform.Platform, platformDeltaText(platform))
+			for _, sentence := range platform.ReasoningRemoved {
+				fmt.Fprintf(w, "      - %s\n", sentence)
+			}
+			for _, sentence := range platform.ReasoningAdded {
+				fmt.Fprintf(w, "      + %s\n", sentence)
+			}
+		}
+	}
+
+	if len(comparison.Risks.Added)+len(comparison.Risks.Removed) > 0 {
+		fmt.Fprintln(w, "\nRISKS:")
+		for _, risk := range comparison.Risks.Removed {
+			fmt.Fprintf(w, "  - %s\n", risk)
+		}
+		for _, risk := range comparison.Risks.Added {
+			fmt.Fprintf(w, "  + %s\n", risk)
+		}
+	}
+	if notes := comparisonNotes(comparison); len(notes) > 0 {
+		fmt.Fprintf(w, "\n%s\n", strings.Join(notes, " "))
+	}
+	return nil
+}
+
+// writeComparisonMarkdown prints the comparison as Markdown
+func writeComparisonMarkdown(w io.Writer, comparison *diff.Comparison) error {
+	fmt.Fprintf(w, "**%s**\n\n", comparison.Summary)
+	if comparison.From != "" || comparison.To != "" {
+		fmt.Fprintf(w, "_%s → %s_\n\n", comparison.From, comparison.To)
+	}
+
+	if len(comparison.Changes) > 0 {
+		fmt.Fprintln(w, "### Edits")
+		fmt.Fprintln(w)
+		for _, change := range comparison.Changes {
+			fmt.Fprintf(w, "- %s\n", changeText(change))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if platforms := changedPlatforms(comparison); len(platforms) > 0 {
+		fmt.Fprintln(w, "### Platforms")
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "| Platform | Change | Reasoning |\n|---|---|---|\n")
+		for _, platform := range platforms {
+			var reasoning []string
+			for _, sentence := range platform.ReasoningRemoved {
+				reasoning = append(reasoning, "− "+sentence)
+			}
+			for _, sentence := range platform.ReasoningAdded {
+				reasoning = append(reasoning, "+ "+sentence)
+			}
+			fmt.Fprintf(w, "| %s | %s | %s |\n", platform.Platform, platformDeltaText(platform), strings.Join(reasoning, "<br>"))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(comparison.Risks.Added)+len(comparison.Risks.Removed) > 0 {
+		fmt.Fprintln(w, "### Risks")
+		fmt.Fprintln(w)
+		for _, risk := range comparison.Risks.Removed {
+			fmt.Fprintf(w, "- Resolved: %s\n", risk)
+		}
+		for _, risk := range comparison.Risks.Added {
+			fmt.Fprintf(w, "- New: %s\n", risk)
+		}
+		fmt.Fprintln(w)
+	}
+	if notes := comparisonNotes(comparison); len(notes) > 0 {
+		fmt.Fprintf(w, "%s\n\n", strings.Join(notes, " "))
+	}
+	return nil
+}
+
+// changedPlatforms drops the platforms whose recommendation did not move
+func changedPlatforms(comparison *diff.Comparison) []diff.PlatformDelta {
+	var platforms []diff.PlatformDelta
+	for _, platform := range comparison.Platforms {
+		if platform.Changed() {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms
+}
+
+// comparisonNotes mentions the parts of the consultation compared as a whole
+func comparisonNotes(comparison *diff.Comparison) []string {
+	var notes []string
+	if comparison.PersonaChanged {
+		notes = append(notes, "The persona changed.")
+	}
+	if comparison.AdviceChanged {
+		notes = append(notes, "The strategic advice changed.")
+	}
+	return notes
+}
+
+// changeText describes an edited field
+func changeText(change diff.Change) string {
+	if change.From == "" && change.To == "" {
+		return change.Field
+	}
+	return fmt.Sprintf("%s: %q → %q", change.Field, change.From, change.To)
+}
+
+// platformDeltaText describes a platform's rank, score and ad spend in both
+// consultations
+func platformDeltaText(platform diff.PlatformDelta) string {
+	switch {
+	case platform.FromRank == 0:
+		return fmt.Sprintf("added at #%d (score %.1f)%s", platform.ToRank, platform.ToScore, adsText(platform.ToAds))
+	case platform.ToRank == 0:
+		return fmt.Sprintf("dropped from #%d (score %.1f)", platform.FromRank, platform.FromScore)
+	}
+	text := fmt.Sprintf("#%d → #%d, score %.1f → %.1f (%+.1f)",
+		platform.FromRank, platform.ToRank, platform.FromScore, platform.ToScore, platform.ScoreDelta)
+	switch {
+	case platform.ToAds && !platform.FromAds:
+		text += ", ads added"
+	case platform.FromAds && !platform.ToAds:
+		text += ", ads dropped"
+	}
+	return text
+}
+
+// adsText marks a platform the budget buys ads on
+func adsText(ads bool) string {
+	if ads {
+		return " with ads"
+	}
+	return ""
+}
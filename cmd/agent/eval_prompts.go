@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"biz-flow/internal/agent"
+	"biz-flow/internal/ai"
+	"biz-flow/internal/core"
+	"biz-flow/internal/eval"
+	"biz-flow/internal/prompts"
+	"biz-flow/internal/reasoning"
+)
+
+// defaultFixtures is the fixture set shipped with the repository
+const defaultFixtures = "fixtures/businesses.jsonl"
+
+// runEvalPrompts scores prompt versions against the fixture set
+func runEvalPrompts(c *cli, args []string) error {
+	fs := flag.NewFlagSet("eval-prompts", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fixtures := fs.String("fixtures", defaultFixtures, "JSONL file of BusinessInput records to evaluate against")
+	stages := fs.String("stage", "", "comma-separated stages to evaluate: persona, content, advice (default: every routed stage)")
+	versions := fs.String("versions", "", "comma-separated prompt versions to compare, e.g. v1,v2 (default: all)")
+	routing := fs.String("routing", os.Getenv("BIZFLOW_ROUTING"), "JSON file routing each LLM stage to providers and models")
+	promptDir := fs.String("prompts", os.Getenv("BIZFLOW_PROMPTS"), "directory of prompt versions overlaying the built-in prompts")
+	format := formatFlag(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	router, err := loadRouter(*routing)
+	if err != nil {
+		return err
+	}
+	library, err := loadPrompts(*promptDir)
+	if err != nil {
+		return err
+	}
+
+	selected, err := evalStages(*stages, router)
+	if err != nil {
+		return err
+	}
+	cases, err := loadCases(*fixtures)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	reports := make([]*eval.Report, 0, len(selected))
+	for _, stage := range selected {
+		templates, err := promptVersions(library, stage, *versions)
+		if err != nil {
+			return err
+		}
+		// Prompts are compared on the stage's primary model; fallbacks
+		// exist for outages, not for grading
+		report, err := eval.Run(ctx, stage, router.Clients(stage)[0], templates, cases)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+
+	text := func(w io.Writer) error {
+		for i, report := range reports {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			if err := report.WriteText(w); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return writeOutput(c.stdout, *format, reports, text, nil)
+}
+
+// evalStages picks the stages to evaluate; each needs a model to ask
+func evalStages(flagValue string, router *ai.Router) ([]string, error) {
+	if flagValue == "" {
+		var stages []string
+		for _, stage := range ai.Stages() {
+			if len(router.Clients(stage)) > 0 {
+				stages = append(stages, stage)
+			}
+		}
+		if len(stages) == 0 {
+			return nil, &exitError{code: exitInvalidInput,
+				err: fmt.Errorf("eval-prompts needs a model: set OPENROUTER_API_KEY or pass -routing")}
+		}
+		return stages, nil
+	}
+
+	var stages []string
+	for _, stage := range strings.Split(flagValue, ",") {
+		stage = strings.TrimSpace(stage)
+		known := false
+		for _, s := range ai.Stages() {
+			known = known || s == stage
+		}
+		if !known {
+			return nil, usageErrorf("unknown stage %q (want %s)", stage, strings.Join(ai.Stages(), ", "))
+		}
+		if len(router.Clients(stage)) == 0 {
+			return nil, &exitError{code: exitInvalidInput, err: fmt.Errorf("stage %s has no model configured", stage)}
+		}
+		stages = append(stages, stage)
+	}
+	return stages, nil
+}
+
+// promptVersions returns the requested versions of a stage's prompt
+func promptVersions(library *prompts.Library, stage, flagValue string) ([]*prompts.Template, error) {
+	if flagValue == "" {
+		return library.Versions(stage), nil
+	}
+	var templates []*prompts.Template
+	for _, version := range strings.Split(flagValue, ",") {
+		prompt, ok := library.Lookup(stage, strings.TrimSpace(version))
+		if !ok {
+			return nil, usageErrorf("unknown prompt version %s@%s", stage, strings.TrimSpace(version))
+		}
+		templates = append(templates, prompt)
+	}
+	return templates, nil
+}
+
+// loadCases reads the fixture businesses and ranks each one so later stages
+// have the recommendation context and candidates their prompts need
+func loadCases(path string) ([]eval.Case, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	consultant := agent.New(nil)
+	advisor := reasoning.NewStrategyAdvisor()
+
+	var cases []eval.Case
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		business, err := core.DecodeBusinessInput(bytes.NewReader(raw))
+		if err != nil {
+			return nil, &exitError{code: exitInvalidInput, err: fmt.Errorf("%s:%d: %w", path, line, err)}
+		}
+		ranked, err := consultant.RecommendTop(business, 0)
+		if err != nil {
+			return nil, &exitError{code: exitInvalidInput, err: fmt.Errorf("%s:%d: %w", path, line, err)}
+		}
+		recommendations := ranked[:min(len(ranked), agent.DefaultTopN)]
+		candidates := make([]core.Platform, 0, len(ranked))
+		for _, recommendation := range ranked {
+			candidates = append(candidates, recommendation.Platform)
+		}
+		cases = append(cases, eval.Case{
+			Business:        business,
+			Recommendations: recommendations,
+			Draft:           advisor.Advise(business, recommendations),
+			Candidates:      candidates,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(cases) == 0 {
+		return nil, &exitError{code: exitInvalidInput, err: fmt.Errorf("%s: no fixtures", path)}
+	}
+	return cases, nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/reasoning"
+)
+
+// runExplain explains how a single platform fits a business
+func runExplain(c *cli, args []string) error {
+	fs := flag.NewFlagSet("explain", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	bf := addBusinessFlags(fs)
+	format := formatFlag(fs)
+
+	// Accept the platform either before or after the flags
+	var name string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if name == "" && fs.NArg() > 0 {
+		name = fs.Arg(0)
+	}
+	if name == "" {
+		return usageErrorf("explain requires a platform name")
+	}
+
+	platform, ok := core.ParsePlatform(name)
+	if !ok {
+		return &exitError{code: exitInvalidInput, err: fmt.Errorf("unknown platform %q", name)}
+	}
+
+	business, err := bf.load(c)
+	if err != nil {
+		return err
+	}
+
+	explanation := reasoning.NewExplainer().ExplainPlatform(business, platform)
+	return writeOutput(c.stdout, *format, explanation,
+		func(w io.Writer) error { return writeExplanationText(w, business, explanation) },
+		func(w io.Writer) error { return writeExplanationMarkdown(w, business, explanation) },
+	)
+}
+
+// writeExplanationText prints a platform explanation as plain text
+func writeExplanationText(w io.Writer, business core.BusinessInput, explanation reasoning.PlatformExplanation) error {
+	fmt.Fprintf(w, "%s for %s\n\n", explanation.Platform, business.String())
+
+	if explanation.PassedFilters {
+		fmt.Fprintf(w, "Passes filters, ranked #%d with score %.1f\n", explanation.Rank, explanation.Scored.Score)
+	} else {
+		fmt.Fprintf(w, "Filtered out for this business (score would be %.1f)\n", explanation.Scored.Score)
+	}
+
+	breakdown := explanation.Scored.Breakdown
+	fmt.Fprintln(w, "\nSCORE BREAKDOWN:")
+	fmt.Fprintf(w, "  Audience: %.2f\n  Budget:   %.2f\n  Effort:   %.2f\n  Return:   %.2f\n  Penalty:  %.2f\n",
+		breakdown.Audience, breakdown.Budget, breakdown.Effort, breakdown.Return, breakdown.Penalty)
+
+	fmt.Fprintln(w, "\nCONSTRAINTS:")
+	for _, check := range explanation.Constraints {
+		fmt.Fprintf(w, "  %-8s %s (Penalty: %.2f)\n", check.Name+":", check.Reason, check.Penalty)
+	}
+
+	fmt.Fprintln(w, "\nFILTERING REASONING:")
+	for _, key := range sortedKeys(explanation.Filtering) {
+		fmt.Fprintf(w, "  %s: %s\n", key, explanation.Filtering[key])
+	}
+
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// writeExplanationMarkdown prints a platform explanation as Markdown
+func writeExplanationMarkdown(w io.Writer, business core.BusinessInput, explanation reasoning.PlatformExplanation) error {
+	fmt.Fprintf(w, "# %s\n\n**Business:** %s\n\n", explanation.Platform, business.String())
+
+	if explanation.PassedFilters {
+		fmt.Fprintf(w, "Passes filters, ranked **#%d** with score **%.1f**.\n\n", explanation.Rank, explanation.Scored.Score)
+	} else {
+		fmt.Fprintf(w, "Filtered out for this business (score would be %.1f).\n\n", explanation.Scored.Score)
+	}
+
+	fmt.Fprintf(w, "| Constraint | Valid | Penalty | Reason |\n|---|---|---|---|\n")
+	for _, check := range explanation.Constraints {
+		fmt.Fprintf(w, "| %s | %v | %.2f | %s |\n", check.Name, check.IsValid, check.Penalty, check.Reason)
+	}
+
+	fmt.Fprintf(w, "\n## Filtering\n\n")
+	for _, key := range sortedKeys(explanation.Filtering) {
+		fmt.Fprintf(w, "- **%s:** %s\n", key, explanation.Filtering[key])
+	}
+	fmt.Fprintln(w)
+
+	return nil
+}
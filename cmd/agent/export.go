@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"biz-flow/internal/calendar"
+	"biz-flow/internal/core"
+	"biz-flow/internal/export"
+)
+
+// exportFlags are the flags shared by commands that write .ics or CSV files
+type exportFlags struct {
+	layout   *string
+	reminder *time.Duration
+	platform *string
+}
+
+// addExportFlags registers the export flags on a command's flag set
+func addExportFlags(fs *flag.FlagSet) *exportFlags {
+	return &exportFlags{
+		layout:   fs.String("layout", string(export.LayoutGeneric), "CSV layout: generic, buffer or hootsuite"),
+		reminder: fs.Duration("remind", export.DefaultReminder, "how long before each post an .ics reminder goes off (0 for none)"),
+		platform: fs.String("platform", "", "export only this platform's posts"),
+	}
+}
+
+// write writes the posts as an .ics file, with event titles in the locale, or
+// a CSV in the chosen layout
+func (ef *exportFlags) write(w io.Writer, format string, posts []export.Post, locale core.Locale) error {
+	if *ef.platform != "" {
+		platform, ok := core.ParsePlatform(*ef.platform)
+		if !ok {
+			return &exitError{code: exitInvalidInput, err: fmt.Errorf("unknown platform %q", *ef.platform)}
+		}
+		posts = export.ForPlatform(posts, platform)
+	}
+	if format == "ics" {
+		if *ef.reminder < 0 {
+			return usageErrorf("-remind must not be negative")
+		}
+		return export.WriteICS(w, posts, export.ICSOptions{Reminder: *ef.reminder, Locale: locale})
+	}
+	layout, err := export.ParseLayout(*ef.layout)
+	if err != nil {
+		return usageErrorf("unknown layout %q (want generic, buffer or hootsuite)", *ef.layout)
+	}
+	return export.WriteCSV(w, posts, layout)
+}
+
+// runExport turns a saved consultation result or content calendar into an
+// .ics or CSV file
+func runExport(c *cli, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	inputPath := fs.String("input", "-", "consult or calendar JSON output to export (\"-\" for stdin)")
+	format := fs.String("format", "ics", "output format: ics or csv")
+	start := fs.String("start", "", "first posting day for a consultation result, YYYY-MM-DD (default next Monday)")
+	businessPath := fs.String("business", "", "business JSON a consultation result was for; fills in content for recommendations without any")
+	locale := fs.String("locale", "", "language of .ics event titles: en, es, pt, sw or hi (default the business's, or en)")
+	ef := addExportFlags(fs)
+	outPath := fs.String("out", "-", "where to write the file (\"-\" for stdout)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if *format != "ics" && *format != "csv" {
+		return usageErrorf("unknown format %q (want ics or csv)", *format)
+	}
+	startDate := calendar.NextMonday(time.Now())
+	if *start != "" {
+		date, err := time.Parse(calendar.DateLayout, *start)
+		if err != nil {
+			return usageErrorf("-start must be a date like 2025-03-03")
+		}
+		startDate = date
+	}
+
+	if err := core.ValidateLocale(core.Locale(*locale)); err != nil {
+		return usageErrorf("-locale: %v", err)
+	}
+	var business *core.BusinessInput
+	if *businessPath != "" {
+		if *businessPath == "-" && *inputPath == "-" {
+			return usageErrorf("-business and -input cannot both read stdin")
+		}
+		loaded, err := readBusinessFile(c, *businessPath)
+		if err != nil {
+			return err
+		}
+		if err := loaded.Validate(); err != nil {
+			return err
+		}
+		business = &loaded
+		if *locale == "" {
+			*locale = string(business.Language())
+		}
+	}
+
+	var raw []byte
+	var err error
+	if *inputPath == "-" {
+		raw, err = io.ReadAll(c.stdin)
+	} else {
+		raw, err = os.ReadFile(*inputPath)
+	}
+	if err != nil {
+		return err
+	}
+	posts, err := decodePosts(raw, business, startDate)
+	if err != nil {
+		return &exitError{code: exitInvalidInput, err: fmt.Errorf("decoding %s: %w", *inputPath, err)}
+	}
+
+	var out io.Writer = c.stdout
+	if *outPath != "-" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	return ef.write(out, *format, posts, core.Locale(*locale))
+}
+
+// decodePosts reads the JSON output of calendar (it has slots) or consult
+// (it has recommendations); a business only applies to a consultation
+func decodePosts(raw []byte, business *core.BusinessInput, start time.Time) ([]export.Post, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	switch {
+	case fields["slots"] != nil:
+		var plan calendar.Calendar
+		if err := json.Unmarshal(raw, &plan); err != nil {
+			return nil, err
+		}
+		return export.FromCalendar(&plan)
+	case fields["recommendations"] != nil:
+		var result core.ConsultationResult
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, err
+		}
+		return export.FromConsultation(&result, business, start), nil
+	}
+	return nil, fmt.Errorf("want the JSON output of consult or calendar")
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"biz-flow/internal/feedback"
+)
+
+// defaultFeedbackDB is where results are kept when neither -db nor
+// $BIZFLOW_FEEDBACK is set
+const defaultFeedbackDB = "bizflow-feedback.db"
+
+// runFeedback imports reported results for a business profile or shows how
+// they recalibrate its platforms
+func runFeedback(c *cli, args []string) error {
+	if len(args) == 0 {
+		return usageErrorf("feedback requires a subcommand: import or show")
+	}
+
+	fs := flag.NewFlagSet("feedback "+args[0], flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	bf := addBusinessFlags(fs)
+	dbPath := os.Getenv("BIZFLOW_FEEDBACK")
+	if dbPath == "" {
+		dbPath = defaultFeedbackDB
+	}
+	fs.StringVar(&dbPath, "db", dbPath, "SQLite database of reported results (also $BIZFLOW_FEEDBACK)")
+	format := formatFlag(fs)
+
+	var csvPath *string
+	switch args[0] {
+	case "import":
+		csvPath = fs.String("csv", "", "CSV export with platform, reach and clicks columns, and optionally period, leads and sales")
+	case "show":
+	default:
+		return usageErrorf("unknown feedback subcommand %q (want import or show)", args[0])
+	}
+	if err := parseFlags(fs, args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if csvPath != nil && *csvPath == "" {
+		return usageErrorf("feedback import requires -csv")
+	}
+
+	business, err := bf.load(c)
+	if err != nil {
+		return err
+	}
+	if business.Profile == "" {
+		return usageErrorf("feedback %s requires -profile (or a profile in the -input file)", args[0])
+	}
+
+	store, err := feedback.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	recorded := 0
+	if csvPath != nil {
+		var r io.Reader = c.stdin
+		if *csvPath != "-" {
+			file, err := os.Open(*csvPath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			r = file
+		}
+		results, err := feedback.ReadCSV(r)
+		if err != nil {
+			return err
+		}
+		if err := store.Record(ctx, business, results); err != nil {
+			return err
+		}
+		recorded = len(results)
+	}
+
+	summary, err := store.Summarize(ctx, business)
+	if err != nil {
+		return err
+	}
+	summary.Recorded = recorded
+	return writeOutput(c.stdout, *format, summary,
+		func(w io.Writer) error { return writeFeedbackText(w, summary) },
+		func(w io.Writer) error { return writeFeedbackMarkdown(w, summary) },
+	)
+}
+
+// writeFeedbackText prints each calibrated platform's estimate before and
+// after the reported results
+func writeFeedbackText(w io.Writer, summary *feedback.Summary) error {
+	fmt.Fprintf(w, "Profile:  %s (%s)\n", summary.Profile, summary.Vertical)
+	if summary.Recorded > 0 {
+		fmt.Fprintf(w, "Recorded: %d results\n", summary.Recorded)
+	}
+	if len(summary.Platforms) == 0 {
+		_, err := fmt.Fprintln(w, "\nNo results reported for this profile or its vertical yet.")
+		return err
+	}
+
+	fmt.Fprintln(w, "\nPLATFORMS (catalog → calibrated):")
+	for _, platform := range summary.Platforms {
+		fmt.Fprintf(w, "%-20s reach: %2d → %4.1f  conversion: %2d → %4.1f  %s\n",
+			platform.Platform,
+			platform.CatalogReach, platform.Calibration.ReachPotential,
+			platform.CatalogConversion, platform.Calibration.ConversionFocus,
+			evidenceText(platform))
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// writeFeedbackMarkdown prints the calibrated platforms as a Markdown table
+func writeFeedbackMarkdown(w io.Writer, summary *feedback.Summary) error {
+	fmt.Fprintf(w, "# Reported results\n\n")
+	fmt.Fprintf(w, "**Profile:** %s (%s)\n\n", summary.Profile, summary.Vertical)
+	if summary.Recorded > 0 {
+		fmt.Fprintf(w, "**Recorded:** %d results\n\n", summary.Recorded)
+	}
+	if len(summary.Platforms) == 0 {
+		_, err := fmt.Fprintln(w, "No results reported for this profile or its vertical yet.")
+		return err
+	}
+	fmt.Fprintf(w, "| Platform | Reach | Conversion | Evidence |\n|---|---|---|---|\n")
+	for _, platform := range summary.Platforms {
+		fmt.Fprintf(w, "| %s | %d → %.1f | %d → %.1f | %s |\n",
+			platform.Platform,
+			platform.CatalogReach, platform.Calibration.ReachPotential,
+			platform.CatalogConversion, platform.Calibration.ConversionFocus,
+			evidenceText(platform))
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+// evidenceText describes the results behind a calibration
+func evidenceText(platform feedback.PlatformSummary) string {
+	text := fmt.Sprintf("%d of yours", platform.Calibration.Results)
+	if platform.Totals.Reach > 0 {
+		text += fmt.Sprintf(" (%d reached, %d clicks, %d leads, %d sales)",
+			platform.Totals.Reach, platform.Totals.Clicks, platform.Totals.Leads, platform.Totals.Sales)
+	}
+	return text + fmt.Sprintf(", %d from the vertical", platform.Calibration.VerticalResults)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"biz-flow/internal/core"
+)
+
+// businessFlags collects a BusinessInput from command-line flags
+type businessFlags struct {
+	fs          *flag.FlagSet
+	inputPath   string
+	interactive bool
+	business    core.BusinessInput
+	budget      float64
+	channels    string
+	voicePath   string
+}
+
+// addBusinessFlags registers the business input flags on fs
+func addBusinessFlags(fs *flag.FlagSet) *businessFlags {
+	bf := &businessFlags{fs: fs}
+	fs.StringVar(&bf.inputPath, "input", "", "JSON file with a BusinessInput (\"-\" for stdin)")
+	fs.BoolVar(&bf.interactive, "interactive", false, "prompt for the business details")
+	fs.Func("type", "business type: retail, service or digital", func(value string) error {
+		bf.business.Type = core.BusinessType(strings.ToLower(value))
+		return nil
+	})
+	fs.StringVar(&bf.business.Description, "description", "", "what the business sells")
+	fs.StringVar(&bf.business.Location, "location", "", "city or region, or \"online\"")
+	fs.Float64Var(&bf.budget, "budget", 0, "monthly marketing budget in dollars")
+	fs.StringVar(&bf.channels, "channels", "", "comma-separated channels already in use")
+	fs.Func("goal", "marketing goal: awareness or sales", func(value string) error {
+		bf.business.Goal = core.MarketingGoal(strings.ToLower(value))
+		return nil
+	})
+	fs.Func("locale", "language of the plan: en, es, pt, sw or hi (default en)", func(value string) error {
+		bf.business.Locale = core.Locale(strings.ToLower(value))
+		return nil
+	})
+	fs.StringVar(&bf.voicePath, "voice", "", "JSON file with a brand voice profile for the content and advice")
+	fs.StringVar(&bf.business.Profile, "profile", "", "profile name the business's reported results are kept under")
+	return bf
+}
+
+// load builds the BusinessInput. A JSON file or interactive answers form the
+// base and any explicitly set flags override it.
+func (bf *businessFlags) load(c *cli) (core.BusinessInput, error) {
+	return bf.edit(c, core.BusinessInput{})
+}
+
+// edit builds the BusinessInput like load, starting from business unless a
+// JSON file or interactive answers replace it
+func (bf *businessFlags) edit(c *cli, business core.BusinessInput) (core.BusinessInput, error) {
+	switch {
+	case bf.inputPath != "" && bf.interactive:
+		return business, usageErrorf("-input and -interactive cannot be combined")
+	case bf.inputPath != "":
+		loaded, err := readBusinessFile(c, bf.inputPath)
+		if err != nil {
+			return business, err
+		}
+		business = loaded
+	case bf.interactive:
+		answered, err := newWizard(c.stdin, c.stderr).run()
+		if err != nil {
+			return business, err
+		}
+		business = answered
+	}
+
+	bf.fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "type":
+			business.Type = bf.business.Type
+		case "description":
+			business.Description = bf.business.Description
+		case "location":
+			business.Location = bf.business.Location
+		case "budget":
+			business.Budget = bf.budget
+		case "channels":
+			business.Channels = splitChannels(bf.channels)
+		case "goal":
+			business.Goal = bf.business.Goal
+		case "locale":
+			business.Locale = bf.business.Locale
+		case "profile":
+			business.Profile = bf.business.Profile
+		}
+	})
+
+	if bf.voicePath != "" {
+		voice, err := readVoiceFile(bf.voicePath)
+		if err != nil {
+			return business, err
+		}
+		business.Voice = voice
+	}
+
+	if business.Channels == nil {
+		business.Channels = []string{}
+	}
+
+	return business, business.Validate()
+}
+
+// readBusinessFile decodes a BusinessInput from a file or stdin
+func readBusinessFile(c *cli, path string) (core.BusinessInput, error) {
+	var r io.Reader = c.stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return core.BusinessInput{}, err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	business, err := core.DecodeBusinessInput(r)
+	if err != nil {
+		return business, &exitError{code: exitInvalidInput, err: fmt.Errorf("decoding %s: %w", path, err)}
+	}
+	return business, nil
+}
+
+// readVoiceFile strictly decodes a brand voice profile
+func readVoiceFile(path string) (*core.BrandVoice, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var voice core.BrandVoice
+	if err := decoder.Decode(&voice); err != nil {
+		return nil, &exitError{code: exitInvalidInput, err: fmt.Errorf("decoding %s: %w", path, err)}
+	}
+	return &voice, nil
+}
+
+// splitChannels parses a comma-separated channel list
+func splitChannels(value string) []string {
+	channels := make([]string, 0)
+	for _, channel := range strings.Split(value, ",") {
+		if channel = strings.TrimSpace(channel); channel != "" {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// lineReader reads trimmed answers one line at a time
+type lineReader struct {
+	scanner *bufio.Scanner
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{scanner: bufio.NewScanner(r)}
+}
+
+// next returns the next line, or io.ErrUnexpectedEOF when input runs out
+func (lr *lineReader) next() (string, error) {
+	if !lr.scanner.Scan() {
+		if err := lr.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("reading answer: %w", io.ErrUnexpectedEOF)
+	}
+	return strings.TrimSpace(lr.scanner.Text()), nil
+}
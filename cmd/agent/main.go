@@ -1,77 +1,189 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"io"
+	"os"
 
-	"github.com/yourusername/marketing-consultant-agent/internal/core"
-	"github.com/yourusername/marketing-consultant-agent/internal/filters"
+	"biz-flow/internal/core"
 )
 
+// Exit codes are part of the CLI contract so scripts can branch on them
+const (
+	exitOK           = 0 // Success
+	exitFailure      = 1 // Runtime failure (I/O, network, LLM)
+	exitUsage        = 2 // Unknown command or bad flags
+	exitInvalidInput = 3 // Business input or config failed validation
+	exitPartial      = 4 // Batch finished but some records failed
+)
+
+// cli holds the streams every command reads from and writes to
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// command is a single CLI subcommand
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(c *cli, args []string) error
+}
+
+func commands() []command {
+	return []command{
+		{"consult", "consult [flags]", "Run a consultation from flags, a JSON file or interactive prompts", runConsult},
+		{"questionnaire", "questionnaire [-out file]", "Answer questions to build a BusinessInput JSON file", runQuestionnaire},
+		{"explain", "explain <platform> [flags]", "Explain how a platform fits a business", runExplain},
+		{"platforms", "platforms list|show <platform>", "List platforms or show one platform's metadata", runPlatforms},
+		{"validate-config", "validate-config [path]", "Validate a platform config file and the environment", runValidateConfig},
+		{"calendar", "calendar [flags]", "Plan weeks of posts across the recommended platforms", runCalendar},
+		{"export", "export -input <file> [flags]", "Export a consultation or calendar as .ics or CSV", runExport},
+		{"profile", "profile save|list|show|history|consult|diff|delete <name>", "Save businesses as revisioned profiles, consult them again and compare", runProfile},
+		{"scenarios", "scenarios [flags]", "Rank what-if variations of budget, goal and weekly hours, and find tipping points", runScenarios},
+		{"sensitivity", "sensitivity [flags]", "Perturb the numbers behind platform scores and report how stable the recommendations are", runSensitivity},
+		{"diff", "diff -before <file> -after <file>|-rerun", "Compare two consultations, or run one again against the current rules", runDiff},
+		{"feedback", "feedback import|show -profile <name> [flags]", "Import reported results or show how they recalibrate platforms", runFeedback},
+		{"report", "report [flags]", "Render a consultation as a Markdown or print-ready HTML report", runReport},
+		{"batch", "batch -input <file> [flags]", "Consult every JSONL record in a file", runBatch},
+		{"eval-prompts", "eval-prompts [flags]", "Score prompt versions against the fixture set", runEvalPrompts},
+		{"serve", "serve [flags]", "Serve the consultation API over HTTP", runServe},
+	}
+}
+
 func main() {
-	fmt.Println("=== Marketing Consultant Agent - Foundation Test ===\n")
-
-	// Create a sample business input
-	business := core.BusinessInput{
-		Type:        core.Retail,
-		Description: "Handmade jewelry business selling unique artisan pieces online and at local markets",
-		Location:    "Austin, TX",
-		Budget:      80.0,
-		Channels:    []string{},
-		Goal:        core.Awareness,
+	c := &cli{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	os.Exit(c.run(os.Args[1:]))
+}
+
+// run dispatches to a subcommand and turns its error into an exit code
+func (c *cli) run(args []string) int {
+	global := flag.NewFlagSet("agent", flag.ContinueOnError)
+	global.SetOutput(c.stderr)
+	platformsPath := global.String("platforms", os.Getenv("BIZFLOW_PLATFORMS"), "platform config file overriding the built-in metadata")
+	global.Usage = func() { c.usage(global) }
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
 	}
 
-	// Validate the input
-	if err := business.Validate(); err != nil {
-		log.Fatalf("Invalid business input: %v", err)
+	if global.NArg() == 0 {
+		c.usage(global)
+		return exitUsage
 	}
 
-	fmt.Printf("Business Input: %s\n\n", business.String())
-	fmt.Printf("Budget Tier: %s\n", business.BudgetTier())
-	fmt.Printf("Is Local: %v\n", business.IsLocal())
-	fmt.Printf("Is Online Only: %v\n\n", business.IsOnlineOnly())
-
-	// Create platform filter
-	platformFilter := filters.NewPlatformFilter()
-
-	// Get filtered platforms
-	relevantPlatforms := platformFilter.ApplyAllFilters(business)
-
-	fmt.Println("RELEVANT PLATFORMS:")
-	fmt.Println("-------------------")
-	for i, platform := range relevantPlatforms {
-		metadata, _ := core.GetPlatformMetadata(platform)
-		fmt.Printf("%d. %s (Effort: %s, Organic: %v)\n",
-			i+1,
-			platform,
-			metadata.EffortLevel,
-			metadata.IsOrganic,
-		)
+	if *platformsPath != "" {
+		if err := applyPlatformConfig(*platformsPath); err != nil {
+			fmt.Fprintf(c.stderr, "error: %v\n", err)
+			return exitInvalidInput
+		}
 	}
 
-	// Get filtering explanations
-	fmt.Println("\nFILTERING REASONING:")
-	fmt.Println("--------------------")
-	explanations := platformFilter.ExplainFiltering(business)
-	for key, explanation := range explanations {
-		fmt.Printf("%s: %s\n", key, explanation)
+	name, rest := global.Arg(0), global.Args()[1:]
+	if name == "help" {
+		c.usage(global)
+		return exitOK
 	}
 
-	// Validate constraints for each platform
-	constraintValidator := filters.NewConstraintValidator()
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return c.exitCode(cmd.run(c, rest))
+		}
+	}
+
+	fmt.Fprintf(c.stderr, "error: unknown command %q\n\n", name)
+	c.usage(global)
+	return exitUsage
+}
 
-	fmt.Println("\nCONSTRAINT ANALYSIS:")
-	fmt.Println("--------------------")
-	for _, platform := range relevantPlatforms {
-		budgetConstraint := constraintValidator.ValidateBudgetConstraints(business.Budget, platform)
-		effortConstraint := constraintValidator.ValidateEffortConstraints(business, platform)
-		combinedPenalty := constraintValidator.GetCombinedPenalty(business, platform)
+// usage prints the list of subcommands
+func (c *cli) usage(global *flag.FlagSet) {
+	fmt.Fprintln(c.stderr, "Usage: agent [-platforms file] <command> [flags]")
+	fmt.Fprintln(c.stderr, "\nCommands:")
+	// Pad every usage to the longest so the summaries line up
+	width := 0
+	for _, cmd := range commands() {
+		width = max(width, len(cmd.usage))
+	}
+	for _, cmd := range commands() {
+		fmt.Fprintf(c.stderr, "  %-*s  %s\n", width, cmd.usage, cmd.summary)
+	}
+	fmt.Fprintln(c.stderr, "\nGlobal flags:")
+	global.PrintDefaults()
+	fmt.Fprintln(c.stderr, "\nRun 'agent <command> -h' for command flags.")
+}
 
-		fmt.Printf("\n%s:\n", platform)
-		fmt.Printf("  Budget: %s (Penalty: %.2f)\n", budgetConstraint.Reason, budgetConstraint.Penalty)
-		fmt.Printf("  Effort: %s (Penalty: %.2f)\n", effortConstraint.Reason, effortConstraint.Penalty)
-		fmt.Printf("  Combined Penalty: %.2f\n", combinedPenalty)
+// exitCode reports an error and maps it to the matching exit code
+func (c *cli) exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
 	}
 
-	fmt.Println("\n=== Foundation Test Complete ===")
-}
\ No newline at end of file
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		if exitErr.err != nil {
+			fmt.Fprintf(c.stderr, "error: %v\n", exitErr.err)
+		}
+		return exitErr.code
+	}
+
+	var validationErr *core.ValidationError
+	if errors.As(err, &validationErr) {
+		fmt.Fprintf(c.stderr, "error: %v\n", err)
+		return exitInvalidInput
+	}
+
+	fmt.Fprintf(c.stderr, "error: %v\n", err)
+	return exitFailure
+}
+
+// exitError carries a specific exit code out of a command
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// usageErrorf reports a usage mistake
+func usageErrorf(format string, args ...interface{}) error {
+	return &exitError{code: exitUsage, err: fmt.Errorf(format, args...)}
+}
+
+// applyPlatformConfig loads a platform config file and makes it active
+func applyPlatformConfig(path string) error {
+	platforms, err := core.LoadPlatformConfig(path)
+	if err != nil {
+		return fmt.Errorf("loading platform config: %w", err)
+	}
+	return core.UsePlatformConfig(platforms)
+}
+
+// parseFlags parses a command's flags, treating -h as success rather than misuse
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &exitError{code: exitUsage}
+	}
+	return nil
+}
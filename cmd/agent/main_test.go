@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// offline keeps commands away from the network and from the environment's
+// config, so they run on rules and templates alone
+func offline(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"OPENROUTER_API_KEY", "BIZFLOW_PLATFORMS", "BIZFLOW_ROUTING", "BIZFLOW_PROMPTS", "BIZFLOW_FEEDBACK"} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	offline(t)
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	valid := `{"type":"retail","description":"Handmade ceramic mugs for coffee lovers","location":"Austin","budget":100,"goal":"sales"}`
+	records := write("records.jsonl", valid+"\n"+valid+"\n")
+	partial := write("partial.jsonl", valid+"\n"+`{"type":"bakery","description":"Bread","goal":"sales"}`+"\n")
+	consult := []string{"consult", "-type", "retail", "-description", "Handmade ceramic mugs", "-goal", "sales", "-content", "templates"}
+
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		want   int
+		stderr string
+	}{
+		{name: "help", args: []string{"help"}, want: exitOK, stderr: "Commands:"},
+		{name: "consultation", args: consult, want: exitOK},
+		{name: "command help", args: []string{"consult", "-h"}, want: exitOK},
+		{name: "batch", args: []string{"batch", "-input", records, "-content", "templates"}, want: exitOK},
+		{
+			name:  "questionnaire",
+			args:  []string{"questionnaire"},
+			stdin: "retail\nHandmade ceramic mugs for coffee lovers\n\n0\nsales\n\n\n",
+			want:  exitOK,
+		},
+		{name: "missing input file", args: []string{"batch", "-input", filepath.Join(dir, "missing.jsonl")}, want: exitFailure},
+		{name: "questionnaire input ends early", args: []string{"questionnaire"}, stdin: "retail\n", want: exitFailure, stderr: "unexpected EOF"},
+		{name: "no command", args: nil, want: exitUsage, stderr: "Usage:"},
+		{name: "unknown command", args: []string{"launch"}, want: exitUsage, stderr: `unknown command "launch"`},
+		{name: "unknown flag", args: []string{"consult", "-colour"}, want: exitUsage},
+		{name: "unexpected argument", args: append(consult, "extra"), want: exitUsage, stderr: "unexpected arguments: extra"},
+		{name: "batch without input", args: []string{"batch"}, want: exitUsage, stderr: "batch requires -input"},
+		{name: "invalid business", args: []string{"consult", "-type", "bakery", "-description", "Bread", "-goal", "sales"}, want: exitInvalidInput},
+		{name: "budget that is not finite", args: append(consult, "-budget", "NaN"), want: exitInvalidInput, stderr: "must be a finite number"},
+		{name: "missing platform config", args: []string{"-platforms", filepath.Join(dir, "missing.json"), "platforms", "list"}, want: exitInvalidInput},
+		{name: "batch with a failed record", args: []string{"batch", "-input", partial, "-content", "templates"}, want: exitPartial, stderr: "1 of 2 records failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			c := &cli{stdin: strings.NewReader(tt.stdin), stdout: &stdout, stderr: &stderr}
+			if got := c.run(tt.args); got != tt.want {
+				t.Fatalf("exit code = %d, want %d\nstderr:\n%s", got, tt.want, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tt.stderr) {
+				t.Errorf("stderr does not contain %q:\n%s", tt.stderr, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// outputFormat selects how command results are rendered
+type outputFormat string
+
+const (
+	formatText     outputFormat = "text"
+	formatJSON     outputFormat = "json"
+	formatYAML     outputFormat = "yaml"
+	formatMarkdown outputFormat = "markdown"
+)
+
+// String implements flag.Value
+func (f *outputFormat) String() string {
+	return string(*f)
+}
+
+// Set implements flag.Value
+func (f *outputFormat) Set(value string) error {
+	switch outputFormat(value) {
+	case formatText, formatJSON, formatYAML, formatMarkdown:
+		*f = outputFormat(value)
+		return nil
+	case "md":
+		*f = formatMarkdown
+		return nil
+	case "yml":
+		*f = formatYAML
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q (want text, json, yaml or markdown)", value)
+	}
+}
+
+// formatFlag registers the -format flag on a command's flag set
+func formatFlag(fs *flag.FlagSet) *outputFormat {
+	format := formatText
+	fs.Var(&format, "format", "output format: text, json, yaml or markdown")
+	return &format
+}
+
+// renderer writes a value in a human-oriented format
+type renderer func(w io.Writer) error
+
+// writeOutput renders value in the requested format. JSON and YAML are
+// derived from the value's JSON encoding so both share the same field names.
+func writeOutput(w io.Writer, format outputFormat, value interface{}, text, markdown renderer) error {
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case formatYAML:
+		return writeYAML(w, value)
+	case formatMarkdown:
+		if markdown != nil {
+			return markdown(w)
+		}
+		return text(w)
+	default:
+		return text(w)
+	}
+}
+
+// writeYAML converts value to YAML while keeping the JSON key order
+func writeYAML(w io.Writer, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	// JSON is valid YAML, so decoding into a node keeps the field order
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	resetStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// resetStyle switches a node tree from JSON flow style to block style
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
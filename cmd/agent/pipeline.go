@@ -0,0 +1,124 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"biz-flow/internal/agent"
+	"biz-flow/internal/ai"
+	"biz-flow/internal/cache"
+	"biz-flow/internal/feedback"
+	"biz-flow/internal/prompts"
+)
+
+// pipelineFlags configures the consultation agent shared by consult, batch
+// and serve
+type pipelineFlags struct {
+	cache    *cacheFlags
+	content  string
+	routing  string
+	prompts  string
+	feedback string
+	reviews  int
+	// results is the opened feedback database, shared with the handlers
+	results *feedback.Store
+}
+
+// addPipelineFlags registers the agent flags on fs; cacheBackend is the
+// command's default cache backend
+func addPipelineFlags(fs *flag.FlagSet, cacheBackend string) *pipelineFlags {
+	pf := &pipelineFlags{cache: addCacheFlags(fs, cacheBackend)}
+	fs.StringVar(&pf.content, "content", string(ai.ContentFromLLM),
+		"content source: llm (falls back to templates) or templates (offline library only)")
+	fs.StringVar(&pf.routing, "routing", os.Getenv("BIZFLOW_ROUTING"),
+		"JSON file routing each LLM stage to providers and models (default: OpenRouter from the environment)")
+	fs.StringVar(&pf.prompts, "prompts", os.Getenv("BIZFLOW_PROMPTS"),
+		"directory of prompt versions and traffic.json overlaying the built-in prompts")
+	fs.StringVar(&pf.feedback, "feedback", os.Getenv("BIZFLOW_FEEDBACK"),
+		"SQLite database of reported results that recalibrates platform scores (see the feedback command)")
+	fs.IntVar(&pf.reviews, "reviews", 0,
+		"times the review model is asked for its own platform picks; confidence rates its agreement (0 skips the review)")
+	return pf
+}
+
+// newAgent builds the agent from the environment and flags. The cache is
+// returned too so callers can report its stats.
+func (pf *pipelineFlags) newAgent() (*agent.Agent, *cache.Cache, error) {
+	source := ai.ContentSource(pf.content)
+	if source != ai.ContentFromLLM && source != ai.ContentFromTemplates {
+		return nil, nil, usageErrorf("unknown content source %q (want llm or templates)", pf.content)
+	}
+
+	router, err := loadRouter(pf.routing)
+	if err != nil {
+		return nil, nil, err
+	}
+	library, err := loadPrompts(pf.prompts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if pf.reviews < 0 {
+		return nil, nil, usageErrorf("-reviews must not be negative")
+	}
+
+	stageCache, err := pf.cache.open()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	consultant := agent.New(router).
+		WithContentSource(source).
+		WithPrompts(library).
+		WithCache(stageCache).
+		WithReviews(pf.reviews)
+	results, err := pf.feedbackStore()
+	if err != nil {
+		return nil, nil, err
+	}
+	if results != nil {
+		consultant.WithFeedback(results)
+	}
+	return consultant, stageCache, nil
+}
+
+// feedbackStore opens the -feedback database once, or returns nil when none
+// is configured
+func (pf *pipelineFlags) feedbackStore() (*feedback.Store, error) {
+	if pf.feedback == "" || pf.results != nil {
+		return pf.results, nil
+	}
+	results, err := feedback.Open(pf.feedback)
+	if err != nil {
+		return nil, err
+	}
+	pf.results = results
+	return results, nil
+}
+
+// loadRouter reads the routing config, or routes from the environment when
+// path is empty
+func loadRouter(path string) (*ai.Router, error) {
+	if path == "" {
+		return ai.RouterFromEnv(), nil
+	}
+	cfg, err := ai.LoadRoutingConfig(path)
+	if err != nil {
+		return nil, &exitError{code: exitInvalidInput, err: err}
+	}
+	router, err := ai.NewRouter(cfg)
+	if err != nil {
+		return nil, &exitError{code: exitInvalidInput, err: fmt.Errorf("%s: %w", path, err)}
+	}
+	return router, nil
+}
+
+// loadPrompts overlays the prompt directory on the built-in prompts
+func loadPrompts(dir string) (*prompts.Library, error) {
+	library, err := prompts.Load(dir)
+	if err != nil {
+		return nil, &exitError{code: exitInvalidInput, err: fmt.Errorf("loading prompts: %w", err)}
+	}
+	return library, nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"biz-flow/internal/core"
+)
+
+// runPlatforms lists all platforms or shows one platform's metadata
+func runPlatforms(c *cli, args []string) error {
+	if len(args) == 0 {
+		return usageErrorf("platforms requires a subcommand: list or show <platform>")
+	}
+
+	fs := flag.NewFlagSet("platforms "+args[0], flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	format := formatFlag(fs)
+
+	switch args[0] {
+	case "list":
+		if err := parseFlags(fs, args[1:]); err != nil {
+			return err
+		}
+
+		all := core.AllPlatforms()
+		platforms := make([]core.PlatformMetadata, 0, len(all))
+		for _, name := range core.GetAllPlatformNames() {
+			platforms = append(platforms, all[name])
+		}
+
+		return writeOutput(c.stdout, *format, platforms,
+			func(w io.Writer) error { return writePlatformListText(w, platforms) },
+			func(w io.Writer) error { return writePlatformListMarkdown(w, platforms) },
+		)
+
+	case "show":
+		rest := args[1:]
+		var name string
+		if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+			name, rest = rest[0], rest[1:]
+		}
+		if err := parseFlags(fs, rest); err != nil {
+			return err
+		}
+		if name == "" && fs.NArg() > 0 {
+			name = fs.Arg(0)
+		}
+		if name == "" {
+			return usageErrorf("platforms show requires a platform name")
+		}
+
+		platform, ok := core.ParsePlatform(name)
+		if !ok {
+			return &exitError{code: exitInvalidInput, err: fmt.Errorf("unknown platform %q", name)}
+		}
+		metadata, _ := core.GetPlatformMetadata(platform)
+
+		return writeOutput(c.stdout, *format, metadata,
+			func(w io.Writer) error { return writePlatformText(w, metadata) },
+			func(w io.Writer) error { return writePlatformMarkdown(w, metadata) },
+		)
+
+	default:
+		return usageErrorf("unknown platforms subcommand %q (want list or show)", args[0])
+	}
+}
+
+// writePlatformListText prints one line per platform
+func writePlatformListText(w io.Writer, platforms []core.PlatformMetadata) error {
+	for _, metadata := range platforms {
+		fmt.Fprintf(w, "%-20s effort: %-6s reach: %2d  conversion: %2d  best for: %s\n",
+			metadata.Name,
+			metadata.EffortLevel,
+			metadata.ReachPotential,
+			metadata.ConversionFocus,
+			joinBusinessTypes(metadata.BestFor),
+		)
+	}
+	return nil
+}
+
+// writePlatformListMarkdown prints the platforms as a Markdown table
+func writePlatformListMarkdown(w io.Writer, platforms []core.PlatformMetadata) error {
+	fmt.Fprintf(w, "| Platform | Effort | Reach | Conversion | Best for |\n|---|---|---|---|---|\n")
+	for _, metadata := range platforms {
+		fmt.Fprintf(w, "| %s | %s | %d | %d | %s |\n",
+			metadata.Name,
+			metadata.EffortLevel,
+			metadata.ReachPotential,
+			metadata.ConversionFocus,
+			joinBusinessTypes(metadata.BestFor),
+		)
+	}
+	return nil
+}
+
+// writePlatformText prints every metadata field of a platform
+func writePlatformText(w io.Writer, metadata core.PlatformMetadata) error {
+	for _, field := range platformFields(metadata) {
+		fmt.Fprintf(w, "%-18s %s\n", field[0]+":", field[1])
+	}
+	return nil
+}
+
+// writePlatformMarkdown prints a platform's metadata as a Markdown table
+func writePlatformMarkdown(w io.Writer, metadata core.PlatformMetadata) error {
+	fmt.Fprintf(w, "# %s\n\n| Field | Value |\n|---|---|\n", metadata.Name)
+	for _, field := range platformFields(metadata)[1:] {
+		fmt.Fprintf(w, "| %s | %s |\n", field[0], field[1])
+	}
+	return nil
+}
+
+// platformFields lists a platform's metadata as label/value pairs
+func platformFields(metadata core.PlatformMetadata) [][2]string {
+	return [][2]string{
+		{"Name", string(metadata.Name)},
+		{"Effort level", string(metadata.EffortLevel)},
+		{"Best for", joinBusinessTypes(metadata.BestFor)},
+		{"Min budget", fmt.Sprintf("$%.2f/month", metadata.MinBudget)},
+		{"Requires visuals", fmt.Sprint(metadata.RequiresVisuals)},
+		{"Requires video", fmt.Sprint(metadata.RequiresVideo)},
+		{"Supports hashtags", fmt.Sprint(metadata.SupportsHashtags)},
+		{"Organic", fmt.Sprint(metadata.IsOrganic)},
+		{"Paid", fmt.Sprint(metadata.IsPaid)},
+		{"Reach potential", fmt.Sprintf("%d/10", metadata.ReachPotential)},
+		{"Conversion focus", fmt.Sprintf("%d/10", metadata.ConversionFocus)},
+	}
+}
+
+// joinBusinessTypes renders business types as a comma-separated list
+func joinBusinessTypes(types []core.BusinessType) string {
+	names := make([]string, 0, len(types))
+	for _, businessType := range types {
+		names = append(names, string(businessType))
+	}
+	return strings.Join(names, ", ")
+}
+
+// sortedKeys returns the keys of a string map in sorted order
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/diff"
+	"biz-flow/internal/profiles"
+)
+
+// defaultProfilesDB is where profiles are kept when neither -db nor
+// $BIZFLOW_PROFILES is set
+const defaultProfilesDB = "bizflow-profiles.db"
+
+// profileSubcommands lists the profile subcommands in the order usage shows
+// them
+var profileSubcommands = []string{"save", "list", "show", "history", "consult", "diff", "delete"}
+
+// runProfile saves businesses as revisioned profiles, consults them again
+// and compares their consultations
+func runProfile(c *cli, args []string) error {
+	if len(args) == 0 {
+		return usageErrorf("profile requires a subcommand: %s", strings.Join(profileSubcommands, ", "))
+	}
+	sub, args := args[0], args[1:]
+
+	fs := flag.NewFlagSet("profile "+sub, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	dbPath := os.Getenv("BIZFLOW_PROFILES")
+	if dbPath == "" {
+		dbPath = defaultProfilesDB
+	}
+	fs.StringVar(&dbPath, "db", dbPath, "SQLite database of business profiles (also $BIZFLOW_PROFILES)")
+	format := formatFlag(fs)
+
+	var (
+		bf       *businessFlags
+		pf       *pipelineFlags
+		note     *string
+		revision *int
+		from, to *int
+		rerun    *bool
+	)
+	switch sub {
+	case "save":
+		bf = addBusinessFlags(fs)
+		note = fs.String("note", "", "why the business changed, kept with the revision")
+	case "show":
+		revision = fs.Int("revision", 0, "revision to show (default latest)")
+	case "consult":
+		pf = addPipelineFlags(fs, "off")
+		revision = fs.Int("revision", 0, "revision to consult (default latest)")
+	case "diff":
+		pf = addPipelineFlags(fs, "off")
+		from = fs.Int("from", 0, "earlier revision (default the consulted revision before -to)")
+		to = fs.Int("to", 0, "later revision (default the latest consulted)")
+		rerun = fs.Bool("rerun", false, "consult -to (default the latest revision) again against the current rules and compare with its stored consultation")
+	case "list", "history", "delete":
+	default:
+		return usageErrorf("unknown profile subcommand %q (want %s)", sub, strings.Join(profileSubcommands, ", "))
+	}
+
+	// Accept the profile name either before or after the flags
+	var name string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if name == "" && fs.NArg() > 0 {
+		name = fs.Arg(0)
+	} else if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if sub == "list" && name != "" {
+		return usageErrorf("profile list takes no profile name")
+	}
+	if sub != "list" && name == "" {
+		return usageErrorf("profile %s requires a profile name", sub)
+	}
+	if rerun != nil && *rerun && *from != 0 {
+		return usageErrorf("-rerun compares with the revision's own consultation; use -to, not -from")
+	}
+	if err := core.ValidateProfile(name); err != nil {
+		return err
+	}
+
+	repo, err := profiles.NewSQLiteRepository(dbPath)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	switch sub {
+	case "save":
+		return saveProfile(ctx, c, repo, bf, name, *note, *format)
+	case "list":
+		list, err := repo.List(ctx)
+		if err != nil {
+			return err
+		}
+		return writeOutput(c.stdout, *format, list,
+			func(w io.Writer) error { return writeProfileListText(w, list) },
+			func(w io.Writer) error { return writeProfileListMarkdown(w, list) },
+		)
+	case "show":
+		shown, err := repo.Get(ctx, name, *revision)
+		if err != nil {
+			return profileError(err)
+		}
+		return writeOutput(c.stdout, *format, shown,
+			func(w io.Writer) error { return writeRevisionText(w, shown) },
+			func(w io.Writer) error { return writeRevisionMarkdown(w, shown) },
+		)
+	case "history":
+		history, err := repo.History(ctx, name)
+		if err != nil {
+			return profileError(err)
+		}
+		return writeOutput(c.stdout, *format, history,
+			func(w io.Writer) error { return writeHistoryText(w, history) },
+			func(w io.Writer) error { return writeHistoryMarkdown(w, history) },
+		)
+	case "consult":
+		consultant, _, err := pf.newAgent()
+		if err != nil {
+			return err
+		}
+		consultation, err := profiles.Consult(ctx, repo, consultant.Consult, name, *revision)
+		if err != nil {
+			return profileError(err)
+		}
+		return writeOutput(c.stdout, *format, consultation,
+			func(w io.Writer) error { return writeProfileConsultationText(w, consultation) },
+			func(w io.Writer) error { return writeProfileConsultationMarkdown(w, consultation) },
+		)
+	case "diff":
+		var comparison *diff.Comparison
+		if *rerun {
+			consultant, _, err := pf.newAgent()
+			if err != nil {
+				return err
+			}
+			comparison, err = profiles.Rerun(ctx, repo, consultant.Consult, name, *to)
+			if err != nil {
+				return profileError(err)
+			}
+		} else if comparison, err = profiles.DiffRevisions(ctx, repo, name, *from, *to); err != nil {
+			return profileError(err)
+		}
+		return writeOutput(c.stdout, *format, comparison,
+			func(w io.Writer) error { return writeComparisonText(w, comparison) },
+			func(w io.Writer) error { return writeComparisonMarkdown(w, comparison) },
+		)
+	default: // delete
+		if err := repo.Delete(ctx, name); err != nil {
+			return profileError(err)
+		}
+		fmt.Fprintf(c.stderr, "Deleted profile %s\n", name)
+		return nil
+	}
+}
+
+// saveProfile stores the flags, applied to the profile's latest revision,
+// as its next revision
+func saveProfile(ctx context.Context, c *cli, repo profiles.Repository, bf *businessFlags, name, note string, format outputFormat) error {
+	var base core.BusinessInput
+	latest, err := repo.Get(ctx, name, 0)
+	if err == nil {
+		base = latest.Business
+	} else if !errors.Is(err, profiles.ErrNotFound) {
+		return err
+	}
+
+	business, err := bf.edit(c, base)
+	if err != nil {
+		return err
+	}
+	saved, created, err := repo.Save(ctx, name, business, note)
+	if err != nil {
+		return err
+	}
+	if !created {
+		fmt.Fprintf(c.stderr, "Profile %s is unchanged at revision %d\n", name, saved.Number)
+	}
+	return writeOutput(c.stdout, format, saved,
+		func(w io.Writer) error { return writeRevisionText(w, saved) },
+		func(w io.Writer) error { return writeRevisionMarkdown(w, saved) },
+	)
+}
+
+// profileError reports unknown profiles, revisions and missing
+// consultations as invalid input
+func profileError(err error) error {
+	if errors.Is(err, profiles.ErrNotFound) || errors.Is(err, profiles.ErrNotConsulted) {
+		return &exitError{code: exitInvalidInput, err: err}
+	}
+	return err
+}
+
+// writeProfileListText prints one line per profile
+func writeProfileListText(w io.Writer, list []profiles.Profile) error {
+	if len(list) == 0 {
+		_, err := fmt.Fprintln(w, "No profiles saved yet.")
+		return err
+	}
+	for _, profile := range list {
+		consulted := "never consulted"
+		if profile.ConsultedAt != nil {
+			consulted = "consulted " + profile.ConsultedAt.Format("2006-01-02")
+		}
+		fmt.Fprintf(w, "%-24s rev %-3d %s; %s\n", profile.Name, profile.Revision, profile.Business.String(), consulted)
+	}
+	return nil
+}
+
+// writeProfileListMarkdown prints the profiles as a Markdown table
+func writeProfileListMarkdown(w io.Writer, list []profiles.Profile) error {
+	fmt.Fprintf(w, "| Profile | Revision | Business | Last consulted |\n|---|---|---|---|\n")
+	for _, profile := range list {
+		consulted := "never"
+		if profile.ConsultedAt != nil {
+			consulted = profile.ConsultedAt.Format("2006-01-02")
+		}
+		fmt.Fprintf(w, "| %s | %d | %s | %s |\n", profile.Name, profile.Revision, profile.Business.String(), consulted)
+	}
+	return nil
+}
+
+// writeRevisionText prints a revision's business and, once consulted, its
+// consultation
+func writeRevisionText(w io.Writer, revision *profiles.Revision) error {
+	fmt.Fprintf(w, "Profile:  %s, revision %d (%s)\n", revision.Profile, revision.Number, revision.CreatedAt.Format("2006-01-02 15:04"))
+	if revision.Note != "" {
+		fmt.Fprintf(w, "Note:     %s\n", revision.Note)
+	}
+	if revision.Result == nil {
+		fmt.Fprintf(w, "Business: %s\n", revision.Business.String())
+		_, err := fmt.Fprintf(w, "\nNot consulted yet; run 'agent profile consult %s'.\n", revision.Profile)
+		return err
+	}
+	return writeConsultationText(w, revision.Business, revision.Result)
+}
+
+// writeRevisionMarkdown prints a revision and its consultation as Markdown
+func writeRevisionMarkdown(w io.Writer, revision *profiles.Revision) error {
+	fmt.Fprintf(w, "**Profile:** %s, revision %d (%s)\n\n", revision.Profile, revision.Number, revision.CreatedAt.Format("2006-01-02 15:04"))
+	if revision.Note != "" {
+		fmt.Fprintf(w, "**Note:** %s\n\n", revision.Note)
+	}
+	if revision.Result == nil {
+		_, err := fmt.Fprintf(w, "**Business:** %s\n\nNot consulted yet.\n", revision.Business.String())
+		return err
+	}
+	return writeConsultationMarkdown(w, revision.Business, revision.Result)
+}
+
+// writeHistoryText prints each revision with what it changed
+func writeHistoryText(w io.Writer, history []profiles.Revision) error {
+	for i, revision := range history {
+		consulted := ""
+		if revision.ConsultedAt != nil {
+			consulted = " [consulted]"
+		}
+		fmt.Fprintf(w, "%d. %s%s", revision.Number, revision.CreatedAt.Format("2006-01-02 15:04"), consulted)
+		if revision.Note != "" {
+			fmt.Fprintf(w, "  %s", revision.Note)
+		}
+		fmt.Fprintln(w)
+		if i == 0 {
+			fmt.Fprintf(w, "   %s\n", revision.Business.String())
+			continue
+		}
+		for _, change := range diff.Changes(history[i-1].Business, revision.Business) {
+			fmt.Fprintf(w, "   %s\n", changeText(change))
+		}
+	}
+	return nil
+}
+
+// writeHistoryMarkdown prints the revisions as a Markdown table
+func writeHistoryMarkdown(w io.Writer, history []profiles.Revision) error {
+	fmt.Fprintf(w, "| Revision | Saved | Consulted | Note | Changes |\n|---|---|---|---|---|\n")
+	for i, revision := range history {
+		consulted := "no"
+		if revision.ConsultedAt != nil {
+			consulted = "yes"
+		}
+		changes := revision.Business.String()
+		if i > 0 {
+			var lines []string
+			for _, change := range diff.Changes(history[i-1].Business, revision.Business) {
+				lines = append(lines, changeText(change))
+			}
+			changes = strings.Join(lines, "; ")
+		}
+		fmt.Fprintf(w, "| %d | %s | %s | %s | %s |\n",
+			revision.Number, revision.CreatedAt.Format("2006-01-02 15:04"), consulted, revision.Note, changes)
+	}
+	return nil
+}
+
+// writeProfileConsultationText prints a profile's new consultation and what
+// changed since the last one
+func writeProfileConsultationText(w io.Writer, consultation *profiles.Consultation) error {
+	if err := writeRevisionText(w, consultation.Revision); err != nil {
+		return err
+	}
+	if consultation.Diff == nil {
+		return nil
+	}
+	fmt.Fprintf(w, "\nSINCE %s:\n", strings.ToUpper(consultation.Diff.From))
+	return writeComparisonText(w, consultation.Diff)
+}
+
+// writeProfileConsultationMarkdown prints a profile's new consultation and
+// its diff as Markdown
+func writeProfileConsultationMarkdown(w io.Writer, consultation *profiles.Consultation) error {
+	if err := writeRevisionMarkdown(w, consultation.Revision); err != nil {
+		return err
+	}
+	if consultation.Diff == nil {
+		return nil
+	}
+	fmt.Fprintf(w, "\n## Since %s\n\n", consultation.Diff.From)
+	return writeComparisonMarkdown(w, consultation.Diff)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+)
+
+// runQuestionnaire asks the business questions and writes the BusinessInput JSON
+func runQuestionnaire(c *cli, args []string) error {
+	fs := flag.NewFlagSet("questionnaire", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	outPath := fs.String("out", "-", "where to write the BusinessInput JSON (\"-\" for stdout)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	business, err := newWizard(c.stdin, c.stderr).run()
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = c.stdout
+	if *outPath != "-" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(business)
+}
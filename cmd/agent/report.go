@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"biz-flow/internal/calendar"
+	"biz-flow/internal/core"
+	"biz-flow/internal/report"
+)
+
+// runReport renders a consultation as a client-ready Markdown or HTML report
+func runReport(c *cli, args []string) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	bf := addBusinessFlags(fs)
+	pf := addPipelineFlags(fs, "off")
+	resultPath := fs.String("result", "", "consult JSON output to report on instead of running a consultation")
+	format := fs.String("format", "markdown", "output format: markdown, html or json")
+	themeDir := fs.String("theme", os.Getenv("BIZFLOW_REPORT_THEME"), "directory of theme files overlaying the built-in report theme")
+	agency := fs.String("agency", "", "agency name shown as the report's author (default the theme's)")
+	start := fs.String("start", "", "first day of the 90-day plan, YYYY-MM-DD, moved forward to a Monday (default next Monday)")
+	hours := fs.Float64("hours", calendar.DefaultHoursPerWeek, "hours a week the owner can spend on content")
+	outPath := fs.String("out", "-", "where to write the report (\"-\" for stdout)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	switch *format {
+	case "md":
+		*format = "markdown"
+	case "markdown", "html", "json":
+	default:
+		return usageErrorf("unknown format %q (want markdown, html or json)", *format)
+	}
+	options := report.Options{Agency: *agency, HoursPerWeek: *hours}
+	if *start != "" {
+		date, err := time.Parse(calendar.DateLayout, *start)
+		if err != nil {
+			return usageErrorf("-start must be a date like 2025-03-03")
+		}
+		options.Start = date
+	}
+	theme, err := report.Load(*themeDir)
+	if err != nil {
+		return fmt.Errorf("loading report theme: %w", err)
+	}
+
+	business, err := bf.load(c)
+	if err != nil {
+		return err
+	}
+
+	var result *core.ConsultationResult
+	if *resultPath != "" {
+		raw, err := os.ReadFile(*resultPath)
+		if err != nil {
+			return err
+		}
+		result = &core.ConsultationResult{}
+		if err := json.Unmarshal(raw, result); err != nil {
+			return &exitError{code: exitInvalidInput, err: fmt.Errorf("decoding %s: %w", *resultPath, err)}
+		}
+	} else {
+		consultant, _, err := pf.newAgent()
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		if result, err = consultant.Consult(ctx, business); err != nil {
+			return err
+		}
+	}
+
+	built, err := report.NewBuilder().Build(business, result, options)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = c.stdout
+	if *outPath != "-" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	switch *format {
+	case "html":
+		return theme.HTML(out, built)
+	case "json":
+		return writeOutput(out, formatJSON, built, nil, nil)
+	default:
+		return theme.Markdown(out, built)
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"biz-flow/internal/agent"
+	"biz-flow/internal/calendar"
+	"biz-flow/internal/core"
+	"biz-flow/internal/scenario"
+)
+
+// runScenarios ranks what-if variations of a business side by side and finds
+// the budgets where platforms enter or drop out of the top places
+func runScenarios(c *cli, args []string) error {
+	fs := flag.NewFlagSet("scenarios", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	bf := addBusinessFlags(fs)
+	var request scenario.ExploreRequest
+	fs.Float64Var(&request.HoursPerWeek, "hours", calendar.DefaultHoursPerWeek, "hours a week the owner can spend on content")
+	fs.Func("budgets", "budget range to sweep, from:to:step, e.g. 0:500:50", func(value string) error {
+		budgets, err := parseRange(value)
+		request.Budget = budgets
+		return err
+	})
+	fs.Func("goals", "comma-separated goals to sweep: awareness, sales", func(value string) error {
+		for _, goal := range splitChannels(value) {
+			request.Goals = append(request.Goals, core.MarketingGoal(strings.ToLower(goal)))
+		}
+		return nil
+	})
+	fs.Func("hours-range", "weekly hours range to sweep, from:to:step, e.g. 2:10:2", func(value string) error {
+		hours, err := parseRange(value)
+		request.Hours = hours
+		return err
+	})
+	fs.Func("vary", "a named what-if, e.g. \"budget=150,goal=sales,hours=5,name=push sales\" (repeatable)", func(value string) error {
+		variation, err := parseVariation(value)
+		request.Variations = append(request.Variations, variation)
+		return err
+	})
+	fs.IntVar(&request.Top, "top", scenario.DefaultTop, "how many places tipping points track")
+	workers := fs.Int("workers", 0, "scenarios ranked at once (default: number of CPUs)")
+	format := formatFlag(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	business, err := bf.load(c)
+	if err != nil {
+		return err
+	}
+	request.Business = business
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ranker := agent.New(nil)
+	rankAll := func(business core.BusinessInput) ([]core.Recommendation, error) {
+		return ranker.RecommendTop(business, 0)
+	}
+	matrix, err := scenario.Explore(ctx, rankAll, request, *workers)
+	if err != nil {
+		return err
+	}
+	return writeOutput(c.stdout, *format, matrix,
+		func(w io.Writer) error { return writeScenariosText(w, matrix) },
+		func(w io.Writer) error { return writeScenariosMarkdown(w, matrix) },
+	)
+}
+
+// parseRange reads a from:to:step range
+func parseRange(value string) (*scenario.Range, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("want from:to:step, got %q", value)
+	}
+	var numbers [3]float64
+	for i, part := range parts {
+		number, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", part)
+		}
+		numbers[i] = number
+	}
+	return &scenario.Range{From: numbers[0], To: numbers[1], Step: numbers[2]}, nil
+}
+
+// parseVariation reads comma-separated key=value overrides
+func parseVariation(value string) (scenario.Variation, error) {
+	var variation scenario.Variation
+	for _, pair := range strings.Split(value, ",") {
+		key, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			return variation, fmt.Errorf("want key=value, got %q", pair)
+		}
+		key, raw = strings.TrimSpace(key), strings.TrimSpace(raw)
+		switch key {
+		case "name":
+			variation.Name = raw
+		case "goal":
+			variation.Goal = core.MarketingGoal(strings.ToLower(raw))
+		case "budget", "hours":
+			number, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return variation, fmt.Errorf("%s: %q is not a number", key, raw)
+			}
+			if key == "budget" {
+				variation.Budget = &number
+			} else {
+				variation.HoursPerWeek = &number
+			}
+		default:
+			return variation, fmt.Errorf("unknown key %q (want budget, goal, hours or name)", key)
+		}
+	}
+	return variation, nil
+}
+
+// writeScenariosText prints one row per scenario with each platform's rank and
+// posts a week, then the tipping points
+func writeScenariosText(w io.Writer, matrix *scenario.Matrix) error {
+	nameWidth := len("SCENARIO")
+	for _, s := range matrix.Scenarios {
+		nameWidth = max(nameWidth, len(s.Name))
+	}
+	fmt.Fprintf(w, "%-*s  %-6s", nameWidth, "SCENARIO", "TIER")
+	for _, platform := range matrix.Platforms {
+		fmt.Fprintf(w, "  %-*s", columnWidth(platform), platform)
+	}
+	fmt.Fprintln(w, "  HOURS")
+	for _, s := range matrix.Scenarios {
+		fmt.Fprintf(w, "%-*s  %-6s", nameWidth, s.Name, s.BudgetTier)
+		for _, platform := range matrix.Platforms {
+			fmt.Fprintf(w, "  %-*s", columnWidth(platform), scenarioCell(s, platform))
+		}
+		fmt.Fprintf(w, "  %g/%g\n", s.PlannedHours, s.HoursPerWeek)
+	}
+	fmt.Fprintf(w, "\nCells are rank, and posts a week for the top %d; HOURS is planned/available content hours a week.\n", matrix.Top)
+
+	if len(matrix.TippingPoints) > 0 {
+		fmt.Fprintf(w, "\nTIPPING POINTS (top %d):\n", matrix.Top)
+		for _, point := range matrix.TippingPoints {
+			fmt.Fprintf(w, "  %s\n", tippingPointText(point, matrix.Top))
+		}
+	}
+	return nil
+}
+
+// writeScenariosMarkdown prints the scenarios as a Markdown table and the
+// tipping points as a list
+func writeScenariosMarkdown(w io.Writer, matrix *scenario.Matrix) error {
+	fmt.Fprint(w, "| Scenario | Budget tier |")
+	for _, platform := range matrix.Platforms {
+		fmt.Fprintf(w, " %s |", platform)
+	}
+	fmt.Fprint(w, " Hours (planned/available) |\n|---|---|")
+	fmt.Fprint(w, strings.Repeat("---|", len(matrix.Platforms)+1))
+	fmt.Fprintln(w)
+	for _, s := range matrix.Scenarios {
+		fmt.Fprintf(w, "| %s | %s |", s.Name, s.BudgetTier)
+		for _, platform := range matrix.Platforms {
+			fmt.Fprintf(w, " %s |", scenarioCell(s, platform))
+		}
+		fmt.Fprintf(w, " %g/%g |\n", s.PlannedHours, s.HoursPerWeek)
+	}
+	fmt.Fprintln(w)
+
+	if len(matrix.TippingPoints) > 0 {
+		fmt.Fprintf(w, "### Tipping points (top %d)\n\n", matrix.Top)
+		for _, point := range matrix.TippingPoints {
+			fmt.Fprintf(w, "- %s\n", tippingPointText(point, matrix.Top))
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// columnWidth fits a platform's column to its name and its cells
+func columnWidth(platform core.Platform) int {
+	return max(len(platform), len("#10 2.5/wk"))
+}
+
+// scenarioCell shows a platform's rank and posts a week in a scenario
+func scenarioCell(s scenario.Scenario, platform core.Platform) string {
+	rank := s.Rank(platform)
+	if rank == 0 {
+		return "-"
+	}
+	for _, cadence := range s.Cadence {
+		if cadence.Platform == platform {
+			return fmt.Sprintf("#%d %g/wk", rank, cadence.PostsPerWeek)
+		}
+	}
+	return fmt.Sprintf("#%d", rank)
+}
+
+// tippingPointText describes where a platform enters or drops out of the top
+// places
+func tippingPointText(point scenario.TippingPoint, top int) string {
+	text := fmt.Sprintf("%s: %s drops out of the top %d from $%.2f", point.Goal, point.Platform, top, point.Budget)
+	if point.Enters {
+		text = fmt.Sprintf("%s: %s enters the top %d at #%d from $%.2f", point.Goal, point.Platform, top, point.Rank, point.Budget)
+	}
+	if point.FromTier != point.ToTier {
+		text += fmt.Sprintf(" (%s → %s budget tier)", point.FromTier, point.ToTier)
+	}
+	return text
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"biz-flow/internal/sensitivity"
+)
+
+// runSensitivity perturbs the numbers behind a business's platform scores and
+// reports how stable its recommendations are
+func runSensitivity(c *cli, args []string) error {
+	fs := flag.NewFlagSet("sensitivity", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	bf := addBusinessFlags(fs)
+	pf := addPipelineFlags(fs, "off")
+	var options sensitivity.Options
+	fs.IntVar(&options.Runs, "runs", sensitivity.DefaultRuns, fmt.Sprintf("Monte Carlo runs, at most %d", sensitivity.MaxRuns))
+	fs.Float64Var(&options.Spread, "spread", sensitivity.DefaultSpread, "largest relative perturbation of each parameter, e.g. 0.2 for ±20%")
+	fs.Int64Var(&options.Seed, "seed", sensitivity.DefaultSeed, "random seed; the same seed gives the same report")
+	format := formatFlag(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	business, err := bf.load(c)
+	if err != nil {
+		return err
+	}
+	analyst, _, err := pf.newAgent()
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	report, err := analyst.Sensitivity(ctx, business, options)
+	if err != nil {
+		return err
+	}
+	return writeOutput(c.stdout, *format, report,
+		func(w io.Writer) error { return writeSensitivityText(w, report) },
+		func(w io.Writer) error { return writeSensitivityMarkdown(w, report) },
+	)
+}
+
+// writeSensitivityText prints each platform's stability, then the parameters
+// the top places depend on most
+func writeSensitivityText(w io.Writer, report *sensitivity.Report) error {
+	fmt.Fprintln(w, sensitivitySummary(report))
+	fmt.Fprintf(w, "\n%-4s  %-20s  %6s  %6s  %-13s  %6s  %6s  %s\n",
+		"RANK", "PLATFORM", "SCORE", "MARGIN", "95% RANGE", "KEPT", "TOP", "VERDICT")
+	for _, platform := range report.Platforms {
+		verdict := platform.Verdict
+		if platform.Rank > report.Top {
+			verdict = ""
+		}
+		fmt.Fprintf(w, "#%-3d  %-20s  %6.1f  %6.1f  %-13s  %5.0f%%  %5.0f%%  %s\n",
+			platform.Rank, platform.Platform, platform.Score, platform.Margin,
+			fmt.Sprintf("%.1f-%.1f", platform.ScoreLow, platform.ScoreHigh),
+			platform.RankStability*100, platform.TopShare*100, verdict)
+	}
+	fmt.Fprintf(w, "\nKEPT is the share of runs a platform held its rank; TOP the share it ranked in the top %d.\n", report.Top)
+
+	if len(report.Influences) > 0 {
+		fmt.Fprintln(w, "\nMOST INFLUENTIAL PARAMETERS:")
+		for _, influence := range report.Influences {
+			fmt.Fprintf(w, "  %s\n", influenceText(influence))
+		}
+	}
+	return nil
+}
+
+// writeSensitivityMarkdown prints the report as Markdown tables
+func writeSensitivityMarkdown(w io.Writer, report *sensitivity.Report) error {
+	fmt.Fprintf(w, "**%s**\n\n", sensitivitySummary(report))
+	fmt.Fprintln(w, "| Rank | Platform | Score | Margin | 95% range | Kept rank | In top | Verdict |")
+	fmt.Fprintln(w, "|---|---|---|---|---|---|---|---|")
+	for _, platform := range report.Platforms {
+		verdict := platform.Verdict
+		if platform.Rank > report.Top {
+			verdict = ""
+		}
+		fmt.Fprintf(w, "| #%d | %s | %.1f | %.1f | %.1f-%.1f | %.0f%% | %.0f%% | %s |\n",
+			platform.Rank, platform.Platform, platform.Score, platform.Margin, platform.ScoreLow, platform.ScoreHigh,
+			platform.RankStability*100, platform.TopShare*100, verdict)
+	}
+	fmt.Fprintln(w)
+
+	if len(report.Influences) > 0 {
+		fmt.Fprintln(w, "### Most influential parameters")
+		fmt.Fprintln(w)
+		for _, influence := range report.Influences {
+			fmt.Fprintf(w, "- %s\n", influenceText(influence))
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// sensitivitySummary says how often the top places held across the runs
+func sensitivitySummary(report *sensitivity.Report) string {
+	return fmt.Sprintf("Top %d held in order in %.0f%% of %d runs, and as a set in %.0f%% (parameters perturbed by up to ±%g%%, seed %d).",
+		report.Top, report.Stability*100, report.Runs, report.SetStability*100, report.Spread*100, report.Seed)
+}
+
+// influenceText describes what moving one parameter across the spread does to
+// its platform's score
+func influenceText(influence sensitivity.Influence) string {
+	text := fmt.Sprintf("%s %s %.2f (%.2f-%.2f): score %.1f-%.1f",
+		influence.Platform, influence.Parameter, influence.Value, influence.Low, influence.High,
+		influence.ScoreLow, influence.ScoreHigh)
+	if influence.Reorders {
+		text += ", reorders the top places"
+	}
+	return text
+}
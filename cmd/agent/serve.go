@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"biz-flow/internal/clarify"
+	"biz-flow/internal/handler"
+	"biz-flow/internal/jobs"
+	"biz-flow/internal/profiles"
+	"biz-flow/web"
+)
+
+// runServe serves the consultation API until interrupted
+func runServe(c *cli, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	addr := fs.String("addr", defaultAddr(), "address to listen on")
+	jobsBackend := fs.String("jobs-backend", "memory", "job queue backend: memory or sqlite")
+	jobsDB := fs.String("jobs-db", "bizflow-jobs.db", "SQLite database for the sqlite job backend")
+	profilesDB := fs.String("profiles-db", os.Getenv("BIZFLOW_PROFILES"), "SQLite database of business profiles; enables the /profiles endpoints")
+	jobWorkers := fs.Int("job-workers", jobs.DefaultOptions().Workers, "number of concurrent background consultations")
+	pf := addPipelineFlags(fs, "memory")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	consultant, stageCache, err := pf.newAgent()
+	if err != nil {
+		return err
+	}
+	pages, err := web.NewHandler(consultant)
+	if err != nil {
+		return err
+	}
+
+	queue, err := openJobQueue(*jobsBackend, *jobsDB)
+	if err != nil {
+		return err
+	}
+	defer queue.Close()
+
+	jobOptions := jobs.DefaultOptions()
+	jobOptions.Workers = *jobWorkers
+	manager := jobs.NewManager(queue, consultant.Consult, jobOptions)
+
+	mux := http.NewServeMux()
+	handler.NewAgentHandler(consultant).RegisterRoutes(mux)
+	handler.NewStreamHandler(consultant).RegisterRoutes(mux)
+	handler.NewJobsHandler(manager).RegisterRoutes(mux)
+	handler.NewExportHandler(consultant).RegisterRoutes(mux)
+	handler.NewDiffHandler(consultant).RegisterRoutes(mux)
+	handler.NewScenariosHandler(consultant).RegisterRoutes(mux)
+	handler.NewSensitivityHandler(consultant).RegisterRoutes(mux)
+	handler.NewConversationsHandler(consultant, clarify.NewStore()).RegisterRoutes(mux)
+	handler.NewMetricsHandler(stageCache).RegisterRoutes(mux)
+	if results, _ := pf.feedbackStore(); results != nil {
+		handler.NewFeedbackHandler(results).RegisterRoutes(mux)
+	}
+	if *profilesDB != "" {
+		repo, err := profiles.NewSQLiteRepository(*profilesDB)
+		if err != nil {
+			return err
+		}
+		defer repo.Close()
+		handler.NewProfilesHandler(repo, consultant).RegisterRoutes(mux)
+	}
+	pages.RegisterRoutes(mux)
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	manager.Start(ctx)
+	defer func() {
+		stop() // Workers only exit once the context is done
+		manager.Wait()
+	}()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		fmt.Fprintf(c.stderr, "Listening on %s\n", *addr)
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// defaultAddr listens on $PORT when set, otherwise on :8080
+func defaultAddr() string {
+	if port := os.Getenv("PORT"); port != "" {
+		return ":" + port
+	}
+	return ":8080"
+}
+
+// openJobQueue opens the configured job queue backend
+func openJobQueue(backend, path string) (jobs.Queue, error) {
+	switch backend {
+	case "memory":
+		return jobs.NewMemoryQueue(), nil
+	case "sqlite":
+		return jobs.NewSQLiteQueue(path)
+	default:
+		return nil, usageErrorf("unknown job backend %q (want memory or sqlite)", backend)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"biz-flow/internal/ai"
+	"biz-flow/internal/core"
+	"biz-flow/internal/prompts"
+)
+
+// defaultPlatformConfig is the platform config shipped with the repository
+const defaultPlatformConfig = "config/platforms.json"
+
+// configReport is the result of validate-config
+type configReport struct {
+	Path     string   `json:"path"`
+	Routing  string   `json:"routing,omitempty"`
+	Prompts  string   `json:"prompts,omitempty"`
+	Valid    bool     `json:"valid"`
+	Problems []string `json:"problems"`
+	Warnings []string `json:"warnings"`
+}
+
+// runValidateConfig checks the platform config file, the optional routing
+// config and prompt directory, and the LLM environment
+func runValidateConfig(c *cli, args []string) error {
+	fs := flag.NewFlagSet("validate-config", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	routing := fs.String("routing", os.Getenv("BIZFLOW_ROUTING"), "LLM routing config to check as well")
+	promptDir := fs.String("prompts", os.Getenv("BIZFLOW_PROMPTS"), "prompt directory to check as well")
+	format := formatFlag(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	report := configReport{
+		Path:     defaultPlatformConfig,
+		Problems: make([]string, 0),
+		Warnings: make([]string, 0),
+	}
+	if fs.NArg() > 0 {
+		report.Path = fs.Arg(0)
+	}
+
+	platforms, err := core.LoadPlatformConfig(report.Path)
+	if err != nil {
+		report.Problems = append(report.Problems, err.Error())
+	}
+	for _, problem := range core.ValidatePlatformConfig(platforms) {
+		if err == nil {
+			report.Problems = append(report.Problems, problem.Error())
+		}
+	}
+
+	if *promptDir != "" {
+		report.Prompts = *promptDir
+		if _, err := prompts.Load(*promptDir); err != nil {
+			report.Problems = append(report.Problems, strings.Split(err.Error(), "\n")...)
+		}
+	}
+
+	switch {
+	case *routing != "":
+		report.Routing = *routing
+		cfg, err := ai.LoadRoutingConfig(*routing)
+		if err != nil {
+			report.Problems = append(report.Problems, strings.Split(err.Error(), "\n")...)
+		} else if _, err := ai.NewRouter(cfg); err != nil {
+			// Keys are deployment concerns; the file itself is fine
+			report.Warnings = append(report.Warnings, err.Error())
+		}
+	case os.Getenv("OPENROUTER_API_KEY") == "":
+		report.Warnings = append(report.Warnings, "OPENROUTER_API_KEY is not set; rule-based personas and offline templates will be used")
+	case os.Getenv("OPENROUTER_MODEL") == "":
+		report.Warnings = append(report.Warnings, fmt.Sprintf("OPENROUTER_MODEL is not set; using %s", ai.DefaultModel))
+	}
+
+	report.Valid = len(report.Problems) == 0
+
+	text := func(w io.Writer) error {
+		status := "valid"
+		if !report.Valid {
+			status = "INVALID"
+		}
+		fmt.Fprintf(w, "%s: %s\n", report.Path, status)
+		if report.Routing != "" {
+			fmt.Fprintf(w, "  routing: %s\n", report.Routing)
+		}
+		if report.Prompts != "" {
+			fmt.Fprintf(w, "  prompts: %s\n", report.Prompts)
+		}
+		for _, problem := range report.Problems {
+			fmt.Fprintf(w, "  error:   %s\n", problem)
+		}
+		for _, warning := range report.Warnings {
+			fmt.Fprintf(w, "  warning: %s\n", warning)
+		}
+		return nil
+	}
+	if err := writeOutput(c.stdout, *format, report, text, nil); err != nil {
+		return err
+	}
+
+	if !report.Valid {
+		return &exitError{code: exitInvalidInput}
+	}
+	return nil
+}
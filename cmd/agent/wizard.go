@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"biz-flow/internal/clarify"
+	"biz-flow/internal/core"
+)
+
+// maxAttempts is how many invalid answers a question tolerates before giving up
+const maxAttempts = 3
+
+// wizard walks a business owner through the BusinessInput questions one line
+// at a time. It reads answers from any reader, so it can be scripted via stdin.
+type wizard struct {
+	lines *lineReader
+	out   io.Writer
+}
+
+// question is one step of the questionnaire
+type question struct {
+	prompt   string
+	hint     string
+	optional bool
+	apply    func(business *core.BusinessInput, answer string) error
+}
+
+func newWizard(in io.Reader, out io.Writer) *wizard {
+	return &wizard{lines: newLineReader(in), out: out}
+}
+
+// run asks every question, then any follow-ups the description calls for
+func (wz *wizard) run() (core.BusinessInput, error) {
+	business := core.BusinessInput{Channels: []string{}}
+
+	fmt.Fprintln(wz.out, "Let's learn about your business. Press Enter to skip optional questions.")
+	for _, q := range questions() {
+		if err := wz.ask(q, &business); err != nil {
+			return business, err
+		}
+	}
+
+	for _, q := range followUps(business) {
+		if err := wz.ask(q, &business); err != nil {
+			return business, err
+		}
+	}
+
+	return business, business.Validate()
+}
+
+// ask repeats a question until the answer passes validation
+func (wz *wizard) ask(q question, business *core.BusinessInput) error {
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		fmt.Fprintf(wz.out, "\n%s", q.prompt)
+		if q.hint != "" {
+			fmt.Fprintf(wz.out, " [%s]", q.hint)
+		}
+		fmt.Fprint(wz.out, ": ")
+
+		answer, err := wz.lines.next()
+		if err != nil {
+			return err
+		}
+		if answer == "" && q.optional {
+			return nil
+		}
+
+		if lastErr = q.apply(business, answer); lastErr == nil {
+			return nil
+		}
+		fmt.Fprintf(wz.out, "  ✗ %v\n", lastErr)
+	}
+	return lastErr
+}
+
+// questions lists the core questionnaire in order
+func questions() []question {
+	return []question{
+		{
+			prompt: "What kind of business is it?",
+			hint:   "1) retail  2) service  3) digital",
+			apply: func(business *core.BusinessInput, answer string) error {
+				businessType := core.BusinessType(choice(answer, "retail", "service", "digital"))
+				if err := core.ValidateType(businessType); err != nil {
+					return err
+				}
+				business.Type = businessType
+				return nil
+			},
+		},
+		{
+			prompt: "Describe what you sell and who buys it",
+			apply: func(business *core.BusinessInput, answer string) error {
+				if err := core.ValidateDescription(answer); err != nil {
+					return err
+				}
+				business.Description = answer
+				return nil
+			},
+		},
+		{
+			prompt:   "Where are you located?",
+			hint:     "city or region, blank for online-only",
+			optional: true,
+			apply: func(business *core.BusinessInput, answer string) error {
+				business.Location = answer
+				return nil
+			},
+		},
+		{
+			prompt: "What is your monthly marketing budget in dollars?",
+			hint:   "0 is fine",
+			apply: func(business *core.BusinessInput, answer string) error {
+				budget, err := clarify.ParseBudget(answer)
+				if err != nil {
+					return err
+				}
+				if err := core.ValidateBudget(budget); err != nil {
+					return err
+				}
+				business.Budget = budget
+				return nil
+			},
+		},
+		{
+			prompt: "What is your main goal?",
+			hint:   "1) awareness  2) sales",
+			apply: func(business *core.BusinessInput, answer string) error {
+				goal := core.MarketingGoal(choice(answer, "awareness", "sales"))
+				if err := core.ValidateGoal(goal); err != nil {
+					return err
+				}
+				business.Goal = goal
+				return nil
+			},
+		},
+		{
+			prompt:   "Which channels do you already use?",
+			hint:     "comma-separated, e.g. instagram, whatsapp",
+			optional: true,
+			apply: func(business *core.BusinessInput, answer string) error {
+				business.Channels = normalizeChannels(splitChannels(answer))
+				return nil
+			},
+		},
+		{
+			prompt:   "Which language should the plan be written in?",
+			hint:     "1) en  2) es  3) pt  4) sw  5) hi, blank for English",
+			optional: true,
+			apply: func(business *core.BusinessInput, answer string) error {
+				locale := core.Locale(choice(answer, "en", "es", "pt", "sw", "hi"))
+				if err := core.ValidateLocale(locale); err != nil {
+					return err
+				}
+				business.Locale = locale
+				return nil
+			},
+		},
+	}
+}
+
+// followUps returns extra questions for descriptions too vague to act on.
+// Answers are appended to the description so later stages can use them.
+func followUps(business core.BusinessInput) []question {
+	words := strings.Fields(business.Description)
+	extras := make([]question, 0)
+
+	if clarify.VagueDescription(business.Description) {
+		extras = append(extras, question{
+			prompt:   "Your description is quite short. What exactly do you sell or offer?",
+			optional: true,
+			apply:    appendToDescription("Offers"),
+		})
+	}
+
+	if len(words) < 12 && !mentionsCustomers(business.Description) {
+		extras = append(extras, question{
+			prompt:   "Who are your typical customers?",
+			hint:     "e.g. students, new parents, local restaurants",
+			optional: true,
+			apply:    appendToDescription("Customers"),
+		})
+	}
+
+	return extras
+}
+
+// appendToDescription adds a labelled follow-up answer to the description
+func appendToDescription(label string) func(*core.BusinessInput, string) error {
+	return func(business *core.BusinessInput, answer string) error {
+		description := strings.TrimRight(strings.TrimSpace(business.Description), ".")
+		business.Description = fmt.Sprintf("%s. %s: %s", description, label, answer)
+		return nil
+	}
+}
+
+// mentionsCustomers reports whether a description already names an audience
+func mentionsCustomers(description string) bool {
+	description = strings.ToLower(description)
+	for _, hint := range []string{"customer", "client", "for ", "buyers", "people", "families", "students"} {
+		if strings.Contains(description, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// choice resolves a numbered or named answer against the options
+func choice(answer string, options ...string) string {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if index, err := strconv.Atoi(answer); err == nil && index >= 1 && index <= len(options) {
+		return options[index-1]
+	}
+	for _, option := range options {
+		if answer != "" && strings.HasPrefix(option, answer) {
+			return option
+		}
+	}
+	return answer
+}
+
+// normalizeChannels maps channel names onto known platforms where possible
+func normalizeChannels(channels []string) []string {
+	normalized := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		if platform, ok := core.ParsePlatform(channel); ok {
+			normalized = append(normalized, string(platform))
+			continue
+		}
+		normalized = append(normalized, channel)
+	}
+	return normalized
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"biz-flow/internal/core"
+)
+
+func TestWizard(t *testing.T) {
+	tests := []struct {
+		name   string
+		script []string
+		want   core.BusinessInput
+		// prompts lists text the wizard must write, in order
+		prompts []string
+		wantErr error
+	}{
+		{
+			name:   "every answer given",
+			script: []string{"2", "Dog grooming for busy pet owners", "Austin", "$80", "sales", "instagram, whatsapp", "es"},
+			want: core.BusinessInput{
+				Type: core.Service, Description: "Dog grooming for busy pet owners", Location: "Austin", Budget: 80,
+				Goal: core.Sales, Channels: []string{"Instagram", "WhatsApp Business"}, Locale: core.Spanish,
+			},
+			prompts: []string{"What kind of business is it?", "Describe what you sell", "Where are you located?",
+				"monthly marketing budget", "main goal", "Which channels", "Which language"},
+		},
+		{
+			name:   "optional questions skipped",
+			script: []string{"retail", "Handmade ceramic mugs for coffee lovers", "", "0", "1", "", ""},
+			want: core.BusinessInput{
+				Type: core.Retail, Description: "Handmade ceramic mugs for coffee lovers", Goal: core.Awareness, Channels: []string{},
+			},
+		},
+		{
+			name: "invalid answers are asked again",
+			script: []string{"restaurant", "3", "", "Online courses for students", "", "lots", "-5", "40/month",
+				"growth", "sales", "", "fr", "pt"},
+			want: core.BusinessInput{
+				Type: core.Digital, Description: "Online courses for students", Budget: 40, Goal: core.Sales,
+				Channels: []string{}, Locale: core.Portuguese,
+			},
+			prompts: []string{"What kind of business is it?", "✗", "What kind of business is it?", "Describe what you sell",
+				"✗", "Describe what you sell", "✗ invalid budget: \"lots\" is not a number", "✗ invalid budget: must not be negative"},
+		},
+		{
+			name:    "budgets that are not finite are asked again",
+			script:  []string{"retail", "Handmade ceramic mugs for coffee lovers", "", "NaN", "Inf", "150", "sales", "", ""},
+			want:    core.BusinessInput{Type: core.Retail, Description: "Handmade ceramic mugs for coffee lovers", Budget: 150, Goal: core.Sales, Channels: []string{}},
+			prompts: []string{"✗ invalid budget: must be a finite number", "✗ invalid budget: must be a finite number"},
+		},
+		{
+			name:    "follow-ups for a vague description",
+			script:  []string{"retail", "Mugs", "", "20", "sales", "", "", "Handmade ceramic mugs", "coffee shops"},
+			want:    core.BusinessInput{Type: core.Retail, Description: "Mugs. Offers: Handmade ceramic mugs. Customers: coffee shops", Budget: 20, Goal: core.Sales, Channels: []string{}},
+			prompts: []string{"Which language", "What exactly do you sell or offer?", "Who are your typical customers?"},
+		},
+		{
+			name:    "follow-ups can be skipped",
+			script:  []string{"retail", "Mugs", "", "20", "sales", "", "", "", ""},
+			want:    core.BusinessInput{Type: core.Retail, Description: "Mugs", Budget: 20, Goal: core.Sales, Channels: []string{}},
+			prompts: []string{"What exactly do you sell or offer?", "Who are your typical customers?"},
+		},
+		{
+			name:    "too many invalid answers",
+			script:  []string{"a", "b", "c", "retail"},
+			wantErr: &core.ValidationError{Field: "type"},
+		},
+		{
+			name:    "input ends mid-questionnaire",
+			script:  []string{"retail", "Handmade ceramic mugs for coffee lovers"},
+			wantErr: io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			business, err := newWizard(strings.NewReader(strings.Join(tt.script, "\n")+"\n"), &out).run()
+
+			rest := out.String()
+			for _, prompt := range tt.prompts {
+				index := strings.Index(rest, prompt)
+				if index < 0 {
+					t.Fatalf("no %q after the earlier prompts in:\n%s", prompt, out.String())
+				}
+				rest = rest[index+len(prompt):]
+			}
+
+			if tt.wantErr != nil {
+				var validationErr *core.ValidationError
+				if want, ok := tt.wantErr.(*core.ValidationError); ok {
+					if !errors.As(err, &validationErr) || validationErr.Field != want.Field {
+						t.Fatalf("error = %v, want a ValidationError on %q", err, want.Field)
+					}
+				} else if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("run: %v\n%s", err, out.String())
+			}
+			if business.Type != tt.want.Type || business.Description != tt.want.Description ||
+				business.Location != tt.want.Location || business.Budget != tt.want.Budget || business.Goal != tt.want.Goal ||
+				business.Locale != tt.want.Locale || !slices.Equal(business.Channels, tt.want.Channels) {
+				t.Errorf("run = %+v, want %+v", business, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Command openapi writes the OpenAPI spec generated from the Go types in
+// internal/core and the typed Go client generated from that spec.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"biz-flow/internal/openapi"
+)
+
+func main() {
+	specPath := flag.String("spec", "api/openapi.json", "where to write the OpenAPI document")
+	clientPath := flag.String("client", "client/client.gen.go", "where to write the generated Go client (empty to skip)")
+	pkg := flag.String("package", "client", "package name of the generated client")
+	check := flag.Bool("check", false, "fail if the files on disk are out of date instead of writing them")
+	flag.Parse()
+
+	doc := openapi.Spec()
+	spec, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		fail(err)
+	}
+	spec = append(spec, '\n')
+
+	outputs := map[string][]byte{*specPath: spec}
+	if *clientPath != "" {
+		// go generate runs from the client directory; name the spec relative to the repo root
+		source := strings.TrimPrefix(filepath.ToSlash(*specPath), "../")
+		client, err := openapi.GenerateClient(doc, *pkg, source)
+		if err != nil {
+			fail(err)
+		}
+		outputs[*clientPath] = client
+	}
+
+	stale := false
+	for path, data := range outputs {
+		if *check {
+			current, err := os.ReadFile(path)
+			if err != nil || !bytes.Equal(current, data) {
+				fmt.Fprintf(os.Stderr, "%s is out of date; run go generate ./client\n", path)
+				stale = true
+			}
+			continue
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			fail(err)
+		}
+	}
+
+	if stale {
+		os.Exit(1)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "openapi: %v\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,376 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"biz-flow/internal/ai"
+	"biz-flow/internal/cache"
+	"biz-flow/internal/confidence"
+	"biz-flow/internal/core"
+	"biz-flow/internal/feedback"
+	"biz-flow/internal/filters"
+	"biz-flow/internal/guardrails"
+	"biz-flow/internal/prompts"
+	"biz-flow/internal/reasoning"
+	"biz-flow/internal/scoring"
+	"biz-flow/internal/sensitivity"
+	"biz-flow/internal/voice"
+)
+
+// DefaultTopN is the number of platforms recommended per consultation
+const DefaultTopN = 3
+
+// Agent runs the full consultation pipeline: filter, score, explain, then
+// enrich the result with persona, content, risks and advice
+type Agent struct {
+	filter    *filters.PlatformFilter
+	scorer    *scoring.Scorer
+	explainer *reasoning.Explainer
+	risks     *reasoning.RiskAssessor
+	advisor   *reasoning.StrategyAdvisor
+	assessor  *confidence.Assessor
+	persona   *ai.PersonaInferrer
+	content   *ai.ContentGenerator
+	writer    *ai.AdviceWriter
+	reviewer  *ai.Reviewer
+	policy    *guardrails.Checker
+	voice     *voice.Checker
+	prompts   *prompts.Library
+	cache     *cache.Cache
+	feedback  *feedback.Store
+	// routes identifies each LLM stage's primary model for cache keys; a
+	// stage without one skips the LLM and is not cached
+	routes map[string]string
+	topN   int
+	// reviews is how many LLM review samples confidence is rated against;
+	// 0 skips the review stage
+	reviews int
+}
+
+// New creates an agent whose LLM stages use the router's per-stage chains
+// before falling back to deterministic output. A nil router uses the
+// rule-based persona and advice and the offline template library.
+func New(router *ai.Router) *Agent {
+	routes := make(map[string]string)
+	for _, stage := range ai.Stages() {
+		if clients := router.Clients(stage); len(clients) > 0 {
+			routes[stage] = clients[0].Provider() + "/" + clients[0].Model()
+		}
+	}
+	return &Agent{
+		filter:    filters.NewPlatformFilter(),
+		scorer:    scoring.NewScorer(),
+		explainer: reasoning.NewExplainer(),
+		risks:     reasoning.NewRiskAssessor(),
+		advisor:   reasoning.NewStrategyAdvisor(),
+		assessor:  confidence.NewAssessor(),
+		persona:   ai.NewPersonaInferrer(router.Clients(ai.StagePersona)...),
+		content:   ai.NewContentGenerator(router.Clients(ai.StageContent)...),
+		writer:    ai.NewAdviceWriter(router.Clients(ai.StageAdvice)...),
+		reviewer:  ai.NewReviewer(router.Clients(ai.StageReview)...),
+		policy:    guardrails.NewChecker(),
+		voice:     voice.NewChecker(),
+		prompts:   prompts.Default(),
+		routes:    routes,
+		topN:      DefaultTopN,
+	}
+}
+
+// WithContentSource picks the primary content generator. ContentFromTemplates
+// skips the LLM for content even when a content route is configured.
+func (a *Agent) WithContentSource(source ai.ContentSource) *Agent {
+	if source == ai.ContentFromTemplates {
+		a.content = ai.NewContentGenerator()
+		delete(a.routes, ai.StageContent)
+	}
+	return a
+}
+
+// WithPrompts replaces the built-in prompt library and its traffic split
+func (a *Agent) WithPrompts(library *prompts.Library) *Agent {
+	a.prompts = library
+	return a
+}
+
+// WithCache makes the agent reuse stage results for near-identical inputs
+func (a *Agent) WithCache(c *cache.Cache) *Agent {
+	a.cache = c
+	return a
+}
+
+// WithFeedback makes the agent score platforms on the results reported for
+// the business's profile and vertical
+func (a *Agent) WithFeedback(store *feedback.Store) *Agent {
+	a.feedback = store
+	return a
+}
+
+// WithReviews asks the review stage's models which platforms they would
+// pick, samples times per consultation, and rates confidence partly on how
+// often they agree with the ranking
+func (a *Agent) WithReviews(samples int) *Agent {
+	a.reviews = samples
+	return a
+}
+
+// Consult validates the business input and produces a consultation result
+func (a *Agent) Consult(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error) {
+	return a.ConsultWithObserver(ctx, business, nil)
+}
+
+// ConsultWithObserver runs a consultation and reports each stage to observe
+// as soon as it completes. The run stops between stages once ctx is done.
+func (a *Agent) ConsultWithObserver(
+	ctx context.Context,
+	business core.BusinessInput,
+	observe Observer,
+) (*core.ConsultationResult, error) {
+	business, err := a.Calibrate(ctx, business)
+	if err != nil {
+		return nil, err
+	}
+	if observe == nil {
+		observe = func(Event) {}
+	}
+
+	platforms, ranked := a.rank(business, a.topN)
+	observe(Event{Stage: StageFiltered, Data: platforms})
+	observe(Event{Stage: StageScored, Data: ranked})
+
+	recommendations := a.explain(business, ranked)
+
+	if err := cancelled(ctx); err != nil {
+		return nil, err
+	}
+
+	// The traffic split picks prompt versions per business rather than per
+	// request, so repeat consultations read the same
+	seed := cache.Key(business)
+	// The platform configuration and top N shape the ranking, so they are
+	// part of the key for everything derived from it
+	key := fmt.Sprintf("%s/%s/%d", seed, cache.Fingerprint(core.AllPlatforms()), a.topN)
+
+	var traces []*ai.Provenance
+	var findings []core.PolicyFinding
+	var samples [][]core.Platform
+	if a.reviews > 0 {
+		prompt := a.prompts.Select(ai.StageReview, seed)
+		review, err := runStage(a, prompt, fmt.Sprintf("%s/%d", key, a.reviews), func() (*ai.Review, error) {
+			return a.reviewer.Sample(ctx, prompt, business, platforms, len(recommendations), a.reviews)
+		})
+		if err != nil {
+			return nil, err
+		}
+		traces = append(traces, review.Trace())
+		samples = review.Samples
+	}
+	questions := a.assessor.Assess(business, a.scorer.Rank(business, platforms), recommendations, samples)
+	observe(Event{Stage: StageConfidence, Data: confidenceEvent(recommendations, questions)})
+
+	prompt := a.prompts.Select(ai.StagePersona, seed)
+	persona, err := runStage(a, prompt, key, func() (*ai.InferredPersona, error) {
+		return a.persona.Infer(ctx, prompt, business)
+	})
+	if err != nil {
+		return nil, err
+	}
+	traces = append(traces, persona.Trace())
+	observe(Event{Stage: StagePersona, Data: persona.Text})
+
+	// Content templates are only generated for the top recommendation
+	if len(recommendations) > 0 {
+		top := &recommendations[0]
+		prompt := a.prompts.Select(ai.StageContent, seed)
+		content, err := runStage(a, prompt, string(top.Platform)+"/"+key, func() (*ai.GeneratedContent, error) {
+			return a.content.Generate(ctx, prompt, business, top.Platform)
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Policy and brand voice checks run after the cache so rule changes
+		// apply at once
+		top.ContentTemplate, findings = a.policy.Check(business, top.Platform, content.Template)
+		top.ContentTemplate, top.VoiceScore = a.voice.Check(business, top.ContentTemplate)
+		traces = append(traces, content.Trace())
+		observe(Event{Stage: StageContent, Data: ContentEvent{
+			Rank:       top.Rank,
+			Platform:   top.Platform,
+			Template:   top.ContentTemplate,
+			VoiceScore: top.VoiceScore,
+		}})
+	}
+
+	if err := cancelled(ctx); err != nil {
+		return nil, err
+	}
+
+	risks := append(a.risks.Assess(business, recommendations), guardrails.Risks(business.Language(), findings)...)
+	observe(Event{Stage: StageRisks, Data: risks})
+
+	prompt = a.prompts.Select(ai.StageAdvice, seed)
+	advice, err := runStage(a, prompt, key, func() (*ai.WrittenAdvice, error) {
+		return a.writer.Write(ctx, prompt, business, recommendations, a.advisor.Advise(business, recommendations))
+	})
+	if err != nil {
+		return nil, err
+	}
+	traces = append(traces, advice.Trace())
+	observe(Event{Stage: StageAdvice, Data: advice.Text})
+
+	return &core.ConsultationResult{
+		Recommendations: recommendations,
+		StrategicAdvice: advice.Text,
+		Risks:           risks,
+		Persona:         persona.Text,
+		Metadata:        buildMetadata(traces, findings),
+		Questions:       questions,
+	}, nil
+}
+
+// Recommend runs only the deterministic stages: the ranked and explained
+// platforms, without persona, content or advice
+func (a *Agent) Recommend(business core.BusinessInput) ([]core.Recommendation, error) {
+	return a.RecommendTop(business, a.topN)
+}
+
+// RecommendTop is Recommend keeping the best n platforms instead of the
+// usual number; n below 1 keeps every platform that passes the filters
+func (a *Agent) RecommendTop(business core.BusinessInput, n int) ([]core.Recommendation, error) {
+	business, err := a.Calibrate(context.Background(), business)
+	if err != nil {
+		return nil, err
+	}
+	platforms, ranked := a.rank(business, n)
+	recommendations := a.explain(business, ranked)
+	a.assessor.Assess(business, a.scorer.Rank(business, platforms), recommendations, nil)
+	return recommendations, nil
+}
+
+// Sensitivity perturbs the numbers behind the business's platform scores and
+// reports how stable its recommendations are
+func (a *Agent) Sensitivity(ctx context.Context, business core.BusinessInput, options sensitivity.Options) (*sensitivity.Report, error) {
+	business, err := a.Calibrate(ctx, business)
+	if err != nil {
+		return nil, err
+	}
+	analyzer := sensitivity.NewAnalyzer(a.scorer)
+	return analyzer.Analyze(business, a.filter.ApplyAllFilters(business), a.topN, options)
+}
+
+// Calibrate validates the business and fills in its calibration from the
+// reported results, unless the caller already did. Consultations calibrate on
+// their own; callers that explain the scores next to a consultation's
+// recommendations use it to see the same business.
+func (a *Agent) Calibrate(ctx context.Context, business core.BusinessInput) (core.BusinessInput, error) {
+	if err := business.Validate(); err != nil {
+		return business, err
+	}
+	if a.feedback == nil || business.Calibration != nil {
+		return business, nil
+	}
+	calibration, err := a.feedback.Calibration(ctx, business)
+	if err != nil {
+		return business, err
+	}
+	if len(calibration) > 0 {
+		business.Calibration = calibration
+	}
+	return business, nil
+}
+
+// explain turns the ranked platforms into recommendations
+func (a *Agent) explain(business core.BusinessInput, ranked []scoring.ScoredPlatform) []core.Recommendation {
+	recommendations := make([]core.Recommendation, 0, len(ranked))
+	for i, scored := range ranked {
+		recommendations = append(recommendations, core.Recommendation{
+			Rank:      i + 1,
+			Platform:  scored.Platform,
+			Reasoning: a.explainer.ExplainRecommendation(business, scored),
+			Score:     scored.Score,
+		})
+	}
+	return recommendations
+}
+
+// buildMetadata collects the prompt versions, fallbacks and model costs of
+// the LLM stages along with the content policy findings
+func buildMetadata(traces []*ai.Provenance, findings []core.PolicyFinding) *core.ResultMetadata {
+	metadata := &core.ResultMetadata{Guardrails: findings, Cost: &core.CostEstimate{}}
+	unpriced := make(map[string]bool)
+	for _, trace := range traces {
+		if trace.Prompt != "" {
+			metadata.Prompts = append(metadata.Prompts, trace.Prompt)
+		}
+		metadata.Fallbacks = append(metadata.Fallbacks, trace.Fallbacks...)
+		for _, call := range trace.Calls {
+			metadata.Cost.Calls = append(metadata.Cost.Calls, call)
+			metadata.Cost.TotalUSD += call.CostUSD
+			if !call.Priced && !unpriced[call.Model] {
+				unpriced[call.Model] = true
+				metadata.Cost.UnpricedModels = append(metadata.Cost.UnpricedModels, call.Model)
+			}
+		}
+	}
+	metadata.Degraded = len(metadata.Fallbacks) > 0
+	return metadata
+}
+
+// rankedStage is the cached output of the deterministic stages
+type rankedStage struct {
+	Filtered []core.Platform          `json:"filtered"`
+	Ranked   []scoring.ScoredPlatform `json:"ranked"`
+}
+
+// rank filters and scores the platforms, keeping the top n; n below 1 keeps
+// them all. The cache key has the exact budget, since platform minimum
+// budgets decide the ranking to the cent.
+func (a *Agent) rank(business core.BusinessInput, n int) ([]core.Platform, []scoring.ScoredPlatform) {
+	key := fmt.Sprintf("%s/%s/%d", cache.DeterministicKey(business), cache.Fingerprint(core.AllPlatforms()), n)
+	var stage rankedStage
+	if a.cache.Get(cache.StageDeterministic, key, &stage) {
+		return stage.Filtered, stage.Ranked
+	}
+
+	stage.Filtered = a.filter.ApplyAllFilters(business)
+	stage.Ranked = a.scorer.Rank(business, stage.Filtered)
+	if n > 0 && len(stage.Ranked) > n {
+		stage.Ranked = stage.Ranked[:n]
+	}
+	a.cache.Set(cache.StageDeterministic, key, stage)
+	return stage.Filtered, stage.Ranked
+}
+
+// runStage runs an LLM stage, reusing cached output from the same route and
+// prompt version. The prompt is named after its stage. Cached output cost
+// nothing this time, so its calls are dropped. Degraded output is never
+// cached so the next request tries the model again.
+func runStage[T ai.Traced](a *Agent, prompt *prompts.Template, key string, run func() (T, error)) (T, error) {
+	route, ok := a.routes[prompt.Name]
+	if !ok {
+		return run()
+	}
+
+	key = fmt.Sprintf("%s/%s/%s", prompt.ID(), route, key)
+	var cached T
+	if a.cache.Get(cache.StageLLM, key, &cached) {
+		cached.Trace().Calls = nil
+		return cached, nil
+	}
+	output, err := run()
+	if err != nil {
+		return output, err
+	}
+	if !output.Trace().Degraded() {
+		a.cache.Set(cache.StageLLM, key, output)
+	}
+	return output, nil
+}
+
+// cancelled wraps the context error once the caller has given up
+func cancelled(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("consultation cancelled: %w", err)
+	}
+	return nil
+}
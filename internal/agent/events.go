@@ -0,0 +1,65 @@
+package agent
+
+import (
+	"biz-flow/internal/core"
+)
+
+// Stage names a step of the consultation pipeline
+type Stage string
+
+const (
+	StageFiltered   Stage = "filtered"   // Data: []core.Platform
+	StageScored     Stage = "scored"     // Data: []scoring.ScoredPlatform
+	StageConfidence Stage = "confidence" // Data: ConfidenceEvent
+	StagePersona    Stage = "persona"    // Data: string
+	StageContent    Stage = "content"    // Data: ContentEvent
+	StageRisks      Stage = "risks"      // Data: []string
+	StageAdvice     Stage = "advice"     // Data: string
+)
+
+// Event reports that a pipeline stage has completed
+type Event struct {
+	Stage Stage       `json:"stage"`
+	Data  interface{} `json:"data"`
+}
+
+// ContentEvent carries the content template generated for the top
+// recommendation, the only one content is generated for
+type ContentEvent struct {
+	Rank     int                   `json:"rank"`
+	Platform core.Platform         `json:"platform"`
+	Template *core.ContentTemplate `json:"template"`
+	// VoiceScore is set when the business has a brand voice
+	VoiceScore *core.VoiceScore `json:"voice_score,omitempty"`
+}
+
+// Observer receives events as the pipeline progresses. It is called from the
+// consulting goroutine, so it should return quickly.
+type Observer func(Event)
+
+// ConfidenceEvent carries how sure the pipeline is of each recommendation and
+// the clarifying questions suggested when it is unsure
+type ConfidenceEvent struct {
+	Recommendations []RatedRecommendation `json:"recommendations"`
+	Questions       []core.Question       `json:"questions,omitempty"`
+}
+
+// RatedRecommendation is one recommendation's confidence
+type RatedRecommendation struct {
+	Rank       int              `json:"rank"`
+	Platform   core.Platform    `json:"platform"`
+	Confidence *core.Confidence `json:"confidence"`
+}
+
+// confidenceEvent collects the recommendations' confidence
+func confidenceEvent(recommendations []core.Recommendation, questions []core.Question) ConfidenceEvent {
+	event := ConfidenceEvent{Recommendations: make([]RatedRecommendation, 0, len(recommendations)), Questions: questions}
+	for _, rec := range recommendations {
+		event.Recommendations = append(event.Recommendations, RatedRecommendation{
+			Rank:       rec.Rank,
+			Platform:   rec.Platform,
+			Confidence: rec.Confidence,
+		})
+	}
+	return event
+}
@@ -0,0 +1,46 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/prompts"
+)
+
+// AdviceWriter turns the rule-based strategy draft into tailored advice with
+// the LLM
+type AdviceWriter struct {
+	clients []*Client
+}
+
+// WrittenAdvice is the strategy text and where it came from
+type WrittenAdvice struct {
+	Text string `json:"text"`
+	Provenance
+}
+
+// NewAdviceWriter creates a new advice writer that tries each client in
+// order. Without clients the draft is used unchanged.
+func NewAdviceWriter(clients ...*Client) *AdviceWriter {
+	return &AdviceWriter{clients: compactClients(clients)}
+}
+
+// Write returns strategy advice for the recommendations using the given
+// prompt version, falling back to the rule-based draft when every model fails
+func (aw *AdviceWriter) Write(
+	ctx context.Context,
+	prompt *prompts.Template,
+	business core.BusinessInput,
+	recommendations []core.Recommendation,
+	draft string,
+) (*WrittenAdvice, error) {
+	parse := func(reply string) (string, error) { return ParseAdvice(reply, recommendations) }
+	vars := prompts.Vars{Business: business, Recommendations: recommendations, Draft: draft}
+	text, provenance, err := runChain(ctx, StageAdvice, aw.clients, prompt, vars, parse,
+		SourceRules, func() string { return draft })
+	if err != nil {
+		return nil, fmt.Errorf("writing strategy advice: %w", err)
+	}
+	return &WrittenAdvice{Text: text, Provenance: provenance}, nil
+}
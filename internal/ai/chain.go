@@ -0,0 +1,166 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/prompts"
+)
+
+// maxRepairs is how many times a model is asked to fix an invalid reply
+// before the chain moves on to the next source
+const maxRepairs = 1
+
+// Provenance records which source produced an LLM stage's output, the prompt
+// version sent to the models, every fallback taken on the way there and the
+// model calls it cost
+type Provenance struct {
+	Source    string           `json:"source"`
+	Prompt    string           `json:"prompt,omitempty"`
+	Fallbacks []core.Fallback  `json:"fallbacks,omitempty"`
+	Calls     []core.ModelCall `json:"calls,omitempty"`
+}
+
+// Degraded reports whether the output came from a fallback source
+func (p Provenance) Degraded() bool {
+	return len(p.Fallbacks) > 0
+}
+
+// Traced is implemented by every LLM stage output through its embedded
+// Provenance
+type Traced interface {
+	Trace() *Provenance
+}
+
+// Trace returns the provenance itself so callers can handle stage outputs
+// generically
+func (p *Provenance) Trace() *Provenance {
+	return p
+}
+
+// compactClients drops nil clients so callers can pass NewClientFromEnv as-is
+func compactClients(clients []*Client) []*Client {
+	compacted := make([]*Client, 0, len(clients))
+	for _, client := range clients {
+		if client != nil {
+			compacted = append(compacted, client)
+		}
+	}
+	return compacted
+}
+
+// completeValid asks the client for a reply that parse accepts, sending the
+// problems back to the model when a reply is invalid. Every completed call is
+// added to provenance, including ones whose reply was rejected.
+func completeValid[T any](
+	ctx context.Context,
+	stage string,
+	client *Client,
+	messages []Message,
+	parse func(string) (T, error),
+	provenance *Provenance,
+) (T, error) {
+	var zero T
+	for attempt := 0; ; attempt++ {
+		completion, err := client.Complete(ctx, messages)
+		if err != nil {
+			return zero, err
+		}
+		cost, priced := client.Cost(completion.Usage)
+		provenance.Calls = append(provenance.Calls, core.ModelCall{
+			Stage:            stage,
+			Provider:         client.Provider(),
+			Model:            client.Model(),
+			PromptTokens:     completion.Usage.PromptTokens,
+			CompletionTokens: completion.Usage.CompletionTokens,
+			CostUSD:          cost,
+			Priced:           priced,
+		})
+
+		value, err := parse(completion.Text)
+		var invalid *InvalidResponseError
+		if err == nil || !errors.As(err, &invalid) || attempt == maxRepairs {
+			return value, err
+		}
+		messages = RepairPrompt(messages, completion.Text, invalid)
+	}
+}
+
+// retriesKey marks a context whose caller retries transient failures itself
+type retriesKey struct{}
+
+// WithRetries marks ctx as belonging to a caller that retries transient
+// failures, such as a background job with attempts left. A chain whose every
+// model fails transiently then returns the error instead of degrading to the
+// deterministic fallback.
+func WithRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retriesKey{}, true)
+}
+
+// retries reports whether ctx was marked by WithRetries
+func retries(ctx context.Context) bool {
+	marked, _ := ctx.Value(retriesKey{}).(bool)
+	return marked
+}
+
+// runChain renders the prompt and tries each client in order and finally the
+// deterministic fallback, recording every step down the chain. Cancellation
+// stops the chain rather than degrading the output, and so does a transient
+// failure of every client when the caller retries them.
+func runChain[T any](
+	ctx context.Context,
+	stage string,
+	clients []*Client,
+	prompt *prompts.Template,
+	vars prompts.Vars,
+	parse func(string) (T, error),
+	fallbackSource string,
+	fallback func() T,
+) (T, Provenance, error) {
+	var provenance Provenance
+	if len(clients) == 0 {
+		provenance.Source = fallbackSource
+		return fallback(), provenance, nil
+	}
+
+	messages, err := Messages(prompt, vars)
+	if err != nil {
+		var zero T
+		return zero, provenance, err
+	}
+	provenance.Prompt = prompt.ID()
+	var lastErr error
+	allTransient := true
+	for i, client := range clients {
+		value, err := completeValid(ctx, stage, client, messages, parse, &provenance)
+		if err == nil {
+			provenance.Source = client.Model()
+			return value, provenance, nil
+		}
+		if ctx.Err() != nil {
+			return value, provenance, err
+		}
+		lastErr = err
+		allTransient = allTransient && IsTransient(err)
+
+		next := fallbackSource
+		if i+1 < len(clients) {
+			next = clients[i+1].Model()
+		}
+		provenance.Fallbacks = append(provenance.Fallbacks, core.Fallback{
+			Stage:  stage,
+			From:   client.Model(),
+			To:     next,
+			Reason: err.Error(),
+		})
+	}
+
+	if allTransient && retries(ctx) {
+		var zero T
+		return zero, provenance, fmt.Errorf("ai: every %s model failed: %w", stage, lastErr)
+	}
+	provenance.Source = fallbackSource
+	return fallback(), provenance, nil
+}
@@ -0,0 +1,140 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/prompts"
+)
+
+// stubClient sends its requests to a server that answers with status and body
+func stubClient(t *testing.T, model string, status int, body string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient("", model)
+	client.baseURL = server.URL
+	return client
+}
+
+func TestRunChain(t *testing.T) {
+	const persona = "Coffee lovers in Austin who buy handmade gifts."
+	reply := `{"choices":[{"message":{"content":"` + persona + `"}}]}`
+	rateLimited := `{"error":{"message":"rate limited"}}`
+	badRequest := `{"error":{"message":"bad request"}}`
+
+	tests := []struct {
+		name          string
+		clients       func(t *testing.T) []*Client
+		retries       bool
+		wantSource    string
+		wantFallbacks int
+		wantTransient bool
+	}{
+		{
+			name:       "first model answers",
+			clients:    func(t *testing.T) []*Client { return []*Client{stubClient(t, "primary", http.StatusOK, reply)} },
+			wantSource: "primary",
+		},
+		{
+			name: "falls back to the next model",
+			clients: func(t *testing.T) []*Client {
+				return []*Client{
+					stubClient(t, "primary", http.StatusTooManyRequests, rateLimited),
+					stubClient(t, "cheap", http.StatusOK, reply),
+				}
+			},
+			retries:       true,
+			wantSource:    "cheap",
+			wantFallbacks: 1,
+		},
+		{
+			name: "degrades when every model fails transiently",
+			clients: func(t *testing.T) []*Client {
+				return []*Client{
+					stubClient(t, "primary", http.StatusTooManyRequests, rateLimited),
+					stubClient(t, "cheap", http.StatusBadGateway, rateLimited),
+				}
+			},
+			wantSource:    SourceRules,
+			wantFallbacks: 2,
+		},
+		{
+			name: "returns the transient error when the caller retries",
+			clients: func(t *testing.T) []*Client {
+				return []*Client{
+					stubClient(t, "primary", http.StatusTooManyRequests, rateLimited),
+					stubClient(t, "cheap", http.StatusBadGateway, rateLimited),
+				}
+			},
+			retries:       true,
+			wantTransient: true,
+		},
+		{
+			name: "degrades on a permanent failure even when the caller retries",
+			clients: func(t *testing.T) []*Client {
+				return []*Client{
+					stubClient(t, "primary", http.StatusTooManyRequests, rateLimited),
+					stubClient(t, "cheap", http.StatusBadRequest, badRequest),
+				}
+			},
+			retries:       true,
+			wantSource:    SourceRules,
+			wantFallbacks: 2,
+		},
+		{
+			name:       "uses the fallback without models",
+			clients:    func(t *testing.T) []*Client { return nil },
+			retries:    true,
+			wantSource: SourceRules,
+		},
+	}
+
+	business := core.BusinessInput{Type: core.Retail, Description: "handmade mugs", Location: "Austin", Goal: core.Awareness}
+	prompt := prompts.Default().Select(StagePersona, "seed")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.retries {
+				ctx = WithRetries(ctx)
+			}
+			text, provenance, err := runChain(ctx, StagePersona, tt.clients(t), prompt, prompts.Vars{Business: business},
+				ParsePersona, SourceRules, func() string { return "rules" })
+
+			if tt.wantTransient {
+				if !IsTransient(err) {
+					t.Fatalf("error = %v, want a transient error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runChain: %v", err)
+			}
+			if provenance.Source != tt.wantSource || len(provenance.Fallbacks) != tt.wantFallbacks {
+				t.Errorf("source %q with %d fallbacks, want %q with %d", provenance.Source, len(provenance.Fallbacks), tt.wantSource, tt.wantFallbacks)
+			}
+			if want := map[bool]string{true: "rules", false: persona}[tt.wantSource == SourceRules]; text != want {
+				t.Errorf("text = %q, want %q", text, want)
+			}
+		})
+	}
+}
+
+func TestRunChainStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := stubClient(t, "primary", http.StatusOK, `{}`)
+	prompt := prompts.Default().Select(StagePersona, "seed")
+	_, _, err := runChain(ctx, StagePersona, []*Client{client}, prompt, prompts.Vars{Business: core.BusinessInput{}},
+		ParsePersona, SourceRules, func() string { return "rules" })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+}
@@ -1 +1,198 @@
-package ai
\ No newline at end of file
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the OpenRouter chat completions API
+	DefaultBaseURL = "https://openrouter.ai/api/v1"
+
+	// DefaultModel is used when OPENROUTER_MODEL is not set
+	DefaultModel = "openai/gpt-4o-mini"
+)
+
+// ErrNoClient is returned when an LLM stage runs without a configured client
+var ErrNoClient = errors.New("ai: no LLM client configured")
+
+// ErrTransient marks failures worth retrying: network errors, rate limits
+// and server-side errors from the model provider
+var ErrTransient = errors.New("ai: transient failure")
+
+// IsTransient reports whether err is a transient LLM failure
+func IsTransient(err error) bool {
+	return errors.Is(err, ErrTransient)
+}
+
+// transient wraps err so that IsTransient recognizes it
+func transient(err error) error {
+	return fmt.Errorf("%w: %w", ErrTransient, err)
+}
+
+// Message is a single chat message sent to the model
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Client talks to an OpenAI-compatible chat completions API. OpenRouter,
+// OpenAI itself, Ollama and llama.cpp all speak this protocol.
+type Client struct {
+	provider    string
+	apiKey      string
+	baseURL     string
+	model       string
+	temperature *float64
+	maxTokens   int
+	price       *Price // nil when the model's price is unknown
+	httpClient  *http.Client
+}
+
+// Usage is the token count reported for one completion
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+// Completion is a model reply and the tokens it used
+type Completion struct {
+	Text  string
+	Usage Usage
+}
+
+// NewClient creates a new OpenRouter client for the given API key and model
+func NewClient(apiKey, model string) *Client {
+	if model == "" {
+		model = DefaultModel
+	}
+	return &Client{
+		provider:   string(ProviderOpenRouter),
+		apiKey:     apiKey,
+		baseURL:    DefaultBaseURL,
+		model:      model,
+		price:      lookupPrice(DefaultPrices(), model),
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// NewClientFromEnv creates a client from OPENROUTER_API_KEY and OPENROUTER_MODEL.
+// It returns nil when no API key is set.
+func NewClientFromEnv() *Client {
+	apiKey := os.Getenv("OPENROUTER_API_KEY")
+	if apiKey == "" {
+		return nil
+	}
+	return NewClient(apiKey, os.Getenv("OPENROUTER_MODEL"))
+}
+
+// Model returns the model the client sends requests to
+func (c *Client) Model() string {
+	return c.model
+}
+
+// Provider returns the name of the provider the client talks to
+func (c *Client) Provider() string {
+	return c.provider
+}
+
+// Cost estimates the USD cost of a completion, reporting false when the
+// model's price is unknown
+func (c *Client) Cost(usage Usage) (float64, bool) {
+	if c.price == nil {
+		return 0, false
+	}
+	return (float64(usage.PromptTokens)*c.price.InputPerMillion +
+		float64(usage.CompletionTokens)*c.price.OutputPerMillion) / 1e6, true
+}
+
+type chatRequest struct {
+	Model       string    `json:"model"`
+	Messages    []Message `json:"messages"`
+	Temperature *float64  `json:"temperature,omitempty"`
+	MaxTokens   int       `json:"max_tokens,omitempty"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message Message `json:"message"`
+	} `json:"choices"`
+	Usage Usage `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Complete sends the messages to the model and returns the reply
+func (c *Client) Complete(ctx context.Context, messages []Message) (*Completion, error) {
+	if c == nil {
+		return nil, ErrNoClient
+	}
+
+	body, err := json.Marshal(chatRequest{
+		Model:       c.model,
+		Messages:    messages,
+		Temperature: c.temperature,
+		MaxTokens:   c.maxTokens,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ai: encoding request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("ai: building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("ai: sending request: %w", ctx.Err())
+		}
+		return nil, transient(fmt.Errorf("ai: sending request: %w", err))
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, transient(fmt.Errorf("ai: reading response: %w", err))
+	}
+
+	// Rate limits and provider outages usually clear up on their own
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+
+	var parsed chatResponse
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		err = fmt.Errorf("ai: decoding response (status %d): %w", resp.StatusCode, err)
+		if retryable {
+			return nil, transient(err)
+		}
+		return nil, err
+	}
+	if parsed.Error != nil || resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("ai: unexpected status %d", resp.StatusCode)
+		if parsed.Error != nil {
+			err = fmt.Errorf("ai: model error (status %d): %s", resp.StatusCode, parsed.Error.Message)
+		}
+		if retryable {
+			return nil, transient(err)
+		}
+		return nil, err
+	}
+	if len(parsed.Choices) == 0 {
+		return nil, errors.New("ai: response contained no choices")
+	}
+
+	return &Completion{Text: parsed.Choices[0].Message.Content, Usage: parsed.Usage}, nil
+}
@@ -1 +1,72 @@
 package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/prompts"
+	"biz-flow/internal/templates"
+)
+
+// SourceTemplates names the offline template library, used as the primary
+// source without clients and at the end of every fallback chain
+const SourceTemplates = "templates"
+
+// ContentSource selects the primary content generator
+type ContentSource string
+
+const (
+	// ContentFromLLM asks the configured models first
+	ContentFromLLM ContentSource = "llm"
+	// ContentFromTemplates uses the offline template library only
+	ContentFromTemplates ContentSource = "templates"
+)
+
+// ContentGenerator generates ready-to-use content templates with the LLM
+type ContentGenerator struct {
+	clients []*Client
+	library *templates.Library
+}
+
+// GeneratedContent is a content template and where it came from
+type GeneratedContent struct {
+	Template *core.ContentTemplate `json:"template"`
+	Provenance
+}
+
+// NewContentGenerator creates a new content generator that tries each client
+// in order, then falls back to the offline template library. Without clients
+// the library is the primary source.
+func NewContentGenerator(clients ...*Client) *ContentGenerator {
+	return &ContentGenerator{clients: compactClients(clients), library: templates.NewLibrary()}
+}
+
+// Generate creates a content template for the platform using the given
+// prompt version
+func (cg *ContentGenerator) Generate(
+	ctx context.Context,
+	prompt *prompts.Template,
+	business core.BusinessInput,
+	platform core.Platform,
+) (*GeneratedContent, error) {
+	parse := func(reply string) (*core.ContentTemplate, error) { return ParseContent(reply, platform) }
+	vars := prompts.Vars{Business: business, Platform: platform}
+	template, provenance, err := runChain(ctx, StageContent, cg.clients, prompt, vars, parse,
+		SourceTemplates, func() *core.ContentTemplate { return cg.library.Generate(business, platform) })
+	if err != nil {
+		return nil, fmt.Errorf("generating %s content: %w", platform, err)
+	}
+	return &GeneratedContent{Template: template, Provenance: provenance}, nil
+}
+
+// ExtractJSON strips markdown code fences and surrounding prose from a reply
+func ExtractJSON(reply string) string {
+	start := strings.Index(reply, "{")
+	end := strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return reply
+	}
+	return reply[start : end+1]
+}
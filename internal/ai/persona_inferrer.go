@@ -1 +1,69 @@
 package ai
+
+import (
+	"context"
+	"fmt"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
+	"biz-flow/internal/prompts"
+)
+
+// SourceRules names the rule-based persona used without a model
+const SourceRules = "rules"
+
+// PersonaInferrer infers the target customer persona for a business
+type PersonaInferrer struct {
+	clients []*Client
+}
+
+// InferredPersona is a persona description and where it came from
+type InferredPersona struct {
+	Text string `json:"text"`
+	Provenance
+}
+
+// NewPersonaInferrer creates a new persona inferrer that tries each client in
+// order. Without clients it uses a rule-based persona.
+func NewPersonaInferrer(clients ...*Client) *PersonaInferrer {
+	return &PersonaInferrer{clients: compactClients(clients)}
+}
+
+// Infer returns a short description of the business's ideal customer using
+// the given prompt version. When every model fails, the rule-based persona is
+// used and the fallbacks are recorded.
+func (pi *PersonaInferrer) Infer(
+	ctx context.Context,
+	prompt *prompts.Template,
+	business core.BusinessInput,
+) (*InferredPersona, error) {
+	text, provenance, err := runChain(ctx, StagePersona, pi.clients, prompt, prompts.Vars{Business: business}, ParsePersona,
+		SourceRules, func() string { return ruleBasedPersona(business) })
+	if err != nil {
+		return nil, fmt.Errorf("inferring persona: %w", err)
+	}
+	return &InferredPersona{Text: text, Provenance: provenance}, nil
+}
+
+// ruleBasedPersona describes a generic persona from the business input
+// alone, in its locale
+func ruleBasedPersona(business core.BusinessInput) string {
+	locale := business.Language()
+	who := i18n.T(locale, "persona.other")
+	switch business.Type {
+	case core.Retail, core.Service, core.Digital:
+		who = i18n.T(locale, "persona."+string(business.Type))
+	}
+
+	where := i18n.T(locale, "persona.online")
+	if business.IsLocal() {
+		where = i18n.T(locale, "persona.local", business.Location)
+	}
+
+	why := i18n.T(locale, "persona.awareness")
+	if business.Goal == core.Sales {
+		why = i18n.T(locale, "persona.sales")
+	}
+
+	return i18n.T(locale, "persona.sentence", who, where, why)
+}
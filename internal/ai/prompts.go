@@ -1 +1,34 @@
 package ai
+
+import (
+	"fmt"
+	"strings"
+
+	"biz-flow/internal/prompts"
+)
+
+// Messages renders a prompt version into the conversation sent to a model
+func Messages(prompt *prompts.Template, vars prompts.Vars) ([]Message, error) {
+	system, user, err := prompt.Render(vars)
+	if err != nil {
+		return nil, err
+	}
+	return []Message{
+		{Role: "system", Content: system},
+		{Role: "user", Content: user},
+	}, nil
+}
+
+// RepairPrompt extends a conversation with the model's invalid reply and a
+// request to correct it
+func RepairPrompt(messages []Message, reply string, invalid *InvalidResponseError) []Message {
+	repaired := make([]Message, 0, len(messages)+2)
+	repaired = append(repaired, messages...)
+	return append(repaired,
+		Message{Role: "assistant", Content: reply},
+		Message{Role: "user", Content: fmt.Sprintf(
+			"That reply could not be used:\n- %s\n\nRespond again with only the corrected answer.",
+			strings.Join(invalid.Problems, "\n- "),
+		)},
+	)
+}
@@ -0,0 +1,68 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/prompts"
+)
+
+// SourceNone names the missing source of a stage that has no deterministic
+// fallback and produced nothing
+const SourceNone = "none"
+
+// Reviewer asks the LLM, independently of the scoring rules, which platforms
+// a business should focus on, so the confidence model can measure how far
+// the two agree
+type Reviewer struct {
+	clients []*Client
+}
+
+// Review is the platforms each sample picked, best first, and where they
+// came from
+type Review struct {
+	Samples [][]core.Platform `json:"samples"`
+	Provenance
+}
+
+// NewReviewer creates a new reviewer that tries each client in order. Without
+// clients it takes no samples.
+func NewReviewer(clients ...*Client) *Reviewer {
+	return &Reviewer{clients: compactClients(clients)}
+}
+
+// Sample asks for the best top candidates samples times using the given
+// prompt version. Samples every model failed on are left out, so a review
+// may have fewer samples than asked for, or none.
+func (r *Reviewer) Sample(
+	ctx context.Context,
+	prompt *prompts.Template,
+	business core.BusinessInput,
+	candidates []core.Platform,
+	top, samples int,
+) (*Review, error) {
+	review := &Review{Samples: [][]core.Platform{}}
+	review.Source = SourceNone
+	if len(r.clients) == 0 || len(candidates) == 0 || samples < 1 {
+		return review, nil
+	}
+
+	parse := func(reply string) ([]core.Platform, error) { return ParseReview(reply, candidates, top) }
+	vars := prompts.Vars{Business: business, Candidates: candidates, Top: top}
+	for i := 0; i < samples; i++ {
+		picks, provenance, err := runChain(ctx, StageReview, r.clients, prompt, vars, parse,
+			SourceNone, func() []core.Platform { return nil })
+		review.Prompt = provenance.Prompt
+		review.Fallbacks = append(review.Fallbacks, provenance.Fallbacks...)
+		review.Calls = append(review.Calls, provenance.Calls...)
+		if err != nil {
+			return nil, fmt.Errorf("reviewing platforms: %w", err)
+		}
+		if picks != nil {
+			review.Samples = append(review.Samples, picks)
+			review.Source = provenance.Source
+		}
+	}
+	return review, nil
+}
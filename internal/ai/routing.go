@@ -0,0 +1,317 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LLM stages that can be routed to their own models
+const (
+	StagePersona = "persona"
+	StageContent = "content"
+	StageAdvice  = "advice"
+	StageReview  = "review"
+)
+
+// Stages lists the routable LLM stages
+func Stages() []string {
+	return []string{StagePersona, StageContent, StageAdvice, StageReview}
+}
+
+// ProviderKind selects the defaults for a provider: base URL, whether an API
+// key is required and whether calls cost anything
+type ProviderKind string
+
+const (
+	// ProviderOpenRouter is the hosted OpenRouter API
+	ProviderOpenRouter ProviderKind = "openrouter"
+	// ProviderOpenAI is any OpenAI-compatible endpoint; base_url is required
+	ProviderOpenAI ProviderKind = "openai"
+	// ProviderOllama is a local Ollama or llama.cpp server; calls are free
+	ProviderOllama ProviderKind = "ollama"
+)
+
+// DefaultOllamaURL is the OpenAI-compatible endpoint of a local Ollama server
+const DefaultOllamaURL = "http://localhost:11434/v1"
+
+// Provider is a named chat completions endpoint
+type Provider struct {
+	Kind    ProviderKind `json:"kind"`
+	BaseURL string       `json:"base_url,omitempty"`
+	// APIKeyEnv names the environment variable holding the API key, so keys
+	// never live in the config file
+	APIKeyEnv string `json:"api_key_env,omitempty"`
+}
+
+// Route picks the provider, model and sampling settings for one step of a
+// stage's fallback chain
+type Route struct {
+	Provider    string   `json:"provider"`
+	Model       string   `json:"model"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	MaxTokens   int      `json:"max_tokens,omitempty"`
+}
+
+// Price is what a model charges in USD per million tokens
+type Price struct {
+	InputPerMillion  float64 `json:"input_per_million"`
+	OutputPerMillion float64 `json:"output_per_million"`
+}
+
+// RoutingConfig maps each LLM stage to an ordered list of routes: the first
+// is the primary model and the rest are its fallbacks
+type RoutingConfig struct {
+	Providers map[string]Provider `json:"providers"`
+	Stages    map[string][]Route  `json:"stages"`
+	// Prices add to or override DefaultPrices, keyed by model name
+	Prices map[string]Price `json:"prices,omitempty"`
+}
+
+// DefaultPrices returns the built-in per-model prices
+func DefaultPrices() map[string]Price {
+	return map[string]Price{
+		"openai/gpt-4o-mini": {InputPerMillion: 0.15, OutputPerMillion: 0.60},
+		"openai/gpt-4o":      {InputPerMillion: 2.50, OutputPerMillion: 10.00},
+		"gpt-4o-mini":        {InputPerMillion: 0.15, OutputPerMillion: 0.60},
+		"gpt-4o":             {InputPerMillion: 2.50, OutputPerMillion: 10.00},
+	}
+}
+
+// defaultStageSettings trade quality for cost per stage when routes come from
+// the environment: personas are short, content wants variety, advice wants
+// consistency and review samples should differ enough to disagree
+var defaultStageSettings = map[string]Route{
+	StagePersona: {Temperature: floatPtr(0.7), MaxTokens: 200},
+	StageContent: {Temperature: floatPtr(0.9), MaxTokens: 500},
+	StageAdvice:  {Temperature: floatPtr(0.3), MaxTokens: 400},
+	StageReview:  {Temperature: floatPtr(0.8), MaxTokens: 100},
+}
+
+// LoadRoutingConfig reads and validates a routing config file
+func LoadRoutingConfig(path string) (*RoutingConfig, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	cfg, err := DecodeRoutingConfig(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// DecodeRoutingConfig reads a routing config from JSON and validates it
+func DecodeRoutingConfig(r io.Reader) (*RoutingConfig, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var cfg RoutingConfig
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("decoding routing config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate checks that every stage is known and every route names a defined
+// provider and a model
+func (cfg *RoutingConfig) Validate() error {
+	var errs []error
+	for _, name := range sortedKeys(cfg.Providers) {
+		provider := cfg.Providers[name]
+		switch provider.Kind {
+		case ProviderOpenRouter, ProviderOllama:
+		case ProviderOpenAI:
+			if provider.BaseURL == "" {
+				errs = append(errs, fmt.Errorf("provider %q: base_url is required for kind %q", name, provider.Kind))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("provider %q: unknown kind %q (want openrouter, openai or ollama)", name, provider.Kind))
+		}
+	}
+
+	for _, stage := range sortedKeys(cfg.Stages) {
+		if !isStage(stage) {
+			errs = append(errs, fmt.Errorf("unknown stage %q (want %s)", stage, strings.Join(Stages(), ", ")))
+			continue
+		}
+		for i, route := range cfg.Stages[stage] {
+			if _, ok := cfg.Providers[route.Provider]; !ok {
+				errs = append(errs, fmt.Errorf("stage %s route %d: unknown provider %q", stage, i+1, route.Provider))
+			}
+			if route.Model == "" {
+				errs = append(errs, fmt.Errorf("stage %s route %d: model is required", stage, i+1))
+			}
+			if route.Temperature != nil && (*route.Temperature < 0 || *route.Temperature > 2) {
+				errs = append(errs, fmt.Errorf("stage %s route %d: temperature must be between 0 and 2", stage, i+1))
+			}
+			if route.MaxTokens < 0 {
+				errs = append(errs, fmt.Errorf("stage %s route %d: max_tokens must not be negative", stage, i+1))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Router hands each LLM stage its chain of clients
+type Router struct {
+	stages map[string][]*Client
+}
+
+// NewRouter resolves a routing config into clients, reading API keys from
+// the environment. A provider that needs a key fails if it is unset.
+func NewRouter(cfg *RoutingConfig) (*Router, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	prices := DefaultPrices()
+	for model, price := range cfg.Prices {
+		prices[model] = price
+	}
+
+	httpClient := &http.Client{Timeout: 60 * time.Second}
+	router := &Router{stages: make(map[string][]*Client)}
+	for _, stage := range sortedKeys(cfg.Stages) {
+		for _, route := range cfg.Stages[stage] {
+			client, err := newRouteClient(route.Provider, cfg.Providers[route.Provider], route, prices, httpClient)
+			if err != nil {
+				return nil, fmt.Errorf("stage %s: %w", stage, err)
+			}
+			router.stages[stage] = append(router.stages[stage], client)
+		}
+	}
+	return router, nil
+}
+
+// RouterFromEnv routes every stage through OpenRouter using OPENROUTER_MODEL,
+// followed by each model in the comma-separated OPENROUTER_FALLBACK_MODELS.
+// It returns nil when OPENROUTER_API_KEY is not set.
+func RouterFromEnv() *Router {
+	primary := NewClientFromEnv()
+	if primary == nil {
+		return nil
+	}
+
+	models := []string{primary.Model()}
+	for _, model := range strings.Split(os.Getenv("OPENROUTER_FALLBACK_MODELS"), ",") {
+		if model = strings.TrimSpace(model); model != "" {
+			models = append(models, model)
+		}
+	}
+
+	router := &Router{stages: make(map[string][]*Client)}
+	for _, stage := range Stages() {
+		settings := defaultStageSettings[stage]
+		for _, model := range models {
+			client := primary.withModel(model)
+			client.temperature, client.maxTokens = settings.Temperature, settings.MaxTokens
+			router.stages[stage] = append(router.stages[stage], client)
+		}
+	}
+	return router
+}
+
+// Clients returns the stage's chain, primary first. A nil router has none.
+func (r *Router) Clients(stage string) []*Client {
+	if r == nil {
+		return nil
+	}
+	return r.stages[stage]
+}
+
+// newRouteClient builds the client for one route
+func newRouteClient(
+	name string,
+	provider Provider,
+	route Route,
+	prices map[string]Price,
+	httpClient *http.Client,
+) (*Client, error) {
+	client := &Client{
+		provider:    name,
+		baseURL:     strings.TrimSuffix(provider.BaseURL, "/"),
+		model:       route.Model,
+		temperature: route.Temperature,
+		maxTokens:   route.MaxTokens,
+		price:       lookupPrice(prices, route.Model),
+		httpClient:  httpClient,
+	}
+
+	keyEnv := provider.APIKeyEnv
+	switch provider.Kind {
+	case ProviderOpenRouter:
+		if client.baseURL == "" {
+			client.baseURL = DefaultBaseURL
+		}
+		if keyEnv == "" {
+			keyEnv = "OPENROUTER_API_KEY"
+		}
+	case ProviderOllama:
+		if client.baseURL == "" {
+			client.baseURL = DefaultOllamaURL
+		}
+		// Local models cost nothing but electricity
+		client.price = &Price{}
+	}
+
+	if keyEnv != "" {
+		client.apiKey = os.Getenv(keyEnv)
+		if client.apiKey == "" {
+			return nil, fmt.Errorf("provider %q: $%s is not set", name, keyEnv)
+		}
+	}
+	return client, nil
+}
+
+// withModel returns a copy of the client that sends requests to model
+func (c *Client) withModel(model string) *Client {
+	copied := *c
+	copied.model = model
+	copied.price = lookupPrice(DefaultPrices(), model)
+	return &copied
+}
+
+// lookupPrice returns the model's price, or nil when it is unknown
+func lookupPrice(prices map[string]Price, model string) *Price {
+	if price, ok := prices[model]; ok {
+		return &price
+	}
+	return nil
+}
+
+// isStage reports whether name is a routable stage
+func isStage(name string) bool {
+	for _, stage := range Stages() {
+		if stage == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the map's keys in a stable order so validation errors
+// are reproducible
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
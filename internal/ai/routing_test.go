@@ -0,0 +1,168 @@
+package ai
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestDecodeRoutingConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr []string
+	}{
+		{
+			name: "every provider kind",
+			config: `{
+				"providers": {
+					"router": {"kind": "openrouter"},
+					"azure": {"kind": "openai", "base_url": "https://example.test/v1", "api_key_env": "AZURE_KEY"},
+					"local": {"kind": "ollama"}
+				},
+				"stages": {
+					"persona": [{"provider": "local", "model": "llama3"}],
+					"content": [{"provider": "router", "model": "openai/gpt-4o", "temperature": 0.9}, {"provider": "local", "model": "llama3"}],
+					"advice": [{"provider": "azure", "model": "gpt-4o-mini", "max_tokens": 400}]
+				},
+				"prices": {"llama3": {"input_per_million": 0, "output_per_million": 0}}
+			}`,
+		},
+		{
+			name:    "unknown field",
+			config:  `{"providers": {}, "stages": {}, "budget": 3}`,
+			wantErr: []string{`unknown field "budget"`},
+		},
+		{
+			name:    "unknown provider kind",
+			config:  `{"providers": {"x": {"kind": "anthropic"}}, "stages": {}}`,
+			wantErr: []string{`provider "x": unknown kind "anthropic"`},
+		},
+		{
+			name:    "OpenAI-compatible provider without a base URL",
+			config:  `{"providers": {"x": {"kind": "openai"}}, "stages": {}}`,
+			wantErr: []string{`provider "x": base_url is required`},
+		},
+		{
+			name:    "unknown stage",
+			config:  `{"providers": {"local": {"kind": "ollama"}}, "stages": {"summary": [{"provider": "local", "model": "m"}]}}`,
+			wantErr: []string{`unknown stage "summary"`},
+		},
+		{
+			name: "every route problem at once",
+			config: `{"providers": {"local": {"kind": "ollama"}}, "stages": {"persona": [
+				{"provider": "remote", "model": ""},
+				{"provider": "local", "model": "m", "temperature": 2.5, "max_tokens": -1}
+			]}}`,
+			wantErr: []string{
+				`stage persona route 1: unknown provider "remote"`,
+				"stage persona route 1: model is required",
+				"stage persona route 2: temperature must be between 0 and 2",
+				"stage persona route 2: max_tokens must not be negative",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeRoutingConfig(strings.NewReader(tt.config))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("DecodeRoutingConfig: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("DecodeRoutingConfig succeeded, want an error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewRouter(t *testing.T) {
+	t.Setenv("OPENROUTER_API_KEY", "router-key")
+	t.Setenv("AZURE_KEY", "")
+	cfg := &RoutingConfig{
+		Providers: map[string]Provider{
+			"router": {Kind: ProviderOpenRouter},
+			"local":  {Kind: ProviderOllama},
+		},
+		Stages: map[string][]Route{
+			StageContent: {{Provider: "router", Model: "openai/gpt-4o"}, {Provider: "local", Model: "llama3"}},
+			StageAdvice:  {{Provider: "router", Model: "mistral/small"}},
+		},
+		Prices: map[string]Price{"openai/gpt-4o": {InputPerMillion: 5, OutputPerMillion: 20}},
+	}
+	router, err := NewRouter(cfg)
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+
+	content := router.Clients(StageContent)
+	if len(content) != 2 || content[0].Model() != "openai/gpt-4o" || content[1].Model() != "llama3" {
+		t.Fatalf("content chain = %v, want gpt-4o then llama3", content)
+	}
+	if content[0].baseURL != DefaultBaseURL || content[0].apiKey != "router-key" || content[1].baseURL != DefaultOllamaURL {
+		t.Errorf("providers were not defaulted: %+v, %+v", content[0], content[1])
+	}
+	if len(router.Clients(StagePersona)) != 0 {
+		t.Error("an unrouted stage has clients")
+	}
+
+	usage := Usage{PromptTokens: 1000, CompletionTokens: 500}
+	tests := []struct {
+		name   string
+		client *Client
+		cost   float64
+		priced bool
+	}{
+		{name: "configured price overrides the default", client: content[0], cost: (1000*5 + 500*20) / 1e6, priced: true},
+		{name: "local models are free", client: content[1], cost: 0, priced: true},
+		{name: "unknown model", client: router.Clients(StageAdvice)[0], priced: false},
+		{name: "default price", client: NewClient("key", "openai/gpt-4o-mini"), cost: (1000*0.15 + 500*0.60) / 1e6, priced: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cost, priced := tt.client.Cost(usage)
+			if priced != tt.priced || math.Abs(cost-tt.cost) > 1e-12 {
+				t.Errorf("Cost = %g, %v, want %g, %v", cost, priced, tt.cost, tt.priced)
+			}
+		})
+	}
+
+	cfg.Providers["azure"] = Provider{Kind: ProviderOpenAI, BaseURL: "https://example.test/v1", APIKeyEnv: "AZURE_KEY"}
+	cfg.Stages[StagePersona] = []Route{{Provider: "azure", Model: "gpt-4o-mini"}}
+	if _, err := NewRouter(cfg); err == nil || !strings.Contains(err.Error(), "$AZURE_KEY is not set") {
+		t.Errorf("error = %v, want the unset key reported", err)
+	}
+}
+
+func TestRouterFromEnv(t *testing.T) {
+	t.Setenv("OPENROUTER_API_KEY", "")
+	if router := RouterFromEnv(); router != nil || router.Clients(StagePersona) != nil {
+		t.Fatal("routed without an API key")
+	}
+
+	t.Setenv("OPENROUTER_API_KEY", "key")
+	t.Setenv("OPENROUTER_MODEL", "openai/gpt-4o")
+	t.Setenv("OPENROUTER_FALLBACK_MODELS", " openai/gpt-4o-mini, ,meta/llama ")
+	router := RouterFromEnv()
+	for _, stage := range Stages() {
+		clients := router.Clients(stage)
+		if len(clients) != 3 || clients[0].Model() != "openai/gpt-4o" || clients[1].Model() != "openai/gpt-4o-mini" || clients[2].Model() != "meta/llama" {
+			t.Fatalf("%s chain has the wrong models", stage)
+		}
+		settings := defaultStageSettings[stage]
+		if *clients[0].temperature != *settings.Temperature || clients[2].maxTokens != settings.MaxTokens {
+			t.Errorf("%s clients do not use the stage settings", stage)
+		}
+		if _, priced := clients[2].Cost(Usage{}); priced {
+			t.Errorf("%s: meta/llama has a price", stage)
+		}
+	}
+}
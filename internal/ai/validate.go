@@ -0,0 +1,224 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"biz-flow/internal/core"
+)
+
+// maxPersonaLength caps a persona reply; anything longer is an essay, not the
+// two sentences the prompt asks for
+const maxPersonaLength = 600
+
+// maxAdviceLength caps a strategy reply of three to five sentences
+const maxAdviceLength = 1500
+
+// platformAliases are other names models use for the built-in platforms;
+// a reply naming one mentions the platform as much as its full name does
+var platformAliases = map[core.Platform][]string{
+	core.GoogleBusiness: {"Google Business Profile", "Google Business", "GMB"},
+	core.WhatsApp:       {"WhatsApp"},
+	core.Email:          {"email newsletter", "newsletter"},
+	core.TikTok:         {"Tik Tok"},
+	core.YouTube:        {"You Tube"},
+}
+
+// InvalidResponseError lists the problems found in a model reply. The
+// problems are phrased so they can be sent back to the model as-is.
+type InvalidResponseError struct {
+	Problems []string
+}
+
+func (e *InvalidResponseError) Error() string {
+	return "invalid model response: " + strings.Join(e.Problems, "; ")
+}
+
+// contentReply is the JSON object ContentPrompt asks the model for
+type contentReply struct {
+	Platform string   `json:"platform"`
+	Hook     string   `json:"hook"`
+	Caption  string   `json:"caption"`
+	CTA      string   `json:"cta"`
+	Hashtags []string `json:"hashtags"`
+}
+
+// ParseContent decodes a content reply and checks it against the template
+// schema: the required fields must be present and the platform must be the
+// one requested. Hashtags are normalized and dropped for platforms that do
+// not support them.
+func ParseContent(reply string, platform core.Platform) (*core.ContentTemplate, error) {
+	var parsed contentReply
+	if err := json.Unmarshal([]byte(ExtractJSON(reply)), &parsed); err != nil {
+		return nil, &InvalidResponseError{Problems: []string{"the reply is not a JSON object: " + err.Error()}}
+	}
+
+	var problems []string
+	switch name := strings.TrimSpace(parsed.Platform); {
+	case name == "":
+		problems = append(problems, fmt.Sprintf("the \"platform\" key is missing; it must be %q", platform))
+	case !isKnownPlatform(name):
+		problems = append(problems, fmt.Sprintf("%q is not a supported platform; it must be %q", name, platform))
+	case !strings.EqualFold(name, string(platform)):
+		problems = append(problems, fmt.Sprintf("the post is for %q but %q was requested", name, platform))
+	}
+	for _, field := range []struct{ key, value string }{
+		{"hook", parsed.Hook},
+		{"caption", parsed.Caption},
+		{"cta", parsed.CTA},
+	} {
+		if strings.TrimSpace(field.value) == "" {
+			problems = append(problems, fmt.Sprintf("the %q key is missing or empty", field.key))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &InvalidResponseError{Problems: problems}
+	}
+
+	return &core.ContentTemplate{
+		Hook:     strings.TrimSpace(parsed.Hook),
+		Caption:  strings.TrimSpace(parsed.Caption),
+		CTA:      strings.TrimSpace(parsed.CTA),
+		Hashtags: normalizeHashtags(parsed.Hashtags, platform),
+	}, nil
+}
+
+// ParsePersona checks that a persona reply is a short plain-text description
+func ParsePersona(reply string) (string, error) {
+	return parsePlainText(reply, maxPersonaLength)
+}
+
+// ParseAdvice checks that a strategy reply is plain text that only mentions
+// the recommended platforms
+func ParseAdvice(reply string, recommendations []core.Recommendation) (string, error) {
+	advice, err := parsePlainText(reply, maxAdviceLength)
+	if err != nil {
+		return "", err
+	}
+
+	recommended := make(map[core.Platform]bool, len(recommendations))
+	for _, rec := range recommendations {
+		recommended[rec.Platform] = true
+	}
+	var problems []string
+	for _, platform := range core.GetAllPlatformNames() {
+		if !recommended[platform] && mentions(advice, platform) {
+			problems = append(problems, fmt.Sprintf("%s was not recommended and must not be mentioned", platform))
+		}
+	}
+	if len(problems) > 0 {
+		return "", &InvalidResponseError{Problems: problems}
+	}
+	return advice, nil
+}
+
+// mentions reports whether text names the platform, or one of its aliases,
+// as whole words in any case
+func mentions(text string, platform core.Platform) bool {
+	for _, name := range append([]string{string(platform)}, platformAliases[platform]...) {
+		pattern := `(?i)(^|[^\pL\pN])` + regexp.QuoteMeta(name) + `($|[^\pL\pN])`
+		if regexp.MustCompile(pattern).MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// reviewReply is the JSON object the review prompt asks the model for
+type reviewReply struct {
+	Platforms []string `json:"platforms"`
+}
+
+// ParseReview decodes a review reply: up to top distinct platforms, each one
+// of the candidates, best first
+func ParseReview(reply string, candidates []core.Platform, top int) ([]core.Platform, error) {
+	var parsed reviewReply
+	if err := json.Unmarshal([]byte(ExtractJSON(reply)), &parsed); err != nil {
+		return nil, &InvalidResponseError{Problems: []string{"the reply is not a JSON object: " + err.Error()}}
+	}
+
+	var problems []string
+	if len(parsed.Platforms) == 0 {
+		problems = append(problems, "the \"platforms\" key is missing or empty")
+	}
+	if len(parsed.Platforms) > top {
+		problems = append(problems, fmt.Sprintf("%d platforms were picked but at most %d may be", len(parsed.Platforms), top))
+	}
+	picks := make([]core.Platform, 0, len(parsed.Platforms))
+	for _, name := range parsed.Platforms {
+		platform, ok := findPlatform(strings.TrimSpace(name), candidates)
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%q is not one of the candidates", name))
+		case slices.Contains(picks, platform):
+			problems = append(problems, fmt.Sprintf("%s is picked more than once", platform))
+		default:
+			picks = append(picks, platform)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &InvalidResponseError{Problems: problems}
+	}
+	return picks, nil
+}
+
+// findPlatform matches a name to one of the platforms, ignoring case
+func findPlatform(name string, platforms []core.Platform) (core.Platform, bool) {
+	for _, platform := range platforms {
+		if strings.EqualFold(name, string(platform)) {
+			return platform, true
+		}
+	}
+	return "", false
+}
+
+// parsePlainText checks that a reply is non-empty prose under maxLength
+func parsePlainText(reply string, maxLength int) (string, error) {
+	text := strings.TrimSpace(reply)
+	var problems []string
+	switch {
+	case text == "":
+		problems = append(problems, "the reply is empty")
+	case strings.HasPrefix(text, "{") || strings.HasPrefix(text, "```"):
+		problems = append(problems, "the reply must be plain sentences, not JSON or code")
+	case len(text) > maxLength:
+		problems = append(problems, fmt.Sprintf("the reply is longer than %d characters", maxLength))
+	}
+	if len(problems) > 0 {
+		return "", &InvalidResponseError{Problems: problems}
+	}
+	return text, nil
+}
+
+// normalizeHashtags strips "#" and spaces, drops duplicates, and returns no
+// hashtags at all for platforms without hashtag support
+func normalizeHashtags(hashtags []string, platform core.Platform) []string {
+	normalized := []string{}
+	if metadata, ok := core.GetPlatformMetadata(platform); !ok || !metadata.SupportsHashtags {
+		return normalized
+	}
+
+	seen := make(map[string]bool, len(hashtags))
+	for _, tag := range hashtags {
+		tag = strings.Join(strings.Fields(strings.TrimLeft(strings.TrimSpace(tag), "#")), "")
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// isKnownPlatform reports whether name is one of the configured platforms
+func isKnownPlatform(name string) bool {
+	for _, known := range core.GetAllPlatformNames() {
+		if strings.EqualFold(name, string(known)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package ai
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"biz-flow/internal/core"
+)
+
+func TestParseContent(t *testing.T) {
+	tests := []struct {
+		name         string
+		reply        string
+		platform     core.Platform
+		want         *core.ContentTemplate
+		wantProblems []string
+	}{
+		{
+			name:     "valid reply",
+			reply:    `{"platform":"Instagram","hook":" New mugs ","caption":"Glazed by hand.","cta":"Shop now","hashtags":["#mugs","Handmade","mugs"," hand made ","##gifts"]}`,
+			platform: core.Instagram,
+			want: &core.ContentTemplate{
+				Hook: "New mugs", Caption: "Glazed by hand.", CTA: "Shop now",
+				Hashtags: []string{"mugs", "Handmade", "gifts"},
+			},
+		},
+		{
+			name:     "JSON wrapped in prose and code fences",
+			reply:    "Sure! ```json\n{\"platform\":\"instagram\",\"hook\":\"h\",\"caption\":\"c\",\"cta\":\"a\"}\n```",
+			platform: core.Instagram,
+			want:     &core.ContentTemplate{Hook: "h", Caption: "c", CTA: "a", Hashtags: []string{}},
+		},
+		{
+			name:     "hashtags dropped where the platform has none",
+			reply:    `{"platform":"Email/Newsletter","hook":"h","caption":"c","cta":"a","hashtags":["mugs"]}`,
+			platform: core.Email,
+			want:     &core.ContentTemplate{Hook: "h", Caption: "c", CTA: "a", Hashtags: []string{}},
+		},
+		{
+			name:         "not JSON",
+			reply:        "Here is a great post about mugs",
+			platform:     core.Instagram,
+			wantProblems: []string{"not a JSON object"},
+		},
+		{
+			name:         "missing fields",
+			reply:        `{"platform":"Instagram","hook":"h","caption":"  "}`,
+			platform:     core.Instagram,
+			wantProblems: []string{`"caption" key is missing or empty`, `"cta" key is missing or empty`},
+		},
+		{
+			name:         "missing platform",
+			reply:        `{"hook":"h","caption":"c","cta":"a"}`,
+			platform:     core.Instagram,
+			wantProblems: []string{`"platform" key is missing`},
+		},
+		{
+			name:         "hallucinated platform",
+			reply:        `{"platform":"MySpace","hook":"h","caption":"c","cta":"a"}`,
+			platform:     core.Instagram,
+			wantProblems: []string{`"MySpace" is not a supported platform`},
+		},
+		{
+			name:         "another platform than requested",
+			reply:        `{"platform":"TikTok","hook":"h","caption":"c","cta":"a"}`,
+			platform:     core.Instagram,
+			wantProblems: []string{`the post is for "TikTok" but "Instagram" was requested`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseContent(tt.reply, tt.platform)
+			checkProblems(t, err, tt.wantProblems)
+			if tt.want == nil {
+				return
+			}
+			if got.Hook != tt.want.Hook || got.Caption != tt.want.Caption || got.CTA != tt.want.CTA ||
+				!slices.Equal(got.Hashtags, tt.want.Hashtags) {
+				t.Errorf("ParseContent = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseReview(t *testing.T) {
+	candidates := []core.Platform{core.Instagram, core.Facebook, core.GoogleBusiness}
+	tests := []struct {
+		name         string
+		reply        string
+		top          int
+		want         []core.Platform
+		wantProblems []string
+	}{
+		{
+			name:  "picks in order, ignoring case",
+			reply: `{"platforms":["google my business"," Instagram "]}`,
+			top:   3,
+			want:  []core.Platform{core.GoogleBusiness, core.Instagram},
+		},
+		{name: "not JSON", reply: "Instagram, Facebook", top: 3, wantProblems: []string{"not a JSON object"}},
+		{name: "no picks", reply: `{"platforms":[]}`, top: 3, wantProblems: []string{`"platforms" key is missing or empty`}},
+		{
+			name:         "too many picks",
+			reply:        `{"platforms":["Instagram","Facebook","Google My Business"]}`,
+			top:          2,
+			wantProblems: []string{"3 platforms were picked but at most 2 may be"},
+		},
+		{
+			name:         "a platform that is not a candidate",
+			reply:        `{"platforms":["TikTok","Instagram"]}`,
+			top:          3,
+			wantProblems: []string{`"TikTok" is not one of the candidates`},
+		},
+		{
+			name:         "a platform picked twice",
+			reply:        `{"platforms":["Instagram","instagram"]}`,
+			top:          3,
+			wantProblems: []string{"Instagram is picked more than once"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseReview(tt.reply, candidates, tt.top)
+			checkProblems(t, err, tt.wantProblems)
+			if tt.wantProblems == nil && !slices.Equal(got, tt.want) {
+				t.Errorf("ParseReview = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// checkProblems expects no error without wanted problems, and otherwise an
+// InvalidResponseError with one problem containing each wanted text
+func checkProblems(t *testing.T, err error, want []string) {
+	t.Helper()
+	if want == nil {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	var invalid *InvalidResponseError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("error = %v, want an InvalidResponseError", err)
+	}
+	if len(invalid.Problems) != len(want) {
+		t.Errorf("problems = %q, want %d", invalid.Problems, len(want))
+	}
+	for _, text := range want {
+		if !slices.ContainsFunc(invalid.Problems, func(problem string) bool { return strings.Contains(problem, text) }) {
+			t.Errorf("problems = %q, want one containing %q", invalid.Problems, text)
+		}
+	}
+}
+
+func TestParseAdvice(t *testing.T) {
+	recommendations := []core.Recommendation{{Platform: core.Instagram, Rank: 1}, {Platform: core.Facebook, Rank: 2}}
+	tests := []struct {
+		name         string
+		reply        string
+		wantProblems []string
+	}{
+		{name: "only recommended platforms", reply: "Post on Instagram daily and share the best posts to facebook."},
+		{name: "no platforms at all", reply: "Post three times a week and answer every comment."},
+		{
+			name:         "a platform by its full name",
+			reply:        "Start on Instagram, then try TikTok.",
+			wantProblems: []string{"TikTok was not recommended"},
+		},
+		{
+			name:         "a platform by a shorter name",
+			reply:        "Answer questions on WhatsApp within the hour.",
+			wantProblems: []string{"WhatsApp Business was not recommended"},
+		},
+		{
+			name:         "a platform by an alias",
+			reply:        "Claim your Google Business listing and keep Instagram fresh.",
+			wantProblems: []string{"Google My Business was not recommended"},
+		},
+		{
+			name:         "several platforms in any case",
+			reply:        "A monthly NEWSLETTER and a linkedin page round it out.",
+			wantProblems: []string{"Email/Newsletter was not recommended", "LinkedIn was not recommended"},
+		},
+		{name: "names inside other words", reply: "Work with linkedinfluencers and youtubers who love Instagram."},
+		{name: "empty", reply: "  ", wantProblems: []string{"the reply is empty"}},
+		{name: "JSON", reply: `{"advice":"Post daily"}`, wantProblems: []string{"plain sentences"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAdvice(tt.reply, recommendations)
+			checkProblems(t, err, tt.wantProblems)
+			if tt.wantProblems == nil && got != strings.TrimSpace(tt.reply) {
+				t.Errorf("ParseAdvice = %q, want the reply", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+
+	"biz-flow/internal/core"
+)
+
+// ConsultFunc runs a single consultation
+type ConsultFunc func(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error)
+
+// Options configures a batch run
+type Options struct {
+	Workers int // Defaults to the number of CPUs
+}
+
+// Output is one JSONL line written for each input record
+type Output struct {
+	Line   int                      `json:"line"`
+	Result *core.ConsultationResult `json:"result,omitempty"`
+	Error  string                   `json:"error,omitempty"`
+}
+
+type job struct {
+	line  int
+	raw   []byte
+	index int
+}
+
+// Run reads newline-delimited BusinessInput records from r, consults each one
+// with a bounded worker pool and writes one Output per record to w in input
+// order. A failing record never stops the batch; its error is recorded in the
+// output and the summary instead.
+func Run(ctx context.Context, r io.Reader, w io.Writer, consult ConsultFunc, opts Options) (*Summary, error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	jobs := make(chan job)
+	outputs := make(chan indexedOutput)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				outputs <- indexedOutput{index: j.index, output: process(ctx, j, consult)}
+			}
+		}()
+	}
+
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(jobs)
+		readErr <- readRecords(ctx, r, jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(outputs)
+	}()
+
+	summary := newSummary()
+	encoder := json.NewEncoder(w)
+	var writeErr error
+
+	// Results arrive out of order; hold them until their turn comes
+	pending := make(map[int]Output)
+	next := 0
+	for out := range outputs {
+		pending[out.index] = out.output
+		for {
+			output, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+
+			summary.add(output)
+			if writeErr == nil {
+				writeErr = encoder.Encode(output)
+			}
+		}
+	}
+
+	if err := <-readErr; err != nil {
+		return summary.finish(), err
+	}
+	if writeErr != nil {
+		return summary.finish(), fmt.Errorf("writing results: %w", writeErr)
+	}
+	return summary.finish(), nil
+}
+
+type indexedOutput struct {
+	index  int
+	output Output
+}
+
+// readRecords sends every non-blank line of r to the jobs channel
+func readRecords(ctx context.Context, r io.Reader, jobs chan<- job) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	line, index := 0, 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		select {
+		case jobs <- job{line: line, raw: append([]byte(nil), raw...), index: index}:
+			index++
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading records: %w", err)
+	}
+	return nil
+}
+
+// process decodes and consults a single record, isolating any failure
+func process(ctx context.Context, j job, consult ConsultFunc) (output Output) {
+	output.Line = j.line
+
+	defer func() {
+		if r := recover(); r != nil {
+			output.Result = nil
+			output.Error = fmt.Sprintf("panic: %v", r)
+		}
+	}()
+
+	business, err := core.DecodeBusinessInput(bytes.NewReader(j.raw))
+	if err != nil {
+		output.Error = fmt.Sprintf("decoding record: %v", err)
+		return output
+	}
+
+	result, err := consult(ctx, business)
+	if err != nil {
+		output.Error = err.Error()
+		return output
+	}
+
+	output.Result = result
+	return output
+}
@@ -0,0 +1,139 @@
+package batch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"biz-flow/internal/core"
+)
+
+// fakeConsult fails or panics on request and otherwise answers after a delay
+// that shrinks down the file, so later records finish first
+func fakeConsult(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error) {
+	switch business.Description {
+	case "fail":
+		return nil, errors.New("consult failed")
+	case "panic":
+		panic("boom")
+	}
+	time.Sleep(time.Duration(50-business.Budget) * time.Millisecond)
+	return &core.ConsultationResult{Recommendations: []core.Recommendation{
+		{Rank: 1, Platform: core.Instagram, Score: business.Budget},
+	}}, nil
+}
+
+func record(description string, budget float64) string {
+	return fmt.Sprintf(`{"type":"retail","description":%q,"budget":%g,"goal":"awareness"}`, description, budget)
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []string
+		workers   int
+		wantLines []int
+		wantError []string // "" for a success
+	}{
+		{
+			name:      "keeps input order when later records finish first",
+			input:     []string{record("a", 10), record("b", 20), record("c", 30), record("d", 40)},
+			workers:   4,
+			wantLines: []int{1, 2, 3, 4},
+			wantError: []string{"", "", "", ""},
+		},
+		{
+			name:      "skips blank lines but keeps their line numbers",
+			input:     []string{record("a", 10), "", "   ", record("b", 20)},
+			workers:   2,
+			wantLines: []int{1, 4},
+			wantError: []string{"", ""},
+		},
+		{
+			name: "isolates failures to their own line",
+			input: []string{
+				record("a", 10),
+				`{"type":`,
+				record("fail", 0),
+				record("panic", 0),
+				`{"type":"retail","description":"x","budget":5,"goal":"awareness","extra":1}`,
+				record("b", 20),
+			},
+			workers:   3,
+			wantLines: []int{1, 2, 3, 4, 5, 6},
+			wantError: []string{"", "decoding record", "consult failed", "panic: boom", "decoding record", ""},
+		},
+		{
+			name:      "runs with a single worker",
+			input:     []string{record("a", 10), record("fail", 0), record("b", 20)},
+			workers:   1,
+			wantLines: []int{1, 2, 3},
+			wantError: []string{"", "consult failed", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			summary, err := Run(context.Background(), strings.NewReader(strings.Join(tt.input, "\n")), &out, fakeConsult, Options{Workers: tt.workers})
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			var outputs []Output
+			scanner := bufio.NewScanner(strings.NewReader(out.String()))
+			for scanner.Scan() {
+				var output Output
+				if err := json.Unmarshal(scanner.Bytes(), &output); err != nil {
+					t.Fatalf("decoding output %q: %v", scanner.Text(), err)
+				}
+				outputs = append(outputs, output)
+			}
+			if len(outputs) != len(tt.wantLines) {
+				t.Fatalf("got %d outputs, want %d:\n%s", len(outputs), len(tt.wantLines), out.String())
+			}
+
+			failed := 0
+			for i, output := range outputs {
+				if output.Line != tt.wantLines[i] {
+					t.Errorf("output %d: line %d, want %d", i, output.Line, tt.wantLines[i])
+				}
+				switch want := tt.wantError[i]; {
+				case want == "" && (output.Error != "" || output.Result == nil):
+					t.Errorf("line %d: error %q, want a result", output.Line, output.Error)
+				case want != "" && !strings.Contains(output.Error, want):
+					t.Errorf("line %d: error %q, want it to contain %q", output.Line, output.Error, want)
+				case want != "" && output.Result != nil:
+					t.Errorf("line %d: failed record has a result", output.Line)
+				}
+				if tt.wantError[i] != "" {
+					failed++
+				}
+			}
+
+			if summary.Total != len(tt.wantLines) || summary.Failed != failed || summary.Succeeded != len(tt.wantLines)-failed {
+				t.Errorf("summary total/succeeded/failed = %d/%d/%d, want %d/%d/%d",
+					summary.Total, summary.Succeeded, summary.Failed, len(tt.wantLines), len(tt.wantLines)-failed, failed)
+			}
+			if len(summary.Failures) != failed {
+				t.Errorf("summary lists %d failures, want %d", len(summary.Failures), failed)
+			}
+		})
+	}
+}
+
+func TestRunStopsReadingWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	input := strings.Repeat(record("a", 49)+"\n", 200)
+	_, err := Run(ctx, strings.NewReader(input), &strings.Builder{}, fakeConsult, Options{Workers: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run error = %v, want context.Canceled", err)
+	}
+}
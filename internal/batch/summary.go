@@ -0,0 +1,118 @@
+package batch
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"biz-flow/internal/core"
+)
+
+// Summary reports aggregate statistics for a batch run
+type Summary struct {
+	Total             int                       `json:"total"`
+	Succeeded         int                       `json:"succeeded"`
+	Failed            int                       `json:"failed"`
+	PlatformFrequency map[core.Platform]int     `json:"platform_frequency"`
+	TopPlatformCount  map[core.Platform]int     `json:"top_platform_count"`
+	AverageScores     map[core.Platform]float64 `json:"average_scores"`
+	AverageTopScore   float64                   `json:"average_top_score"`
+	Failures          []Failure                 `json:"failures"`
+
+	scoreTotals map[core.Platform]float64
+	topTotal    float64
+}
+
+// Failure records a record that could not be consulted
+type Failure struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+func newSummary() *Summary {
+	return &Summary{
+		PlatformFrequency: make(map[core.Platform]int),
+		TopPlatformCount:  make(map[core.Platform]int),
+		AverageScores:     make(map[core.Platform]float64),
+		Failures:          make([]Failure, 0),
+		scoreTotals:       make(map[core.Platform]float64),
+	}
+}
+
+// add folds one output into the running totals
+func (s *Summary) add(output Output) {
+	s.Total++
+	if output.Result == nil {
+		s.Failed++
+		s.Failures = append(s.Failures, Failure{Line: output.Line, Error: output.Error})
+		return
+	}
+
+	s.Succeeded++
+	for _, rec := range output.Result.Recommendations {
+		s.PlatformFrequency[rec.Platform]++
+		s.scoreTotals[rec.Platform] += rec.Score
+		if rec.Rank == 1 {
+			s.TopPlatformCount[rec.Platform]++
+			s.topTotal += rec.Score
+		}
+	}
+}
+
+// finish turns the running totals into averages
+func (s *Summary) finish() *Summary {
+	for platform, total := range s.scoreTotals {
+		s.AverageScores[platform] = round(total / float64(s.PlatformFrequency[platform]))
+	}
+	if s.Succeeded > 0 {
+		s.AverageTopScore = round(s.topTotal / float64(s.Succeeded))
+	}
+	return s
+}
+
+// WriteText writes a human-readable version of the summary
+func (s *Summary) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "Consultations: %d total, %d succeeded, %d failed\n", s.Total, s.Succeeded, s.Failed)
+	if s.Succeeded > 0 {
+		fmt.Fprintf(w, "Average top score: %.1f\n", s.AverageTopScore)
+	}
+
+	platforms := make([]core.Platform, 0, len(s.PlatformFrequency))
+	for platform := range s.PlatformFrequency {
+		platforms = append(platforms, platform)
+	}
+	sort.Slice(platforms, func(i, j int) bool {
+		if s.PlatformFrequency[platforms[i]] != s.PlatformFrequency[platforms[j]] {
+			return s.PlatformFrequency[platforms[i]] > s.PlatformFrequency[platforms[j]]
+		}
+		return platforms[i] < platforms[j]
+	})
+
+	if len(platforms) > 0 {
+		fmt.Fprintln(w, "\nPLATFORM FREQUENCY:")
+		for _, platform := range platforms {
+			fmt.Fprintf(w, "  %-20s recommended %d times (top pick %d), avg score %.1f\n",
+				platform,
+				s.PlatformFrequency[platform],
+				s.TopPlatformCount[platform],
+				s.AverageScores[platform],
+			)
+		}
+	}
+
+	if len(s.Failures) > 0 {
+		fmt.Fprintln(w, "\nFAILURES:")
+		for _, failure := range s.Failures {
+			fmt.Fprintf(w, "  line %d: %s\n", failure.Line, failure.Error)
+		}
+	}
+
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// round rounds a score to one decimal place
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Store is a cache backend holding encoded values until they expire
+type Store interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration) error
+}
+
+// Stage groups cache entries that share a TTL and hit/miss counters
+type Stage string
+
+const (
+	// StageDeterministic covers filtering and scoring, which only change when
+	// the input or platform configuration does
+	StageDeterministic Stage = "deterministic"
+	// StageLLM covers persona inference and content generation
+	StageLLM Stage = "llm"
+)
+
+// Stages lists the cache stages in pipeline order
+func Stages() []Stage {
+	return []Stage{StageDeterministic, StageLLM}
+}
+
+// Options configures how long each stage's entries live. A zero TTL turns
+// caching off for that stage.
+type Options struct {
+	DeterministicTTL time.Duration
+	LLMTTL           time.Duration
+}
+
+// DefaultOptions returns the TTLs used when none are configured
+func DefaultOptions() Options {
+	return Options{
+		DeterministicTTL: 24 * time.Hour,
+		LLMTTL:           6 * time.Hour,
+	}
+}
+
+// Stats counts cache lookups for one stage
+type Stats struct {
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	Errors  int64   `json:"errors"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// Cache stores pipeline stage results in a Store. A nil *Cache is valid and
+// never hits, so callers need not check whether caching is enabled.
+type Cache struct {
+	store Store
+	ttls  map[Stage]time.Duration
+
+	mu    sync.Mutex
+	stats map[Stage]*Stats
+}
+
+// New creates a cache over store
+func New(store Store, opts Options) *Cache {
+	return &Cache{
+		store: store,
+		ttls: map[Stage]time.Duration{
+			StageDeterministic: opts.DeterministicTTL,
+			StageLLM:           opts.LLMTTL,
+		},
+		stats: make(map[Stage]*Stats),
+	}
+}
+
+// Get decodes the entry stored under key into v and reports whether it was
+// found. Disabled stages always miss without being counted.
+func (c *Cache) Get(stage Stage, key string, v any) bool {
+	if c == nil || c.ttls[stage] <= 0 {
+		return false
+	}
+
+	data, ok := c.store.Get(string(stage) + "/" + key)
+	if ok && json.Unmarshal(data, v) != nil {
+		// A corrupt entry is treated as a miss and overwritten by the next Set
+		c.record(stage, func(s *Stats) { s.Errors++ })
+		ok = false
+	}
+	c.record(stage, func(s *Stats) {
+		if ok {
+			s.Hits++
+		} else {
+			s.Misses++
+		}
+	})
+	return ok
+}
+
+// Set stores v under key for the stage's TTL. Failures are counted rather
+// than returned, since a cache write must never fail a consultation.
+func (c *Cache) Set(stage Stage, key string, v any) {
+	if c == nil || c.ttls[stage] <= 0 {
+		return
+	}
+
+	data, err := json.Marshal(v)
+	if err == nil {
+		err = c.store.Set(string(stage)+"/"+key, data, c.ttls[stage])
+	}
+	if err != nil {
+		c.record(stage, func(s *Stats) { s.Errors++ })
+	}
+}
+
+// Stats returns the hit/miss counters for every stage
+func (c *Cache) Stats() map[Stage]Stats {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := make(map[Stage]Stats, len(c.ttls))
+	for _, stage := range Stages() {
+		var s Stats
+		if counted, ok := c.stats[stage]; ok {
+			s = *counted
+		}
+		if lookups := s.Hits + s.Misses; lookups > 0 {
+			s.HitRate = float64(s.Hits) / float64(lookups)
+		}
+		stats[stage] = s
+	}
+	return stats
+}
+
+// record updates the counters for stage under the lock
+func (c *Cache) record(stage Stage, update func(*Stats)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.stats[stage]
+	if !ok {
+		s = &Stats{}
+		c.stats[stage] = s
+	}
+	update(s)
+}
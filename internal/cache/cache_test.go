@@ -0,0 +1,201 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"biz-flow/internal/core"
+)
+
+var base = core.BusinessInput{
+	Type:        core.Retail,
+	Description: "Handmade ceramic mugs",
+	Location:    "Austin, TX",
+	Budget:      80,
+	Channels:    []string{"instagram", "facebook"},
+	Goal:        core.Awareness,
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		edit  func(b *core.BusinessInput)
+		same  bool
+		exact bool // whether DeterministicKey should match as well
+	}{
+		{name: "case and spacing", edit: func(b *core.BusinessInput) {
+			b.Description = "  handmade   CERAMIC mugs "
+			b.Location = "austin,  tx"
+		}, same: true, exact: true},
+		{name: "channel order, case and duplicates", edit: func(b *core.BusinessInput) {
+			b.Channels = []string{"Facebook", "instagram", " facebook ", ""}
+		}, same: true, exact: true},
+		{name: "budget in the same bucket", edit: func(b *core.BusinessInput) { b.Budget = 89.99 }, same: true},
+		{name: "budget in the next bucket", edit: func(b *core.BusinessInput) { b.Budget = 90 }},
+		{name: "explicit English locale", edit: func(b *core.BusinessInput) { b.Locale = core.English }, same: true, exact: true},
+		{name: "another locale", edit: func(b *core.BusinessInput) { b.Locale = core.Spanish }},
+		{name: "description", edit: func(b *core.BusinessInput) { b.Description = "Handmade ceramic bowls" }},
+		{name: "goal", edit: func(b *core.BusinessInput) { b.Goal = core.Sales }},
+		{name: "brand voice", edit: func(b *core.BusinessInput) { b.Voice = &core.BrandVoice{BannedWords: []string{"cheap"}} }},
+		{name: "calibration", edit: func(b *core.BusinessInput) {
+			b.Calibration = map[core.Platform]core.Calibration{core.Instagram: {Results: 2}}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edited := base
+			edited.Channels = append([]string{}, base.Channels...)
+			tt.edit(&edited)
+
+			if got := Key(edited) == Key(base); got != tt.same {
+				t.Errorf("Key match = %v, want %v", got, tt.same)
+			}
+			if got := DeterministicKey(edited) == DeterministicKey(base); got != tt.exact {
+				t.Errorf("DeterministicKey match = %v, want %v", got, tt.exact)
+			}
+		})
+	}
+
+	// The tier boundary splits a bucket: 200 is medium and 200.5 high
+	var low, high core.BusinessInput = base, base
+	low.Budget, high.Budget = 200, 200.5
+	if Key(low) == Key(high) {
+		t.Error("budgets on either side of a tier boundary share a key")
+	}
+}
+
+// TestKeyLocation checks that locations sharing a key also agree on whether
+// the business is local, which the filters and scorers branch on
+func TestKeyLocation(t *testing.T) {
+	locations := []string{"", " ", "online", "Online", " ONLINE ", "online only", "Austin", "austin", " Austin ", "Online, TX"}
+	for _, first := range locations {
+		for _, second := range locations {
+			a, b := base, base
+			a.Location, b.Location = first, second
+			if a.IsLocal() == b.IsLocal() {
+				continue
+			}
+			if Key(a) == Key(b) || DeterministicKey(a) == DeterministicKey(b) {
+				t.Errorf("%q (local %v) and %q (local %v) share a key", first, a.IsLocal(), second, b.IsLocal())
+			}
+		}
+	}
+
+	online, Online := base, base
+	online.Location, Online.Location = "online", "Online"
+	if DeterministicKey(online) != DeterministicKey(Online) || online.IsLocal() || Online.IsLocal() {
+		t.Error(`"online" and "Online" should both be online-only and share a key`)
+	}
+}
+
+func TestLRU(t *testing.T) {
+	type step struct {
+		set  string // key to set, or "" to get
+		get  string
+		want bool
+	}
+	tests := []struct {
+		name     string
+		capacity int
+		steps    []step
+	}{
+		{
+			name:     "evicts the least recently set",
+			capacity: 2,
+			steps: []step{
+				{set: "a"}, {set: "b"}, {set: "c"},
+				{get: "a", want: false}, {get: "b", want: true}, {get: "c", want: true},
+			},
+		},
+		{
+			name:     "a read makes an entry recent",
+			capacity: 2,
+			steps: []step{
+				{set: "a"}, {set: "b"}, {get: "a", want: true}, {set: "c"},
+				{get: "a", want: true}, {get: "b", want: false}, {get: "c", want: true},
+			},
+		},
+		{
+			name:     "overwriting an entry makes it recent without growing",
+			capacity: 2,
+			steps: []step{
+				{set: "a"}, {set: "b"}, {set: "a"}, {set: "c"},
+				{get: "a", want: true}, {get: "b", want: false},
+			},
+		},
+		{
+			name:     "capacity below one uses the default",
+			capacity: 0,
+			steps: []step{
+				{set: "a"}, {set: "b"}, {set: "c"},
+				{get: "a", want: true}, {get: "b", want: true}, {get: "c", want: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lru := NewLRU(tt.capacity)
+			for i, s := range tt.steps {
+				if s.set != "" {
+					if err := lru.Set(s.set, []byte(fmt.Sprintf("%s%d", s.set, i)), time.Hour); err != nil {
+						t.Fatalf("Set(%q): %v", s.set, err)
+					}
+					continue
+				}
+				if _, ok := lru.Get(s.get); ok != s.want {
+					t.Errorf("step %d: Get(%q) found = %v, want %v", i, s.get, ok, s.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLRUExpiry(t *testing.T) {
+	lru := NewLRU(2)
+	lru.Set("a", []byte("1"), -time.Second)
+	if _, ok := lru.Get("a"); ok {
+		t.Error("expired entry was returned")
+	}
+	lru.Set("b", []byte("2"), time.Hour)
+	lru.Set("c", []byte("3"), time.Hour)
+	if _, ok := lru.Get("b"); !ok {
+		t.Error("removing an expired entry did not free its slot")
+	}
+}
+
+func TestCache(t *testing.T) {
+	cache := New(NewLRU(4), Options{DeterministicTTL: time.Hour})
+
+	cache.Set(StageDeterministic, "k", []string{"instagram"})
+	var got []string
+	if !cache.Get(StageDeterministic, "k", &got) || len(got) != 1 || got[0] != "instagram" {
+		t.Errorf("Get = %v, want the stored value", got)
+	}
+	if cache.Get(StageDeterministic, "missing", &got) {
+		t.Error("Get found a missing key")
+	}
+
+	// A zero TTL turns the stage off without counting lookups
+	cache.Set(StageLLM, "k", "persona")
+	var persona string
+	if cache.Get(StageLLM, "k", &persona) {
+		t.Error("disabled stage hit")
+	}
+
+	stats := cache.Stats()
+	if s := stats[StageDeterministic]; s.Hits != 1 || s.Misses != 1 || s.HitRate != 0.5 {
+		t.Errorf("deterministic stats = %+v, want 1 hit and 1 miss", s)
+	}
+	if s := stats[StageLLM]; s.Hits != 0 || s.Misses != 0 {
+		t.Errorf("llm stats = %+v, want no lookups", s)
+	}
+
+	var nilCache *Cache
+	nilCache.Set(StageDeterministic, "k", 1)
+	if nilCache.Get(StageDeterministic, "k", &got) {
+		t.Error("nil cache hit")
+	}
+}
@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"biz-flow/internal/core"
+)
+
+// budgetBucketSize is the width of the budget buckets within a tier, in dollars
+const budgetBucketSize = 10
+
+// canonicalInput is the normalized form of a BusinessInput that keys are
+// derived from
+type canonicalInput struct {
+	Type        string   `json:"type"`
+	Description string   `json:"description"`
+	Location    string   `json:"location"`
+	Budget      string   `json:"budget"`
+	Channels    []string `json:"channels"`
+	Goal        string   `json:"goal"`
+	// Locale is empty for English so keys from before locales existed
+	// still match
+	Locale string `json:"locale,omitempty"`
+	// Voice is kept as given: every detail of it reaches the prompts
+	Voice *core.BrandVoice `json:"voice,omitempty"`
+	// Calibration changes the ranking, so new results give new keys
+	Calibration map[core.Platform]core.Calibration `json:"calibration,omitempty"`
+}
+
+// Key canonicalizes a business input so near-identical inputs share LLM stage
+// cache entries: case and whitespace are normalized, channels are sorted and
+// deduplicated, and the budget is bucketed within its tier.
+func Key(business core.BusinessInput) string {
+	return key(business, budgetBucket(business))
+}
+
+// DeterministicKey is Key with the exact budget, for the filter and score
+// stage: platform minimum budgets can fall anywhere inside a bucket, and the
+// ranking must change exactly where they do
+func DeterministicKey(business core.BusinessInput) string {
+	return key(business, strconv.FormatFloat(business.Budget, 'f', -1, 64))
+}
+
+// key canonicalizes a business input with the budget already in key form
+func key(business core.BusinessInput, budget string) string {
+	var locale string
+	if business.Language() != core.English {
+		locale = string(business.Language())
+	}
+	return Fingerprint(canonicalInput{
+		Type:        normalizeText(string(business.Type)),
+		Description: normalizeText(business.Description),
+		Location:    normalizeText(business.Location),
+		Budget:      budget,
+		Channels:    normalizeChannels(business.Channels),
+		Goal:        normalizeText(string(business.Goal)),
+		Locale:      locale,
+		Voice:       business.Voice,
+		Calibration: business.Calibration,
+	})
+}
+
+// Fingerprint hashes the JSON encoding of v, for folding configuration into
+// cache keys
+func Fingerprint(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		// Only unencodable values reach here; never share their entries
+		data = []byte(fmt.Sprintf("%#v", v))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeText lowercases s and collapses runs of whitespace
+func normalizeText(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// normalizeChannels returns the distinct non-empty channels, sorted
+func normalizeChannels(channels []string) []string {
+	seen := make(map[string]bool, len(channels))
+	normalized := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		channel = normalizeText(channel)
+		if channel == "" || seen[channel] {
+			continue
+		}
+		seen[channel] = true
+		normalized = append(normalized, channel)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// budgetBucket rounds the budget down to its bucket. The tier is kept so a
+// bucket never straddles a tier boundary the filters and scorers care about.
+func budgetBucket(business core.BusinessInput) string {
+	bucket := math.Floor(business.Budget/budgetBucketSize) * budgetBucketSize
+	return fmt.Sprintf("%s:%.0f", business.BudgetTier(), bucket)
+}
@@ -0,0 +1,129 @@
+package calendar
+
+import (
+	"math"
+
+	"biz-flow/internal/core"
+)
+
+// Cadence is how often the calendar posts to a platform
+type Cadence struct {
+	Platform core.Platform `json:"platform"`
+	// PostsPerWeek is an average; 1.5 alternates one and two posts a week
+	PostsPerWeek float64 `json:"posts_per_week"`
+	HoursPerPost float64 `json:"hours_per_post"`
+	// Time is the local posting time, HH:MM
+	Time string `json:"time"`
+}
+
+// effort is the ideal frequency and the hours each post takes for an
+// effort level: quick updates can go out often, video is made less often
+type effort struct {
+	postsPerWeek float64
+	hoursPerPost float64
+}
+
+var efforts = map[core.EffortLevel]effort{
+	core.LowEffort:    {postsPerWeek: 2, hoursPerPost: 0.5},
+	core.MediumEffort: {postsPerWeek: 3, hoursPerPost: 1},
+	core.HighEffort:   {postsPerWeek: 2, hoursPerPost: 2.5},
+}
+
+// postingTimes are when each platform's audience is usually scrolling
+var postingTimes = map[core.Platform]string{
+	core.Instagram:      "11:00",
+	core.Facebook:       "13:00",
+	core.TikTok:         "19:00",
+	core.GoogleBusiness: "10:00",
+	core.WhatsApp:       "12:00",
+	core.Email:          "09:00",
+	core.LinkedIn:       "08:30",
+	core.YouTube:        "17:00",
+}
+
+// PostingTime is the local time, HH:MM, to post on the platform
+func PostingTime(platform core.Platform) string {
+	if at, ok := postingTimes[platform]; ok {
+		return at
+	}
+	return "12:00"
+}
+
+// minPostsPerWeek keeps every recommended platform alive: one post every
+// other week
+const minPostsPerWeek = 0.5
+
+// Cadences shares the weekly hours between the platforms, best ranked
+// first. Each gets a share weighted by rank (3:2:1 for three platforms),
+// posts at most its ideal frequency, and hours left over go back to the
+// platforms in rank order.
+func Cadences(platforms []core.Platform, hoursPerWeek float64) []Cadence {
+	plan := make([]Cadence, len(platforms))
+	ideal := make([]float64, len(platforms))
+	totalWeight := float64(len(platforms) * (len(platforms) + 1) / 2)
+	used := 0.0
+	for i, platform := range platforms {
+		cost := efforts[core.MediumEffort]
+		if metadata, ok := core.GetPlatformMetadata(platform); ok {
+			if e, ok := efforts[metadata.EffortLevel]; ok {
+				cost = e
+			}
+		}
+		share := hoursPerWeek * float64(len(platforms)-i) / totalWeight
+		posts := math.Max(minPostsPerWeek, halves(math.Min(cost.postsPerWeek, share/cost.hoursPerPost)))
+		plan[i] = Cadence{Platform: platform, PostsPerWeek: posts, HoursPerPost: cost.hoursPerPost, Time: PostingTime(platform)}
+		ideal[i] = cost.postsPerWeek
+		used += posts * cost.hoursPerPost
+	}
+
+	for added := true; added; {
+		added = false
+		for i := range plan {
+			step := minPostsPerWeek * plan[i].HoursPerPost
+			if plan[i].PostsPerWeek < ideal[i] && used+step <= hoursPerWeek+1e-9 {
+				plan[i].PostsPerWeek += minPostsPerWeek
+				used += step
+				added = true
+			}
+		}
+	}
+	return plan
+}
+
+// halves rounds down to a multiple of one half
+func halves(x float64) float64 {
+	return math.Floor(x*2+1e-9) / 2
+}
+
+// postsInWeek spreads a fractional weekly rate over whole weeks, front-loaded:
+// 1.5 a week is 2, 1, 2, 1...
+func postsInWeek(week int, rate float64) int {
+	return int(math.Ceil(float64(week+1)*rate-1e-9) - math.Ceil(float64(week)*rate-1e-9))
+}
+
+// weekdaySpread is which days of the week (0 is Monday) n posts go out on
+var weekdaySpread = [][]int{
+	{},
+	{2},
+	{1, 3},
+	{0, 2, 4},
+	{0, 1, 3, 4},
+	{0, 1, 2, 3, 4},
+	{0, 1, 2, 3, 4, 5},
+	{0, 1, 2, 3, 4, 5, 6},
+}
+
+// postingDays returns the days of the week a platform posts on. Lower
+// ranked platforms are shifted a day per rank so that a week's posts do not
+// all land on the same weekdays.
+func postingDays(n, rank int) []int {
+	n = min(n, len(weekdaySpread)-1)
+	days := make([]int, n)
+	for i, day := range weekdaySpread[n] {
+		if n <= 5 {
+			day = (day + rank) % 5
+		}
+		days[i] = day
+	}
+	return days
+}
@@ -0,0 +1,295 @@
+// Package calendar plans weeks of posts across the recommended platforms:
+// how often to post on each, which content pillar each post serves, which
+// holidays to build posts around, and a content template for every slot.
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"biz-flow/internal/cache"
+	"biz-flow/internal/core"
+	"biz-flow/internal/templates"
+	"biz-flow/internal/voice"
+)
+
+// Limits and defaults for a calendar
+const (
+	MinWeeks            = 4
+	MaxWeeks            = 12
+	DefaultWeeks        = 4
+	DefaultHoursPerWeek = 3
+	MaxHoursPerWeek     = 60
+)
+
+// DateLayout is how calendar dates are written
+const DateLayout = "2006-01-02"
+
+// Options shape a calendar. Zero values take the defaults.
+type Options struct {
+	Weeks int
+	// Start is moved forward to a Monday; zero means next Monday
+	Start time.Time
+	// HoursPerWeek is the time the owner can spend on content each week
+	HoursPerWeek float64
+}
+
+// PlanRequest is the body of POST /calendar
+type PlanRequest struct {
+	Business core.BusinessInput `json:"business"`
+	Weeks    int                `json:"weeks,omitempty"`
+	// Start is a date, YYYY-MM-DD; empty means next Monday
+	Start        string  `json:"start,omitempty"`
+	HoursPerWeek float64 `json:"hours_per_week,omitempty"`
+}
+
+// Options returns the request's calendar options
+func (r PlanRequest) Options() (Options, error) {
+	options := Options{Weeks: r.Weeks, HoursPerWeek: r.HoursPerWeek}
+	if r.Start != "" {
+		start, err := time.Parse(DateLayout, r.Start)
+		if err != nil {
+			return options, &core.ValidationError{Field: "start", Message: "must be a date like 2025-03-03"}
+		}
+		options.Start = start
+	}
+	return options, nil
+}
+
+// Calendar is a posting plan of whole weeks starting on a Monday
+type Calendar struct {
+	// ID identifies the business the calendar was planned for, so that
+	// exporting a regenerated plan updates its events
+	ID           string  `json:"id,omitempty"`
+	Start        string  `json:"start"`
+	End          string  `json:"end"`
+	Weeks        int     `json:"weeks"`
+	HoursPerWeek float64 `json:"hours_per_week"`
+	// PlannedHours is the average weekly time the plan takes
+	PlannedHours float64   `json:"planned_hours"`
+	Cadence      []Cadence `json:"cadence"`
+	Slots        []Slot    `json:"slots"`
+}
+
+// Slot is one post in the calendar
+type Slot struct {
+	Date     string        `json:"date"`
+	Time     string        `json:"time"`
+	Week     int           `json:"week"`
+	Platform core.Platform `json:"platform"`
+	Pillar   Pillar        `json:"pillar"`
+	// Holiday names the seasonal hook the post is built around
+	Holiday    string                `json:"holiday,omitempty"`
+	Template   *core.ContentTemplate `json:"template"`
+	VoiceScore *core.VoiceScore      `json:"voice_score,omitempty"`
+}
+
+// Planner builds content calendars from the offline template library
+type Planner struct {
+	voice *voice.Checker
+}
+
+// NewPlanner creates a calendar planner
+func NewPlanner() *Planner {
+	return &Planner{voice: voice.NewChecker()}
+}
+
+// Plan schedules posts on the recommended platforms, in rank order. The same
+// input and options always give the same calendar.
+func (p *Planner) Plan(business core.BusinessInput, recommendations []core.Recommendation, options Options) (*Calendar, error) {
+	if err := business.Validate(); err != nil {
+		return nil, err
+	}
+	options, err := normalize(options)
+	if err != nil {
+		return nil, err
+	}
+	if len(recommendations) == 0 {
+		return nil, errors.New("calendar: no recommended platforms to plan for")
+	}
+	ranked := append([]core.Recommendation{}, recommendations...)
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Rank < ranked[j].Rank })
+	platforms := make([]core.Platform, len(ranked))
+	for i, recommendation := range ranked {
+		platforms[i] = recommendation.Platform
+	}
+
+	end := options.Start.AddDate(0, 0, 7*options.Weeks-1)
+	calendar := &Calendar{
+		ID:           BusinessID(business),
+		Start:        options.Start.Format(DateLayout),
+		End:          end.Format(DateLayout),
+		Weeks:        options.Weeks,
+		HoursPerWeek: options.HoursPerWeek,
+		Cadence:      Cadences(platforms, options.HoursPerWeek),
+	}
+	for _, cadence := range calendar.Cadence {
+		calendar.PlannedHours += cadence.PostsPerWeek * cadence.HoursPerPost
+	}
+	calendar.PlannedHours = math.Round(calendar.PlannedHours*100) / 100
+
+	type planned struct {
+		Slot
+		date    time.Time
+		rank    int
+		holiday *occurrence
+	}
+	var slots []*planned
+	for week := 0; week < options.Weeks; week++ {
+		for rank, cadence := range calendar.Cadence {
+			for _, day := range postingDays(postsInWeek(week, cadence.PostsPerWeek), rank) {
+				date := options.Start.AddDate(0, 0, 7*week+day)
+				slots = append(slots, &planned{
+					Slot: Slot{Date: date.Format(DateLayout), Time: cadence.Time, Week: week + 1, Platform: cadence.Platform},
+					date: date,
+					rank: rank,
+				})
+			}
+		}
+	}
+	sort.SliceStable(slots, func(i, j int) bool {
+		if !slots[i].date.Equal(slots[j].date) {
+			return slots[i].date.Before(slots[j].date)
+		}
+		if slots[i].Time != slots[j].Time {
+			return slots[i].Time < slots[j].Time
+		}
+		return slots[i].rank < slots[j].rank
+	})
+
+	// Each holiday gets the last post on each platform inside its lead window
+	holidays := occurrences(business, options.Start, end.AddDate(0, 0, maxLeadDays()))
+	for i := range holidays {
+		holiday := &holidays[i]
+		from := holiday.date.AddDate(0, 0, -holiday.LeadDays)
+		for _, platform := range platforms {
+			for j := len(slots) - 1; j >= 0; j-- {
+				slot := slots[j]
+				if slot.Platform != platform || slot.holiday != nil || slot.date.After(holiday.date) || slot.date.Before(from) {
+					continue
+				}
+				slot.holiday = holiday
+				break
+			}
+		}
+	}
+
+	// The other posts follow the goal's pillar mix, per platform, giving
+	// each post to the pillar furthest behind its share
+	mix := pillarMix[business.Goal]
+	counts := make(map[core.Platform]map[Pillar]int, len(platforms))
+	for _, platform := range platforms {
+		counts[platform] = make(map[Pillar]int, len(Pillars()))
+	}
+	for _, slot := range slots {
+		if slot.holiday != nil {
+			slot.Pillar = slot.holiday.Pillar
+		} else {
+			slot.Pillar = nextPillar(mix, counts[slot.Platform])
+		}
+		counts[slot.Platform][slot.Pillar]++
+	}
+
+	lang := localCopy[business.Language()]
+	used := make(map[core.Platform]map[Pillar]int, len(platforms))
+	for _, platform := range platforms {
+		used[platform] = make(map[Pillar]int, len(Pillars()))
+	}
+	calendar.Slots = make([]Slot, len(slots))
+	for i, slot := range slots {
+		variant := business
+		variant.Goal = pillarGoal(slot.Pillar)
+		var pattern templates.Pattern
+		if slot.holiday != nil {
+			slot.Holiday = slot.holiday.Name(business.Language())
+			pattern = lang.greeting
+			if slot.holiday.Pillar == Promotional {
+				pattern = lang.holiday
+			}
+			named := strings.NewReplacer("{holiday}", slot.Holiday)
+			pattern = templates.Pattern{Hook: named.Replace(pattern.Hook), Caption: named.Replace(pattern.Caption), CTA: named.Replace(pattern.CTA)}
+		} else {
+			// Platforms start at different patterns so that the same week
+			// does not repeat one post everywhere
+			patterns := lang.pillars[slot.Pillar]
+			pattern = patterns[(used[slot.Platform][slot.Pillar]+slot.rank)%len(patterns)]
+			used[slot.Platform][slot.Pillar]++
+		}
+		if slot.Platform == core.Email {
+			pattern.Hook = lang.subject + " " + pattern.Hook
+		}
+		slot.Template, slot.VoiceScore = p.voice.Check(business, templates.Fill(variant, slot.Platform, pattern))
+		calendar.Slots[i] = slot.Slot
+	}
+	return calendar, nil
+}
+
+// BusinessID identifies a business across plans and exports by its type,
+// description and location, ignoring case and spacing
+func BusinessID(business core.BusinessInput) string {
+	location := "online"
+	if business.IsLocal() {
+		location = business.Location
+	}
+	fields := []string{string(business.Type), business.Description, location}
+	for i, field := range fields {
+		fields[i] = strings.Join(strings.Fields(strings.ToLower(field)), " ")
+	}
+	return cache.Fingerprint(fields)[:16]
+}
+
+// normalize applies the defaults and checks the limits
+func normalize(options Options) (Options, error) {
+	if options.Weeks == 0 {
+		options.Weeks = DefaultWeeks
+	}
+	if options.Weeks < MinWeeks || options.Weeks > MaxWeeks {
+		return options, &core.ValidationError{Field: "weeks", Message: fmt.Sprintf("must be between %d and %d", MinWeeks, MaxWeeks)}
+	}
+	if options.HoursPerWeek == 0 {
+		options.HoursPerWeek = DefaultHoursPerWeek
+	}
+	if math.IsNaN(options.HoursPerWeek) || options.HoursPerWeek < 0 || options.HoursPerWeek > MaxHoursPerWeek {
+		return options, &core.ValidationError{Field: "hours_per_week", Message: fmt.Sprintf("must be between 0 and %d", MaxHoursPerWeek)}
+	}
+	if options.Start.IsZero() {
+		options.Start = time.Now()
+	}
+	options.Start = NextMonday(options.Start)
+	return options, nil
+}
+
+// NextMonday returns t's date if it is a Monday, or the Monday after
+func NextMonday(t time.Time) time.Time {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return date.AddDate(0, 0, (int(time.Monday)-int(date.Weekday())+7)%7)
+}
+
+// nextPillar picks the pillar furthest behind its share of the posts so far;
+// ties go to the pillar listed first
+func nextPillar(mix map[Pillar]float64, counts map[Pillar]int) Pillar {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	best, bestDeficit := Educational, math.Inf(-1)
+	for _, pillar := range Pillars() {
+		if deficit := mix[pillar]*float64(total+1) - float64(counts[pillar]); deficit > bestDeficit+1e-9 {
+			best, bestDeficit = pillar, deficit
+		}
+	}
+	return best
+}
+
+// maxLeadDays is the longest lead window in the bundled calendar
+func maxLeadDays() int {
+	longest := 0
+	for _, holiday := range Holidays() {
+		longest = max(longest, holiday.LeadDays)
+	}
+	return longest
+}
@@ -0,0 +1,348 @@
+package calendar
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"biz-flow/internal/core"
+)
+
+var shop = core.BusinessInput{
+	Type:        core.Retail,
+	Description: "Handmade ceramic mugs",
+	Location:    "Austin, TX",
+	Budget:      80,
+	Goal:        core.Awareness,
+}
+
+// ranked is deliberately out of rank order
+var ranked = []core.Recommendation{
+	{Platform: core.Facebook, Rank: 2},
+	{Platform: core.Instagram, Rank: 1},
+}
+
+// wednesday is a mid-week start, so plans begin the Monday after, 2025-02-10
+var wednesday = time.Date(2025, 2, 5, 15, 30, 0, 0, time.UTC)
+
+func TestPlan(t *testing.T) {
+	options := Options{Start: wednesday, HoursPerWeek: 5}
+	calendar, err := NewPlanner().Plan(shop, ranked, options)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+
+	if calendar.Start != "2025-02-10" || calendar.End != "2025-03-09" || calendar.Weeks != DefaultWeeks {
+		t.Errorf("calendar runs %s to %s over %d weeks, want 2025-02-10 to 2025-03-09 over %d",
+			calendar.Start, calendar.End, calendar.Weeks, DefaultWeeks)
+	}
+	want := []Cadence{
+		{Platform: core.Instagram, PostsPerWeek: 3, HoursPerPost: 1, Time: "11:00"},
+		{Platform: core.Facebook, PostsPerWeek: 2, HoursPerPost: 1, Time: "13:00"},
+	}
+	if !reflect.DeepEqual(calendar.Cadence, want) {
+		t.Errorf("Cadence = %+v, want %+v", calendar.Cadence, want)
+	}
+	if calendar.PlannedHours != 5 {
+		t.Errorf("PlannedHours = %v, want 5", calendar.PlannedHours)
+	}
+	if calendar.ID != BusinessID(shop) || calendar.ID == "" {
+		t.Errorf("ID = %q, want the business ID %q", calendar.ID, BusinessID(shop))
+	}
+
+	start, _ := time.Parse(DateLayout, calendar.Start)
+	perWeek := make(map[core.Platform]map[int]int)
+	var previous time.Time
+	for _, slot := range calendar.Slots {
+		date, err := time.Parse(DateLayout, slot.Date)
+		if err != nil {
+			t.Fatalf("slot date %q: %v", slot.Date, err)
+		}
+		if date.Before(previous) {
+			t.Errorf("slot on %s comes after %s", slot.Date, previous.Format(DateLayout))
+		}
+		previous = date
+		if week := int(date.Sub(start).Hours()/24)/7 + 1; slot.Week != week {
+			t.Errorf("slot on %s is in week %d, want %d", slot.Date, slot.Week, week)
+		}
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			t.Errorf("slot on %s falls on a weekend", slot.Date)
+		}
+		if slot.Time != PostingTime(slot.Platform) {
+			t.Errorf("%s slot at %s, want %s", slot.Platform, slot.Time, PostingTime(slot.Platform))
+		}
+		if slot.Template == nil || strings.ContainsAny(slot.Template.Hook+slot.Template.Caption+slot.Template.CTA, "{}") {
+			t.Errorf("slot on %s has template %+v, want a filled one", slot.Date, slot.Template)
+		}
+		if perWeek[slot.Platform] == nil {
+			perWeek[slot.Platform] = make(map[int]int)
+		}
+		perWeek[slot.Platform][slot.Week]++
+	}
+	for _, cadence := range calendar.Cadence {
+		for week := 1; week <= calendar.Weeks; week++ {
+			if got := perWeek[cadence.Platform][week]; float64(got) != cadence.PostsPerWeek {
+				t.Errorf("%s posts %d times in week %d, want %v", cadence.Platform, got, week, cadence.PostsPerWeek)
+			}
+		}
+	}
+
+	again, err := NewPlanner().Plan(shop, ranked, options)
+	if err != nil || !reflect.DeepEqual(again, calendar) {
+		t.Error("Plan is not deterministic for the same input and options")
+	}
+}
+
+func TestBusinessID(t *testing.T) {
+	same := shop
+	same.Description, same.Location, same.Budget = "  handmade CERAMIC mugs", "austin, tx", 500
+	online, other := shop, shop
+	online.Location = "Online"
+	other.Description = "Sourdough bakery"
+	blank := online
+	blank.Location = ""
+
+	if BusinessID(same) != BusinessID(shop) {
+		t.Error("case, spacing and budget change the business ID")
+	}
+	if BusinessID(online) != BusinessID(blank) {
+		t.Error("online and blank locations have different business IDs")
+	}
+	for name, business := range map[string]core.BusinessInput{"online": online, "description": other} {
+		if BusinessID(business) == BusinessID(shop) {
+			t.Errorf("another %s has the same business ID", name)
+		}
+	}
+}
+
+func TestPlanHolidays(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale core.Locale
+		want   map[string]string // date and platform to holiday
+	}{
+		{
+			// Valentine's Day is a Friday, a posting day, so the post goes out
+			// on the day itself. Women's Day is a Saturday; the last post in
+			// its three-day window is on the Friday before.
+			name:   "English",
+			locale: core.English,
+			want: map[string]string{
+				"2025-02-14 Instagram": "Valentine's Day",
+				"2025-02-14 Facebook":  "Valentine's Day",
+				"2025-03-07 Instagram": "International Women's Day",
+				"2025-03-07 Facebook":  "International Women's Day",
+			},
+		},
+		{
+			// Brazil celebrates Dia dos Namorados in June instead
+			name:   "Portuguese",
+			locale: core.Portuguese,
+			want: map[string]string{
+				"2025-03-07 Instagram": "Dia Internacional da Mulher",
+				"2025-03-07 Facebook":  "Dia Internacional da Mulher",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			business := shop
+			business.Locale = tt.locale
+			calendar, err := NewPlanner().Plan(business, ranked, Options{Start: wednesday, HoursPerWeek: 5})
+			if err != nil {
+				t.Fatalf("Plan: %v", err)
+			}
+
+			got := make(map[string]string)
+			for _, slot := range calendar.Slots {
+				if slot.Holiday == "" {
+					continue
+				}
+				got[slot.Date+" "+string(slot.Platform)] = slot.Holiday
+				if !strings.Contains(slot.Template.Hook+slot.Template.Caption, slot.Holiday) {
+					t.Errorf("%s post does not mention %s: %+v", slot.Date, slot.Holiday, slot.Template)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("holiday posts = %v, want %v", got, tt.want)
+			}
+
+			for _, slot := range calendar.Slots {
+				switch slot.Holiday {
+				case "Valentine's Day":
+					if slot.Pillar != Promotional {
+						t.Errorf("Valentine's Day post is %s, want promotional", slot.Pillar)
+					}
+				case "International Women's Day", "Dia Internacional da Mulher":
+					if slot.Pillar != Educational {
+						t.Errorf("Women's Day post is %s, want educational", slot.Pillar)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPlanErrors(t *testing.T) {
+	invalid := shop
+	invalid.Budget = -1
+
+	tests := []struct {
+		name            string
+		business        core.BusinessInput
+		recommendations []core.Recommendation
+		options         Options
+		field           string // the ValidationError's field, or "" for another error
+	}{
+		{name: "invalid business", business: invalid, recommendations: ranked, field: "budget"},
+		{name: "invalid options", business: shop, recommendations: ranked, options: Options{Weeks: 2}, field: "weeks"},
+		{name: "no recommendations", business: shop},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPlanner().Plan(tt.business, tt.recommendations, tt.options)
+			if err == nil {
+				t.Fatal("Plan succeeded, want an error")
+			}
+			var validation *core.ValidationError
+			if isValidation := errors.As(err, &validation); isValidation != (tt.field != "") ||
+				isValidation && validation.Field != tt.field {
+				t.Errorf("Plan error = %v, want a validation error on %q", err, tt.field)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	monday := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		options Options
+		want    Options
+		field   string // the ValidationError's field, if any
+	}{
+		{name: "defaults", options: Options{Start: monday}, want: Options{Weeks: DefaultWeeks, Start: monday, HoursPerWeek: DefaultHoursPerWeek}},
+		{name: "limits", options: Options{Weeks: MaxWeeks, Start: monday, HoursPerWeek: MaxHoursPerWeek},
+			want: Options{Weeks: MaxWeeks, Start: monday, HoursPerWeek: MaxHoursPerWeek}},
+		{name: "start moved to Monday", options: Options{Weeks: MinWeeks, Start: wednesday, HoursPerWeek: 1},
+			want: Options{Weeks: MinWeeks, Start: monday, HoursPerWeek: 1}},
+		{name: "Sunday moves to the next day", options: Options{Start: monday.AddDate(0, 0, -1)},
+			want: Options{Weeks: DefaultWeeks, Start: monday, HoursPerWeek: DefaultHoursPerWeek}},
+		{name: "too few weeks", options: Options{Weeks: MinWeeks - 1}, field: "weeks"},
+		{name: "too many weeks", options: Options{Weeks: MaxWeeks + 1}, field: "weeks"},
+		{name: "negative weeks", options: Options{Weeks: -4}, field: "weeks"},
+		{name: "negative hours", options: Options{HoursPerWeek: -1}, field: "hours_per_week"},
+		{name: "too many hours", options: Options{HoursPerWeek: MaxHoursPerWeek + 0.5}, field: "hours_per_week"},
+		{name: "NaN hours", options: Options{HoursPerWeek: math.NaN()}, field: "hours_per_week"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalize(tt.options)
+			if tt.field != "" {
+				var validation *core.ValidationError
+				if !errors.As(err, &validation) || validation.Field != tt.field {
+					t.Errorf("normalize error = %v, want a validation error on %s", err, tt.field)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalize: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("normalize = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("zero start is the next Monday", func(t *testing.T) {
+		got, err := normalize(Options{})
+		if err != nil {
+			t.Fatalf("normalize: %v", err)
+		}
+		today := NextMonday(time.Now()).AddDate(0, 0, -7)
+		if got.Start.Weekday() != time.Monday || got.Start.Before(today) {
+			t.Errorf("Start = %s, want the next Monday", got.Start.Format(DateLayout))
+		}
+	})
+}
+
+func TestNextPillar(t *testing.T) {
+	even := map[Pillar]float64{Educational: 0.25, Promotional: 0.25, BehindTheScenes: 0.25, UGC: 0.25}
+
+	tests := []struct {
+		name string
+		mix  map[Pillar]float64
+		n    int
+		want []Pillar
+	}{
+		{
+			name: "ties go to the pillar listed first",
+			mix:  even,
+			n:    6,
+			want: []Pillar{Educational, Promotional, BehindTheScenes, UGC, Educational, Promotional},
+		},
+		{
+			name: "awareness",
+			mix:  pillarMix[core.Awareness],
+			n:    7,
+			want: []Pillar{Educational, BehindTheScenes, UGC, Promotional, Educational, BehindTheScenes, Educational},
+		},
+		{
+			name: "sales",
+			mix:  pillarMix[core.Sales],
+			n:    4,
+			want: []Pillar{Promotional, Educational, UGC, BehindTheScenes},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts := make(map[Pillar]int)
+			var got []Pillar
+			for i := 0; i < tt.n; i++ {
+				pillar := nextPillar(tt.mix, counts)
+				counts[pillar]++
+				got = append(got, pillar)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pillars = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("shares converge on the mix", func(t *testing.T) {
+		counts := make(map[Pillar]int)
+		const n = 100
+		for i := 0; i < n; i++ {
+			counts[nextPillar(pillarMix[core.Awareness], counts)]++
+		}
+		for pillar, share := range pillarMix[core.Awareness] {
+			if got := float64(counts[pillar]) / n; math.Abs(got-share) > 0.01 {
+				t.Errorf("%s share = %.2f, want %.2f", pillar, got, share)
+			}
+		}
+	})
+}
+
+func TestPostsInWeek(t *testing.T) {
+	for rate, want := range map[float64][]int{
+		0.5: {1, 0, 1, 0},
+		1.5: {2, 1, 2, 1},
+		3:   {3, 3, 3, 3},
+	} {
+		var got []int
+		for week := 0; week < len(want); week++ {
+			got = append(got, postsInWeek(week, rate))
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("postsInWeek at %v = %v, want %v", rate, got, want)
+		}
+	}
+}
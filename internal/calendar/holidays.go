@@ -0,0 +1,175 @@
+package calendar
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"biz-flow/internal/core"
+)
+
+//go:embed holidays.json
+var holidaysFile []byte
+
+// Holiday is a seasonal hook from the bundled calendar. Its date is a fixed
+// month and day, the nth weekday of a month (plus Offset days, so Black Friday
+// follows Thanksgiving), or a table of dates for holidays that follow a lunar
+// calendar.
+type Holiday struct {
+	ID    string                 `json:"id"`
+	Names map[core.Locale]string `json:"names"`
+	Month time.Month             `json:"month,omitempty"`
+	Day   int                    `json:"day,omitempty"`
+	// Weekday and Nth pick the nth weekday of Month; -1 is the last
+	Weekday string            `json:"weekday,omitempty"`
+	Nth     int               `json:"nth,omitempty"`
+	Offset  int               `json:"offset,omitempty"`
+	Dates   map[string]string `json:"dates,omitempty"`
+	// Locales limits the holiday to audiences that celebrate it; empty means
+	// every locale
+	Locales []core.Locale `json:"locales,omitempty"`
+	// Local and Online limit the holiday to local or online businesses
+	Local  bool `json:"local,omitempty"`
+	Online bool `json:"online,omitempty"`
+	// Pillar is the pillar a post about the holiday belongs to
+	Pillar Pillar `json:"pillar"`
+	// LeadDays is how many days ahead of the holiday a post can go out
+	LeadDays int `json:"lead_days"`
+}
+
+var (
+	holidaysOnce sync.Once
+	holidays     []Holiday
+)
+
+// Holidays returns the bundled holiday calendar
+func Holidays() []Holiday {
+	holidaysOnce.Do(func() {
+		if err := json.Unmarshal(holidaysFile, &holidays); err != nil {
+			panic(fmt.Sprintf("calendar: built-in holidays: %v", err))
+		}
+		for _, holiday := range holidays {
+			if err := holiday.validate(); err != nil {
+				panic(fmt.Sprintf("calendar: built-in holidays: %v", err))
+			}
+		}
+	})
+	return holidays
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+func (h Holiday) validate() error {
+	if h.Names[core.English] == "" {
+		return fmt.Errorf("%s: missing English name", h.ID)
+	}
+	if !h.Pillar.valid() {
+		return fmt.Errorf("%s: unknown pillar %q", h.ID, h.Pillar)
+	}
+	if len(h.Dates) > 0 {
+		for year, date := range h.Dates {
+			if parsed, err := time.Parse(DateLayout, date); err != nil || strconv.Itoa(parsed.Year()) != year {
+				return fmt.Errorf("%s: bad date %q for %s", h.ID, date, year)
+			}
+		}
+		return nil
+	}
+	if h.Month < time.January || h.Month > time.December {
+		return fmt.Errorf("%s: month must be 1-12", h.ID)
+	}
+	if h.Weekday != "" {
+		if _, ok := weekdays[h.Weekday]; !ok || h.Nth == 0 || h.Nth > 5 || h.Nth < -1 {
+			return fmt.Errorf("%s: bad weekday rule", h.ID)
+		}
+		return nil
+	}
+	if h.Day < 1 || h.Day > 31 {
+		return fmt.Errorf("%s: day must be 1-31", h.ID)
+	}
+	return nil
+}
+
+// On returns the holiday's date in year, or false when the bundled calendar
+// does not know it
+func (h Holiday) On(year int) (time.Time, bool) {
+	if len(h.Dates) > 0 {
+		date, ok := h.Dates[strconv.Itoa(year)]
+		if !ok {
+			return time.Time{}, false
+		}
+		parsed, err := time.Parse(DateLayout, date)
+		return parsed, err == nil
+	}
+	if h.Weekday == "" {
+		return time.Date(year, h.Month, h.Day+h.Offset, 0, 0, 0, 0, time.UTC), true
+	}
+
+	weekday := weekdays[h.Weekday]
+	var date time.Time
+	if h.Nth > 0 {
+		first := time.Date(year, h.Month, 1, 0, 0, 0, 0, time.UTC)
+		date = first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+7*(h.Nth-1))
+	} else {
+		last := time.Date(year, h.Month+1, 0, 0, 0, 0, 0, time.UTC)
+		date = last.AddDate(0, 0, -((int(last.Weekday()) - int(weekday) + 7) % 7))
+	}
+	if date.Month() != h.Month {
+		return time.Time{}, false
+	}
+	return date.AddDate(0, 0, h.Offset), true
+}
+
+// Name is the holiday's name in the locale, falling back to English
+func (h Holiday) Name(locale core.Locale) string {
+	if name := h.Names[locale]; name != "" {
+		return name
+	}
+	return h.Names[core.English]
+}
+
+// AppliesTo reports whether the holiday is worth a post for the business
+func (h Holiday) AppliesTo(business core.BusinessInput) bool {
+	if h.Local && !business.IsLocal() || h.Online && business.IsLocal() {
+		return false
+	}
+	if len(h.Locales) == 0 {
+		return true
+	}
+	for _, locale := range h.Locales {
+		if locale == business.Language() {
+			return true
+		}
+	}
+	return false
+}
+
+// occurrence is a holiday on a particular date
+type occurrence struct {
+	Holiday
+	date time.Time
+}
+
+// occurrences lists the holidays that fall between from and to, inclusive,
+// in date order
+func occurrences(business core.BusinessInput, from, to time.Time) []occurrence {
+	var found []occurrence
+	for _, holiday := range Holidays() {
+		if !holiday.AppliesTo(business) {
+			continue
+		}
+		for year := from.Year(); year <= to.Year(); year++ {
+			if date, ok := holiday.On(year); ok && !date.Before(from) && !date.After(to) {
+				found = append(found, occurrence{holiday, date})
+			}
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].date.Before(found[j].date) })
+	return found
+}
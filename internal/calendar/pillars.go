@@ -0,0 +1,179 @@
+package calendar
+
+import (
+	"biz-flow/internal/core"
+	"biz-flow/internal/templates"
+)
+
+// Pillar is a recurring kind of post that keeps a feed from being all sales
+type Pillar string
+
+const (
+	// Educational posts teach something about the product or the trade
+	Educational Pillar = "educational"
+	// Promotional posts sell: offers, launches, reasons to buy now
+	Promotional Pillar = "promotional"
+	// BehindTheScenes posts show the people and the process
+	BehindTheScenes Pillar = "behind-the-scenes"
+	// UGC posts feature or ask for customers' own content
+	UGC Pillar = "ugc"
+)
+
+// Pillars returns every content pillar
+func Pillars() []Pillar {
+	return []Pillar{Educational, Promotional, BehindTheScenes, UGC}
+}
+
+func (p Pillar) valid() bool {
+	for _, pillar := range Pillars() {
+		if p == pillar {
+			return true
+		}
+	}
+	return false
+}
+
+// pillarMix is the share of posts each pillar gets, by goal. Awareness leans
+// on teaching and showing; sales still keeps promotion to about a third.
+var pillarMix = map[core.MarketingGoal]map[Pillar]float64{
+	core.Awareness: {Educational: 0.35, BehindTheScenes: 0.30, UGC: 0.20, Promotional: 0.15},
+	core.Sales:     {Promotional: 0.35, Educational: 0.25, UGC: 0.25, BehindTheScenes: 0.15},
+}
+
+// pillarGoal is the goal whose default offer suits a pillar, so that a
+// promotional post names a deal and the others a softer invitation
+func pillarGoal(pillar Pillar) core.MarketingGoal {
+	if pillar == Promotional {
+		return core.Sales
+	}
+	return core.Awareness
+}
+
+// copywriting is a locale's calendar patterns. Holiday patterns have a
+// {holiday} slot.
+type copywriting struct {
+	// subject prefixes email hooks
+	subject string
+	pillars map[Pillar][]templates.Pattern
+	// holiday is used for promotional holidays and greeting for the rest
+	holiday  templates.Pattern
+	greeting templates.Pattern
+}
+
+var localCopy = map[core.Locale]copywriting{
+	core.English: {
+		subject: "Subject:",
+		pillars: map[Pillar][]templates.Pattern{
+			Educational: {
+				{Hook: "How to choose the right {product}", Caption: "A quick guide from our team {place}: what to look for, what to skip and the questions worth asking before you buy.", CTA: "Save this for later and send us your questions."},
+				{Hook: "{Product}: the one thing most people get wrong", Caption: "We see it every week. Here's the fix, explained in under a minute, plus {offer}.", CTA: "Follow for more tips like this."},
+			},
+			Promotional: {
+				{Hook: "Now's the time to try our {product}", Caption: "{Product} {place}, ready when you are. This week only: {offer}.", CTA: "Order today before it's gone."},
+				{Hook: "A little something for our regulars", Caption: "Something special on our {product} {place}: {offer}. Tell a friend who's been meaning to try us.", CTA: "Message us to claim it."},
+			},
+			BehindTheScenes: {
+				{Hook: "Behind the scenes: how our {product} comes together", Caption: "No filters, no script. This is what a normal day looks like for us {place}.", CTA: "Ask us anything about how we work."},
+				{Hook: "Meet the people behind the {product}", Caption: "We're a small team that cares about every detail. Here's {offer}.", CTA: "Say hi in the comments."},
+			},
+			UGC: {
+				{Hook: "You said it best", Caption: "Nothing beats hearing from customers who love our {product}. Share your story and we may feature you next.", CTA: "Tag us in your photos for a chance to be featured."},
+				{Hook: "Show us your {product}", Caption: "We love seeing our {product} out in the world {place}. Post a photo, tag us and we'll share our favorites.", CTA: "Tag us and use our hashtag."},
+			},
+		},
+		holiday:  templates.Pattern{Hook: "{holiday} is coming: treat someone to {product}", Caption: "Planning ahead for {holiday}? Our {product} {place} makes it easy, and right now we have {offer}.", CTA: "Order or book early to beat the rush."},
+		greeting: templates.Pattern{Hook: "Happy {holiday} from our team", Caption: "{holiday} is a good moment to thank everyone who supports our {product} {place}.", CTA: "Tell us how you're celebrating."},
+	},
+	core.Spanish: {
+		subject: "Asunto:",
+		pillars: map[Pillar][]templates.Pattern{
+			Educational: {
+				{Hook: "Cómo elegir {product} sin equivocarte", Caption: "Una guía rápida de nuestro equipo {place}: qué buscar, qué evitar y qué preguntar antes de comprar.", CTA: "Guarda esta publicación y envíanos tus preguntas."},
+				{Hook: "{Product}: el error más común", Caption: "Lo vemos cada semana. Aquí está la solución en menos de un minuto, y además {offer}.", CTA: "Síguenos para más consejos como este."},
+			},
+			Promotional: {
+				{Hook: "Es el momento de probar {product}", Caption: "{Product} {place}, listo cuando tú lo estés. Solo esta semana: {offer}.", CTA: "Haz tu pedido hoy antes de que se agote."},
+				{Hook: "Un detalle para nuestros clientes de siempre", Caption: "Tenemos algo especial en {product} {place}: {offer}. Cuéntaselo a quien tenga ganas de conocernos.", CTA: "Escríbenos para aprovecharlo."},
+			},
+			BehindTheScenes: {
+				{Hook: "Detrás de cámaras: así preparamos {product}", Caption: "Sin filtros ni guion. Así es un día normal para nosotros {place}.", CTA: "Pregúntanos lo que quieras sobre nuestro trabajo."},
+				{Hook: "Conoce a las personas detrás de {product}", Caption: "Somos un equipo pequeño que cuida cada detalle. Aquí tienes {offer}.", CTA: "Salúdanos en los comentarios."},
+			},
+			UGC: {
+				{Hook: "Ustedes lo dicen mejor", Caption: "Nada supera las palabras de clientes que disfrutan de {product}. Comparte tu experiencia y podríamos destacarte.", CTA: "Etiquétanos en tus fotos para aparecer en nuestra cuenta."},
+				{Hook: "Muéstranos cómo disfrutas {product}", Caption: "Nos encanta ver {product} en tu día a día {place}. Publica una foto, etiquétanos y compartiremos nuestras favoritas.", CTA: "Etiquétanos y usa nuestro hashtag."},
+			},
+		},
+		holiday:  templates.Pattern{Hook: "Se acerca {holiday}: regala {product}", Caption: "¿Ya piensas en {holiday}? Con {product} {place} es fácil, y ahora tenemos {offer}.", CTA: "Pide o reserva con tiempo para evitar las prisas."},
+		greeting: templates.Pattern{Hook: "¡Feliz {holiday} de parte de nuestro equipo!", Caption: "{holiday} es un buen momento para agradecer a todos los que apoyan nuestro trabajo {place}.", CTA: "Cuéntanos cómo lo celebras."},
+	},
+	core.Portuguese: {
+		subject: "Assunto:",
+		pillars: map[Pillar][]templates.Pattern{
+			Educational: {
+				{Hook: "Como escolher {product} sem errar", Caption: "Um guia rápido da nossa equipe {place}: o que observar, o que evitar e o que perguntar antes de comprar.", CTA: "Salve este post e mande suas dúvidas."},
+				{Hook: "{Product}: o erro mais comum", Caption: "A gente vê isso toda semana. Aqui está a solução em menos de um minuto, e ainda {offer}.", CTA: "Siga para mais dicas como esta."},
+			},
+			Promotional: {
+				{Hook: "Chegou a hora de experimentar {product}", Caption: "{Product} {place}, pronto quando você estiver. Só esta semana: {offer}.", CTA: "Faça seu pedido hoje antes que acabe."},
+				{Hook: "Um mimo para nossos clientes de sempre", Caption: "Temos algo especial em {product} {place}: {offer}. Conte para quem está querendo nos conhecer.", CTA: "Mande uma mensagem para aproveitar."},
+			},
+			BehindTheScenes: {
+				{Hook: "Bastidores: como preparamos {product}", Caption: "Sem filtro e sem roteiro. É assim um dia normal para nós {place}.", CTA: "Pergunte o que quiser sobre o nosso trabalho."},
+				{Hook: "Conheça as pessoas por trás de {product}", Caption: "Somos uma equipe pequena que cuida de cada detalhe. Aqui está {offer}.", CTA: "Diga oi nos comentários."},
+			},
+			UGC: {
+				{Hook: "Vocês falam melhor do que nós", Caption: "Nada supera a opinião de clientes que curtem {product}. Compartilhe a sua e você pode aparecer aqui.", CTA: "Marque a gente nas suas fotos para ser destaque."},
+				{Hook: "Mostre como você aproveita {product}", Caption: "Adoramos ver {product} no seu dia a dia {place}. Poste uma foto, marque a gente e vamos compartilhar as favoritas.", CTA: "Marque a gente e use nossa hashtag."},
+			},
+		},
+		holiday:  templates.Pattern{Hook: "{holiday} está chegando: presenteie com {product}", Caption: "Já pensando em {holiday}? Com {product} {place} fica fácil, e agora temos {offer}.", CTA: "Encomende ou reserve com antecedência para fugir da correria."},
+		greeting: templates.Pattern{Hook: "Feliz {holiday}, com carinho da nossa equipe", Caption: "{holiday} é um ótimo momento para agradecer a todos que apoiam o nosso trabalho {place}.", CTA: "Conte para a gente como você vai comemorar."},
+	},
+	core.Swahili: {
+		subject: "Mada:",
+		pillars: map[Pillar][]templates.Pattern{
+			Educational: {
+				{Hook: "Jinsi ya kuchagua {product} bora", Caption: "Mwongozo mfupi kutoka kwa timu yetu {place}: cha kuangalia, cha kuepuka na maswali ya kuuliza kabla ya kununua.", CTA: "Hifadhi chapisho hili na ututumie maswali yako."},
+				{Hook: "{Product}: kosa ambalo wengi hufanya", Caption: "Tunaliona kila wiki. Hili hapa suluhisho kwa chini ya dakika moja, pamoja na {offer}.", CTA: "Tufuate kwa vidokezo zaidi kama hiki."},
+			},
+			Promotional: {
+				{Hook: "Sasa ndio wakati wa kujaribu {product}", Caption: "{Product} {place}, tayari wakati wowote. Wiki hii tu: {offer}.", CTA: "Agiza leo kabla hazijaisha."},
+				{Hook: "Zawadi ndogo kwa wateja wetu wa kudumu", Caption: "Tuna kitu maalum kwa {product} {place}: {offer}. Mwambie rafiki ambaye amekuwa akitaka kutujaribu.", CTA: "Tutumie ujumbe ili upate ofa hii."},
+			},
+			BehindTheScenes: {
+				{Hook: "Nyuma ya pazia: jinsi tunavyoandaa {product}", Caption: "Bila vichujio, bila maandishi. Hivi ndivyo siku ya kawaida ilivyo kwetu {place}.", CTA: "Tuulize chochote kuhusu kazi yetu."},
+				{Hook: "Wafahamu watu walio nyuma ya {product}", Caption: "Sisi ni timu ndogo inayojali kila undani. Huu hapa {offer}.", CTA: "Tusalimie kwenye maoni."},
+			},
+			UGC: {
+				{Hook: "Ninyi mnasema vizuri zaidi", Caption: "Hakuna kinachoshinda maneno ya wateja wanaopenda {product}. Shiriki yako nasi na huenda tukakuangazia.", CTA: "Tutambulishe kwenye picha zako ili uangaziwe."},
+				{Hook: "Tuonyeshe unavyofurahia {product}", Caption: "Tunapenda kuona {product} katika maisha yako ya kila siku {place}. Weka picha, tutambulishe na tutashiriki tunazozipenda zaidi.", CTA: "Tutambulishe na utumie hashtag yetu."},
+			},
+		},
+		holiday:  templates.Pattern{Hook: "{holiday} inakaribia: mpe mtu zawadi ya {product}", Caption: "Unajiandaa kwa {holiday}? Tunakurahisishia kwa {product} {place}, na sasa tuna {offer}.", CTA: "Agiza au weka nafasi mapema ili kuepuka msongamano."},
+		greeting: templates.Pattern{Hook: "Heri ya {holiday} kutoka kwa timu yetu", Caption: "{holiday} ni wakati mzuri wa kuwashukuru wote wanaounga mkono kazi yetu {place}.", CTA: "Tuambie unavyosherehekea."},
+	},
+	core.Hindi: {
+		subject: "विषय:",
+		pillars: map[Pillar][]templates.Pattern{
+			Educational: {
+				{Hook: "सही {product} कैसे चुनें", Caption: "{place} हमारी टीम की छोटी-सी गाइड: क्या देखें, किससे बचें और खरीदने से पहले क्या पूछें।", CTA: "इस पोस्ट को सेव करें और अपने सवाल हमें भेजें।"},
+				{Hook: "{Product}: सबसे आम गलती", Caption: "हम इसे हर हफ़्ते देखते हैं। यह रहा एक मिनट से कम में इसका हल, साथ में {offer}।", CTA: "ऐसी और टिप्स के लिए हमें फ़ॉलो करें।"},
+			},
+			Promotional: {
+				{Hook: "{product} आज़माने का सही समय", Caption: "{place} {product}, जब आप चाहें तब तैयार। सिर्फ़ इस हफ़्ते: {offer}।", CTA: "खत्म होने से पहले आज ही ऑर्डर करें।"},
+				{Hook: "हमारे पुराने ग्राहकों के लिए कुछ खास", Caption: "{place} हमारे {product} पर खास पेशकश: {offer}। उस दोस्त को बताएं जो हमें आज़माना चाहता है।", CTA: "इसका फ़ायदा उठाने के लिए हमें मैसेज करें।"},
+			},
+			BehindTheScenes: {
+				{Hook: "पर्दे के पीछे: हमारा {product} कैसे तैयार होता है", Caption: "न कोई फ़िल्टर, न कोई स्क्रिप्ट। {place} हमारा एक आम दिन ऐसा दिखता है।", CTA: "हमारे काम के बारे में कुछ भी पूछें।"},
+				{Hook: "{product} के पीछे के लोगों से मिलिए", Caption: "हम एक छोटी टीम हैं जो हर बारीकी का ध्यान रखती है। पेश है {offer}।", CTA: "कमेंट में हमें नमस्ते कहें।"},
+			},
+			UGC: {
+				{Hook: "आपने सबसे अच्छा कहा", Caption: "{product} पसंद करने वाले ग्राहकों की बातों से बढ़कर कुछ नहीं। अपना अनुभव शेयर करें, अगली बार हम आपको दिखा सकते हैं।", CTA: "फ़ीचर होने के लिए अपनी फ़ोटो में हमें टैग करें।"},
+				{Hook: "हमें दिखाइए आप {product} का कैसे आनंद लेते हैं", Caption: "{place} आपकी रोज़मर्रा की ज़िंदगी में {product} देखना हमें अच्छा लगता है। फ़ोटो पोस्ट करें, हमें टैग करें और हम अपनी पसंदीदा शेयर करेंगे।", CTA: "हमें टैग करें और हमारा हैशटैग इस्तेमाल करें।"},
+			},
+		},
+		holiday:  templates.Pattern{Hook: "{holiday} आने वाला है: किसी खास को {product} दें", Caption: "{holiday} की तैयारी कर रहे हैं? {place} हमारा {product} इसे आसान बनाता है, और अभी हमारे पास {offer} है।", CTA: "भीड़ से बचने के लिए पहले से ऑर्डर या बुक करें।"},
+		greeting: templates.Pattern{Hook: "हमारी टीम की ओर से {holiday} की शुभकामनाएँ", Caption: "{holiday} हमारे काम का साथ देने वाले सभी लोगों को धन्यवाद कहने का अच्छा मौका है।", CTA: "हमें बताएं आप कैसे मना रहे हैं।"},
+	},
+}
@@ -0,0 +1,141 @@
+// Package clarify finds what a business input leaves missing or ambiguous,
+// asks targeted follow-up questions before the pipeline runs and merges the
+// answers back into the input
+package clarify
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
+)
+
+// Fields the follow-up questions ask about, named after their BusinessInput
+// JSON keys
+const (
+	FieldDescription = "description"
+	FieldLocation    = "location"
+	FieldBudget      = "budget"
+)
+
+// minDescriptionWords is the shortest description taken to reveal the product
+const minDescriptionWords = 4
+
+// genericWords say nothing about what a business actually sells
+var genericWords = map[string]bool{
+	"a": true, "an": true, "the": true, "my": true, "our": true, "and": true, "of": true,
+	"business": true, "company": true, "shop": true, "store": true, "services": true,
+	"service": true, "products": true, "product": true, "stuff": true, "things": true,
+	"online": true, "small": true, "local": true, "brand": true, "startup": true,
+}
+
+// Detect returns a question for each field that is missing or ambiguous,
+// skipping the fields in asked: no description or one that does not reveal
+// the product, no location, or no budget for a sales goal
+func Detect(business core.BusinessInput, asked []string) []core.Question {
+	var fields []string
+	if VagueDescription(business.Description) {
+		fields = append(fields, FieldDescription)
+	}
+	if strings.TrimSpace(business.Location) == "" {
+		fields = append(fields, FieldLocation)
+	}
+	if business.Budget == 0 && business.Goal == core.Sales {
+		fields = append(fields, FieldBudget)
+	}
+
+	locale := business.Language()
+	questions := []core.Question{}
+	for _, field := range fields {
+		if !slices.Contains(asked, field) {
+			questions = append(questions, core.Question{Field: field, Text: i18n.T(locale, "question."+field)})
+		}
+	}
+	return questions
+}
+
+// VagueDescription reports whether a description is too short, or made of
+// filler words only, to tell what the business sells
+func VagueDescription(description string) bool {
+	words := strings.Fields(description)
+	if len(words) < minDescriptionWords {
+		return true
+	}
+	for _, word := range words {
+		if !genericWords[strings.Trim(strings.ToLower(word), ".,!?")] {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply merges answers, keyed by field, into the business. A description
+// answer is added to the description, a blank or "online" location makes the
+// business online-only and a budget answer may be written like "$80" or
+// "150/month". Blank answers leave the other fields as they are.
+func Apply(business core.BusinessInput, answers map[string]string) (core.BusinessInput, error) {
+	// Check the fields in a stable order so error messages are deterministic
+	fields := make([]string, 0, len(answers))
+	for field := range answers {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+	for _, field := range fields {
+		if field != FieldDescription && field != FieldLocation && field != FieldBudget {
+			return business, &core.ValidationError{Field: "answers." + field, Message: "no follow-up question asks about it"}
+		}
+	}
+	for _, field := range []string{FieldDescription, FieldLocation, FieldBudget} {
+		answer, ok := answers[field]
+		if !ok {
+			continue
+		}
+		answer = strings.TrimSpace(answer)
+		switch field {
+		case FieldDescription:
+			if answer == "" {
+				continue
+			}
+			if description := strings.TrimRight(strings.TrimSpace(business.Description), "."); description != "" {
+				answer = description + ". " + answer
+			}
+			business.Description = answer
+		case FieldLocation:
+			if answer == "" || strings.EqualFold(answer, "online") || strings.EqualFold(answer, "online only") {
+				answer = "online"
+			}
+			business.Location = answer
+		case FieldBudget:
+			if answer == "" {
+				continue
+			}
+			budget, err := ParseBudget(answer)
+			if err != nil {
+				return business, err
+			}
+			if err := core.ValidateBudget(budget); err != nil {
+				return business, err
+			}
+			business.Budget = budget
+		}
+	}
+	return business, nil
+}
+
+// ParseBudget accepts answers like "80", "$80", "1,200" or "150/month"
+func ParseBudget(answer string) (float64, error) {
+	cleaned := strings.ToLower(strings.TrimSpace(answer))
+	for _, suffix := range []string{"/month", "per month", "a month", "/mo", "usd"} {
+		cleaned = strings.TrimSpace(strings.TrimSuffix(cleaned, suffix))
+	}
+	cleaned = strings.NewReplacer("$", "", ",", "").Replace(cleaned)
+
+	budget, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, &core.ValidationError{Field: "budget", Message: fmt.Sprintf("%q is not a number", answer)}
+	}
+	return budget, nil
+}
@@ -0,0 +1,154 @@
+package clarify
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"biz-flow/internal/core"
+)
+
+func TestDetect(t *testing.T) {
+	clear := core.BusinessInput{Type: core.Retail, Description: "Handmade ceramic mugs and bowls", Location: "Austin", Budget: 100, Goal: core.Sales}
+	tests := []struct {
+		name   string
+		edit   func(b *core.BusinessInput)
+		asked  []string
+		fields []string
+	}{
+		{name: "nothing missing", edit: func(b *core.BusinessInput) {}, fields: []string{}},
+		{name: "no description", edit: func(b *core.BusinessInput) { b.Description = "" }, fields: []string{FieldDescription}},
+		{name: "short description", edit: func(b *core.BusinessInput) { b.Description = "Ceramic mugs" }, fields: []string{FieldDescription}},
+		{
+			name:   "filler words only",
+			edit:   func(b *core.BusinessInput) { b.Description = "A small local online shop." },
+			fields: []string{FieldDescription},
+		},
+		{name: "no location", edit: func(b *core.BusinessInput) { b.Location = "  " }, fields: []string{FieldLocation}},
+		{name: "no budget for sales", edit: func(b *core.BusinessInput) { b.Budget = 0 }, fields: []string{FieldBudget}},
+		{
+			name:   "no budget for awareness",
+			edit:   func(b *core.BusinessInput) { b.Budget, b.Goal = 0, core.Awareness },
+			fields: []string{},
+		},
+		{
+			name:   "fields already asked are skipped",
+			edit:   func(b *core.BusinessInput) { b.Description, b.Location, b.Budget = "", "", 0 },
+			asked:  []string{FieldLocation},
+			fields: []string{FieldDescription, FieldBudget},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			business := clear
+			tt.edit(&business)
+			questions := Detect(business, tt.asked)
+			fields := []string{}
+			for _, question := range questions {
+				fields = append(fields, question.Field)
+				if question.Text == "" || question.Text == "question."+question.Field {
+					t.Errorf("question %q has no text", question.Field)
+				}
+			}
+			if !slices.Equal(fields, tt.fields) {
+				t.Errorf("Detect asked about %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	base := core.BusinessInput{Type: core.Retail, Description: "Ceramic mugs.", Location: "Austin", Budget: 50, Goal: core.Sales}
+	tests := []struct {
+		name      string
+		answers   map[string]string
+		want      core.BusinessInput
+		wantField string
+	}{
+		{name: "no answers", answers: nil, want: base},
+		{
+			name:    "description is added to",
+			answers: map[string]string{FieldDescription: " Sold to coffee shops "},
+			want:    core.BusinessInput{Description: "Ceramic mugs. Sold to coffee shops", Location: "Austin", Budget: 50},
+		},
+		{
+			name:    "online location",
+			answers: map[string]string{FieldLocation: "Online only"},
+			want:    core.BusinessInput{Description: "Ceramic mugs.", Location: "online", Budget: 50},
+		},
+		{
+			name:    "blank location means online",
+			answers: map[string]string{FieldLocation: " "},
+			want:    core.BusinessInput{Description: "Ceramic mugs.", Location: "online", Budget: 50},
+		},
+		{
+			name:    "budget written with a currency and period",
+			answers: map[string]string{FieldBudget: "$1,200/month"},
+			want:    core.BusinessInput{Description: "Ceramic mugs.", Location: "Austin", Budget: 1200},
+		},
+		{
+			name:    "blank answers change nothing",
+			answers: map[string]string{FieldDescription: "", FieldBudget: "  "},
+			want:    base,
+		},
+		{name: "budget that is not a number", answers: map[string]string{FieldBudget: "a lot"}, wantField: "budget"},
+		{name: "negative budget", answers: map[string]string{FieldBudget: "-5"}, wantField: "budget"},
+		{name: "budget that is not finite", answers: map[string]string{FieldBudget: "NaN"}, wantField: "budget"},
+		{
+			name:      "a field no question asks about",
+			answers:   map[string]string{FieldBudget: "80", "goal": "awareness"},
+			wantField: "answers.goal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply(base, tt.answers)
+			if tt.wantField != "" {
+				var validationErr *core.ValidationError
+				if !errors.As(err, &validationErr) || validationErr.Field != tt.wantField {
+					t.Fatalf("error = %v, want a ValidationError on %q", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if got.Description != tt.want.Description || got.Location != tt.want.Location || got.Budget != tt.want.Budget {
+				t.Errorf("Apply = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConversation(t *testing.T) {
+	c, err := Start(core.BusinessInput{Type: core.Retail, Goal: core.Sales})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if c.Status != StatusAsking || len(c.Questions) != 3 {
+		t.Fatalf("started %s with %d questions, want asking with 3", c.Status, len(c.Questions))
+	}
+
+	// The description may not stay empty, so skipping it is refused
+	if err := c.Answer(map[string]string{FieldLocation: "Austin"}); err == nil {
+		t.Fatal("answering without a description succeeded")
+	}
+	if err := c.Answer(map[string]string{FieldDescription: "Mugs", FieldLocation: "Austin"}); err != nil {
+		t.Fatalf("Answer: %v", err)
+	}
+	// The description is still vague but was asked about once, and the
+	// skipped budget is not asked again
+	if c.Status != StatusReady || len(c.Questions) != 0 || c.Business.Budget != 0 {
+		t.Fatalf("after answering: %s with %v and budget %g, want ready", c.Status, c.Questions, c.Business.Budget)
+	}
+	if err := c.Answer(map[string]string{FieldBudget: "80"}); err == nil {
+		t.Error("answering a question that is not pending succeeded")
+	}
+
+	c.Complete(&core.ConsultationResult{})
+	if err := c.Answer(nil); !errors.Is(err, ErrComplete) {
+		t.Errorf("error = %v, want ErrComplete", err)
+	}
+}
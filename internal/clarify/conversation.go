@@ -0,0 +1,236 @@
+package clarify
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"biz-flow/internal/core"
+)
+
+// ConversationStatus is where a conversation stands
+type ConversationStatus string
+
+const (
+	// StatusAsking waits for answers to the questions
+	StatusAsking ConversationStatus = "asking"
+	// StatusReady has every answer it needs and waits to be consulted
+	StatusReady ConversationStatus = "ready"
+	// StatusComplete holds the consultation result
+	StatusComplete ConversationStatus = "complete"
+)
+
+var (
+	// ErrNotFound is returned when a conversation ID is unknown or expired
+	ErrNotFound = errors.New("clarify: conversation not found")
+	// ErrComplete is returned when answering a conversation that already has
+	// its result
+	ErrComplete = errors.New("clarify: conversation already complete")
+)
+
+// Conversation is a business input being clarified before it is consulted
+type Conversation struct {
+	ID       string             `json:"id"`
+	Status   ConversationStatus `json:"status"`
+	Business core.BusinessInput `json:"business"`
+	// Questions are the follow-ups still waiting for answers
+	Questions []core.Question `json:"questions"`
+	// Asked lists the fields already asked about; each is asked once
+	Asked     []string                 `json:"asked"`
+	Result    *core.ConsultationResult `json:"result,omitempty"`
+	CreatedAt time.Time                `json:"created_at"`
+	UpdatedAt time.Time                `json:"updated_at"`
+}
+
+// AnswerRequest carries answers to a conversation's pending questions, keyed
+// by the questions' fields
+type AnswerRequest struct {
+	Answers map[string]string `json:"answers"`
+}
+
+// Start opens a conversation about the business. Its other fields must be
+// valid; the description may be empty, since it is asked for.
+func Start(business core.BusinessInput) (*Conversation, error) {
+	if err := validate(business); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	c := &Conversation{Business: business, Asked: []string{}, CreatedAt: now, UpdatedAt: now}
+	c.ask()
+	return c, nil
+}
+
+// Answer merges answers to the pending questions into the business. A
+// pending question left unanswered counts as skipped, except for a
+// description that is still empty. Any follow-ups the answers raise are
+// asked next; without any the conversation is ready.
+func (c *Conversation) Answer(answers map[string]string) error {
+	if c.Status == StatusComplete {
+		return ErrComplete
+	}
+	for field := range answers {
+		if err := c.pending(field); err != nil {
+			return err
+		}
+	}
+	business, err := Apply(c.Business, answers)
+	if err != nil {
+		return err
+	}
+	if err := core.ValidateDescription(business.Description); err != nil {
+		return &core.ValidationError{Field: "answers." + FieldDescription, Message: "the business needs a description"}
+	}
+
+	c.Business = business
+	c.UpdatedAt = time.Now().UTC()
+	c.ask()
+	return nil
+}
+
+// Check validates a single answer without merging it, so a client can ask
+// again before answering
+func (c *Conversation) Check(field, answer string) error {
+	if err := c.pending(field); err != nil {
+		return err
+	}
+	business, err := Apply(c.Business, map[string]string{field: answer})
+	if err != nil {
+		return err
+	}
+	if field == FieldDescription {
+		return core.ValidateDescription(business.Description)
+	}
+	return nil
+}
+
+// pending rejects a field that no pending question asks about
+func (c *Conversation) pending(field string) error {
+	if !slices.ContainsFunc(c.Questions, func(q core.Question) bool { return q.Field == field }) {
+		return &core.ValidationError{Field: "answers." + field, Message: "is not one of the pending questions"}
+	}
+	return nil
+}
+
+// Complete records the consultation result
+func (c *Conversation) Complete(result *core.ConsultationResult) {
+	c.Result = result
+	c.Status = StatusComplete
+	c.UpdatedAt = time.Now().UTC()
+}
+
+// ask sets the questions for fields not asked about yet and the status
+func (c *Conversation) ask() {
+	c.Questions = Detect(c.Business, c.Asked)
+	for _, q := range c.Questions {
+		c.Asked = append(c.Asked, q.Field)
+	}
+	c.Status = StatusAsking
+	if len(c.Questions) == 0 {
+		c.Status = StatusReady
+	}
+}
+
+// validate checks every field but the description, which a conversation can
+// ask for
+func validate(business core.BusinessInput) error {
+	for _, check := range []func() error{
+		func() error { return core.ValidateType(business.Type) },
+		func() error { return core.ValidateBudget(business.Budget) },
+		func() error { return core.ValidateGoal(business.Goal) },
+		func() error { return core.ValidateLocale(business.Locale) },
+		func() error { return core.ValidateProfile(business.Profile) },
+		func() error { return core.ValidateVoice(business.Voice) },
+	} {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Store limits: conversations idle longer than MaxIdle are dropped, and the
+// oldest are dropped beyond MaxConversations
+const (
+	MaxIdle          = time.Hour
+	MaxConversations = 10000
+)
+
+// Store keeps conversations in process memory. They are lost on restart.
+type Store struct {
+	mu            sync.Mutex
+	conversations map[string]*Conversation
+}
+
+// NewStore creates an empty conversation store
+func NewStore() *Store {
+	return &Store{conversations: make(map[string]*Conversation)}
+}
+
+// Save stores a copy of the conversation, giving it an ID if it has none
+func (s *Store) Save(c *Conversation) error {
+	if c.ID == "" {
+		id, err := newID()
+		if err != nil {
+			return err
+		}
+		c.ID = id
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.conversations[c.ID]; !ok {
+		s.expireLocked()
+	}
+	s.conversations[c.ID] = c.clone()
+	return nil
+}
+
+// Get returns a copy of the conversation, or ErrNotFound
+func (s *Store) Get(id string) (*Conversation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.conversations[id]
+	if !ok || time.Since(c.UpdatedAt) > MaxIdle {
+		return nil, ErrNotFound
+	}
+	return c.clone(), nil
+}
+
+// clone copies the conversation so stored and returned ones share no slices
+func (c *Conversation) clone() *Conversation {
+	copied := *c
+	copied.Questions = slices.Clone(c.Questions)
+	copied.Asked = slices.Clone(c.Asked)
+	return &copied
+}
+
+// expireLocked drops idle conversations, then the least recently updated
+// ones while the store is full
+func (s *Store) expireLocked() {
+	for id, c := range s.conversations {
+		if time.Since(c.UpdatedAt) > MaxIdle {
+			delete(s.conversations, id)
+		}
+	}
+	for len(s.conversations) >= MaxConversations {
+		var oldest *Conversation
+		for _, c := range s.conversations {
+			if oldest == nil || c.UpdatedAt.Before(oldest.UpdatedAt) {
+				oldest = c
+			}
+		}
+		delete(s.conversations, oldest.ID)
+	}
+}
+
+// newID returns a random 128-bit hex conversation ID
+func newID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,243 @@
+// Package confidence rates how sure the pipeline is of each recommendation.
+// It weighs how much the business input reveals, the score margins between
+// adjacent ranks, how often independent LLM review samples pick the same
+// platforms and the reported results behind the calibration, and suggests
+// clarifying questions when confidence is low.
+package confidence
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
+	"biz-flow/internal/scoring"
+)
+
+// Signal weights; without review samples agreement's weight is shared out
+// between the others
+const (
+	completenessWeight = 0.45
+	marginWeight       = 0.3
+	agreementWeight    = 0.15
+	evidenceWeight     = 0.1
+)
+
+const (
+	// marginScale is the score gap, in points, at which the margin signal is
+	// full
+	marginScale = 10.0
+	// closeCall is the score gap under which two adjacent platforms are worth
+	// a question
+	closeCall = 2.5
+	// evidenceScale is how many reported periods of the business's own make
+	// the evidence signal full; a period from elsewhere in the vertical counts
+	// a quarter as much
+	evidenceScale  = 6.0
+	verticalWeight = 0.25
+	// detailedWords and vagueWords bound the description lengths that reveal
+	// the product fully and hardly at all
+	detailedWords = 12
+	vagueWords    = 4
+	// maxQuestions caps the clarifying questions suggested at once
+	maxQuestions = 4
+)
+
+// Levels: a score from highLevel up is high, from mediumLevel medium
+const (
+	highLevel   = 0.75
+	mediumLevel = 0.5
+)
+
+// Assessor rates recommendations
+type Assessor struct{}
+
+// NewAssessor creates a new confidence assessor
+func NewAssessor() *Assessor {
+	return &Assessor{}
+}
+
+// Assess sets each recommendation's confidence. ranked is every platform that
+// passes the filters, best first, and samples the platforms each LLM review
+// sample picked; none means agreement is not rated. When any recommendation's
+// confidence is low it returns clarifying questions, most useful first.
+func (a *Assessor) Assess(
+	business core.BusinessInput,
+	ranked []scoring.ScoredPlatform,
+	recommendations []core.Recommendation,
+	samples [][]core.Platform,
+) []core.Question {
+	completeness, gaps := Completeness(business)
+	low := false
+	for i := range recommendations {
+		platform := recommendations[i].Platform
+		confidence := &core.Confidence{
+			Completeness: completeness,
+			Margin:       margin(ranked, platform),
+			Agreement:    agreement(samples, platform),
+			Evidence:     evidence(business.Calibration[platform]),
+		}
+		confidence.Score, confidence.Level = combine(confidence)
+		recommendations[i].Confidence = confidence
+		low = low || confidence.Level == core.ConfidenceLow
+	}
+	if !low {
+		return nil
+	}
+
+	locale := business.Language()
+	var questions []core.Question
+	for _, gap := range gaps {
+		questions = append(questions, core.Question{Field: gap.Field, Text: i18n.T(locale, gap.Question)})
+	}
+	for _, pair := range closeCalls(ranked, len(recommendations)) {
+		questions = append(questions, core.Question{
+			Field: "channels",
+			Text:  i18n.T(locale, "question.close_call", pair[0], pair[1]),
+		})
+	}
+	if len(questions) > maxQuestions {
+		questions = questions[:maxQuestions]
+	}
+	return questions
+}
+
+// Gap is a part of the business input that holds back completeness
+type Gap struct {
+	// Field is the BusinessInput field that would close the gap
+	Field string
+	// Question is the catalog key of the question that asks for it
+	Question string
+	// Lost is how much completeness the gap costs
+	Lost float64
+}
+
+// Completeness rates how much the business input reveals, from 0 to 1, and
+// lists what is missing, costliest first
+func Completeness(business core.BusinessInput) (float64, []Gap) {
+	var gaps []Gap
+	add := func(field string, weight, score float64) {
+		if score < 1 {
+			gaps = append(gaps, Gap{Field: field, Question: "question." + field, Lost: weight * (1 - score)})
+		}
+	}
+
+	words := len(strings.Fields(business.Description))
+	description := 1.0
+	switch {
+	case words <= vagueWords:
+		description = 0.2
+	case words < detailedWords:
+		description = 0.6
+	}
+	add("description", 0.4, description)
+
+	location := 1.0
+	if business.Type != core.Digital && strings.TrimSpace(business.Location) == "" {
+		location = 0
+	}
+	add("location", 0.2, location)
+
+	budget := 1.0
+	if business.Budget == 0 && business.Goal == core.Sales {
+		budget = 0.3
+	}
+	add("budget", 0.2, budget)
+
+	channels := 1.0
+	if len(business.Channels) == 0 {
+		channels = 0.5
+	}
+	add("channels", 0.2, channels)
+
+	completeness := 1.0
+	for _, g := range gaps {
+		completeness -= g.Lost
+	}
+	slices.SortStableFunc(gaps, func(a, b Gap) int {
+		switch {
+		case a.Lost > b.Lost:
+			return -1
+		case a.Lost < b.Lost:
+			return 1
+		}
+		return 0
+	})
+	return round(completeness), gaps
+}
+
+// margin rates the score gap to the platforms ranked just above and below,
+// whichever is closer; a platform with no neighbours has a full margin
+func margin(ranked []scoring.ScoredPlatform, platform core.Platform) float64 {
+	i := slices.IndexFunc(ranked, func(scored scoring.ScoredPlatform) bool { return scored.Platform == platform })
+	if i < 0 {
+		return 0
+	}
+	gap := math.Inf(1)
+	if i > 0 {
+		gap = ranked[i-1].Score - ranked[i].Score
+	}
+	if i+1 < len(ranked) {
+		gap = math.Min(gap, ranked[i].Score-ranked[i+1].Score)
+	}
+	return round(math.Min(1, gap/marginScale))
+}
+
+// agreement is the share of review samples that picked the platform, or nil
+// without samples
+func agreement(samples [][]core.Platform, platform core.Platform) *float64 {
+	if len(samples) == 0 {
+		return nil
+	}
+	picked := 0
+	for _, sample := range samples {
+		if slices.Contains(sample, platform) {
+			picked++
+		}
+	}
+	share := round(float64(picked) / float64(len(samples)))
+	return &share
+}
+
+// evidence rates the reported results behind a platform's calibration
+func evidence(calibration core.Calibration) float64 {
+	periods := float64(calibration.Results) + verticalWeight*float64(calibration.VerticalResults)
+	return round(math.Min(1, periods/evidenceScale))
+}
+
+// combine weighs the signals into a score and its level
+func combine(confidence *core.Confidence) (float64, core.ConfidenceLevel) {
+	weighted := completenessWeight*confidence.Completeness + marginWeight*confidence.Margin + evidenceWeight*confidence.Evidence
+	total := completenessWeight + marginWeight + evidenceWeight
+	if confidence.Agreement != nil {
+		weighted += agreementWeight * *confidence.Agreement
+		total += agreementWeight
+	}
+	score := round(weighted / total)
+	switch {
+	case score >= highLevel:
+		return score, core.ConfidenceHigh
+	case score >= mediumLevel:
+		return score, core.ConfidenceMedium
+	default:
+		return score, core.ConfidenceLow
+	}
+}
+
+// closeCalls pairs adjacent platforms whose scores are too close to call,
+// among the top places and the first platform below them
+func closeCalls(ranked []scoring.ScoredPlatform, top int) [][2]core.Platform {
+	var pairs [][2]core.Platform
+	for i := 1; i < len(ranked) && i <= top; i++ {
+		if ranked[i-1].Score-ranked[i].Score < closeCall {
+			pairs = append(pairs, [2]core.Platform{ranked[i-1].Platform, ranked[i].Platform})
+		}
+	}
+	return pairs
+}
+
+// round rounds a rating to two decimal places
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,277 @@
+package confidence
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
+	"biz-flow/internal/scoring"
+)
+
+var detailed = core.BusinessInput{
+	Type:        core.Retail,
+	Description: "Handmade ceramic mugs and bowls, thrown and glazed in our studio, sold to coffee lovers and gift buyers",
+	Location:    "Austin, TX",
+	Budget:      150,
+	Goal:        core.Awareness,
+	Channels:    []string{"Instagram"},
+}
+
+// ranking scores the platforms in order
+func ranking(scores ...float64) []scoring.ScoredPlatform {
+	platforms := []core.Platform{core.Instagram, core.Facebook, core.TikTok, core.Email}
+	ranked := make([]scoring.ScoredPlatform, len(scores))
+	for i, score := range scores {
+		ranked[i] = scoring.ScoredPlatform{Platform: platforms[i], Score: score}
+	}
+	return ranked
+}
+
+// recommend recommends the first top platforms of ranked
+func recommend(ranked []scoring.ScoredPlatform, top int) []core.Recommendation {
+	recommendations := make([]core.Recommendation, top)
+	for i := range recommendations {
+		recommendations[i] = core.Recommendation{Platform: ranked[i].Platform, Rank: i + 1, Score: ranked[i].Score}
+	}
+	return recommendations
+}
+
+func TestCompleteness(t *testing.T) {
+	tests := []struct {
+		name  string
+		edit  func(b *core.BusinessInput)
+		score float64
+		gaps  []string
+	}{
+		{name: "detailed", edit: func(b *core.BusinessInput) {}, score: 1},
+		{
+			name:  "some detail",
+			edit:  func(b *core.BusinessInput) { b.Description = "Handmade ceramic mugs for coffee lovers" },
+			score: 0.84,
+			gaps:  []string{"description"},
+		},
+		{
+			name: "sparse",
+			edit: func(b *core.BusinessInput) {
+				b.Description, b.Location, b.Budget, b.Goal, b.Channels = "Mugs", "", 0, core.Sales, nil
+			},
+			score: 0.24,
+			gaps:  []string{"description", "location", "budget", "channels"},
+		},
+		{
+			name:  "digital needs no location",
+			edit:  func(b *core.BusinessInput) { b.Type, b.Location = core.Digital, "" },
+			score: 1,
+		},
+		{
+			name:  "no budget is fine without a sales goal",
+			edit:  func(b *core.BusinessInput) { b.Budget = 0 },
+			score: 1,
+		},
+		{
+			name:  "blank location",
+			edit:  func(b *core.BusinessInput) { b.Location, b.Channels = "  ", nil },
+			score: 0.7,
+			gaps:  []string{"location", "channels"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			business := detailed
+			tt.edit(&business)
+			score, gaps := Completeness(business)
+			if score != tt.score {
+				t.Errorf("Completeness = %v, want %v", score, tt.score)
+			}
+			var fields []string
+			for _, gap := range gaps {
+				fields = append(fields, gap.Field)
+				if gap.Question != "question."+gap.Field || gap.Lost <= 0 {
+					t.Errorf("gap = %+v, want its question and a loss", gap)
+				}
+			}
+			if !reflect.DeepEqual(fields, tt.gaps) {
+				t.Errorf("gaps = %q, want %q", fields, tt.gaps)
+			}
+		})
+	}
+}
+
+func TestCombine(t *testing.T) {
+	zero, half := 0.0, 0.5
+	tests := []struct {
+		name                           string
+		completeness, margin, evidence float64
+		agreement                      *float64
+		score                          float64
+		level                          core.ConfidenceLevel
+	}{
+		{name: "every signal full", completeness: 1, margin: 1, evidence: 1, score: 1, level: core.ConfidenceHigh},
+		{name: "high threshold", completeness: 0.75, margin: 0.75, evidence: 0.75, score: 0.75, level: core.ConfidenceHigh},
+		{name: "just under high", completeness: 0.74, margin: 0.74, evidence: 0.74, score: 0.74, level: core.ConfidenceMedium},
+		{name: "medium threshold", completeness: 0.5, margin: 0.5, evidence: 0.5, score: 0.5, level: core.ConfidenceMedium},
+		{name: "just under medium", completeness: 0.49, margin: 0.49, evidence: 0.49, score: 0.49, level: core.ConfidenceLow},
+		{name: "agreement counts", completeness: 0.5, margin: 0.5, evidence: 0.5, agreement: &zero, score: 0.43, level: core.ConfidenceLow},
+		{name: "agreement at the others' level", completeness: 0.5, margin: 0.5, evidence: 0.5, agreement: &half, score: 0.5, level: core.ConfidenceMedium},
+		{name: "no evidence", completeness: 1, margin: 1, evidence: 0, score: 0.88, level: core.ConfidenceHigh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, level := combine(&core.Confidence{
+				Completeness: tt.completeness, Margin: tt.margin, Evidence: tt.evidence, Agreement: tt.agreement,
+			})
+			if score != tt.score || level != tt.level {
+				t.Errorf("combine = %v, %s, want %v, %s", score, level, tt.score, tt.level)
+			}
+		})
+	}
+}
+
+func TestMargin(t *testing.T) {
+	ranked := ranking(80, 79, 70, 50)
+	for platform, want := range map[core.Platform]float64{
+		core.Instagram: 0.1, // one point clear of Facebook
+		core.Facebook:  0.1,
+		core.TikTok:    0.9,
+		core.Email:     1,
+		core.LinkedIn:  0, // not ranked
+	} {
+		if got := margin(ranked, platform); got != want {
+			t.Errorf("margin(%s) = %v, want %v", platform, got, want)
+		}
+	}
+	if got := margin(ranking(40), core.Instagram); got != 1 {
+		t.Errorf("margin of a lone platform = %v, want 1", got)
+	}
+}
+
+func TestAgreementAndEvidence(t *testing.T) {
+	if got := agreement(nil, core.Instagram); got != nil {
+		t.Errorf("agreement without samples = %v, want nil", *got)
+	}
+	samples := [][]core.Platform{{core.Instagram, core.Facebook}, {core.Instagram}, {core.TikTok}}
+	if got := agreement(samples, core.Instagram); got == nil || *got != 0.67 {
+		t.Errorf("agreement = %v, want 0.67", got)
+	}
+
+	for calibration, want := range map[core.Calibration]float64{
+		{}:                               0,
+		{Results: 3}:                     0.5,
+		{Results: 10}:                    1,
+		{VerticalResults: 8}:             0.33,
+		{Results: 4, VerticalResults: 8}: 1,
+	} {
+		if got := evidence(calibration); got != want {
+			t.Errorf("evidence(%+v) = %v, want %v", calibration, got, want)
+		}
+	}
+}
+
+func TestAssess(t *testing.T) {
+	tests := []struct {
+		name      string
+		edit      func(b *core.BusinessInput)
+		ranked    []scoring.ScoredPlatform
+		top       int
+		level     core.ConfidenceLevel // of the first recommendation
+		questions []string             // the questions' fields, in order
+	}{
+		{
+			name:   "detailed with clear margins",
+			edit:   func(b *core.BusinessInput) {},
+			ranked: ranking(90, 70, 55, 40),
+			top:    3,
+			level:  core.ConfidenceHigh,
+		},
+		{
+			name:   "close call alone is not low",
+			edit:   func(b *core.BusinessInput) {},
+			ranked: ranking(80, 79, 60),
+			top:    2,
+			level:  core.ConfidenceMedium,
+		},
+		{
+			name: "sparse input asks for the costliest gaps first",
+			edit: func(b *core.BusinessInput) {
+				b.Description, b.Location, b.Budget, b.Goal, b.Channels = "Mugs", "", 0, core.Sales, nil
+			},
+			ranked:    ranking(90, 70, 69),
+			top:       2,
+			level:     core.ConfidenceLow,
+			questions: []string{"description", "location", "budget", "channels"},
+		},
+		{
+			name:      "vague description and a close call",
+			edit:      func(b *core.BusinessInput) { b.Description = "Mugs" },
+			ranked:    ranking(80, 79, 60),
+			top:       2,
+			level:     core.ConfidenceLow,
+			questions: []string{"description", "channels"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			business := detailed
+			tt.edit(&business)
+			recommendations := recommend(tt.ranked, tt.top)
+			questions := NewAssessor().Assess(business, tt.ranked, recommendations, nil)
+
+			for _, rec := range recommendations {
+				if rec.Confidence == nil || rec.Confidence.Agreement != nil {
+					t.Fatalf("%s Confidence = %+v, want one without agreement", rec.Platform, rec.Confidence)
+				}
+			}
+			if level := recommendations[0].Confidence.Level; level != tt.level {
+				t.Errorf("Level = %s (score %v), want %s", level, recommendations[0].Confidence.Score, tt.level)
+			}
+			var fields []string
+			for _, question := range questions {
+				fields = append(fields, question.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.questions) {
+				t.Errorf("questions = %q, want %q", fields, tt.questions)
+			}
+		})
+	}
+}
+
+func TestAssessQuestionText(t *testing.T) {
+	business := detailed
+	business.Description, business.Locale = "Tazas", core.Spanish
+	ranked := ranking(80, 79, 60)
+	questions := NewAssessor().Assess(business, ranked, recommend(ranked, 2), nil)
+	if len(questions) != 2 {
+		t.Fatalf("questions = %+v, want a description question and a close call", questions)
+	}
+	if want := i18n.T(core.Spanish, "question.description"); questions[0].Text != want {
+		t.Errorf("question = %q, want %q", questions[0].Text, want)
+	}
+	if text := questions[1].Text; !strings.Contains(text, "Instagram") || !strings.Contains(text, "Facebook") ||
+		text == i18n.T(core.English, "question.close_call", "Instagram", "Facebook") {
+		t.Errorf("close call = %q, want the Spanish question naming Instagram and Facebook", text)
+	}
+}
+
+func TestCloseCalls(t *testing.T) {
+	ranked := ranking(80, 79, 70, 68)
+	tests := []struct {
+		top  int
+		want [][2]core.Platform
+	}{
+		{top: 1, want: [][2]core.Platform{{core.Instagram, core.Facebook}}},
+		{top: 2, want: [][2]core.Platform{{core.Instagram, core.Facebook}}},
+		{top: 3, want: [][2]core.Platform{{core.Instagram, core.Facebook}, {core.TikTok, core.Email}}},
+		{top: 0},
+	}
+
+	for _, tt := range tests {
+		if got := closeCalls(ranked, tt.top); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("closeCalls(top %d) = %v, want %v", tt.top, got, tt.want)
+		}
+	}
+}
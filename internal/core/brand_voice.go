@@ -0,0 +1,170 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Limits on a brand voice profile, which is sent with every content prompt
+const (
+	MaxBannedWords      = 50
+	MaxSignaturePhrases = 10
+	MaxSamplePosts      = 5
+	MaxSamplePostLength = 2200
+)
+
+// Tone places copy on the brand voice sliders. Each slider runs from -1 to 1;
+// 0 leaves that axis open.
+type Tone struct {
+	// Formality runs from playful (-1) to formal (1)
+	Formality float64 `json:"formality"`
+	// Enthusiasm runs from calm (-1) to enthusiastic (1)
+	Enthusiasm float64 `json:"enthusiasm"`
+}
+
+// EmojiPolicy is how freely generated content may use emoji
+type EmojiPolicy string
+
+const (
+	// EmojiNone forbids emoji
+	EmojiNone EmojiPolicy = "none"
+	// EmojiSparing allows one emoji per post
+	EmojiSparing EmojiPolicy = "sparing"
+	// EmojiGenerous welcomes emoji
+	EmojiGenerous EmojiPolicy = "generous"
+)
+
+// EmojiPolicies returns every supported emoji policy
+func EmojiPolicies() []EmojiPolicy {
+	return []EmojiPolicy{EmojiNone, EmojiSparing, EmojiGenerous}
+}
+
+// BrandVoice is how the owner wants generated content to sound
+type BrandVoice struct {
+	Tone Tone `json:"tone"`
+	// BannedWords must never appear in generated content
+	BannedWords []string `json:"banned_words,omitempty"`
+	// Emoji is empty when the owner has no preference
+	Emoji EmojiPolicy `json:"emoji,omitempty"`
+	// SignaturePhrases are the owner's recurring lines, e.g. "Stay cozy"
+	SignaturePhrases []string `json:"signature_phrases,omitempty"`
+	// SamplePosts are past posts that show the voice; sliders left at 0
+	// take their tone from these
+	SamplePosts []string `json:"sample_posts,omitempty"`
+}
+
+// ValidateVoice checks a brand voice profile on its own; nil means none
+func ValidateVoice(voice *BrandVoice) error {
+	if voice == nil {
+		return nil
+	}
+	for _, slider := range []struct {
+		field string
+		value float64
+	}{
+		{"voice.tone.formality", voice.Tone.Formality},
+		{"voice.tone.enthusiasm", voice.Tone.Enthusiasm},
+	} {
+		if math.IsNaN(slider.value) || slider.value < -1 || slider.value > 1 {
+			return &ValidationError{Field: slider.field, Message: "must be between -1 and 1"}
+		}
+	}
+	switch voice.Emoji {
+	case "", EmojiNone, EmojiSparing, EmojiGenerous:
+	default:
+		return &ValidationError{Field: "voice.emoji", Message: fmt.Sprintf("%q is not one of none, sparing, generous", voice.Emoji)}
+	}
+	for _, list := range []struct {
+		field string
+		items []string
+		max   int
+	}{
+		{"voice.banned_words", voice.BannedWords, MaxBannedWords},
+		{"voice.signature_phrases", voice.SignaturePhrases, MaxSignaturePhrases},
+		{"voice.sample_posts", voice.SamplePosts, MaxSamplePosts},
+	} {
+		if len(list.items) > list.max {
+			return &ValidationError{Field: list.field, Message: fmt.Sprintf("must have at most %d entries", list.max)}
+		}
+		for _, item := range list.items {
+			if strings.TrimSpace(item) == "" {
+				return &ValidationError{Field: list.field, Message: "must not contain empty entries"}
+			}
+		}
+	}
+	for _, post := range voice.SamplePosts {
+		if len([]rune(post)) > MaxSamplePostLength {
+			return &ValidationError{Field: "voice.sample_posts", Message: fmt.Sprintf("each post must be at most %d characters", MaxSamplePostLength)}
+		}
+	}
+	return nil
+}
+
+// VoiceFromForm reads a brand voice from the web form's fields, which the
+// stream endpoint also accepts as query parameters. It returns nil when every
+// field is left at its default.
+func VoiceFromForm(values url.Values) (*BrandVoice, error) {
+	var voice BrandVoice
+	for _, slider := range []struct {
+		name  string
+		value *float64
+	}{
+		{"voice_formality", &voice.Tone.Formality},
+		{"voice_enthusiasm", &voice.Tone.Enthusiasm},
+	} {
+		raw := strings.TrimSpace(values.Get(slider.name))
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, &ValidationError{Field: "voice.tone." + strings.TrimPrefix(slider.name, "voice_"), Message: "must be a number"}
+		}
+		*slider.value = value
+	}
+	voice.Emoji = EmojiPolicy(strings.TrimSpace(values.Get("voice_emoji")))
+	voice.BannedWords = splitList(values.Get("voice_banned_words"), ",")
+	voice.SignaturePhrases = splitList(values.Get("voice_signature_phrases"), "\n")
+	// Sample posts are separated by a blank line
+	voice.SamplePosts = splitList(strings.ReplaceAll(values.Get("voice_sample_posts"), "\r\n", "\n"), "\n\n")
+
+	if voice.Tone == (Tone{}) && voice.Emoji == "" && len(voice.BannedWords) == 0 &&
+		len(voice.SignaturePhrases) == 0 && len(voice.SamplePosts) == 0 {
+		return nil, nil
+	}
+	return &voice, ValidateVoice(&voice)
+}
+
+// splitList splits value on sep, dropping blank entries
+func splitList(value, sep string) []string {
+	var items []string
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// VoiceScore is how closely a content template matches the brand voice
+type VoiceScore struct {
+	// Score runs from 0 to 100, where 100 is fully on-voice
+	Score float64 `json:"score"`
+	// Tone is the template's measured place on the sliders
+	Tone   Tone         `json:"tone"`
+	Issues []VoiceIssue `json:"issues,omitempty"`
+	// Adjustments lists what the checker changed to honour the profile
+	Adjustments []string `json:"adjustments,omitempty"`
+}
+
+// VoiceIssue is one way a content template strays from the brand voice
+type VoiceIssue struct {
+	// Check is banned-word, emoji, formality, enthusiasm, signature or style
+	Check   string  `json:"check"`
+	Field   string  `json:"field,omitempty"`
+	Detail  string  `json:"detail"`
+	Penalty float64 `json:"penalty"`
+}
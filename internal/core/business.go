@@ -1,5 +1,11 @@
 package core
 
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
 type BusinessType string
 
 const (
@@ -15,6 +21,50 @@ const (
 	Sales     MarketingGoal = "sales"
 )
 
+// Locale is the language consultations are written in, as an ISO 639-1 code
+type Locale string
+
+const (
+	English    Locale = "en"
+	Spanish    Locale = "es"
+	Portuguese Locale = "pt"
+	Swahili    Locale = "sw"
+	Hindi      Locale = "hi"
+)
+
+// BusinessTypes returns every supported business type
+func BusinessTypes() []BusinessType {
+	return []BusinessType{Retail, Service, Digital}
+}
+
+// MarketingGoals returns every supported marketing goal
+func MarketingGoals() []MarketingGoal {
+	return []MarketingGoal{Awareness, Sales}
+}
+
+// Locales returns every supported locale, English first
+func Locales() []Locale {
+	return []Locale{English, Spanish, Portuguese, Swahili, Hindi}
+}
+
+// Name returns the locale's language as its speakers write it
+func (l Locale) Name() string {
+	switch l {
+	case English:
+		return "English"
+	case Spanish:
+		return "Español"
+	case Portuguese:
+		return "Português"
+	case Swahili:
+		return "Kiswahili"
+	case Hindi:
+		return "हिन्दी"
+	default:
+		return string(l)
+	}
+}
+
 type BusinessInput struct {
 	Type        BusinessType   `json:"type"`
 	Description string         `json:"description"`
@@ -22,16 +72,35 @@ type BusinessInput struct {
 	Budget      float64        `json:"budget"`
 	Channels    []string       `json:"channels"`
 	Goal        MarketingGoal  `json:"goal"`
+	Locale      Locale         `json:"locale,omitempty"`
+	Voice       *BrandVoice    `json:"voice,omitempty"`
+	// Profile names the stored profile the business's reported results are
+	// kept under
+	Profile     string         `json:"profile,omitempty"`
+	// Calibration re-estimates platform potential from reported results; the
+	// pipeline fills it in from the profile
+	Calibration map[Platform]Calibration `json:"-"`
+}
+
+// Language returns the locale to write in, English when none is set
+func (b BusinessInput) Language() Locale {
+	if b.Locale == "" {
+		return English
+	}
+	return b.Locale
 }
 
 // IsLocal checks if the business is local (not online-only)
 func (b BusinessInput) IsLocal() bool {
-	return b.Location != "" && b.Location != "online"
+	return !b.IsOnlineOnly()
 }
 
-// IsOnlineOnly checks if the business is online-only
+// IsOnlineOnly checks if the business is online-only: no location, or
+// "online" in any case. Cache keys ignore case and spacing in the location,
+// so this must too.
 func (b BusinessInput) IsOnlineOnly() bool {
-	return b.Location == "" || b.Location == "online"
+	location := strings.TrimSpace(b.Location)
+	return location == "" || strings.EqualFold(location, "online")
 }
 
 // HasLowBudget checks if budget is low (<$50/month)
@@ -47,4 +116,110 @@ func (b BusinessInput) HasMediumBudget() bool {
 // HasHighBudget checks if budget is high (>$200/month)
 func (b BusinessInput) HasHighBudget() bool {
 	return b.Budget > 200
-}
\ No newline at end of file
+}
+
+// BudgetTier returns the budget band used by the filters ("low", "medium" or "high")
+func (b BusinessInput) BudgetTier() string {
+	switch {
+	case b.HasLowBudget():
+		return "low"
+	case b.HasMediumBudget():
+		return "medium"
+	default:
+		return "high"
+	}
+}
+
+// String returns a one-line summary of the business input
+func (b BusinessInput) String() string {
+	location := b.Location
+	if b.IsOnlineOnly() {
+		location = "online"
+	}
+	return fmt.Sprintf("%s business (%s), $%.2f/month, goal: %s", b.Type, location, b.Budget, b.Goal)
+}
+
+// ValidationError describes a single invalid field of a BusinessInput
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Message)
+}
+
+// Validate checks that the input has everything the pipeline needs
+func (b BusinessInput) Validate() error {
+	if err := ValidateType(b.Type); err != nil {
+		return err
+	}
+	if err := ValidateDescription(b.Description); err != nil {
+		return err
+	}
+	if err := ValidateBudget(b.Budget); err != nil {
+		return err
+	}
+	if err := ValidateGoal(b.Goal); err != nil {
+		return err
+	}
+	if err := ValidateLocale(b.Locale); err != nil {
+		return err
+	}
+	if err := ValidateProfile(b.Profile); err != nil {
+		return err
+	}
+	return ValidateVoice(b.Voice)
+}
+
+// ValidateType checks a business type on its own
+func ValidateType(businessType BusinessType) error {
+	switch businessType {
+	case Retail, Service, Digital:
+		return nil
+	default:
+		return &ValidationError{Field: "type", Message: fmt.Sprintf("%q is not one of retail, service, digital", businessType)}
+	}
+}
+
+// ValidateDescription checks a business description on its own
+func ValidateDescription(description string) error {
+	if strings.TrimSpace(description) == "" {
+		return &ValidationError{Field: "description", Message: "must not be empty"}
+	}
+	return nil
+}
+
+// ValidateBudget checks a monthly budget on its own
+func ValidateBudget(budget float64) error {
+	if math.IsNaN(budget) || math.IsInf(budget, 0) {
+		return &ValidationError{Field: "budget", Message: "must be a finite number"}
+	}
+	if budget < 0 {
+		return &ValidationError{Field: "budget", Message: "must not be negative"}
+	}
+	return nil
+}
+
+// ValidateGoal checks a marketing goal on its own
+func ValidateGoal(goal MarketingGoal) error {
+	switch goal {
+	case Awareness, Sales:
+		return nil
+	default:
+		return &ValidationError{Field: "goal", Message: fmt.Sprintf("%q is not one of awareness, sales", goal)}
+	}
+}
+
+// ValidateLocale checks a locale on its own; empty means English
+func ValidateLocale(locale Locale) error {
+	if locale == "" {
+		return nil
+	}
+	for _, supported := range Locales() {
+		if locale == supported {
+			return nil
+		}
+	}
+	return &ValidationError{Field: "locale", Message: fmt.Sprintf("%q is not one of en, es, pt, sw, hi", locale)}
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// LegacyFieldAliases maps field names used by early API clients (and the
+// original docs) to the current BusinessInput JSON fields
+var LegacyFieldAliases = map[string]string{
+	"business_type":  "type",
+	"monthly_budget": "budget",
+}
+
+// DecodeBusinessInput strictly decodes a single BusinessInput from JSON.
+// Unknown fields are rejected, but legacy field names are accepted as
+// aliases for their current names.
+func DecodeBusinessInput(r io.Reader) (BusinessInput, error) {
+	var business BusinessInput
+
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&fields); err != nil {
+		return business, err
+	}
+	if fields == nil {
+		return business, fmt.Errorf("expected a JSON object")
+	}
+
+	// Visit aliases in a stable order so error messages are deterministic
+	aliases := make([]string, 0, len(LegacyFieldAliases))
+	for alias := range LegacyFieldAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	for _, alias := range aliases {
+		value, ok := fields[alias]
+		if !ok {
+			continue
+		}
+		name := LegacyFieldAliases[alias]
+		if _, both := fields[name]; both {
+			return business, fmt.Errorf("fields %q and %q are aliases; send only %q", alias, name, name)
+		}
+		fields[name] = value
+		delete(fields, alias)
+	}
+
+	normalized, err := json.Marshal(fields)
+	if err != nil {
+		return business, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(normalized))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&business); err != nil {
+		return business, err
+	}
+	if business.Channels == nil {
+		business.Channels = []string{}
+	}
+	return business, nil
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeBusinessInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    BusinessInput
+		wantErr string
+	}{
+		{
+			name: "current field names",
+			body: `{"type":"retail","description":"handmade mugs","location":"Austin","budget":150,"goal":"sales"}`,
+			want: BusinessInput{Type: Retail, Description: "handmade mugs", Location: "Austin", Budget: 150, Goal: Sales},
+		},
+		{
+			name: "legacy aliases",
+			body: `{"business_type":"service","description":"dog grooming","monthly_budget":80,"goal":"awareness"}`,
+			want: BusinessInput{Type: Service, Description: "dog grooming", Budget: 80, Goal: Awareness},
+		},
+		{
+			name: "one alias next to current names",
+			body: `{"type":"digital","description":"courses","monthly_budget":0,"goal":"awareness","channels":["Instagram"]}`,
+			want: BusinessInput{Type: Digital, Description: "courses", Goal: Awareness, Channels: []string{"Instagram"}},
+		},
+		{
+			name:    "an alias and its current name together",
+			body:    `{"type":"retail","business_type":"retail","description":"mugs","goal":"sales"}`,
+			wantErr: `fields "business_type" and "type" are aliases; send only "type"`,
+		},
+		{
+			name:    "unknown field",
+			body:    `{"type":"retail","description":"mugs","goal":"sales","budgett":10}`,
+			wantErr: `unknown field "budgett"`,
+		},
+		{name: "not an object", body: `["retail"]`, wantErr: "cannot unmarshal array"},
+		{name: "null", body: `null`, wantErr: "expected a JSON object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeBusinessInput(strings.NewReader(tt.body))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeBusinessInput: %v", err)
+			}
+			if got.Channels == nil {
+				t.Error("Channels is nil, want an empty list")
+			}
+			if got.Type != tt.want.Type || got.Description != tt.want.Description || got.Location != tt.want.Location ||
+				got.Budget != tt.want.Budget || got.Goal != tt.want.Goal || len(got.Channels) != len(tt.want.Channels) {
+				t.Errorf("DecodeBusinessInput = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package core
+
+// ConfidenceLevel buckets a confidence score for display
+type ConfidenceLevel string
+
+const (
+	ConfidenceHigh   ConfidenceLevel = "high"
+	ConfidenceMedium ConfidenceLevel = "medium"
+	ConfidenceLow    ConfidenceLevel = "low"
+)
+
+// Confidence is how sure the pipeline is of a recommendation, and the
+// signals behind it, each from 0 to 1
+type Confidence struct {
+	Score float64         `json:"score"`
+	Level ConfidenceLevel `json:"level"`
+	// Completeness rates how much the business input reveals: a detailed
+	// description, a location, existing channels and a budget that fits the
+	// goal
+	Completeness float64 `json:"completeness"`
+	// Margin rates the score gap to the platforms ranked just above and below
+	Margin float64 `json:"margin"`
+	// Agreement is the share of LLM review samples that picked the platform
+	// too; unset when no samples were taken
+	Agreement *float64 `json:"agreement,omitempty"`
+	// Evidence rates the reported results behind the platform's calibration
+	Evidence float64 `json:"evidence"`
+}
+
+// Question is a clarifying question whose answer would firm up the
+// recommendations
+type Question struct {
+	// Field is the BusinessInput field the answer goes into
+	Field string `json:"field"`
+	Text  string `json:"text"`
+}
@@ -0,0 +1,68 @@
+package core
+
+// Fallback records a pipeline stage that could not use its preferred source
+// and moved on to the next one in its chain
+type Fallback struct {
+	Stage  string `json:"stage"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Reason string `json:"reason"`
+}
+
+// ResultMetadata describes how a consultation result was produced
+type ResultMetadata struct {
+	// Degraded is set when any stage fell back to a lesser source
+	Degraded  bool       `json:"degraded"`
+	Fallbacks []Fallback `json:"fallbacks,omitempty"`
+	// Prompts lists the prompt version each stage sent to a model, e.g.
+	// "content@v2"; stages that never asked a model are not listed
+	Prompts []string `json:"prompts,omitempty"`
+	// Guardrails lists the content policy problems found in generated
+	// templates and what was done about each
+	Guardrails []PolicyFinding `json:"guardrails,omitempty"`
+	Cost       *CostEstimate   `json:"cost,omitempty"`
+}
+
+// PolicyAction is what the content policy checker did about a finding
+type PolicyAction string
+
+const (
+	// PolicyRewritten means the offending words were replaced
+	PolicyRewritten PolicyAction = "rewritten"
+	// PolicyRemoved means the offending sentence or hashtag was dropped
+	PolicyRemoved PolicyAction = "removed"
+	// PolicyFlagged means the text was left as-is and a risk was added
+	PolicyFlagged PolicyAction = "flagged"
+)
+
+// PolicyFinding is one content policy rule a generated template broke
+type PolicyFinding struct {
+	Rule     string       `json:"rule"`
+	Platform Platform     `json:"platform"`
+	Field    string       `json:"field"`
+	Match    string       `json:"match"`
+	Action   PolicyAction `json:"action"`
+	Concern  string       `json:"concern"`
+}
+
+// ModelCall is one request to a model and its estimated cost
+type ModelCall struct {
+	Stage            string  `json:"stage"`
+	Provider         string  `json:"provider"`
+	Model            string  `json:"model"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+	// Priced is false when the model's price is unknown and CostUSD is zero
+	Priced bool `json:"priced"`
+}
+
+// CostEstimate is the estimated LLM spend for one consultation. Results served
+// from the cache cost nothing and are not listed.
+type CostEstimate struct {
+	TotalUSD float64     `json:"total_usd"`
+	Calls    []ModelCall `json:"calls,omitempty"`
+	// UnpricedModels lists models with no known price; their calls count as
+	// zero, so the total is a lower bound
+	UnpricedModels []string `json:"unpriced_models,omitempty"`
+}
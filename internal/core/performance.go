@@ -0,0 +1,40 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// profilePattern is the accepted profile name: letters, digits, dots, dashes
+// and underscores, starting with a letter or digit
+var profilePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// ValidateProfile checks a profile name on its own; empty means none
+func ValidateProfile(profile string) error {
+	if profile == "" || profilePattern.MatchString(profile) {
+		return nil
+	}
+	return &ValidationError{Field: "profile", Message: fmt.Sprintf("%q must be up to 64 letters, digits, dots, dashes or underscores", profile)}
+}
+
+// Calibration is a platform's reach and conversion potential re-estimated
+// from reported results, on the same 1-10 scale as the platform metadata
+type Calibration struct {
+	ReachPotential  float64 `json:"reach_potential"`
+	ConversionFocus float64 `json:"conversion_focus"`
+	// Results counts the business's own reported periods behind the estimate
+	Results int `json:"results"`
+	// VerticalResults counts the periods other businesses in the vertical
+	// reported
+	VerticalResults int `json:"vertical_results"`
+}
+
+// Potential returns the platform's reach and conversion potential for the
+// business: calibrated from its reported results when there are any,
+// otherwise the platform metadata's estimates
+func (b BusinessInput) Potential(metadata PlatformMetadata) (reach, conversion float64) {
+	if calibration, ok := b.Calibration[metadata.Name]; ok {
+		return calibration.ReachPotential, calibration.ConversionFocus
+	}
+	return float64(metadata.ReachPotential), float64(metadata.ConversionFocus)
+}
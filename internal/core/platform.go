@@ -1,5 +1,9 @@
 package core
 
+import (
+	"strings"
+)
+
 type Platform string
 
 const (
@@ -21,21 +25,38 @@ const (
 )
 
 type PlatformMetadata struct {
-	Name              Platform
-	RequiresVisuals   bool
-	RequiresVideo     bool
-	MinBudget         float64
-	EffortLevel       EffortLevel
-	BestFor           []BusinessType
-	SupportsHashtags  bool
-	IsOrganic         bool
-	IsPaid            bool
-	ReachPotential    int         // 1-10 scale
-	ConversionFocus   int         // 1-10 scale
+	Name              Platform       `json:"name"`
+	RequiresVisuals   bool           `json:"requires_visuals"`
+	RequiresVideo     bool           `json:"requires_video"`
+	MinBudget         float64        `json:"min_budget"`
+	EffortLevel       EffortLevel    `json:"effort_level"`
+	BestFor           []BusinessType `json:"best_for"`
+	SupportsHashtags  bool           `json:"supports_hashtags"`
+	IsOrganic         bool           `json:"is_organic"`
+	IsPaid            bool           `json:"is_paid"`
+	ReachPotential    int            `json:"reach_potential"`  // 1-10 scale
+	ConversionFocus   int            `json:"conversion_focus"` // 1-10 scale
 }
 
-// AllPlatforms returns a map of all platforms and their metadata
+// AllPlatforms returns a map of all platforms and their metadata, including
+// any overrides loaded from a platform config file
 func AllPlatforms() map[Platform]PlatformMetadata {
+	platformsMu.RLock()
+	defer platformsMu.RUnlock()
+
+	if platformOverrides == nil {
+		return DefaultPlatforms()
+	}
+
+	platforms := make(map[Platform]PlatformMetadata, len(platformOverrides))
+	for name, metadata := range platformOverrides {
+		platforms[name] = metadata
+	}
+	return platforms
+}
+
+// DefaultPlatforms returns the built-in metadata for every platform
+func DefaultPlatforms() map[Platform]PlatformMetadata {
 	return map[Platform]PlatformMetadata{
 		Instagram: {
 			Name:            Instagram,
@@ -156,4 +177,30 @@ func GetAllPlatformNames() []Platform {
 		LinkedIn,
 		YouTube,
 	}
-}
\ No newline at end of file
+}
+
+// GetPlatformMetadata looks up the metadata for a single platform
+func GetPlatformMetadata(platform Platform) (PlatformMetadata, bool) {
+	metadata, exists := AllPlatforms()[platform]
+	return metadata, exists
+}
+
+// ParsePlatform resolves a platform from a case-insensitive name or prefix,
+// e.g. "google" or "whatsapp"
+func ParsePlatform(name string) (Platform, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", false
+	}
+	for _, platform := range GetAllPlatformNames() {
+		if strings.ToLower(string(platform)) == name {
+			return platform, true
+		}
+	}
+	for _, platform := range GetAllPlatformNames() {
+		if strings.HasPrefix(strings.ToLower(string(platform)), name) {
+			return platform, true
+		}
+	}
+	return "", false
+}
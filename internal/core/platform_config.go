@@ -0,0 +1,127 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+var (
+	platformsMu       sync.RWMutex
+	platformOverrides map[Platform]PlatformMetadata
+)
+
+// LoadPlatformConfig reads platform metadata from a JSON config file.
+// The file holds an array of PlatformMetadata objects.
+func LoadPlatformConfig(path string) ([]PlatformMetadata, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return DecodePlatformConfig(file)
+}
+
+// DecodePlatformConfig decodes platform metadata from JSON
+func DecodePlatformConfig(r io.Reader) ([]PlatformMetadata, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var platforms []PlatformMetadata
+	if err := decoder.Decode(&platforms); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("platform config is empty")
+		}
+		return nil, fmt.Errorf("decoding platform config: %w", err)
+	}
+	return platforms, nil
+}
+
+// ValidatePlatformConfig checks every entry of a platform config and returns
+// all problems found
+func ValidatePlatformConfig(platforms []PlatformMetadata) []error {
+	problems := make([]error, 0)
+	known := make(map[Platform]bool)
+	for _, name := range GetAllPlatformNames() {
+		known[name] = true
+	}
+
+	seen := make(map[Platform]bool)
+	for i, metadata := range platforms {
+		where := fmt.Sprintf("entry %d (%s)", i, metadata.Name)
+
+		if !known[metadata.Name] {
+			problems = append(problems, fmt.Errorf("%s: unknown platform", where))
+		}
+		if seen[metadata.Name] {
+			problems = append(problems, fmt.Errorf("%s: duplicate platform", where))
+		}
+		seen[metadata.Name] = true
+
+		switch metadata.EffortLevel {
+		case LowEffort, MediumEffort, HighEffort:
+		default:
+			problems = append(problems, fmt.Errorf("%s: effort_level %q is not one of low, medium, high", where, metadata.EffortLevel))
+		}
+
+		if metadata.MinBudget < 0 {
+			problems = append(problems, fmt.Errorf("%s: min_budget must not be negative", where))
+		}
+		if metadata.ReachPotential < 1 || metadata.ReachPotential > 10 {
+			problems = append(problems, fmt.Errorf("%s: reach_potential must be between 1 and 10", where))
+		}
+		if metadata.ConversionFocus < 1 || metadata.ConversionFocus > 10 {
+			problems = append(problems, fmt.Errorf("%s: conversion_focus must be between 1 and 10", where))
+		}
+		if !metadata.IsOrganic && !metadata.IsPaid {
+			problems = append(problems, fmt.Errorf("%s: must be organic, paid or both", where))
+		}
+		if metadata.RequiresVideo && !metadata.RequiresVisuals {
+			problems = append(problems, fmt.Errorf("%s: video platforms must also require visuals", where))
+		}
+
+		for _, businessType := range metadata.BestFor {
+			switch businessType {
+			case Retail, Service, Digital:
+			default:
+				problems = append(problems, fmt.Errorf("%s: best_for contains unknown business type %q", where, businessType))
+			}
+		}
+	}
+
+	for _, name := range GetAllPlatformNames() {
+		if !seen[name] {
+			problems = append(problems, fmt.Errorf("platform %s is missing from the config", name))
+		}
+	}
+
+	return problems
+}
+
+// UsePlatformConfig replaces the built-in platform metadata with a validated
+// config. Passing nil restores the defaults.
+func UsePlatformConfig(platforms []PlatformMetadata) error {
+	if platforms == nil {
+		platformsMu.Lock()
+		platformOverrides = nil
+		platformsMu.Unlock()
+		return nil
+	}
+
+	if problems := ValidatePlatformConfig(platforms); len(problems) > 0 {
+		return fmt.Errorf("invalid platform config: %w", problems[0])
+	}
+
+	overrides := make(map[Platform]PlatformMetadata, len(platforms))
+	for _, metadata := range platforms {
+		overrides[metadata.Name] = metadata
+	}
+
+	platformsMu.Lock()
+	platformOverrides = overrides
+	platformsMu.Unlock()
+	return nil
+}
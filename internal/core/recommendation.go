@@ -13,6 +13,10 @@ type Recommendation struct {
     Reasoning       string           `json:"reasoning"`
     Score           float64          `json:"score"`
     ContentTemplate *ContentTemplate `json:"content_template,omitempty"`
+    // VoiceScore rates the content template against the brand voice
+    VoiceScore      *VoiceScore      `json:"voice_score,omitempty"`
+    // Confidence is how sure the pipeline is of the recommendation
+    Confidence      *Confidence      `json:"confidence,omitempty"`
 }
 
 type ConsultationResult struct {
@@ -20,4 +24,8 @@ type ConsultationResult struct {
     StrategicAdvice string           `json:"strategic_advice"`
     Risks           []string         `json:"risks"`
     Persona         string           `json:"persona"`
+    Metadata        *ResultMetadata  `json:"metadata,omitempty"`
+    // Questions are suggested when confidence is low; answering them in the
+    // business input firms up the recommendations
+    Questions       []Question       `json:"questions,omitempty"`
 }
\ No newline at end of file
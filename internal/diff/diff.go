@@ -0,0 +1,314 @@
+// Package diff compares two consultation results: how platforms moved in
+// rank and score, which were added or dropped, which risks appeared or were
+// resolved and how each platform's reasoning changed. When the businesses
+// behind the results are known it also lists the edited fields and which
+// platforms gained or lost ad spend, and summarizes the edits and their
+// effect in one line.
+package diff
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
+)
+
+// scoreThreshold is the smallest score change the summary mentions for a
+// platform that kept its rank
+const scoreThreshold = 1.0
+
+// Snapshot is a consultation result and, when known, the business it was
+// run for
+type Snapshot struct {
+	Business *core.BusinessInput      `json:"business,omitempty"`
+	Result   *core.ConsultationResult `json:"result"`
+}
+
+// Comparison is the difference between two consultations
+type Comparison struct {
+	// From and To label the two consultations, e.g. "revision 2" or a file
+	// name
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+	// Changes lists the edited business fields; it is empty unless both
+	// businesses are known
+	Changes []Change `json:"changes"`
+	// Platforms covers every platform recommended in either consultation,
+	// in the later rank order with dropped platforms last
+	Platforms      []PlatformDelta `json:"platforms"`
+	Added          []core.Platform `json:"added"`
+	Removed        []core.Platform `json:"removed"`
+	Risks          RiskChanges     `json:"risks"`
+	AdviceChanged  bool            `json:"advice_changed"`
+	PersonaChanged bool            `json:"persona_changed"`
+	// Summary reads as one line in the later business's locale, e.g.
+	// "Budget rose from $80 to $250 → Facebook ads now recommended"
+	Summary string `json:"summary"`
+}
+
+// Change is one edited field of the business
+type Change struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// PlatformDelta is one platform's recommendation in both consultations
+type PlatformDelta struct {
+	Platform core.Platform `json:"platform"`
+	// FromRank and ToRank are 0 when the platform was not recommended
+	FromRank  int     `json:"from_rank"`
+	ToRank    int     `json:"to_rank"`
+	FromScore float64 `json:"from_score"`
+	ToScore   float64 `json:"to_score"`
+	// ScoreDelta is set for platforms both consultations recommend
+	ScoreDelta float64 `json:"score_delta"`
+	// FromAds and ToAds report whether the budget buys ads on the platform
+	// rather than only funding organic posts
+	FromAds bool `json:"from_ads"`
+	ToAds   bool `json:"to_ads"`
+	// ReasoningAdded and ReasoningRemoved are the sentences of the reasoning
+	// that only the later or only the earlier consultation gave
+	ReasoningAdded   []string `json:"reasoning_added,omitempty"`
+	ReasoningRemoved []string `json:"reasoning_removed,omitempty"`
+}
+
+// Changed reports whether anything about the platform's recommendation moved
+func (p PlatformDelta) Changed() bool {
+	return p.FromRank != p.ToRank || p.ScoreDelta != 0 || p.FromAds != p.ToAds ||
+		len(p.ReasoningAdded) > 0 || len(p.ReasoningRemoved) > 0
+}
+
+// RiskChanges lists the risks only one of the consultations raised
+type RiskChanges struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// Compare diffs two consultations
+func Compare(from, to Snapshot) (*Comparison, error) {
+	if from.Result == nil || to.Result == nil {
+		return nil, &core.ValidationError{Field: "result", Message: "both consultations need a result"}
+	}
+
+	comparison := &Comparison{
+		Changes:        []Change{},
+		Added:          []core.Platform{},
+		Removed:        []core.Platform{},
+		Risks:          RiskChanges{Added: missing(to.Result.Risks, from.Result.Risks), Removed: missing(from.Result.Risks, to.Result.Risks)},
+		AdviceChanged:  from.Result.StrategicAdvice != to.Result.StrategicAdvice,
+		PersonaChanged: from.Result.Persona != to.Result.Persona,
+	}
+	if from.Business != nil && to.Business != nil {
+		comparison.Changes = Changes(*from.Business, *to.Business)
+	}
+
+	before := make(map[core.Platform]core.Recommendation)
+	for _, rec := range from.Result.Recommendations {
+		before[rec.Platform] = rec
+	}
+	after := make(map[core.Platform]bool)
+	for _, rec := range to.Result.Recommendations {
+		after[rec.Platform] = true
+		delta := PlatformDelta{Platform: rec.Platform, ToRank: rec.Rank, ToScore: rec.Score, ToAds: buysAds(to.Business, rec.Platform)}
+		if earlier, ok := before[rec.Platform]; ok {
+			delta.FromRank, delta.FromScore = earlier.Rank, earlier.Score
+			delta.FromAds = buysAds(from.Business, rec.Platform)
+			delta = delta.withReasoning(earlier.Reasoning, rec.Reasoning)
+		} else {
+			comparison.Added = append(comparison.Added, rec.Platform)
+		}
+		comparison.Platforms = append(comparison.Platforms, delta)
+	}
+	for _, rec := range from.Result.Recommendations {
+		if after[rec.Platform] {
+			continue
+		}
+		comparison.Removed = append(comparison.Removed, rec.Platform)
+		comparison.Platforms = append(comparison.Platforms, PlatformDelta{
+			Platform: rec.Platform, FromRank: rec.Rank, FromScore: rec.Score, FromAds: buysAds(from.Business, rec.Platform),
+		})
+	}
+
+	comparison.Summary = comparison.summarize(locale(from, to), from.Business, to.Business)
+	return comparison, nil
+}
+
+// withReasoning fills in the score delta and the reasoning sentences that
+// differ, for a platform both consultations recommend
+func (p PlatformDelta) withReasoning(from, to string) PlatformDelta {
+	p.ScoreDelta = math.Round((p.ToScore-p.FromScore)*10) / 10
+	fromSentences, toSentences := sentences(from), sentences(to)
+	p.ReasoningAdded = missing(toSentences, fromSentences)
+	p.ReasoningRemoved = missing(fromSentences, toSentences)
+	return p
+}
+
+// Changes lists the fields edited between two versions of a business
+func Changes(from, to core.BusinessInput) []Change {
+	changes := []Change{}
+	add := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, Change{Field: field, From: before, To: after})
+		}
+	}
+	add("type", string(from.Type), string(to.Type))
+	add("description", from.Description, to.Description)
+	add("location", location(from), location(to))
+	add("budget", fmt.Sprintf("%g", from.Budget), fmt.Sprintf("%g", to.Budget))
+	add("channels", strings.Join(from.Channels, ", "), strings.Join(to.Channels, ", "))
+	add("goal", string(from.Goal), string(to.Goal))
+	add("locale", string(from.Language()), string(to.Language()))
+	if !voiceEqual(from.Voice, to.Voice) {
+		changes = append(changes, Change{Field: "voice"})
+	}
+	return changes
+}
+
+// location names where the business sells, treating no location as online
+func location(business core.BusinessInput) string {
+	if business.IsOnlineOnly() {
+		return "online"
+	}
+	return business.Location
+}
+
+// voiceEqual compares two brand voices field by field
+func voiceEqual(a, b *core.BrandVoice) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Tone == b.Tone && a.Emoji == b.Emoji &&
+		slices.Equal(a.BannedWords, b.BannedWords) &&
+		slices.Equal(a.SignaturePhrases, b.SignaturePhrases) &&
+		slices.Equal(a.SamplePosts, b.SamplePosts)
+}
+
+// buysAds reports whether the business's budget goes on ads for the
+// platform, as the report's budget split decides it; with no business it
+// cannot tell and reports false
+func buysAds(business *core.BusinessInput, platform core.Platform) bool {
+	if business == nil {
+		return false
+	}
+	metadata, ok := core.GetPlatformMetadata(platform)
+	return ok && metadata.IsPaid && !business.HasLowBudget()
+}
+
+// locale is the later business's language, else the earlier one's, else
+// English
+func locale(from, to Snapshot) core.Locale {
+	switch {
+	case to.Business != nil:
+		return to.Business.Language()
+	case from.Business != nil:
+		return from.Business.Language()
+	}
+	return core.English
+}
+
+// sentences splits reasoning into its sentences
+func sentences(text string) []string {
+	var split []string
+	for text = strings.TrimSpace(text); text != ""; {
+		end := len(text)
+		for _, stop := range []string{". ", "। "} {
+			if i := strings.Index(text, stop); i >= 0 && i+len(stop)-1 < end {
+				end = i + len(stop) - 1
+			}
+		}
+		split = append(split, strings.TrimSpace(text[:end]))
+		text = strings.TrimSpace(text[end:])
+	}
+	return split
+}
+
+// missing returns the items of a that b lacks, in a's order
+func missing(a, b []string) []string {
+	found := []string{}
+	for _, item := range a {
+		if !slices.Contains(b, item) {
+			found = append(found, item)
+		}
+	}
+	return found
+}
+
+// summarize describes the edits, if the businesses are known, and what
+// moved in one line
+func (c *Comparison) summarize(locale core.Locale, from, to *core.BusinessInput) string {
+	var moves []string
+	for _, platform := range c.Platforms {
+		name := string(platform.Platform)
+		switch {
+		case platform.ToRank == 0:
+			moves = append(moves, i18n.T(locale, "diff.removed", name))
+		case platform.FromRank == 0 && platform.ToAds:
+			moves = append(moves, i18n.T(locale, "diff.ads", name))
+		case platform.FromRank == 0:
+			moves = append(moves, i18n.T(locale, "diff.added", name))
+		default:
+			if platform.FromRank != platform.ToRank {
+				moves = append(moves, i18n.T(locale, "diff.moved", name, platform.FromRank, platform.ToRank))
+			} else if math.Abs(platform.ScoreDelta) >= scoreThreshold {
+				moves = append(moves, i18n.T(locale, "diff.score", name, platform.ScoreDelta))
+			}
+			if platform.ToAds && !platform.FromAds {
+				moves = append(moves, i18n.T(locale, "diff.ads", name))
+			} else if platform.FromAds && !platform.ToAds {
+				moves = append(moves, i18n.T(locale, "diff.organic", name))
+			}
+		}
+	}
+	if n := len(c.Risks.Added); n > 0 {
+		moves = append(moves, i18n.T(locale, "diff.risks.added", n))
+	}
+	if n := len(c.Risks.Removed); n > 0 {
+		moves = append(moves, i18n.T(locale, "diff.risks.removed", n))
+	}
+	if len(moves) == 0 {
+		moves = append(moves, i18n.T(locale, "diff.unchanged"))
+	}
+	if from == nil || to == nil {
+		return capitalize(i18n.List(locale, moves))
+	}
+
+	var edits []string
+	for _, change := range c.Changes {
+		switch change.Field {
+		case "type":
+			edits = append(edits, i18n.T(locale, "diff.type", i18n.BusinessType(locale, from.Type), i18n.BusinessType(locale, to.Type)))
+		case "budget":
+			key := "diff.budget.rose"
+			if to.Budget < from.Budget {
+				key = "diff.budget.fell"
+			}
+			edits = append(edits, i18n.T(locale, key, from.Budget, to.Budget))
+		case "goal":
+			edits = append(edits, i18n.T(locale, "diff.goal", i18n.T(locale, "goal."+change.From), i18n.T(locale, "goal."+change.To)))
+		case "locale":
+			edits = append(edits, i18n.T(locale, "diff.locale", to.Language().Name()))
+		case "channels":
+			edits = append(edits, i18n.T(locale, "diff.channels", i18n.List(locale, to.Channels)))
+		case "location":
+			edits = append(edits, i18n.T(locale, "diff.location", change.From, change.To))
+		default:
+			edits = append(edits, i18n.T(locale, "diff."+change.Field))
+		}
+	}
+	if len(edits) == 0 {
+		edits = append(edits, i18n.T(locale, "diff.no_changes"))
+	}
+	return capitalize(i18n.List(locale, edits) + " → " + i18n.List(locale, moves))
+}
+
+// capitalize upper-cases the first letter, for scripts that have case
+func capitalize(text string) string {
+	first, size := utf8.DecodeRuneInString(text)
+	return string(unicode.ToUpper(first)) + text[size:]
+}
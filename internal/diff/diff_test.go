@@ -0,0 +1,383 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"biz-flow/internal/core"
+)
+
+var cafe = core.BusinessInput{
+	Type:        core.Retail,
+	Description: "Neighborhood coffee shop",
+	Location:    "Austin, TX",
+	Budget:      80,
+	Goal:        core.Awareness,
+}
+
+// consultation recommends the platforms in rank order, scoring each 10
+// below the one before
+func consultation(platforms ...core.Platform) *core.ConsultationResult {
+	result := &core.ConsultationResult{StrategicAdvice: "Post weekly.", Persona: "Locals"}
+	for i, platform := range platforms {
+		result.Recommendations = append(result.Recommendations, core.Recommendation{
+			Platform:  platform,
+			Rank:      i + 1,
+			Score:     float64(90 - 10*i),
+			Reasoning: "Reaches locals. Fits the budget.",
+		})
+	}
+	return result
+}
+
+// with returns a copy of the business after edit
+func with(edit func(b *core.BusinessInput)) *core.BusinessInput {
+	business := cafe
+	edit(&business)
+	return &business
+}
+
+func TestCompare(t *testing.T) {
+	rescored := consultation(core.Instagram, core.Facebook)
+	rescored.Recommendations[0].Score = 84.96
+	nudged := consultation(core.Instagram, core.Facebook)
+	nudged.Recommendations[0].Score = 89.2
+	reasoned := consultation(core.Instagram, core.Facebook)
+	reasoned.Recommendations[0].Reasoning = "Reaches locals. Shows off the latte art."
+	risky := consultation(core.Instagram, core.Facebook)
+	risky.Risks = []string{"Reels need weekly video"}
+	advised := consultation(core.Instagram, core.Facebook)
+	advised.StrategicAdvice, advised.Persona = "Post daily.", "Commuters"
+
+	tests := []struct {
+		name         string
+		from, to     Snapshot
+		added        []core.Platform
+		removed      []core.Platform
+		risksAdded   []string
+		risksRemoved []string
+		advice       bool
+		summary      string
+	}{
+		{
+			name:    "identical",
+			from:    Snapshot{Business: &cafe, Result: consultation(core.Instagram, core.Facebook)},
+			to:      Snapshot{Business: &cafe, Result: consultation(core.Instagram, core.Facebook)},
+			summary: "No edits → recommendations unchanged",
+		},
+		{
+			name:    "identical without businesses",
+			from:    Snapshot{Result: consultation(core.Instagram, core.Facebook)},
+			to:      Snapshot{Result: consultation(core.Instagram, core.Facebook)},
+			summary: "Recommendations unchanged",
+		},
+		{
+			name:    "reordered",
+			from:    Snapshot{Result: consultation(core.Instagram, core.Facebook)},
+			to:      Snapshot{Result: consultation(core.Facebook, core.Instagram)},
+			summary: "Facebook moved from #2 to #1 and Instagram moved from #1 to #2",
+		},
+		{
+			name:    "score change",
+			from:    Snapshot{Result: consultation(core.Instagram, core.Facebook)},
+			to:      Snapshot{Result: rescored},
+			summary: "Instagram score -5.0",
+		},
+		{
+			name:    "small score change",
+			from:    Snapshot{Result: consultation(core.Instagram, core.Facebook)},
+			to:      Snapshot{Result: nudged},
+			summary: "Recommendations unchanged",
+		},
+		{
+			name:    "platform added organically",
+			from:    Snapshot{Business: with(func(b *core.BusinessInput) { b.Budget = 20 }), Result: consultation(core.Instagram)},
+			to:      Snapshot{Business: with(func(b *core.BusinessInput) { b.Budget = 20 }), Result: consultation(core.Instagram, core.WhatsApp)},
+			added:   []core.Platform{core.WhatsApp},
+			summary: "No edits → WhatsApp Business now recommended",
+		},
+		{
+			name:    "platform removed",
+			from:    Snapshot{Result: consultation(core.Instagram, core.Facebook, core.TikTok)},
+			to:      Snapshot{Result: consultation(core.Instagram, core.Facebook)},
+			removed: []core.Platform{core.TikTok},
+			summary: "TikTok no longer recommended",
+		},
+		{
+			name:    "budget raised buys ads",
+			from:    Snapshot{Business: with(func(b *core.BusinessInput) { b.Budget = 30 }), Result: consultation(core.Instagram, core.WhatsApp)},
+			to:      Snapshot{Business: with(func(b *core.BusinessInput) { b.Budget = 250 }), Result: consultation(core.Instagram, core.WhatsApp, core.Facebook)},
+			added:   []core.Platform{core.Facebook},
+			summary: "Budget rose from $30 to $250 → Instagram ads now recommended and Facebook ads now recommended",
+		},
+		{
+			name:    "budget cut goes organic",
+			from:    Snapshot{Business: &cafe, Result: consultation(core.Instagram)},
+			to:      Snapshot{Business: with(func(b *core.BusinessInput) { b.Budget = 10 }), Result: consultation(core.Instagram)},
+			summary: "Budget fell from $80 to $10 → Instagram now organic only",
+		},
+		{
+			name:       "risk added",
+			from:       Snapshot{Result: consultation(core.Instagram, core.Facebook)},
+			to:         Snapshot{Result: risky},
+			risksAdded: []string{"Reels need weekly video"},
+			summary:    "New risks: 1",
+		},
+		{
+			name:         "risk removed",
+			from:         Snapshot{Result: risky},
+			to:           Snapshot{Result: consultation(core.Instagram, core.Facebook)},
+			risksRemoved: []string{"Reels need weekly video"},
+			summary:      "Resolved risks: 1",
+		},
+		{
+			name:    "reasoning alone does not change the summary",
+			from:    Snapshot{Result: consultation(core.Instagram, core.Facebook)},
+			to:      Snapshot{Result: reasoned},
+			summary: "Recommendations unchanged",
+		},
+		{
+			name:    "advice and persona",
+			from:    Snapshot{Result: consultation(core.Instagram, core.Facebook)},
+			to:      Snapshot{Result: advised},
+			advice:  true,
+			summary: "Recommendations unchanged",
+		},
+		{
+			name: "several edits in the later locale",
+			from: Snapshot{Business: &cafe, Result: consultation(core.Instagram)},
+			to: Snapshot{
+				Business: with(func(b *core.BusinessInput) { b.Goal, b.Locale, b.Description = core.Sales, core.Spanish, "Cafetería" }),
+				Result:   consultation(core.Facebook, core.Instagram),
+			},
+			added:   []core.Platform{core.Facebook},
+			summary: "Se editó la descripción, el objetivo cambió de reconocimiento a ventas y el idioma cambió a Español → ahora se recomiendan anuncios en Facebook y Instagram pasó del n.º 1 al n.º 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparison, err := Compare(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Compare: %v", err)
+			}
+			if comparison.Summary != tt.summary {
+				t.Errorf("Summary = %q, want %q", comparison.Summary, tt.summary)
+			}
+			for _, got := range []struct {
+				name      string
+				got, want any
+			}{
+				{"Added", comparison.Added, orEmpty(tt.added)},
+				{"Removed", comparison.Removed, orEmpty(tt.removed)},
+				{"Risks.Added", comparison.Risks.Added, orEmpty(tt.risksAdded)},
+				{"Risks.Removed", comparison.Risks.Removed, orEmpty(tt.risksRemoved)},
+			} {
+				if !reflect.DeepEqual(got.got, got.want) {
+					t.Errorf("%s = %v, want %v", got.name, got.got, got.want)
+				}
+			}
+			if comparison.AdviceChanged != tt.advice || comparison.PersonaChanged != tt.advice {
+				t.Errorf("AdviceChanged, PersonaChanged = %v, %v, want %v", comparison.AdviceChanged, comparison.PersonaChanged, tt.advice)
+			}
+
+			// Every platform of either consultation appears once, the later
+			// ranking first and dropped platforms last
+			var platforms []core.Platform
+			for _, delta := range comparison.Platforms {
+				platforms = append(platforms, delta.Platform)
+			}
+			var want []core.Platform
+			for _, rec := range tt.to.Result.Recommendations {
+				want = append(want, rec.Platform)
+			}
+			want = append(want, tt.removed...)
+			if !reflect.DeepEqual(platforms, want) {
+				t.Errorf("Platforms = %v, want %v", platforms, want)
+			}
+		})
+	}
+
+	if _, err := Compare(Snapshot{}, Snapshot{Result: consultation()}); err == nil {
+		t.Error("Compare without a result succeeded")
+	}
+}
+
+func TestPlatformDelta(t *testing.T) {
+	from := consultation(core.Instagram, core.Facebook)
+	to := consultation(core.Facebook, core.Instagram)
+	to.Recommendations[1].Score = 82.44
+	to.Recommendations[1].Reasoning = "Reaches locals. Shows off the latte art."
+
+	comparison, err := Compare(Snapshot{Business: &cafe, Result: from}, Snapshot{Business: &cafe, Result: to})
+	if err != nil {
+		t.Fatalf("Compare: %v", err)
+	}
+	want := []PlatformDelta{
+		{Platform: core.Facebook, FromRank: 2, ToRank: 1, FromScore: 80, ToScore: 90, ScoreDelta: 10, FromAds: true, ToAds: true,
+			ReasoningAdded: []string{}, ReasoningRemoved: []string{}},
+		{Platform: core.Instagram, FromRank: 1, ToRank: 2, FromScore: 90, ToScore: 82.44, ScoreDelta: -7.6, FromAds: true, ToAds: true,
+			ReasoningAdded: []string{"Shows off the latte art."}, ReasoningRemoved: []string{"Fits the budget."}},
+	}
+	if !reflect.DeepEqual(comparison.Platforms, want) {
+		t.Errorf("Platforms = %+v, want %+v", comparison.Platforms, want)
+	}
+	for _, delta := range comparison.Platforms {
+		if !delta.Changed() {
+			t.Errorf("%s did not change", delta.Platform)
+		}
+	}
+	if same := (PlatformDelta{Platform: core.Email, FromRank: 1, ToRank: 1}); same.Changed() {
+		t.Error("an unmoved platform changed")
+	}
+}
+
+func TestChanges(t *testing.T) {
+	voice := &core.BrandVoice{Emoji: core.EmojiSparing, BannedWords: []string{"cheap"}}
+	edited := &core.BrandVoice{Emoji: core.EmojiSparing, BannedWords: []string{"cheap", "deal"}}
+
+	tests := []struct {
+		name string
+		edit func(b *core.BusinessInput)
+		want []Change
+	}{
+		{name: "none", edit: func(b *core.BusinessInput) {}, want: []Change{}},
+		{
+			name: "budget and goal",
+			edit: func(b *core.BusinessInput) { b.Budget, b.Goal = 120.5, core.Sales },
+			want: []Change{{Field: "budget", From: "80", To: "120.5"}, {Field: "goal", From: "awareness", To: "sales"}},
+		},
+		{
+			name: "moved online",
+			edit: func(b *core.BusinessInput) { b.Location = "" },
+			want: []Change{{Field: "location", From: "Austin, TX", To: "online"}},
+		},
+		{
+			name: "channels",
+			edit: func(b *core.BusinessInput) { b.Channels = []string{"Instagram", "Email/Newsletter"} },
+			want: []Change{{Field: "channels", To: "Instagram, Email/Newsletter"}},
+		},
+		{
+			name: "empty locale is English",
+			edit: func(b *core.BusinessInput) { b.Locale = core.English },
+			want: []Change{},
+		},
+		{
+			name: "locale",
+			edit: func(b *core.BusinessInput) { b.Locale = core.Hindi },
+			want: []Change{{Field: "locale", From: "en", To: "hi"}},
+		},
+		{
+			name: "voice added",
+			edit: func(b *core.BusinessInput) { b.Voice = voice },
+			want: []Change{{Field: "voice"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Changes(cafe, *with(tt.edit)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Changes = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	from, to := cafe, cafe
+	from.Voice, to.Voice = voice, &core.BrandVoice{Emoji: core.EmojiSparing, BannedWords: []string{"cheap"}}
+	if changes := Changes(from, to); len(changes) != 0 {
+		t.Errorf("Changes between equal voices = %+v, want none", changes)
+	}
+	to.Voice = edited
+	if changes := Changes(from, to); !reflect.DeepEqual(changes, []Change{{Field: "voice"}}) {
+		t.Errorf("Changes after editing the voice = %+v, want voice", changes)
+	}
+}
+
+func TestBuysAds(t *testing.T) {
+	low := with(func(b *core.BusinessInput) { b.Budget = 49 })
+	tests := []struct {
+		name     string
+		business *core.BusinessInput
+		platform core.Platform
+		want     bool
+	}{
+		{"paid platform", &cafe, core.Instagram, true},
+		{"low budget", low, core.Instagram, false},
+		{"budget at the threshold", with(func(b *core.BusinessInput) { b.Budget = 50 }), core.Facebook, true},
+		{"organic platform", &cafe, core.WhatsApp, false},
+		{"unknown platform", &cafe, core.Platform("Myspace"), false},
+		{"no business", nil, core.Instagram, false},
+	}
+
+	for _, tt := range tests {
+		if got := buysAds(tt.business, tt.platform); got != tt.want {
+			t.Errorf("%s: buysAds = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSentences(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"  One sentence.  ", []string{"One sentence."}},
+		{"Reaches locals. Costs $4.50 a day. Fits", []string{"Reaches locals.", "Costs $4.50 a day.", "Fits"}},
+		{"ताज़ा ब्रेड। आज ही आएं।", []string{"ताज़ा ब्रेड।", "आज ही आएं।"}},
+	}
+
+	for _, tt := range tests {
+		if got := sentences(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("sentences(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCompareRequest(t *testing.T) {
+	ctx := context.Background()
+	before := &Snapshot{Business: &cafe, Result: consultation(core.Instagram)}
+	consult := func(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error) {
+		return consultation(core.Instagram, core.Facebook), nil
+	}
+
+	tests := []struct {
+		name    string
+		request CompareRequest
+		field   string
+	}{
+		{"no before", CompareRequest{After: before}, "before"},
+		{"no after", CompareRequest{Before: before}, "after"},
+		{"after with rerun", CompareRequest{Before: before, After: before, Rerun: true}, "after"},
+		{"rerun without a business", CompareRequest{Before: &Snapshot{Result: before.Result}, Rerun: true}, "before"},
+	}
+	for _, tt := range tests {
+		var validation *core.ValidationError
+		if _, err := tt.request.Run(ctx, consult); !errors.As(err, &validation) || validation.Field != tt.field {
+			t.Errorf("%s: error = %v, want a validation error on %s", tt.name, err, tt.field)
+		}
+	}
+
+	comparison, err := CompareRequest{Before: before, Rerun: true}.Run(ctx, consult)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if comparison.To != "current rules" || !reflect.DeepEqual(comparison.Added, []core.Platform{core.Facebook}) {
+		t.Errorf("rerun = %+v, want Facebook added under the current rules", comparison)
+	}
+
+	failed := errors.New("model unavailable")
+	if _, err := Rerun(ctx, func(context.Context, core.BusinessInput) (*core.ConsultationResult, error) { return nil, failed }, *before); !errors.Is(err, failed) {
+		t.Errorf("Rerun error = %v, want the consultation's", err)
+	}
+}
+
+// orEmpty returns items, or an empty slice for nil, as Compare reports none
+func orEmpty[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
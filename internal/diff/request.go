@@ -0,0 +1,60 @@
+package diff
+
+import (
+	"context"
+
+	"biz-flow/internal/core"
+)
+
+// ConsultFunc runs a consultation, e.g. (*agent.Agent).Consult
+type ConsultFunc func(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error)
+
+// CompareRequest is the body of POST /diff: two consultations to compare,
+// or one to run again against the current rules
+type CompareRequest struct {
+	Before *Snapshot `json:"before"`
+	After  *Snapshot `json:"after,omitempty"`
+	// Rerun consults before's business again and compares the fresh result
+	// with before's; after must be empty
+	Rerun bool `json:"rerun,omitempty"`
+}
+
+// Run compares the request's consultations, running before's business
+// through consult first when Rerun is set
+func (r CompareRequest) Run(ctx context.Context, consult ConsultFunc) (*Comparison, error) {
+	if r.Before == nil || r.Before.Result == nil {
+		return nil, &core.ValidationError{Field: "before", Message: "needs a result"}
+	}
+	if !r.Rerun {
+		if r.After == nil || r.After.Result == nil {
+			return nil, &core.ValidationError{Field: "after", Message: "needs a result, or set rerun"}
+		}
+		return Compare(*r.Before, *r.After)
+	}
+
+	if r.After != nil {
+		return nil, &core.ValidationError{Field: "after", Message: "must be empty when rerun is set"}
+	}
+	if r.Before.Business == nil {
+		return nil, &core.ValidationError{Field: "before", Message: "needs the business to run again"}
+	}
+	return Rerun(ctx, consult, *r.Before)
+}
+
+// Rerun consults the snapshot's business against the current rules and
+// compares the fresh result with the snapshot's
+func Rerun(ctx context.Context, consult ConsultFunc, before Snapshot) (*Comparison, error) {
+	if before.Business == nil {
+		return nil, &core.ValidationError{Field: "business", Message: "is required to run the consultation again"}
+	}
+	result, err := consult(ctx, *before.Business)
+	if err != nil {
+		return nil, err
+	}
+	comparison, err := Compare(before, Snapshot{Business: before.Business, Result: result})
+	if err != nil {
+		return nil, err
+	}
+	comparison.To = "current rules"
+	return comparison, nil
+}
@@ -0,0 +1,181 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"biz-flow/internal/ai"
+	"biz-flow/internal/core"
+	"biz-flow/internal/prompts"
+)
+
+// Case is one fixture business with the recommendation context later
+// stages' prompts need
+type Case struct {
+	Business        core.BusinessInput
+	Recommendations []core.Recommendation
+	// Draft is the rule-based strategy the advice prompt improves on
+	Draft string
+	// Candidates are the platforms that pass the filters, which the review
+	// prompt picks from
+	Candidates []core.Platform
+}
+
+// Platform is the platform content is written for: the top recommendation
+func (c Case) Platform() core.Platform {
+	if len(c.Recommendations) == 0 {
+		return ""
+	}
+	return c.Recommendations[0].Platform
+}
+
+// vars binds the case to a prompt
+func (c Case) vars() prompts.Vars {
+	return prompts.Vars{
+		Business:        c.Business,
+		Platform:        c.Platform(),
+		Recommendations: c.Recommendations,
+		Draft:           c.Draft,
+		Candidates:      c.Candidates,
+		Top:             len(c.Recommendations),
+	}
+}
+
+// Sample is one prompt version's reply to one case and its grade
+type Sample struct {
+	Case   int     `json:"case"`
+	Score  float64 `json:"score"`
+	Checks []Check `json:"checks,omitempty"`
+	Error  string  `json:"error,omitempty"`
+}
+
+// VersionReport summarizes how one prompt version did across the cases
+type VersionReport struct {
+	Prompt string `json:"prompt"`
+	// Score is the mean share of checks passed; failed calls score zero
+	Score float64 `json:"score"`
+	// PassRate is the share of replies that passed each check
+	PassRate         map[string]float64 `json:"pass_rate"`
+	Errors           int                `json:"errors"`
+	PromptTokens     int                `json:"prompt_tokens"`
+	CompletionTokens int                `json:"completion_tokens"`
+	CostUSD          float64            `json:"cost_usd"`
+	Samples          []Sample           `json:"samples"`
+}
+
+// Report compares the versions of one stage's prompt
+type Report struct {
+	Stage    string          `json:"stage"`
+	Model    string          `json:"model"`
+	Cases    int             `json:"cases"`
+	Versions []VersionReport `json:"versions"`
+	// Best is the highest scoring version
+	Best string `json:"best"`
+}
+
+// Run sends every case to the client once per prompt version and grades the
+// replies. Calls that fail are recorded and scored zero; only cancellation
+// stops the run.
+func Run(
+	ctx context.Context,
+	stage string,
+	client *ai.Client,
+	versions []*prompts.Template,
+	cases []Case,
+) (*Report, error) {
+	report := &Report{Stage: stage, Model: client.Model(), Cases: len(cases)}
+	for _, prompt := range versions {
+		version := VersionReport{Prompt: prompt.ID(), PassRate: make(map[string]float64)}
+		passed := make(map[string]int)
+		graded := 0
+		for i, c := range cases {
+			sample := Sample{Case: i + 1}
+			completion, err := complete(ctx, client, prompt, c)
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if err != nil {
+				sample.Error = err.Error()
+				version.Errors++
+				version.Samples = append(version.Samples, sample)
+				continue
+			}
+
+			version.PromptTokens += completion.Usage.PromptTokens
+			version.CompletionTokens += completion.Usage.CompletionTokens
+			if cost, ok := client.Cost(completion.Usage); ok {
+				version.CostUSD += cost
+			}
+
+			sample.Checks = Score(stage, completion.Text, c)
+			ok := 0
+			for _, check := range sample.Checks {
+				if check.Passed {
+					ok++
+					passed[check.Name]++
+				} else if _, seen := passed[check.Name]; !seen {
+					passed[check.Name] = 0
+				}
+			}
+			if len(sample.Checks) > 0 {
+				sample.Score = float64(ok) / float64(len(sample.Checks))
+			}
+			graded++
+			version.Score += sample.Score
+			version.Samples = append(version.Samples, sample)
+		}
+
+		if len(cases) > 0 {
+			version.Score /= float64(len(cases))
+		}
+		for name, count := range passed {
+			version.PassRate[name] = float64(count) / float64(graded)
+		}
+		report.Versions = append(report.Versions, version)
+	}
+
+	best := -1.0
+	for _, version := range report.Versions {
+		if version.Score > best {
+			best, report.Best = version.Score, version.Prompt
+		}
+	}
+	return report, nil
+}
+
+// complete renders the prompt for the case and asks the model once
+func complete(ctx context.Context, client *ai.Client, prompt *prompts.Template, c Case) (*ai.Completion, error) {
+	messages, err := ai.Messages(prompt, c.vars())
+	if err != nil {
+		return nil, err
+	}
+	return client.Complete(ctx, messages)
+}
+
+// WriteText prints one line per version with its score and pass rates
+func (r *Report) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "%s prompts on %s (%d cases)\n", r.Stage, r.Model, r.Cases)
+	for _, version := range r.Versions {
+		names := make([]string, 0, len(version.PassRate))
+		for name := range version.PassRate {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		rates := make([]string, 0, len(names))
+		for _, name := range names {
+			rates = append(rates, fmt.Sprintf("%s %.0f%%", name, version.PassRate[name]*100))
+		}
+		marker := " "
+		if version.Prompt == r.Best {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s %-12s score %.2f  %s  errors %d  tokens %d/%d  $%.4f\n",
+			marker, version.Prompt, version.Score, strings.Join(rates, "  "),
+			version.Errors, version.PromptTokens, version.CompletionTokens, version.CostUSD)
+	}
+	return nil
+}
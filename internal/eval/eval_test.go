@@ -0,0 +1,176 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"biz-flow/internal/ai"
+	"biz-flow/internal/core"
+	"biz-flow/internal/prompts"
+)
+
+func TestScore(t *testing.T) {
+	c := Case{
+		Recommendations: []core.Recommendation{{Platform: core.Instagram, Rank: 1}, {Platform: core.Facebook, Rank: 2}},
+		Candidates:      []core.Platform{core.Instagram, core.Facebook, core.TikTok},
+	}
+	email := Case{Recommendations: []core.Recommendation{{Platform: core.Email, Rank: 1}}}
+	tests := []struct {
+		name   string
+		stage  string
+		reply  string
+		c      Case
+		failed []string
+	}{
+		{name: "persona", stage: ai.StagePersona, reply: "Coffee lovers in Austin. They buy gifts."},
+		{name: "long persona", stage: ai.StagePersona, reply: "One. Two. Three.", failed: []string{"sentences"}},
+		{
+			name:  "content",
+			stage: ai.StageContent,
+			reply: `{"platform":"Instagram","hook":"New mugs","caption":"Glazed by hand.","cta":"Shop now.","hashtags":["mugs","ceramics","gifts"]}`,
+			c:     c,
+		},
+		{
+			name:   "content with raw hashtags and two calls to action",
+			stage:  ai.StageContent,
+			reply:  `{"platform":"Instagram","hook":"New mugs","caption":"Glazed by hand.","cta":"Shop now. Tell a friend.","hashtags":["#mugs","mugs","gifts"]}`,
+			c:      c,
+			failed: []string{"cta", "hashtags"},
+		},
+		{
+			name:   "content with hashtags where there are none",
+			stage:  ai.StageContent,
+			reply:  `{"platform":"Email/Newsletter","hook":"` + strings.Repeat("x", 101) + `","caption":"c","cta":"a","hashtags":["mugs"]}`,
+			c:      email,
+			failed: []string{"length", "hashtags"},
+		},
+		{name: "content that is not JSON", stage: ai.StageContent, reply: "Buy mugs", c: c, failed: []string{"schema", "cta", "length", "hashtags"}},
+		{name: "advice", stage: ai.StageAdvice, reply: "Lead with Instagram. Post daily. Reuse posts on Facebook.", c: c},
+		{
+			name:   "advice off the recommendations",
+			stage:  ai.StageAdvice,
+			reply:  "Go big on TikTok. Post daily. Reply fast. Hire help. Be patient. Have fun.",
+			c:      c,
+			failed: []string{"schema", "sentences", "top_platform"},
+		},
+		{name: "review", stage: ai.StageReview, reply: `{"platforms":["TikTok","Instagram"]}`, c: c},
+		{name: "short review", stage: ai.StageReview, reply: `{"platforms":["TikTok"]}`, c: c, failed: []string{"picks"}},
+		{name: "unknown stage", stage: "summary", reply: "anything"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var failed []string
+			for _, check := range Score(tt.stage, tt.reply, tt.c) {
+				if !check.Passed {
+					failed = append(failed, check.Name)
+					if check.Detail == "" {
+						t.Errorf("%s failed without a detail", check.Name)
+					}
+				}
+			}
+			if strings.Join(failed, ",") != strings.Join(tt.failed, ",") {
+				t.Errorf("failed checks = %v, want %v", failed, tt.failed)
+			}
+		})
+	}
+}
+
+func TestCountSentences(t *testing.T) {
+	tests := map[string]int{
+		"":                      0,
+		"One sentence.":         1,
+		"No full stop":          1,
+		"Costs $4.50. Buy now!": 2,
+		"Really? Yes. And more": 3,
+		"हाथ से बने मग। अभी खरीदें।": 2,
+	}
+	for text, want := range tests {
+		if got := countSentences(text); got != want {
+			t.Errorf("countSentences(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	// The model answers in two sentences, or four when the prompt asks for
+	// detail, and fails outright for one business
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Messages []ai.Message `json:"messages"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		user := request.Messages[len(request.Messages)-1].Content
+		if strings.Contains(user, "broken") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"message":"bad request"}}`))
+			return
+		}
+		reply := "Coffee lovers. They buy gifts."
+		if strings.Contains(user, "in detail") {
+			reply = "Coffee lovers. They buy gifts. They live nearby. They like craft."
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": reply}}},
+			"usage":   map[string]int{"prompt_tokens": 100, "completion_tokens": 10},
+		})
+	}))
+	defer server.Close()
+
+	router, err := ai.NewRouter(&ai.RoutingConfig{
+		Providers: map[string]ai.Provider{"local": {Kind: ai.ProviderOllama, BaseURL: server.URL}},
+		Stages:    map[string][]ai.Route{ai.StagePersona: {{Provider: "local", Model: "llama3"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "persona"), 0o755)
+	os.WriteFile(filepath.Join(dir, "persona", "v2.tmpl"), []byte(`{{define "system"}}{{template "consultant" .}}{{end}}
+{{define "user"}}Describe the ideal customer for {{.Business.Description}} in detail.{{end}}`), 0o644)
+	library, err := prompts.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []Case{
+		{Business: core.BusinessInput{Type: core.Retail, Description: "handmade mugs", Goal: core.Sales}},
+		{Business: core.BusinessInput{Type: core.Retail, Description: "broken kettles", Goal: core.Sales}},
+	}
+	report, err := Run(context.Background(), ai.StagePersona, router.Clients(ai.StagePersona)[0], library.Versions("persona"), cases)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if report.Best != "persona@v1" || len(report.Versions) != 2 {
+		t.Fatalf("best = %s of %d versions, want persona@v1 of 2", report.Best, len(report.Versions))
+	}
+	tests := []struct {
+		version   VersionReport
+		score     float64
+		sentences float64
+	}{
+		{version: report.Versions[0], score: 0.5, sentences: 1},
+		{version: report.Versions[1], score: 0.25, sentences: 0},
+	}
+	for _, tt := range tests {
+		v := tt.version
+		if v.Score != tt.score || v.PassRate["sentences"] != tt.sentences || v.PassRate["schema"] != 1 {
+			t.Errorf("%s scored %g with pass rates %v, want %g and sentences %g", v.Prompt, v.Score, v.PassRate, tt.score, tt.sentences)
+		}
+		if v.Errors != 1 || v.Samples[1].Error == "" || v.PromptTokens != 100 || v.CompletionTokens != 10 || v.CostUSD != 0 {
+			t.Errorf("%s: errors %d, tokens %d/%d, cost %g", v.Prompt, v.Errors, v.PromptTokens, v.CompletionTokens, v.CostUSD)
+		}
+	}
+
+	var text strings.Builder
+	if err := report.WriteText(&text); err != nil || !strings.Contains(text.String(), "persona@v2") {
+		t.Errorf("WriteText = %q, %v", text.String(), err)
+	}
+}
@@ -0,0 +1,179 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"biz-flow/internal/ai"
+	"biz-flow/internal/core"
+)
+
+// Length limits the rubric holds content to; they fit the tightest of the
+// supported platforms
+const (
+	maxHookLength    = 100
+	maxCaptionLength = 2200
+	maxCTALength     = 120
+	minHashtags      = 3
+	maxHashtags      = 6
+)
+
+// Check is one rubric item and whether a reply met it
+type Check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// check builds a Check that only explains itself on failure
+func check(name string, passed bool, detail string, args ...interface{}) Check {
+	if passed {
+		return Check{Name: name, Passed: true}
+	}
+	return Check{Name: name, Detail: fmt.Sprintf(detail, args...)}
+}
+
+// Score grades a model's first reply to a stage's prompt. Replies are graded
+// as sent, before any repair or normalization, since that is what the prompt
+// is responsible for.
+func Score(stage string, reply string, c Case) []Check {
+	switch stage {
+	case ai.StagePersona:
+		return scorePersona(reply)
+	case ai.StageContent:
+		return scoreContent(reply, c.Platform())
+	case ai.StageAdvice:
+		return scoreAdvice(reply, c.Recommendations)
+	case ai.StageReview:
+		return scoreReview(reply, c)
+	}
+	return nil
+}
+
+// scorePersona expects the two sentences the prompt asks for
+func scorePersona(reply string) []Check {
+	_, err := ai.ParsePersona(reply)
+	sentences := countSentences(reply)
+	return []Check{
+		check("schema", err == nil, "%v", err),
+		check("sentences", sentences >= 1 && sentences <= 2, "%d sentences, want 1 to 2", sentences),
+	}
+}
+
+// rawContent is the content reply exactly as the model sent it
+type rawContent struct {
+	Hook     string   `json:"hook"`
+	Caption  string   `json:"caption"`
+	CTA      string   `json:"cta"`
+	Hashtags []string `json:"hashtags"`
+}
+
+// scoreContent checks the schema, the call to action, length limits and the
+// platform's hashtag rules
+func scoreContent(reply string, platform core.Platform) []Check {
+	_, err := ai.ParseContent(reply, platform)
+	checks := []Check{check("schema", err == nil, "%v", err)}
+
+	var raw rawContent
+	if err := json.Unmarshal([]byte(ai.ExtractJSON(reply)), &raw); err != nil {
+		for _, name := range []string{"cta", "length", "hashtags"} {
+			checks = append(checks, check(name, false, "not JSON"))
+		}
+		return checks
+	}
+
+	cta := strings.TrimSpace(raw.CTA)
+	checks = append(checks, check("cta", cta != "" && countSentences(cta) <= 1,
+		"want a single-sentence call to action, got %q", cta))
+
+	var long []string
+	for _, field := range []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"hook", raw.Hook, maxHookLength},
+		{"caption", raw.Caption, maxCaptionLength},
+		{"cta", raw.CTA, maxCTALength},
+	} {
+		if n := utf8.RuneCountInString(strings.TrimSpace(field.value)); n > field.max {
+			long = append(long, fmt.Sprintf("%s is %d characters (max %d)", field.name, n, field.max))
+		}
+	}
+	checks = append(checks, check("length", len(long) == 0, "%s", strings.Join(long, "; ")))
+
+	return append(checks, checkHashtags(raw.Hashtags, platform))
+}
+
+// checkHashtags wants 3 to 6 bare, distinct tags on platforms that use them
+// and none elsewhere
+func checkHashtags(hashtags []string, platform core.Platform) Check {
+	if metadata, ok := core.GetPlatformMetadata(platform); !ok || !metadata.SupportsHashtags {
+		return check("hashtags", len(hashtags) == 0, "%s does not use hashtags but got %d", platform, len(hashtags))
+	}
+	if len(hashtags) < minHashtags || len(hashtags) > maxHashtags {
+		return check("hashtags", false, "%d hashtags, want %d to %d", len(hashtags), minHashtags, maxHashtags)
+	}
+	seen := make(map[string]bool, len(hashtags))
+	for _, tag := range hashtags {
+		switch {
+		case strings.HasPrefix(tag, "#"):
+			return check("hashtags", false, "%q includes the # symbol", tag)
+		case strings.ContainsAny(tag, " \t"):
+			return check("hashtags", false, "%q contains spaces", tag)
+		case seen[strings.ToLower(tag)]:
+			return check("hashtags", false, "%q is repeated", tag)
+		}
+		seen[strings.ToLower(tag)] = true
+	}
+	return check("hashtags", true, "")
+}
+
+// scoreAdvice expects three to five sentences that mention the top platform
+// and stay within the recommendations
+func scoreAdvice(reply string, recommendations []core.Recommendation) []Check {
+	_, err := ai.ParseAdvice(reply, recommendations)
+	sentences := countSentences(reply)
+	checks := []Check{
+		check("schema", err == nil, "%v", err),
+		check("sentences", sentences >= 3 && sentences <= 5, "%d sentences, want 3 to 5", sentences),
+	}
+	if len(recommendations) > 0 {
+		top := recommendations[0].Platform
+		checks = append(checks, check("top_platform",
+			strings.Contains(strings.ToLower(reply), strings.ToLower(string(top))), "%s is not mentioned", top))
+	}
+	return checks
+}
+
+// scoreReview expects a full set of picks from the candidates
+func scoreReview(reply string, c Case) []Check {
+	picks, err := ai.ParseReview(reply, c.Candidates, len(c.Recommendations))
+	return []Check{
+		check("schema", err == nil, "%v", err),
+		check("picks", err != nil || len(picks) == len(c.Recommendations),
+			"%d platforms picked, want %d", len(picks), len(c.Recommendations)),
+	}
+}
+
+// countSentences counts sentence-ending punctuation, including the Hindi
+// danda, followed by a space or the end of the text, plus a final sentence left unpunctuated
+func countSentences(text string) int {
+	text = strings.TrimSpace(text)
+	count := 0
+	for i, r := range text {
+		if r != '.' && r != '!' && r != '?' && r != '।' {
+			continue
+		}
+		next := i + utf8.RuneLen(r)
+		if next == len(text) || text[next] == ' ' || text[next] == '\n' {
+			count++
+		}
+	}
+	if last, _ := utf8.DecodeLastRuneInString(text); text != "" && !strings.ContainsRune(".!?।", last) {
+		count++
+	}
+	return count
+}
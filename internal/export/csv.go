@@ -0,0 +1,89 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"biz-flow/internal/core"
+)
+
+// Layout is a CSV column layout
+type Layout string
+
+const (
+	// LayoutGeneric has a column per field, for spreadsheets
+	LayoutGeneric Layout = "generic"
+	// LayoutBuffer matches Buffer's bulk upload: Text, Image URL, Tags and
+	// Posting Time as YYYY-MM-DD HH:mm, with the pillar as the tag
+	LayoutBuffer Layout = "buffer"
+	// LayoutHootsuite matches Hootsuite's bulk composer: no header, then the
+	// date as DD/MM/YYYY HH:mm, the message and an optional link
+	LayoutHootsuite Layout = "hootsuite"
+)
+
+// Layouts returns every CSV layout
+func Layouts() []Layout {
+	return []Layout{LayoutGeneric, LayoutBuffer, LayoutHootsuite}
+}
+
+// ParseLayout checks a layout name; empty means generic
+func ParseLayout(name string) (Layout, error) {
+	if name == "" {
+		return LayoutGeneric, nil
+	}
+	for _, layout := range Layouts() {
+		if Layout(name) == layout {
+			return layout, nil
+		}
+	}
+	return "", &core.ValidationError{Field: "layout", Message: fmt.Sprintf("%q is not one of generic, buffer, hootsuite", name)}
+}
+
+// genericHeader names the columns of the generic layout
+var genericHeader = []string{"date", "time", "week", "platform", "pillar", "holiday", "hook", "caption", "cta", "hashtags"}
+
+// WriteCSV writes the posts in the layout, one row per post
+func WriteCSV(w io.Writer, posts []Post, layout Layout) error {
+	var header []string
+	switch layout {
+	case LayoutGeneric, "":
+		header = genericHeader
+	case LayoutBuffer:
+		header = []string{"Text", "Image URL", "Tags", "Posting Time"}
+	case LayoutHootsuite:
+	default:
+		return fmt.Errorf("unknown CSV layout %q", layout)
+	}
+	out := csv.NewWriter(w)
+	if header != nil {
+		if err := out.Write(header); err != nil {
+			return err
+		}
+	}
+
+	for _, post := range posts {
+		var row []string
+		switch layout {
+		case LayoutBuffer:
+			row = []string{message(post), "", post.Pillar, post.At.Format("2006-01-02 15:04")}
+		case LayoutHootsuite:
+			row = []string{post.At.Format("02/01/2006 15:04"), message(post), ""}
+		default:
+			template := post.Template
+			if template == nil {
+				template = &core.ContentTemplate{}
+			}
+			row = []string{
+				post.At.Format("2006-01-02"), post.At.Format("15:04"), strconv.Itoa(post.Week), string(post.Platform),
+				post.Pillar, post.Holiday, template.Hook, template.Caption, template.CTA, hashtagText(template.Hashtags),
+			}
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
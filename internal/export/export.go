@@ -0,0 +1,222 @@
+// Package export turns a content calendar or a consultation result into
+// files owners can load elsewhere: iCalendar events for their phone calendar
+// and CSV for spreadsheets and social scheduling tools.
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"biz-flow/internal/cache"
+	"biz-flow/internal/calendar"
+	"biz-flow/internal/core"
+	"biz-flow/internal/templates"
+)
+
+// Post is one scheduled post, whatever it was planned by
+type Post struct {
+	// At is the local posting time. It carries no zone: the post goes out at
+	// that hour wherever the owner is.
+	At       time.Time
+	Week     int
+	Platform core.Platform
+	// Pillar is empty for posts from a consultation result
+	Pillar   string
+	Holiday  string
+	Template *core.ContentTemplate
+	// Source identifies the business or calendar the post belongs to, so
+	// that two businesses posting at the same time get different events
+	Source string
+}
+
+// FromCalendar lists the calendar's slots as posts. Calendars saved before
+// they had an ID are identified by their slots.
+func FromCalendar(plan *calendar.Calendar) ([]Post, error) {
+	source := plan.ID
+	if source == "" {
+		source = cache.Fingerprint(plan.Slots)[:16]
+	}
+	posts := make([]Post, 0, len(plan.Slots))
+	for i, slot := range plan.Slots {
+		at, err := time.Parse(calendar.DateLayout+" 15:04", slot.Date+" "+slot.Time)
+		if err != nil {
+			return nil, &core.ValidationError{
+				Field:   fmt.Sprintf("calendar.slots[%d]", i),
+				Message: fmt.Sprintf("date %q and time %q must look like 2025-03-03 and 09:30", slot.Date, slot.Time),
+			}
+		}
+		posts = append(posts, Post{
+			At:       at,
+			Week:     slot.Week,
+			Platform: slot.Platform,
+			Pillar:   string(slot.Pillar),
+			Holiday:  slot.Holiday,
+			Template: slot.Template,
+			Source:   source,
+		})
+	}
+	return posts, nil
+}
+
+// FromConsultation schedules every recommendation on its own day, in rank
+// order from start, at the platform's usual posting time. Recommendations
+// without a content template get one from the offline library when the
+// business is known, and are scheduled without content otherwise. Without
+// the business, the posts are identified by the recommendations.
+func FromConsultation(result *core.ConsultationResult, business *core.BusinessInput, start time.Time) []Post {
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	source := cache.Fingerprint(result.Recommendations)[:16]
+	if business != nil {
+		source = calendar.BusinessID(*business)
+	}
+	var library *templates.Library
+	var posts []Post
+	for _, recommendation := range result.Recommendations {
+		template := recommendation.ContentTemplate
+		if template == nil && business != nil {
+			if library == nil {
+				library = templates.NewLibrary()
+			}
+			template = library.Generate(*business, recommendation.Platform)
+		}
+		clock, _ := time.Parse("15:04", calendar.PostingTime(recommendation.Platform))
+		at := day.Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute)
+		posts = append(posts, Post{
+			At:       at,
+			Week:     len(posts)/7 + 1,
+			Platform: recommendation.Platform,
+			Template: template,
+			Source:   source,
+		})
+		day = day.AddDate(0, 0, 1)
+	}
+	return posts
+}
+
+// ForPlatform keeps the posts for one platform; an empty platform keeps all
+func ForPlatform(posts []Post, platform core.Platform) []Post {
+	if platform == "" {
+		return posts
+	}
+	var kept []Post
+	for _, post := range posts {
+		if post.Platform == platform {
+			kept = append(kept, post)
+		}
+	}
+	return kept
+}
+
+// DefaultReminder is how long before a post its calendar reminder goes off
+const DefaultReminder = 30 * time.Minute
+
+// ExportRequest is the body of POST /export/ics and POST /export/csv. Exactly
+// one of Result and Calendar is set.
+type ExportRequest struct {
+	Result   *core.ConsultationResult `json:"result,omitempty"`
+	Calendar *calendar.Calendar       `json:"calendar,omitempty"`
+	// Business is the business a result was for; with it, recommendations
+	// without content get a template from the offline library
+	Business *core.BusinessInput `json:"business,omitempty"`
+	// Locale is the language of .ics event titles; empty means the
+	// business's, or English
+	Locale core.Locale `json:"locale,omitempty"`
+	// Start is the first posting day for a result, YYYY-MM-DD; empty means
+	// next Monday
+	Start string `json:"start,omitempty"`
+	// Platform limits the export to one platform
+	Platform core.Platform `json:"platform,omitempty"`
+	// Layout is the CSV column layout
+	Layout Layout `json:"layout,omitempty"`
+	// ReminderMinutes is how long before each post an .ics reminder goes
+	// off; 0 turns reminders off and null means 30
+	ReminderMinutes *int `json:"reminder_minutes,omitempty"`
+}
+
+// Posts validates the request and lists the posts to export
+func (r ExportRequest) Posts(now time.Time) ([]Post, error) {
+	if (r.Result == nil) == (r.Calendar == nil) {
+		return nil, &core.ValidationError{Field: "result", Message: "set exactly one of result and calendar"}
+	}
+	if r.Platform != "" {
+		platform, ok := core.ParsePlatform(string(r.Platform))
+		if !ok {
+			return nil, &core.ValidationError{Field: "platform", Message: fmt.Sprintf("unknown platform %q", r.Platform)}
+		}
+		r.Platform = platform
+	}
+	if _, err := ParseLayout(string(r.Layout)); err != nil {
+		return nil, err
+	}
+	if err := core.ValidateLocale(r.Locale); err != nil {
+		return nil, err
+	}
+	if r.Business != nil {
+		if r.Calendar != nil {
+			return nil, &core.ValidationError{Field: "business", Message: "only applies to a result"}
+		}
+		if err := r.Business.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Calendar != nil {
+		posts, err := FromCalendar(r.Calendar)
+		if err != nil {
+			return nil, err
+		}
+		return ForPlatform(posts, r.Platform), nil
+	}
+	start := calendar.NextMonday(now)
+	if r.Start != "" {
+		parsed, err := time.Parse(calendar.DateLayout, r.Start)
+		if err != nil {
+			return nil, &core.ValidationError{Field: "start", Message: "must be a date like 2025-03-03"}
+		}
+		start = parsed
+	}
+	return ForPlatform(FromConsultation(r.Result, r.Business, start), r.Platform), nil
+}
+
+// Language is the locale of .ics event titles
+func (r ExportRequest) Language() core.Locale {
+	if r.Locale == "" && r.Business != nil {
+		return r.Business.Language()
+	}
+	return r.Locale
+}
+
+// Reminder is how long before each post the reminder goes off
+func (r ExportRequest) Reminder() (time.Duration, error) {
+	if r.ReminderMinutes == nil {
+		return DefaultReminder, nil
+	}
+	if *r.ReminderMinutes < 0 || *r.ReminderMinutes > 7*24*60 {
+		return 0, &core.ValidationError{Field: "reminder_minutes", Message: "must be between 0 and 10080 (a week)"}
+	}
+	return time.Duration(*r.ReminderMinutes) * time.Minute, nil
+}
+
+// message is a post's full text: hook, caption, call to action and hashtags
+func message(post Post) string {
+	if post.Template == nil {
+		return ""
+	}
+	parts := []string{post.Template.Hook, post.Template.Caption, post.Template.CTA, hashtagText(post.Template.Hashtags)}
+	kept := parts[:0]
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, "\n\n")
+}
+
+func hashtagText(hashtags []string) string {
+	tags := make([]string, len(hashtags))
+	for i, tag := range hashtags {
+		tags[i] = "#" + strings.TrimPrefix(tag, "#")
+	}
+	return strings.Join(tags, " ")
+}
@@ -0,0 +1,313 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"biz-flow/internal/calendar"
+	"biz-flow/internal/core"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// golden compares got with testdata/name, rewriting the file with -update
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v (run go test -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the golden file:\n got: %q\nwant: %q", name, got, want)
+	}
+}
+
+// posts are two posts with text that needs escaping, quoting and folding
+var posts = []Post{
+	{
+		At:       time.Date(2025, 3, 3, 11, 0, 0, 0, time.UTC),
+		Week:     1,
+		Platform: core.Instagram,
+		Pillar:   string(calendar.Educational),
+		Template: &core.ContentTemplate{
+			Hook:     "How to choose the right mug",
+			Caption:  "Glaze, weight, handle; what to look for.\nA quick guide from our studio in Austin, TX — with \"real\" tips and a backslash \\ too.",
+			CTA:      "Save this for later.",
+			Hashtags: []string{"#mugs", "pottery"},
+		},
+		Source: "a1b2c3d4e5f60718",
+	},
+	{
+		At:       time.Date(2025, 3, 7, 13, 0, 0, 0, time.UTC),
+		Week:     1,
+		Platform: core.GoogleBusiness,
+		Pillar:   string(calendar.Educational),
+		Holiday:  "International Women's Day",
+		Template: &core.ContentTemplate{Hook: "Celebrating the women who make our mugs ☕️", Caption: "Meet the team."},
+		Source:   "a1b2c3d4e5f60718",
+	},
+}
+
+func TestWriteICS(t *testing.T) {
+	stamp := time.Date(2025, 2, 20, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		options ICSOptions
+		golden  string
+	}{
+		{name: "English with reminders", options: ICSOptions{Reminder: DefaultReminder, Stamp: stamp}, golden: "calendar.en.ics"},
+		{name: "Hindi without reminders", options: ICSOptions{Name: "Mugs, plan; v2", Stamp: stamp, Locale: core.Hindi}, golden: "calendar.hi.ics"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := WriteICS(&out, posts, tt.options); err != nil {
+				t.Fatalf("WriteICS: %v", err)
+			}
+			golden(t, tt.golden, out.Bytes())
+
+			lines := strings.Split(strings.TrimSuffix(out.String(), "\r\n"), "\r\n")
+			for _, line := range lines {
+				if len(line) > 75 {
+					t.Errorf("line is %d octets, want at most 75: %q", len(line), line)
+				}
+				if strings.Contains(line, "\n") {
+					t.Errorf("line has a bare line feed: %q", line)
+				}
+			}
+			if got := strings.Count(out.String(), "BEGIN:VALARM"); (got > 0) != (tt.options.Reminder > 0) {
+				t.Errorf("%d alarms with reminder %v", got, tt.options.Reminder)
+			}
+		})
+	}
+}
+
+func TestICSTitles(t *testing.T) {
+	tests := []struct {
+		locale core.Locale
+		want   string
+	}{
+		{"", "SUMMARY:Post on Instagram (educational): How to choose the right mug"},
+		{core.English, "SUMMARY:Post on Instagram (educational)"},
+		{core.Spanish, "SUMMARY:Publicar en Instagram (educational)"},
+		{core.Portuguese, "SUMMARY:Publicar no Instagram"},
+		{core.Swahili, "SUMMARY:Chapisha kwenye Instagram"},
+		{core.Hindi, "SUMMARY:Instagram पर पोस्ट करें"},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if err := WriteICS(&out, posts[:1], ICSOptions{Locale: tt.locale}); err != nil {
+			t.Fatalf("WriteICS: %v", err)
+		}
+		if unfolded := strings.ReplaceAll(out.String(), "\r\n ", ""); !strings.Contains(unfolded, tt.want) {
+			t.Errorf("%q export has no %q:\n%s", tt.locale, tt.want, unfolded)
+		}
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain text", "plain text"},
+		{"a, b; c", `a\, b\; c`},
+		{`back\slash`, `back\\slash`},
+		{"two\nlines\r\nhere", `two\nlines\nhere`},
+		{`\n is not a newline`, `\\n is not a newline`},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	tests := []string{
+		"",
+		"SUMMARY:short",
+		"DESCRIPTION:" + strings.Repeat("x", 63), // exactly 75 octets
+		"DESCRIPTION:" + strings.Repeat("x", 64), // one over
+		"DESCRIPTION:" + strings.Repeat("x", 300), // several continuation lines
+		"DESCRIPTION:" + strings.Repeat("ह", 100), // three-octet characters
+		"DESCRIPTION:" + strings.Repeat("☕️ ", 40),
+	}
+
+	for _, line := range tests {
+		var out bytes.Buffer
+		w := bufio.NewWriter(&out)
+		writeFolded(w, line)
+		w.Flush()
+
+		folded := out.String()
+		if !strings.HasSuffix(folded, "\r\n") {
+			t.Errorf("folded %q does not end in CRLF", folded)
+		}
+		for i, part := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+			if len(part) > 75 {
+				t.Errorf("part %d is %d octets, want at most 75", i, len(part))
+			}
+			if i > 0 && !strings.HasPrefix(part, " ") {
+				t.Errorf("continuation %d does not start with a space: %q", i, part)
+			}
+			if !utf8.ValidString(part) {
+				t.Errorf("part %d splits a character: %q", i, part)
+			}
+		}
+		if unfolded := strings.ReplaceAll(strings.TrimSuffix(folded, "\r\n"), "\r\n ", ""); unfolded != line {
+			t.Errorf("unfolded = %q, want %q", unfolded, line)
+		}
+	}
+}
+
+func TestEventUID(t *testing.T) {
+	post := posts[0]
+	other := post
+	other.Source = "ffffffffffffffff"
+	moved := post
+	moved.At = post.At.Add(time.Hour)
+	bare := post
+	bare.Source = ""
+	bare.Platform = core.GoogleBusiness
+
+	if got, want := eventUID(post), "20250303-1100-instagram-a1b2c3d4e5f60718@bizflow"; got != want {
+		t.Errorf("eventUID = %q, want %q", got, want)
+	}
+	if got, want := eventUID(bare), "20250303-1100-google-my-business@bizflow"; got != want {
+		t.Errorf("eventUID without a source = %q, want %q", got, want)
+	}
+	if eventUID(post) == eventUID(other) {
+		t.Error("posts from different sources share a UID")
+	}
+	if eventUID(post) == eventUID(moved) {
+		t.Error("posts at different times share a UID")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	tests := []struct {
+		layout  Layout
+		golden  string
+		columns int
+		header  bool
+	}{
+		{LayoutGeneric, "posts.generic.csv", len(genericHeader), true},
+		{LayoutBuffer, "posts.buffer.csv", 4, true},
+		{LayoutHootsuite, "posts.hootsuite.csv", 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.layout), func(t *testing.T) {
+			var out bytes.Buffer
+			if err := WriteCSV(&out, posts, tt.layout); err != nil {
+				t.Fatalf("WriteCSV: %v", err)
+			}
+			golden(t, tt.golden, out.Bytes())
+
+			rows, err := csv.NewReader(&out).ReadAll()
+			if err != nil {
+				t.Fatalf("reading the CSV back: %v", err)
+			}
+			want := len(posts)
+			if tt.header {
+				want++
+			}
+			if len(rows) != want {
+				t.Fatalf("%d rows, want %d", len(rows), want)
+			}
+			for i, row := range rows {
+				if len(row) != tt.columns {
+					t.Errorf("row %d has %d columns, want %d", i, len(row), tt.columns)
+				}
+			}
+		})
+	}
+
+	if err := WriteCSV(&bytes.Buffer{}, posts, "excel"); err == nil {
+		t.Error("WriteCSV with an unknown layout succeeded")
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	for name, want := range map[string]Layout{"": LayoutGeneric, "buffer": LayoutBuffer, "hootsuite": LayoutHootsuite} {
+		if got, err := ParseLayout(name); err != nil || got != want {
+			t.Errorf("ParseLayout(%q) = %q, %v, want %q", name, got, err, want)
+		}
+	}
+	var validation *core.ValidationError
+	if _, err := ParseLayout("Buffer"); !errors.As(err, &validation) || validation.Field != "layout" {
+		t.Errorf("ParseLayout(Buffer) error = %v, want a validation error on layout", err)
+	}
+}
+
+func TestSources(t *testing.T) {
+	business := core.BusinessInput{Type: core.Retail, Description: "Handmade mugs", Location: "Austin, TX", Budget: 80, Goal: core.Awareness}
+	neighbor := business
+	neighbor.Description = "Sourdough bakery"
+	result := &core.ConsultationResult{Recommendations: []core.Recommendation{
+		{Platform: core.Instagram, Rank: 1, ContentTemplate: &core.ContentTemplate{Hook: "Hi"}},
+		{Platform: core.Facebook, Rank: 2},
+	}}
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	ours := FromConsultation(result, &business, monday)
+	theirs := FromConsultation(result, &neighbor, monday)
+	anonymous := FromConsultation(result, nil, monday)
+	if ours[0].Source != calendar.BusinessID(business) {
+		t.Errorf("Source = %q, want the business ID", ours[0].Source)
+	}
+	if eventUID(ours[0]) == eventUID(theirs[0]) {
+		t.Error("two businesses' posts at the same time share a UID")
+	}
+	if anonymous[0].Source == "" || anonymous[0].Source == ours[0].Source {
+		t.Errorf("Source without a business = %q, want one from the recommendations", anonymous[0].Source)
+	}
+	if got := ours[1].At.Format("2006-01-02 15:04"); got != "2025-03-04 13:00" {
+		t.Errorf("second post at %s, want the next day at Facebook's posting time", got)
+	}
+	if ours[1].Template == nil || anonymous[1].Template != nil {
+		t.Error("want library content for a recommendation without any only when the business is known")
+	}
+
+	plan, err := calendar.NewPlanner().Plan(business, result.Recommendations, calendar.Options{Start: monday})
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	planned, err := FromCalendar(plan)
+	if err != nil {
+		t.Fatalf("FromCalendar: %v", err)
+	}
+	if planned[0].Source != ours[0].Source {
+		t.Errorf("calendar Source = %q, want the business ID %q", planned[0].Source, ours[0].Source)
+	}
+	plan.ID = ""
+	if saved, _ := FromCalendar(plan); saved[0].Source == "" {
+		t.Error("a calendar without an ID has no Source")
+	}
+
+	plan.Slots[0].Time = "9am"
+	var validation *core.ValidationError
+	if _, err := FromCalendar(plan); !errors.As(err, &validation) || validation.Field != "calendar.slots[0]" {
+		t.Errorf("FromCalendar error = %v, want a validation error on calendar.slots[0]", err)
+	}
+}
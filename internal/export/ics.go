@@ -0,0 +1,121 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
+)
+
+// ICSOptions shape an iCalendar export
+type ICSOptions struct {
+	// Name is the calendar's display name
+	Name string
+	// Reminder is how long before each post an alarm goes off; 0 means none
+	Reminder time.Duration
+	// Stamp is when the file was made; zero means now
+	Stamp time.Time
+	// Locale is the language of the event titles; empty means English
+	Locale core.Locale
+}
+
+// WriteICS writes the posts as an iCalendar (RFC 5545) file with one event
+// per post. Events are in floating local time, so a post lands at the same
+// hour in any time zone.
+func WriteICS(w io.Writer, posts []Post, options ICSOptions) error {
+	out := bufio.NewWriter(w)
+	if options.Stamp.IsZero() {
+		options.Stamp = time.Now()
+	}
+	if options.Name == "" {
+		options.Name = "Content calendar"
+	}
+	stamp := options.Stamp.UTC().Format("20060102T150405Z")
+	line := func(name, value string) {
+		writeFolded(out, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//BizFlow//Content Calendar//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("X-WR-CALNAME", escapeText(options.Name))
+	for _, post := range posts {
+		summary := i18n.T(options.Locale, "export.post_on", post.Platform)
+		if post.Pillar != "" {
+			summary += fmt.Sprintf(" (%s)", post.Pillar)
+		}
+		if post.Template != nil && post.Template.Hook != "" {
+			summary += ": " + post.Template.Hook
+		}
+		var categories []string
+		for _, category := range []string{post.Pillar, post.Holiday} {
+			if category != "" {
+				categories = append(categories, escapeText(category))
+			}
+		}
+
+		line("BEGIN", "VEVENT")
+		line("UID", eventUID(post))
+		line("DTSTAMP", stamp)
+		line("DTSTART", post.At.Format("20060102T150405"))
+		line("DURATION", "PT30M")
+		line("SUMMARY", escapeText(summary))
+		line("DESCRIPTION", escapeText(message(post)))
+		if len(categories) > 0 {
+			line("CATEGORIES", strings.Join(categories, ","))
+		}
+		if options.Reminder > 0 {
+			line("BEGIN", "VALARM")
+			line("ACTION", "DISPLAY")
+			line("TRIGGER", fmt.Sprintf("-PT%dM", int(options.Reminder.Minutes())))
+			line("DESCRIPTION", escapeText(summary))
+			line("END", "VALARM")
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return out.Flush()
+}
+
+// eventUID identifies a post across exports by its time, platform and
+// source, so that importing a regenerated plan updates its events instead of
+// duplicating them, and another business's plan never replaces them
+func eventUID(post Post) string {
+	platform := strings.Join(strings.FieldsFunc(strings.ToLower(string(post.Platform)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}), "-")
+	uid := post.At.Format("20060102-1504") + "-" + platform
+	if post.Source != "" {
+		uid += "-" + post.Source
+	}
+	return uid + "@bizflow"
+}
+
+// escapeText escapes an iCalendar TEXT value
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// writeFolded writes a content line ending in CRLF, folded so that no line
+// is longer than 75 octets and no character is split
+func writeFolded(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts
+		limit = 74
+	}
+	w.WriteString(line + "\r\n")
+}
@@ -0,0 +1,141 @@
+package feedback
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"biz-flow/internal/core"
+)
+
+// columns maps the header names platform exports use to a result field
+var columns = map[string]string{
+	"platform":    "platform",
+	"channel":     "platform",
+	"network":     "platform",
+	"period":      "period",
+	"date":        "period",
+	"week":        "period",
+	"month":       "period",
+	"reach":       "reach",
+	"impressions": "reach",
+	"views":       "reach",
+	"clicks":      "clicks",
+	"link clicks": "clicks",
+	"leads":       "leads",
+	"messages":    "leads",
+	"calls":       "leads",
+	"sales":       "sales",
+	"purchases":   "sales",
+	"orders":      "sales",
+}
+
+// ReadCSV reads results from a CSV export with a header row. Columns are
+// matched by name, ignoring case; platform, reach and clicks are required,
+// the period, leads and sales are optional, and other columns are ignored.
+func ReadCSV(r io.Reader) ([]Result, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, &core.ValidationError{Field: "csv", Message: "is empty"}
+	}
+	if err != nil {
+		return nil, &core.ValidationError{Field: "csv", Message: err.Error()}
+	}
+	index := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if field, ok := columns[name]; ok {
+			if _, seen := index[field]; !seen {
+				index[field] = i
+			}
+		}
+	}
+	for _, field := range []string{"platform", "reach", "clicks"} {
+		if _, ok := index[field]; !ok {
+			return nil, &core.ValidationError{Field: "csv", Message: fmt.Sprintf("has no %s column", field)}
+		}
+	}
+
+	var results []Result
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			return nil, &core.ValidationError{Field: "csv", Message: err.Error()}
+		}
+		cell := func(field string) string {
+			if i, ok := index[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		name := cell("platform")
+		platform, ok := core.ParsePlatform(name)
+		if !ok {
+			return nil, lineError(line, fmt.Sprintf("unknown platform %q", name))
+		}
+		result := Result{Platform: platform, Period: cell("period")}
+		for _, count := range []struct {
+			field string
+			into  *int64
+		}{
+			{"reach", &result.Reach},
+			{"clicks", &result.Clicks},
+			{"leads", &result.Leads},
+			{"sales", &result.Sales},
+		} {
+			if *count.into, err = parseCount(cell(count.field)); err != nil {
+				return nil, lineError(line, fmt.Sprintf("%s %v", count.field, err))
+			}
+		}
+		if err := result.validate(); err != nil {
+			return nil, lineError(line, err.Error())
+		}
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return nil, &core.ValidationError{Field: "csv", Message: "has no results"}
+	}
+	return results, nil
+}
+
+// parseCount reads a whole, non-negative count; exports often write
+// thousands separators, and an empty cell is zero
+func parseCount(value string) (int64, error) {
+	value = strings.NewReplacer(",", "", " ", "", "_", "").Replace(value)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		// Some exports write counts as decimals, e.g. "120.0"
+		f, ferr := strconv.ParseFloat(value, 64)
+		if ferr != nil || f != float64(int64(f)) {
+			return 0, fmt.Errorf("%q is not a whole number", value)
+		}
+		n = int64(f)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return n, nil
+}
+
+// lineError reports a problem on a line of the CSV
+func lineError(line int, message string) error {
+	return &core.ValidationError{Field: fmt.Sprintf("csv line %d", line), Message: message}
+}
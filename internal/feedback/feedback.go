@@ -0,0 +1,152 @@
+// Package feedback recalibrates platform scores from the results a business
+// reports. Each platform's click rate and conversion rate are Beta-Binomial
+// estimates: the platform metadata sets the prior, results from other
+// businesses in the same vertical update it, and the business's own results
+// update it again. The posterior means map back onto the metadata's 1-10
+// reach and conversion scales, so later consultations rank platforms by what
+// actually worked.
+package feedback
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/guardrails"
+)
+
+// Reference rates a platform with a potential of 5 out of 10 is expected to
+// reach: clicks per person reached, and leads or sales per click
+const (
+	referenceClickRate      = 0.02
+	referenceConversionRate = 0.05
+)
+
+// Prior strengths, in trials: the metadata's estimate counts as much as this
+// many people reached or clicks
+const (
+	clickPriorStrength      = 2000
+	conversionPriorStrength = 100
+)
+
+// verticalWeight caps the vertical's evidence at this many prior strengths,
+// so a busy vertical informs a business without outweighing its own results
+const verticalWeight = 5
+
+// Result is one platform's reported performance for a period
+type Result struct {
+	Platform core.Platform `json:"platform"`
+	// Period is the label the export gave the row, e.g. "2025-03"; uploading
+	// a period again replaces it
+	Period string `json:"period,omitempty"`
+	Reach  int64  `json:"reach"`
+	Clicks int64  `json:"clicks"`
+	Leads  int64  `json:"leads"`
+	Sales  int64  `json:"sales"`
+}
+
+// validate checks that the counts are consistent
+func (r Result) validate() error {
+	if r.Reach < 0 || r.Clicks < 0 || r.Leads < 0 || r.Sales < 0 {
+		return fmt.Errorf("counts must not be negative")
+	}
+	if r.Clicks > r.Reach {
+		return fmt.Errorf("clicks (%d) exceed reach (%d)", r.Clicks, r.Reach)
+	}
+	return nil
+}
+
+// Totals sums a platform's reported results
+type Totals struct {
+	Results int   `json:"results"`
+	Reach   int64 `json:"reach"`
+	Clicks  int64 `json:"clicks"`
+	Leads   int64 `json:"leads"`
+	Sales   int64 `json:"sales"`
+}
+
+// conversions are leads and sales, which cannot outnumber clicks
+func (t Totals) conversions() int64 {
+	return min(t.Leads+t.Sales, t.Clicks)
+}
+
+// Vertical groups businesses whose results inform each other: the business
+// type, narrowed by the industry its description points to, e.g. "retail"
+// or "service/health"
+func Vertical(business core.BusinessInput) string {
+	parts := []string{string(business.Type)}
+	for _, vertical := range guardrails.Verticals(business) {
+		parts = append(parts, string(vertical))
+	}
+	return strings.Join(parts, "/")
+}
+
+// beta is a Beta distribution over a rate
+type beta struct {
+	alpha, beta float64
+}
+
+// prior centres a Beta distribution on mean with the strength of n trials
+func prior(mean, n float64) beta {
+	mean = math.Max(1e-4, math.Min(1-1e-4, mean))
+	return beta{alpha: mean * n, beta: (1 - mean) * n}
+}
+
+// update adds successes out of trials, each counting weight times
+func (b beta) update(successes, trials int64, weight float64) beta {
+	return beta{
+		alpha: b.alpha + weight*float64(successes),
+		beta:  b.beta + weight*float64(trials-successes),
+	}
+}
+
+// mean is the expected rate
+func (b beta) mean() float64 {
+	return b.alpha / (b.alpha + b.beta)
+}
+
+// discount is the weight that caps trials at limit
+func discount(trials int64, limit float64) float64 {
+	if float64(trials) <= limit {
+		return 1
+	}
+	return limit / float64(trials)
+}
+
+// Calibrate re-estimates reach and conversion potential for every platform
+// with results, from the business's own totals and its vertical's
+func Calibrate(own, vertical map[core.Platform]Totals) map[core.Platform]core.Calibration {
+	calibrated := make(map[core.Platform]core.Calibration)
+	for _, platform := range core.GetAllPlatformNames() {
+		mine, hasOwn := own[platform]
+		theirs, hasVertical := vertical[platform]
+		if !hasOwn && !hasVertical {
+			continue
+		}
+		metadata, ok := core.GetPlatformMetadata(platform)
+		if !ok {
+			continue
+		}
+
+		clicks := prior(referenceClickRate*float64(metadata.ReachPotential)/5, clickPriorStrength).
+			update(theirs.Clicks, theirs.Reach, discount(theirs.Reach, verticalWeight*clickPriorStrength)).
+			update(mine.Clicks, mine.Reach, 1)
+		conversions := prior(referenceConversionRate*float64(metadata.ConversionFocus)/5, conversionPriorStrength).
+			update(theirs.conversions(), theirs.Clicks, discount(theirs.Clicks, verticalWeight*conversionPriorStrength)).
+			update(mine.conversions(), mine.Clicks, 1)
+
+		calibrated[platform] = core.Calibration{
+			ReachPotential:  potential(clicks.mean(), referenceClickRate),
+			ConversionFocus: potential(conversions.mean(), referenceConversionRate),
+			Results:         mine.Results,
+			VerticalResults: theirs.Results,
+		}
+	}
+	return calibrated
+}
+
+// potential maps a rate back onto the 1-10 scale, one decimal place
+func potential(rate, reference float64) float64 {
+	return math.Round(math.Max(1, math.Min(10, 5*rate/reference))*10) / 10
+}
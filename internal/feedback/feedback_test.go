@@ -0,0 +1,74 @@
+package feedback
+
+import (
+	"testing"
+
+	"biz-flow/internal/core"
+)
+
+func TestCalibrate(t *testing.T) {
+	metadata, ok := core.GetPlatformMetadata(core.Instagram)
+	if !ok {
+		t.Fatal("no Instagram metadata")
+	}
+	reach, conversion := float64(metadata.ReachPotential), float64(metadata.ConversionFocus)
+
+	tests := []struct {
+		name     string
+		own      map[core.Platform]Totals
+		vertical map[core.Platform]Totals
+		// want is Instagram's calibration; nil when it should have none
+		want *core.Calibration
+	}{
+		{name: "no results", want: nil},
+		{
+			name: "empty results keep the metadata's estimates",
+			own:  map[core.Platform]Totals{core.Instagram: {Results: 1}},
+			want: &core.Calibration{ReachPotential: reach, ConversionFocus: conversion, Results: 1},
+		},
+		{
+			name: "strong results raise the estimates to the top of the scale",
+			own:  map[core.Platform]Totals{core.Instagram: {Results: 3, Reach: 100000, Clicks: 10000, Leads: 2000, Sales: 1000}},
+			want: &core.Calibration{ReachPotential: 10, ConversionFocus: 10, Results: 3},
+		},
+		{
+			name: "no clicks or conversions lower the estimates to the bottom",
+			own:  map[core.Platform]Totals{core.Instagram: {Results: 2, Reach: 100000, Clicks: 0}},
+			want: &core.Calibration{ReachPotential: 1, ConversionFocus: conversion, Results: 2},
+		},
+		{
+			// The vertical counts as five prior strengths however much it reached
+			name:     "vertical results alone",
+			vertical: map[core.Platform]Totals{core.Instagram: {Results: 40, Reach: 1000000, Clicks: 0}},
+			want:     &core.Calibration{ReachPotential: potential(reach/6, 5), ConversionFocus: conversion, VerticalResults: 40},
+		},
+		{
+			name:     "a busy vertical does not outweigh the business's own results",
+			own:      map[core.Platform]Totals{core.Instagram: {Results: 1, Reach: 10000, Clicks: 2000}},
+			vertical: map[core.Platform]Totals{core.Instagram: {Results: 500, Reach: 1000000000, Clicks: 0}},
+			want:     &core.Calibration{ReachPotential: 10, ConversionFocus: 1, Results: 1, VerticalResults: 500},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calibrated := Calibrate(tt.own, tt.vertical)
+			got, ok := calibrated[core.Instagram]
+			if tt.want == nil {
+				if len(calibrated) != 0 {
+					t.Fatalf("Calibrate = %v, want none", calibrated)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("Instagram is not calibrated")
+			}
+			if len(calibrated) != 1 {
+				t.Errorf("calibrated %d platforms, want only Instagram", len(calibrated))
+			}
+			if got != *tt.want {
+				t.Errorf("Calibrate = %+v, want %+v", got, *tt.want)
+			}
+		})
+	}
+}
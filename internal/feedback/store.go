@@ -0,0 +1,148 @@
+package feedback
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/sqlite"
+)
+
+const resultsSchema = `
+CREATE TABLE IF NOT EXISTS results (
+	profile     TEXT NOT NULL,
+	vertical    TEXT NOT NULL,
+	platform    TEXT NOT NULL,
+	period      TEXT NOT NULL,
+	reach       INTEGER NOT NULL,
+	clicks      INTEGER NOT NULL,
+	leads       INTEGER NOT NULL,
+	sales       INTEGER NOT NULL,
+	uploaded_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (profile, platform, period)
+);
+CREATE INDEX IF NOT EXISTS results_vertical ON results (vertical);
+`
+
+// Store keeps reported results per business profile in a SQLite database
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (creating if needed) the results database at path
+func Open(path string) (*Store, error) {
+	db, err := sqlite.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(resultsSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating results table: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Record stores results under the business's profile and vertical. A result
+// for a platform and period the profile already reported replaces it, so
+// uploading the same export twice counts it once.
+func (s *Store) Record(ctx context.Context, business core.BusinessInput, results []Result) error {
+	if business.Profile == "" {
+		return &core.ValidationError{Field: "profile", Message: "is required to record results"}
+	}
+	for _, result := range results {
+		if err := result.validate(); err != nil {
+			return &core.ValidationError{Field: "results", Message: fmt.Sprintf("%s %s: %v", result.Platform, result.Period, err)}
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	now := time.Now().UTC()
+	vertical := Vertical(business)
+	for _, result := range results {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO results (profile, vertical, platform, period, reach, clicks, leads, sales, uploaded_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (profile, platform, period) DO UPDATE SET
+				vertical = excluded.vertical, reach = excluded.reach, clicks = excluded.clicks,
+				leads = excluded.leads, sales = excluded.sales, uploaded_at = excluded.uploaded_at`,
+			business.Profile, vertical, string(result.Platform), result.Period,
+			result.Reach, result.Clicks, result.Leads, result.Sales, now,
+		); err != nil {
+			return fmt.Errorf("recording results: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("recording results: %w", err)
+	}
+	return nil
+}
+
+// Totals sums the profile's results per platform
+func (s *Store) Totals(ctx context.Context, profile string) (map[core.Platform]Totals, error) {
+	return s.totals(ctx, `WHERE profile = ?`, profile)
+}
+
+// VerticalTotals sums the results of every other profile in the vertical
+func (s *Store) VerticalTotals(ctx context.Context, vertical, exceptProfile string) (map[core.Platform]Totals, error) {
+	return s.totals(ctx, `WHERE vertical = ? AND profile != ?`, vertical, exceptProfile)
+}
+
+// totals sums the results matching where, per platform
+func (s *Store) totals(ctx context.Context, where string, args ...any) (map[core.Platform]Totals, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT platform, COUNT(*), SUM(reach), SUM(clicks), SUM(leads), SUM(sales) FROM results `+where+` GROUP BY platform`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("reading results: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make(map[core.Platform]Totals)
+	for rows.Next() {
+		var platform string
+		var t Totals
+		if err := rows.Scan(&platform, &t.Results, &t.Reach, &t.Clicks, &t.Leads, &t.Sales); err != nil {
+			return nil, fmt.Errorf("reading results: %w", err)
+		}
+		totals[core.Platform(platform)] = t
+	}
+	return totals, rows.Err()
+}
+
+// Calibration re-estimates the business's platform potential from its
+// profile's results and its vertical's. A business without a profile draws
+// on its vertical alone.
+func (s *Store) Calibration(ctx context.Context, business core.BusinessInput) (map[core.Platform]core.Calibration, error) {
+	own, vertical, err := s.evidence(ctx, business)
+	if err != nil {
+		return nil, err
+	}
+	return Calibrate(own, vertical), nil
+}
+
+// evidence reads the totals of the business's profile and of the rest of its
+// vertical
+func (s *Store) evidence(ctx context.Context, business core.BusinessInput) (own, vertical map[core.Platform]Totals, err error) {
+	own = map[core.Platform]Totals{}
+	if business.Profile != "" {
+		if own, err = s.Totals(ctx, business.Profile); err != nil {
+			return nil, nil, err
+		}
+	}
+	if vertical, err = s.VerticalTotals(ctx, Vertical(business), business.Profile); err != nil {
+		return nil, nil, err
+	}
+	return own, vertical, nil
+}
@@ -0,0 +1,81 @@
+package feedback
+
+import (
+	"context"
+	"strings"
+
+	"biz-flow/internal/core"
+)
+
+// UploadRequest is the body of POST /performance: a business with a profile
+// and its results, either as the text of a CSV export or as JSON
+type UploadRequest struct {
+	Business core.BusinessInput `json:"business"`
+	CSV      string             `json:"csv,omitempty"`
+	Results  []Result           `json:"results,omitempty"`
+}
+
+// Parse returns the request's results
+func (r UploadRequest) Parse() ([]Result, error) {
+	switch {
+	case r.CSV != "" && len(r.Results) > 0:
+		return nil, &core.ValidationError{Field: "csv", Message: "send either csv or results, not both"}
+	case r.CSV != "":
+		return ReadCSV(strings.NewReader(r.CSV))
+	case len(r.Results) > 0:
+		for _, result := range r.Results {
+			if _, ok := core.GetPlatformMetadata(result.Platform); !ok {
+				return nil, &core.ValidationError{Field: "results", Message: "unknown platform " + string(result.Platform)}
+			}
+		}
+		return r.Results, nil
+	}
+	return nil, &core.ValidationError{Field: "results", Message: "must not be empty"}
+}
+
+// Summary compares each platform's catalog estimate with what the reported
+// results say
+type Summary struct {
+	Profile  string `json:"profile,omitempty"`
+	Vertical string `json:"vertical"`
+	// Recorded is how many results the upload stored
+	Recorded  int               `json:"recorded,omitempty"`
+	Platforms []PlatformSummary `json:"platforms"`
+}
+
+// PlatformSummary is one platform's estimate before and after the results
+type PlatformSummary struct {
+	Platform          core.Platform    `json:"platform"`
+	CatalogReach      int              `json:"catalog_reach"`
+	CatalogConversion int              `json:"catalog_conversion"`
+	Calibration       core.Calibration `json:"calibration"`
+	// Totals are the profile's own results
+	Totals Totals `json:"totals"`
+}
+
+// Summarize lists the calibrated platforms for the business, in catalog
+// order
+func (s *Store) Summarize(ctx context.Context, business core.BusinessInput) (*Summary, error) {
+	own, vertical, err := s.evidence(ctx, business)
+	if err != nil {
+		return nil, err
+	}
+	calibration := Calibrate(own, vertical)
+
+	summary := &Summary{Profile: business.Profile, Vertical: Vertical(business), Platforms: []PlatformSummary{}}
+	for _, platform := range core.GetAllPlatformNames() {
+		calibrated, ok := calibration[platform]
+		if !ok {
+			continue
+		}
+		metadata, _ := core.GetPlatformMetadata(platform)
+		summary.Platforms = append(summary.Platforms, PlatformSummary{
+			Platform:          platform,
+			CatalogReach:      metadata.ReachPotential,
+			CatalogConversion: metadata.ConversionFocus,
+			Calibration:       calibrated,
+			Totals:            own[platform],
+		})
+	}
+	return summary, nil
+}
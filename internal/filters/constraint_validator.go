@@ -1,16 +1,23 @@
 package filters
 
 import (
-	"fmt"
 	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
 )
 
 // ConstraintValidator validates specific constraints for platforms
-type ConstraintValidator struct{}
+type ConstraintValidator struct {
+	locale core.Locale // Language the reasons are written in
+}
 
 // NewConstraintValidator creates a new constraint validator
 func NewConstraintValidator() *ConstraintValidator {
-	return &ConstraintValidator{}
+	return &ConstraintValidator{locale: core.English}
+}
+
+// ForLocale returns a validator that writes its reasons in the locale
+func (cv *ConstraintValidator) ForLocale(locale core.Locale) *ConstraintValidator {
+	return &ConstraintValidator{locale: locale}
 }
 
 // BudgetConstraint represents the result of a budget validation
@@ -36,7 +43,7 @@ func (cv *ConstraintValidator) ValidateBudgetConstraints(
 	if !exists {
 		return BudgetConstraint{
 			IsValid: false,
-			Reason:  "Platform metadata not found",
+			Reason:  i18n.T(cv.locale, "constraint.unknown_platform"),
 			Penalty: 1.0,
 		}
 	}
@@ -45,8 +52,9 @@ func (cv *ConstraintValidator) ValidateBudgetConstraints(
 	if budget < metadata.MinBudget {
 		return BudgetConstraint{
 			IsValid: false,
-			Reason: fmt.Sprintf(
-				"Budget ($%.2f/month) is below minimum required ($%.2f/month) for %s",
+			Reason: i18n.T(
+				cv.locale,
+				"constraint.budget.below_minimum",
 				budget,
 				metadata.MinBudget,
 				platform,
@@ -61,13 +69,13 @@ func (cv *ConstraintValidator) ValidateBudgetConstraints(
 		if !metadata.IsOrganic {
 			return BudgetConstraint{
 				IsValid: true,
-				Reason:  "Low budget makes paid platforms less effective",
+				Reason:  i18n.T(cv.locale, "constraint.budget.low_paid"),
 				Penalty: 0.7, // Heavy penalty for paid platforms
 			}
 		}
 		return BudgetConstraint{
 			IsValid: true,
-			Reason:  "Perfect fit for low-budget organic marketing",
+			Reason:  i18n.T(cv.locale, "constraint.budget.low_organic"),
 			Penalty: 0.0,
 		}
 	}
@@ -77,13 +85,13 @@ func (cv *ConstraintValidator) ValidateBudgetConstraints(
 		if metadata.IsPaid && !metadata.IsOrganic {
 			return BudgetConstraint{
 				IsValid: true,
-				Reason:  "Medium budget can support limited paid advertising",
+				Reason:  i18n.T(cv.locale, "constraint.budget.medium_paid"),
 				Penalty: 0.3, // Soft penalty for paid-only platforms
 			}
 		}
 		return BudgetConstraint{
 			IsValid: true,
-			Reason:  "Good budget for consistent organic presence",
+			Reason:  i18n.T(cv.locale, "constraint.budget.medium_organic"),
 			Penalty: 0.0,
 		}
 	}
@@ -91,7 +99,7 @@ func (cv *ConstraintValidator) ValidateBudgetConstraints(
 	// High budget (>$200): All platforms viable
 	return BudgetConstraint{
 		IsValid: true,
-		Reason:  "Budget supports both organic and paid strategies",
+		Reason:  i18n.T(cv.locale, "constraint.budget.high"),
 		Penalty: 0.0,
 	}
 }
@@ -105,7 +113,7 @@ func (cv *ConstraintValidator) ValidateEffortConstraints(
 	if !exists {
 		return EffortConstraint{
 			IsValid: false,
-			Reason:  "Platform metadata not found",
+			Reason:  i18n.T(cv.locale, "constraint.unknown_platform"),
 			Penalty: 1.0,
 		}
 	}
@@ -116,7 +124,7 @@ func (cv *ConstraintValidator) ValidateEffortConstraints(
 		if business.Type == core.Retail {
 			return EffortConstraint{
 				IsValid: true,
-				Reason:  "Visual products are well-suited for video content",
+				Reason:  i18n.T(cv.locale, "constraint.effort.video_retail"),
 				Penalty: 0.2, // Small penalty (video still takes effort)
 			}
 		}
@@ -124,7 +132,7 @@ func (cv *ConstraintValidator) ValidateEffortConstraints(
 		// Service and Digital businesses: video is harder
 		return EffortConstraint{
 			IsValid: true,
-			Reason:  "Video content requires significant production effort for service/digital businesses",
+			Reason:  i18n.T(cv.locale, "constraint.effort.video_other"),
 			Penalty: 0.6, // Moderate-to-heavy penalty
 		}
 	}
@@ -134,25 +142,25 @@ func (cv *ConstraintValidator) ValidateEffortConstraints(
 	case core.HighEffort:
 		return EffortConstraint{
 			IsValid: true,
-			Reason:  "High-effort platform may strain micro-business resources",
+			Reason:  i18n.T(cv.locale, "constraint.effort.high"),
 			Penalty: 0.4,
 		}
 	case core.MediumEffort:
 		return EffortConstraint{
 			IsValid: true,
-			Reason:  "Moderate effort required, manageable for consistent posting",
+			Reason:  i18n.T(cv.locale, "constraint.effort.medium"),
 			Penalty: 0.1,
 		}
 	case core.LowEffort:
 		return EffortConstraint{
 			IsValid: true,
-			Reason:  "Low-effort platform, ideal for resource-constrained businesses",
+			Reason:  i18n.T(cv.locale, "constraint.effort.low"),
 			Penalty: 0.0,
 		}
 	default:
 		return EffortConstraint{
 			IsValid: true,
-			Reason:  "Unknown effort level",
+			Reason:  i18n.T(cv.locale, "constraint.effort.unknown"),
 			Penalty: 0.0,
 		}
 	}
@@ -167,7 +175,7 @@ func (cv *ConstraintValidator) ValidateVisualRequirements(
 	if !exists {
 		return EffortConstraint{
 			IsValid: false,
-			Reason:  "Platform metadata not found",
+			Reason:  i18n.T(cv.locale, "constraint.unknown_platform"),
 			Penalty: 1.0,
 		}
 	}
@@ -176,7 +184,7 @@ func (cv *ConstraintValidator) ValidateVisualRequirements(
 		// Platform doesn't require visuals, always valid
 		return EffortConstraint{
 			IsValid: true,
-			Reason:  "Platform works well with text-based content",
+			Reason:  i18n.T(cv.locale, "constraint.visuals.text"),
 			Penalty: 0.0,
 		}
 	}
@@ -187,27 +195,27 @@ func (cv *ConstraintValidator) ValidateVisualRequirements(
 		// Retail products are inherently visual
 		return EffortConstraint{
 			IsValid: true,
-			Reason:  "Retail products provide natural visual content opportunities",
+			Reason:  i18n.T(cv.locale, "constraint.visuals.retail"),
 			Penalty: 0.0,
 		}
 	case core.Service:
 		// Services can show before/after, team photos, etc.
 		return EffortConstraint{
 			IsValid: true,
-			Reason:  "Services can create visual content (before/after, testimonials, team)",
+			Reason:  i18n.T(cv.locale, "constraint.visuals.service"),
 			Penalty: 0.2,
 		}
 	case core.Digital:
 		// Digital products/services may struggle with visuals
 		return EffortConstraint{
 			IsValid: true,
-			Reason:  "Digital products may require creative approaches to visual content",
+			Reason:  i18n.T(cv.locale, "constraint.visuals.digital"),
 			Penalty: 0.3,
 		}
 	default:
 		return EffortConstraint{
 			IsValid: true,
-			Reason:  "Unknown business type",
+			Reason:  i18n.T(cv.locale, "constraint.visuals.unknown"),
 			Penalty: 0.0,
 		}
 	}
@@ -222,7 +230,7 @@ func (cv *ConstraintValidator) ValidateGoalAlignment(
 	if !exists {
 		return BudgetConstraint{
 			IsValid: false,
-			Reason:  "Platform metadata not found",
+			Reason:  i18n.T(cv.locale, "constraint.unknown_platform"),
 			Penalty: 1.0,
 		}
 	}
@@ -233,19 +241,19 @@ func (cv *ConstraintValidator) ValidateGoalAlignment(
 		if metadata.ReachPotential >= 8 {
 			return BudgetConstraint{
 				IsValid: true,
-				Reason:  "Excellent reach potential for awareness campaigns",
+				Reason:  i18n.T(cv.locale, "constraint.goal.awareness.high"),
 				Penalty: 0.0,
 			}
 		} else if metadata.ReachPotential >= 6 {
 			return BudgetConstraint{
 				IsValid: true,
-				Reason:  "Moderate reach potential for awareness",
+				Reason:  i18n.T(cv.locale, "constraint.goal.awareness.medium"),
 				Penalty: 0.2,
 			}
 		}
 		return BudgetConstraint{
 			IsValid: true,
-			Reason:  "Limited reach potential for awareness goals",
+			Reason:  i18n.T(cv.locale, "constraint.goal.awareness.low"),
 			Penalty: 0.4,
 		}
 
@@ -254,26 +262,26 @@ func (cv *ConstraintValidator) ValidateGoalAlignment(
 		if metadata.ConversionFocus >= 8 {
 			return BudgetConstraint{
 				IsValid: true,
-				Reason:  "Excellent conversion potential for sales goals",
+				Reason:  i18n.T(cv.locale, "constraint.goal.sales.high"),
 				Penalty: 0.0,
 			}
 		} else if metadata.ConversionFocus >= 6 {
 			return BudgetConstraint{
 				IsValid: true,
-				Reason:  "Moderate conversion potential for sales",
+				Reason:  i18n.T(cv.locale, "constraint.goal.sales.medium"),
 				Penalty: 0.2,
 			}
 		}
 		return BudgetConstraint{
 			IsValid: true,
-			Reason:  "Limited conversion potential for direct sales",
+			Reason:  i18n.T(cv.locale, "constraint.goal.sales.low"),
 			Penalty: 0.4,
 		}
 
 	default:
 		return BudgetConstraint{
 			IsValid: true,
-			Reason:  "Unknown goal",
+			Reason:  i18n.T(cv.locale, "constraint.goal.unknown"),
 			Penalty: 0.0,
 		}
 	}
@@ -2,6 +2,7 @@ package filters
 
 import (
 	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
 )
 
 // PlatformFilter handles filtering platforms based on business characteristics
@@ -146,51 +147,40 @@ func (pf *PlatformFilter) GetFilteredCount(business core.BusinessInput) int {
 	return len(pf.ApplyAllFilters(business))
 }
 
-// ExplainFiltering returns a human-readable explanation of why platforms were filtered
+// ExplainFiltering returns a human-readable explanation of why platforms were filtered,
+// written in the business's locale
 func (pf *PlatformFilter) ExplainFiltering(business core.BusinessInput) map[string]string {
 	explanations := make(map[string]string)
+	locale := business.Language()
 	
 	// Business type filtering
-	explanations["business_type"] = string(business.Type) + " businesses are best suited for " + 
-		formatPlatforms(pf.FilterByBusinessType(business.Type))
+	explanations["business_type"] = i18n.T(locale, "filtering.business_type",
+		i18n.BusinessType(locale, business.Type), formatPlatforms(locale, pf.FilterByBusinessType(business.Type)))
 	
 	// Budget filtering
 	if business.HasLowBudget() {
-		explanations["budget"] = "Low budget (<$50/month) limits platforms to organic-only channels"
+		explanations["budget"] = i18n.T(locale, "filtering.budget.low")
 	} else if business.HasMediumBudget() {
-		explanations["budget"] = "Medium budget ($50-$200/month) allows organic and some paid channels"
+		explanations["budget"] = i18n.T(locale, "filtering.budget.medium")
 	} else {
-		explanations["budget"] = "High budget (>$200/month) enables all channel types including paid advertising"
+		explanations["budget"] = i18n.T(locale, "filtering.budget.high")
 	}
 	
 	// Location filtering
 	if business.IsLocal() {
-		explanations["location"] = "Local business benefits from location-based platforms like Google My Business"
+		explanations["location"] = i18n.T(locale, "filtering.location.local")
 	} else if business.IsOnlineOnly() {
-		explanations["location"] = "Online-only business can leverage any platform regardless of location"
+		explanations["location"] = i18n.T(locale, "filtering.location.online")
 	}
 	
 	return explanations
 }
 
-// formatPlatforms converts a slice of platforms to a readable string
-func formatPlatforms(platforms []core.Platform) string {
-	if len(platforms) == 0 {
-		return "none"
-	}
-	if len(platforms) == 1 {
-		return string(platforms[0])
-	}
-	
-	result := ""
-	for i, p := range platforms {
-		if i == len(platforms)-1 {
-			result += "and " + string(p)
-		} else if i == len(platforms)-2 {
-			result += string(p) + " "
-		} else {
-			result += string(p) + ", "
-		}
+// formatPlatforms converts a slice of platforms to a readable list in the locale
+func formatPlatforms(locale core.Locale, platforms []core.Platform) string {
+	names := make([]string, 0, len(platforms))
+	for _, p := range platforms {
+		names = append(names, string(p))
 	}
-	return result
-}
\ No newline at end of file
+	return i18n.List(locale, names)
+}
@@ -0,0 +1,249 @@
+package guardrails
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
+)
+
+// Checker applies content policy rules to generated templates. It runs
+// entirely offline on pattern and keyword rules.
+type Checker struct {
+	rules []Rule
+}
+
+// NewChecker creates a checker with the built-in rule set
+func NewChecker() *Checker {
+	return &Checker{rules: defaultRules()}
+}
+
+// Check applies every rule for the platform and the business's verticals to
+// a copy of the template. It returns the cleaned template and what was found.
+func (c *Checker) Check(
+	business core.BusinessInput,
+	platform core.Platform,
+	template *core.ContentTemplate,
+) (*core.ContentTemplate, []core.PolicyFinding) {
+	if template == nil {
+		return nil, nil
+	}
+	checked := *template
+	checked.Hashtags = append([]string{}, template.Hashtags...)
+
+	locale := business.Language()
+	verticals := Verticals(business)
+	input := strings.ToLower(business.Description + " " + strings.Join(business.Channels, " "))
+
+	var findings []core.PolicyFinding
+	for _, rule := range c.rules {
+		if !rule.appliesTo(platform, verticals) {
+			continue
+		}
+		finding := func(field, match string, action core.PolicyAction) {
+			findings = append(findings, core.PolicyFinding{
+				Rule:     rule.ID,
+				Platform: platform,
+				Field:    field,
+				Match:    strings.TrimSpace(match),
+				Action:   action,
+				Concern:  rule.concern(locale),
+			})
+		}
+		allowed := func(match string) bool {
+			return rule.AllowFromInput && strings.Contains(input, strings.ToLower(strings.TrimSpace(match)))
+		}
+
+		if rule.Hashtags {
+			kept := checked.Hashtags[:0]
+			for _, tag := range checked.Hashtags {
+				switch {
+				case !rule.Pattern.MatchString(tag) || allowed(tag):
+					kept = append(kept, tag)
+				case rule.Action == Flag:
+					finding("hashtags", tag, core.PolicyFlagged)
+					kept = append(kept, tag)
+				default:
+					finding("hashtags", tag, core.PolicyRemoved)
+				}
+			}
+			checked.Hashtags = kept
+			continue
+		}
+
+		for _, field := range []struct {
+			name string
+			text *string
+		}{
+			{"hook", &checked.Hook},
+			{"caption", &checked.Caption},
+			{"cta", &checked.CTA},
+		} {
+			var matches []string
+			for _, match := range rule.Pattern.FindAllString(*field.text, -1) {
+				if !allowed(match) && !covered(findings, field.name, match) {
+					matches = append(matches, match)
+				}
+			}
+			if len(matches) == 0 {
+				continue
+			}
+
+			action := core.PolicyFlagged
+			switch rule.Action {
+			case Rewrite:
+				if replacement, ok := rule.replacement(locale); ok {
+					*field.text = rewrite(*field.text, rule.Pattern, replacement)
+					action = core.PolicyRewritten
+				}
+			case Remove:
+				if kept := removeSentences(*field.text, rule); kept != "" {
+					*field.text = kept
+					action = core.PolicyRemoved
+				}
+			}
+			for _, match := range matches {
+				finding(field.name, match, action)
+			}
+		}
+	}
+	return &checked, findings
+}
+
+// Risks turns the findings left in the text into risks for the owner to
+// review, in the locale. Rewritten and removed text needs no attention.
+func Risks(locale core.Locale, findings []core.PolicyFinding) []string {
+	var risks []string
+	seen := make(map[string]bool)
+	for _, finding := range findings {
+		if finding.Action != core.PolicyFlagged {
+			continue
+		}
+		risk := i18n.T(locale, "policy.review", finding.Platform, i18n.T(locale, "policy.field."+finding.Field), finding.Match, finding.Concern)
+		if !seen[risk] {
+			seen[risk] = true
+			risks = append(risks, risk)
+		}
+	}
+	return risks
+}
+
+// covered reports whether a flagged finding in the field already includes the
+// match, so "guaranteed returns" is not also reported as a plain guarantee
+func covered(findings []core.PolicyFinding, field, match string) bool {
+	for _, finding := range findings {
+		if finding.Field == field && finding.Action == core.PolicyFlagged &&
+			strings.Contains(strings.ToLower(finding.Match), strings.ToLower(strings.TrimSpace(match))) {
+			return true
+		}
+	}
+	return false
+}
+
+// appliesTo reports whether the rule covers the platform and any of the
+// business's verticals
+func (r Rule) appliesTo(platform core.Platform, verticals []Vertical) bool {
+	if len(r.Platforms) > 0 && !containsPlatform(r.Platforms, platform) {
+		return false
+	}
+	if containsPlatform(r.ExceptPlatforms, platform) {
+		return false
+	}
+	if len(r.Verticals) == 0 {
+		return true
+	}
+	for _, want := range r.Verticals {
+		for _, vertical := range verticals {
+			if want == vertical {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsPlatform(platforms []core.Platform, platform core.Platform) bool {
+	for _, p := range platforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
+
+// rewrite replaces every match, keeping a sentence-initial capital
+func rewrite(text string, pattern *regexp.Regexp, replacement string) string {
+	rewritten := tidy(pattern.ReplaceAllString(text, replacement))
+	if first, _ := utf8.DecodeRuneInString(text); unicode.IsUpper(first) {
+		rewritten = capitalize(rewritten)
+	}
+	return rewritten
+}
+
+// removeSentences drops every sentence the rule matches
+func removeSentences(text string, rule Rule) string {
+	var kept []string
+	for _, sentence := range splitSentences(text) {
+		if !rule.Pattern.MatchString(sentence) {
+			kept = append(kept, sentence)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// splitSentences splits text after sentence-ending punctuation followed by
+// whitespace, keeping the punctuation. Abbreviations such as "Dr." do not
+// end a sentence.
+func splitSentences(text string) []string {
+	var sentences []string
+	start := 0
+	runes := []rune(text)
+	for i, r := range runes {
+		if (r == '.' || r == '!' || r == '?' || r == '।') && i+1 < len(runes) && unicode.IsSpace(runes[i+1]) {
+			if r == '.' && !endsSentence(runes[start:i], runes[i+1:]) {
+				continue
+			}
+			sentences = append(sentences, strings.TrimSpace(string(runes[start:i+1])))
+			start = i + 1
+		}
+	}
+	if rest := strings.TrimSpace(string(runes[start:])); rest != "" {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
+
+// abbreviations end in a period without ending the sentence
+var abbreviations = map[string]bool{
+	"dr": true, "mr": true, "mrs": true, "ms": true, "prof": true, "st": true, "sr": true, "jr": true,
+	"no": true, "vs": true, "etc": true, "e.g": true, "i.e": true, "a.m": true, "p.m": true,
+}
+
+// endsSentence reports whether a period after before ends the sentence: not
+// after a known abbreviation, and not when the text goes on in lower case
+func endsSentence(before, after []rune) bool {
+	words := strings.Fields(string(before))
+	if len(words) > 0 && abbreviations[strings.ToLower(strings.TrimLeft(words[len(words)-1], "(\"'"))] {
+		return false
+	}
+	next := strings.TrimLeftFunc(string(after), unicode.IsSpace)
+	first, _ := utf8.DecodeRuneInString(next)
+	return !unicode.IsLower(first)
+}
+
+// tidy collapses the whitespace a replacement leaves behind
+func tidy(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,313 @@
+package guardrails
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"biz-flow/internal/core"
+)
+
+var shop = core.BusinessInput{
+	Type:        core.Retail,
+	Description: "Handmade ceramic mugs",
+	Location:    "Austin, TX",
+	Budget:      80,
+	Goal:        core.Awareness,
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string // replaces the shop's description when set
+		locale      core.Locale
+		platform    core.Platform
+		caption     string
+		hashtags    []string
+		want        string
+		wantTags    []string
+		findings    []string // rule/action/match
+	}{
+		{
+			name:     "no rule matches",
+			platform: core.Instagram,
+			caption:  "Fresh mugs from the kiln this week. Come say hi!",
+			want:     "Fresh mugs from the kiln this week. Come say hi!",
+		},
+		{
+			name:     "ranking and rating rewritten",
+			platform: core.Instagram,
+			caption:  "We're the #1 mug shop and top-rated in town.",
+			want:     "We're a favorite mug shop and well-reviewed in town.",
+			findings: []string{"unverifiable-ranking/rewritten/the #1", "unverifiable-rating/rewritten/top-rated"},
+		},
+		{
+			name:     "rewrite keeps a leading capital",
+			platform: core.Facebook,
+			caption:  "Our No. 1 glaze is back.",
+			want:     "A favorite glaze is back.",
+			findings: []string{"unverifiable-ranking/rewritten/Our No. 1"},
+		},
+		{
+			name:     "rewrite in the locale",
+			locale:   core.Spanish,
+			platform: core.Instagram,
+			caption:  "Nuestro pan es #1 en Austin.",
+			want:     "Nuestro pan es uno de los favoritos en Austin.",
+			findings: []string{"unverifiable-ranking/rewritten/#1"},
+		},
+		{
+			name:     "locale without a replacement flags",
+			locale:   core.Swahili,
+			platform: core.Instagram,
+			caption:  "Sisi ni #1 Austin.",
+			want:     "Sisi ni #1 Austin.",
+			findings: []string{"unverifiable-ranking/flagged/#1"},
+		},
+		{
+			name:     "comparison sentence removed",
+			platform: core.Instagram,
+			caption:  "Better than Starbucks Mugs and cheaper too. Open daily.",
+			want:     "Open daily.",
+			findings: []string{"competitor-comparison/removed/Better than Starbucks Mugs"},
+		},
+		{
+			name:     "final sentence without a terminator removed",
+			platform: core.Instagram,
+			caption:  "Open daily! Fresher than Dunkin",
+			want:     "Open daily!",
+			findings: []string{"competitor-comparison/removed/Fresher than Dunkin"},
+		},
+		{
+			name:        "abbreviation does not split the sentence",
+			description: "Skin clinic",
+			platform:    core.Instagram,
+			caption:     "Ask Dr. Lee how our cream cures acne. Book now.",
+			want:        "Book now.",
+			findings:    []string{"health-claim/removed/cures acne"},
+		},
+		{
+			name:        "abbreviation kept in a clean sentence",
+			description: "Skin clinic",
+			platform:    core.Instagram,
+			caption:     "Visit Dr. Lee today. Our cream cures acne fast. Book now.",
+			want:        "Visit Dr. Lee today. Book now.",
+			findings:    []string{"health-claim/removed/cures acne"},
+		},
+		{
+			name:     "nothing left after removal is flagged",
+			platform: core.Instagram,
+			caption:  "Our cream cures acne fast",
+			want:     "Our cream cures acne fast",
+			findings: []string{"health-claim/flagged/cures acne"},
+		},
+		{
+			name:     "lenient platform flags",
+			platform: core.Email,
+			caption:  "Our cream cures acne fast. Reply to book.",
+			want:     "Our cream cures acne fast. Reply to book.",
+			findings: []string{"health-claim/flagged/cures acne"},
+		},
+		{
+			name:     "overlapping matches reported once",
+			platform: core.Email,
+			caption:  "Guaranteed returns on every order.",
+			want:     "Guaranteed returns on every order.",
+			findings: []string{"financial-promise/flagged/Guaranteed returns"},
+		},
+		{
+			name:     "every match in a field",
+			platform: core.WhatsApp,
+			caption:  "Guaranteed returns or 20% returns!",
+			want:     "Guaranteed returns or 20% returns!",
+			findings: []string{"financial-promise/flagged/Guaranteed returns", "financial-promise/flagged/20% returns"},
+		},
+		{
+			name:        "wording from the owner's input allowed",
+			description: "Organic sourdough bakery",
+			platform:    core.Instagram,
+			caption:     "Organic loaves, gluten-free rolls.",
+			want:        "Organic loaves, gluten-free rolls.",
+			findings:    []string{"dietary-claim/flagged/gluten-free"},
+		},
+		{
+			name:        "vertical rules skip other businesses",
+			description: "Handmade ceramic mugs",
+			platform:    core.Instagram,
+			caption:     "Organic glazes only.",
+			want:        "Organic glazes only.",
+		},
+		{
+			name:     "platform-scoped rewrite",
+			platform: core.Email,
+			caption:  "New mugs are in!!!",
+			want:     "New mugs are in!",
+			findings: []string{"email-exclamations/rewritten/!!!"},
+		},
+		{
+			name:     "engagement bait hashtags removed",
+			platform: core.Instagram,
+			caption:  "New mugs are in.",
+			hashtags: []string{"#mugs", "f4f", "#pottery"},
+			want:     "New mugs are in.",
+			wantTags: []string{"#mugs", "#pottery"},
+			findings: []string{"engagement-bait-hashtag/removed/f4f"},
+		},
+		{
+			name:     "hashtag rules scoped to platforms",
+			platform: core.LinkedIn,
+			caption:  "New mugs are in.",
+			hashtags: []string{"f4f"},
+			want:     "New mugs are in.",
+			wantTags: []string{"f4f"},
+		},
+	}
+
+	checker := NewChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			business := shop
+			if tt.description != "" {
+				business.Description = tt.description
+			}
+			business.Locale = tt.locale
+			template := &core.ContentTemplate{Hook: "Hello", Caption: tt.caption, CTA: "Visit us", Hashtags: tt.hashtags}
+			checked, findings := checker.Check(business, tt.platform, template)
+
+			if checked.Caption != tt.want {
+				t.Errorf("Caption = %q, want %q", checked.Caption, tt.want)
+			}
+			if len(checked.Hashtags) > 0 || len(tt.wantTags) > 0 {
+				if !reflect.DeepEqual(checked.Hashtags, tt.wantTags) {
+					t.Errorf("Hashtags = %q, want %q", checked.Hashtags, tt.wantTags)
+				}
+			}
+			if template.Caption != tt.caption {
+				t.Errorf("template changed to %q, want a copy checked", template.Caption)
+			}
+
+			var got []string
+			for _, finding := range findings {
+				got = append(got, fmt.Sprintf("%s/%s/%s", finding.Rule, finding.Action, finding.Match))
+				if finding.Field != "caption" && finding.Field != "hashtags" {
+					t.Errorf("finding in %s, want caption or hashtags", finding.Field)
+				}
+				if finding.Platform != tt.platform || finding.Concern == "" {
+					t.Errorf("finding = %+v, want platform %s and a concern", finding, tt.platform)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.findings) {
+				t.Errorf("findings = %q, want %q", got, tt.findings)
+			}
+		})
+	}
+
+	if checked, findings := checker.Check(shop, core.Instagram, nil); checked != nil || findings != nil {
+		t.Errorf("Check(nil) = %v, %v, want nil", checked, findings)
+	}
+}
+
+func TestLocalizedReplacements(t *testing.T) {
+	for _, rule := range defaultRules() {
+		if rule.Action != Rewrite {
+			continue
+		}
+		replacement, ok := rule.replacement(core.English)
+		if !ok || replacement == "" {
+			t.Errorf("%s has no English replacement", rule.ID)
+		}
+		if !rule.Localized {
+			continue
+		}
+		for _, locale := range core.Locales() {
+			replacement, ok := rule.replacement(locale)
+			if ok && (replacement == "" || strings.ContainsAny(replacement, "%$")) {
+				t.Errorf("%s replacement in %s = %q, want plain text", rule.ID, locale, replacement)
+			}
+			if english, _ := rule.replacement(core.English); ok && locale != core.English && replacement == english {
+				t.Errorf("%s replacement in %s is the English text", rule.ID, locale)
+			}
+		}
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"One sentence", []string{"One sentence"}},
+		{"One. Two! Three? Four", []string{"One.", "Two!", "Three?", "Four"}},
+		{"  Spaced.   Out.  ", []string{"Spaced.", "Out."}},
+		{"Prices from $4.50 today.", []string{"Prices from $4.50 today."}},
+		{"Ask Dr. Lee. Or Mrs. Ng.", []string{"Ask Dr. Lee.", "Or Mrs. Ng."}},
+		{"Open 9 a.m. to 5 p.m. daily. Closed Sundays.", []string{"Open 9 a.m. to 5 p.m. daily.", "Closed Sundays."}},
+		{"Mugs, bowls, etc. and more.", []string{"Mugs, bowls, etc. and more."}},
+		{"ताज़ा ब्रेड। आज ही आएं।", []string{"ताज़ा ब्रेड।", "आज ही आएं।"}},
+	}
+
+	for _, tt := range tests {
+		if got := splitSentences(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitSentences(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRewriteAndRemove(t *testing.T) {
+	rules := make(map[string]Rule)
+	for _, rule := range defaultRules() {
+		rules[rule.ID] = rule
+	}
+
+	tests := []struct {
+		rule   string
+		remove bool
+		text   string
+		want   string
+	}{
+		{rule: "unverifiable-ranking", text: "the #1 and number one shop", want: "a favorite and a favorite shop"},
+		{rule: "unverifiable-ranking", text: "The #1 shop", want: "A favorite shop"},
+		{rule: "unverifiable-rating", text: "Top-rated,  best rated mugs", want: "Well-reviewed, well-reviewed mugs"},
+		{rule: "unverifiable-rating", text: "no ratings here", want: "no ratings here"},
+		{rule: "competitor-comparison", remove: true, text: "Cheaper than Etsy. Faster than Amazon Prime! Fresh daily.", want: "Fresh daily."},
+		{rule: "competitor-comparison", remove: true, text: "Cheaper than Etsy", want: ""},
+		{rule: "competitor-comparison", remove: true, text: "Nothing to remove here", want: "Nothing to remove here"},
+		{rule: "phone-number", remove: true, text: "Call 555-123-4567. Walk-ins welcome", want: "Walk-ins welcome"},
+	}
+
+	for _, tt := range tests {
+		rule, ok := rules[tt.rule]
+		if !ok {
+			t.Fatalf("no rule %s", tt.rule)
+		}
+		var got string
+		if tt.remove {
+			got = removeSentences(tt.text, rule)
+		} else {
+			replacement, _ := rule.replacement(core.English)
+			got = rewrite(tt.text, rule.Pattern, replacement)
+		}
+		if got != tt.want {
+			t.Errorf("%s(%q) = %q, want %q", tt.rule, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRisks(t *testing.T) {
+	findings := []core.PolicyFinding{
+		{Rule: "guarantee", Platform: core.Email, Field: "caption", Match: "risk-free", Action: core.PolicyFlagged, Concern: "is a promise"},
+		{Rule: "guarantee", Platform: core.Email, Field: "caption", Match: "risk-free", Action: core.PolicyFlagged, Concern: "is a promise"},
+		{Rule: "unverifiable-rating", Platform: core.Email, Field: "caption", Match: "top-rated", Action: core.PolicyRewritten},
+		{Rule: "health-claim", Platform: core.Email, Field: "hook", Match: "cures acne", Action: core.PolicyRemoved},
+	}
+
+	risks := Risks(core.English, findings)
+	if len(risks) != 1 {
+		t.Fatalf("Risks = %q, want only the flagged finding once", risks)
+	}
+	if !strings.Contains(risks[0], "risk-free") || !strings.Contains(risks[0], "is a promise") {
+		t.Errorf("Risks = %q, want the match and concern", risks[0])
+	}
+}
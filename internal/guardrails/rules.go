@@ -0,0 +1,191 @@
+package guardrails
+
+import (
+	"regexp"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
+)
+
+// Action is what the checker does when a rule matches
+type Action int
+
+const (
+	// Flag leaves the text alone and reports the concern as a risk
+	Flag Action = iota
+	// Rewrite replaces the match with the rule's replacement
+	Rewrite
+	// Remove drops the sentence or hashtag containing the match. A field
+	// with nothing left is flagged instead.
+	Remove
+)
+
+// Rule is one content policy, scoped to platforms and verticals
+type Rule struct {
+	ID      string
+	Pattern *regexp.Regexp
+	Action  Action
+	// Replacement is used by Rewrite and may refer to submatches as $1
+	Replacement string
+	// Localized takes the replacement from the message catalogs under
+	// policy.replacement.<rule ID> instead. Locales without a translation
+	// flag the match rather than rewrite it in the wrong language.
+	Localized bool
+	// Hashtags makes the rule match each hashtag instead of the hook,
+	// caption and CTA
+	Hashtags bool
+	// Platforms and Verticals limit where the rule applies; empty means
+	// everywhere. ExceptPlatforms excludes platforms from that.
+	Platforms       []core.Platform
+	ExceptPlatforms []core.Platform
+	Verticals       []Vertical
+	// AllowFromInput skips matches the owner used in their own description
+	// or channels, e.g. a bakery that describes itself as organic
+	AllowFromInput bool
+}
+
+// concern completes the sentence `"<match>" ...` in the risk shown to the
+// owner. The text lives in the message catalogs under policy.<rule ID>.
+func (r Rule) concern(locale core.Locale) string {
+	return i18n.T(locale, "policy."+r.ID)
+}
+
+// replacement returns the rewrite text for the locale, or false when the
+// locale's catalog has no translation of it
+func (r Rule) replacement(locale core.Locale) (string, bool) {
+	if !r.Localized {
+		return r.Replacement, true
+	}
+	replacement, ok := i18n.Catalogs()[locale]["policy.replacement."+r.ID]
+	return replacement, ok
+}
+
+// adPlatforms enforce advertising policies on organic posts too, so rule
+// breaks there are removed rather than flagged
+var adPlatforms = []core.Platform{
+	core.Instagram, core.Facebook, core.TikTok, core.GoogleBusiness, core.LinkedIn, core.YouTube,
+}
+
+// strictOnAds splits a rule into a Remove rule for ad platforms and a Flag
+// rule everywhere else, such as messages to people who opted in
+func strictOnAds(rule Rule) []Rule {
+	strict, lenient := rule, rule
+	strict.Action, strict.Platforms = Remove, adPlatforms
+	lenient.Action, lenient.ExceptPlatforms = Flag, adPlatforms
+	return []Rule{strict, lenient}
+}
+
+// Patterns shared by the strict and lenient variants of a rule
+var (
+	healthClaim = regexp.MustCompile(`(?i)\b(?:cures?|heals?|treats?|prevents?|reverses?)\s+(?:\w+\s+){0,2}?` +
+		`(?:cancer|diabetes|anxiety|depression|disease|acne|pain|illness|arthritis|insomnia|infections?)\b|` +
+		`\bclinically\s+proven\b|\bfda[\s-]approved\b|\bmiracle\b|\bdetox\w*\b|\blose\s+\d+\s*(?:lbs?|pounds|kg)\b`)
+	financialPromise = regexp.MustCompile(`(?i)\bguaranteed\s+(?:returns?|income|profits?|savings|refunds?)\b|` +
+		`\bget\s+rich\b|\bdouble\s+your\s+money\b|\b\d+%\s+(?:returns?|roi)\b|` +
+		`\bmake\s+\$\d[\d,]*\s+(?:a|per)\s+(?:day|week|month)\b|\bpassive\s+income\b`)
+)
+
+// defaultRules is the built-in policy set. Rules run in order against the
+// text earlier rules left behind, so specific rules come before general ones.
+func defaultRules() []Rule {
+	var rules []Rule
+	rules = append(rules, strictOnAds(Rule{
+		ID:      "financial-promise",
+		Pattern: financialPromise,
+	})...)
+	rules = append(rules, strictOnAds(Rule{
+		ID:      "health-claim",
+		Pattern: healthClaim,
+	})...)
+	return append(rules,
+		Rule{
+			ID:        "unverifiable-ranking",
+			Pattern:   regexp.MustCompile(`(?i)(?:\b(?:the|our)\s+)?(?:#1\b|\bno\.\s?1\b|\bnumber[\s-]one\b)`),
+			Action:    Rewrite,
+			Localized: true,
+		},
+		Rule{
+			ID:        "unverifiable-rating",
+			Pattern:   regexp.MustCompile(`(?i)\b(?:top|highest|best)[\s-]rated\b`),
+			Action:    Rewrite,
+			Localized: true,
+		},
+		Rule{
+			ID:      "superlative",
+			Pattern: regexp.MustCompile(`(?i)\bworld'?s\s+best\b|\bbest\s+in\s+(?:town|the\s+(?:city|state|country|world))\b`),
+			Action:  Flag,
+		},
+		Rule{
+			ID:      "guarantee",
+			Pattern: regexp.MustCompile(`(?i)\bguaranteed?\b|\b100%\s+(?:satisfaction|results|effective)\b|\bno[\s-]risk\b|\brisk[\s-]free\b`),
+			Action:  Flag,
+		},
+		Rule{
+			ID: "competitor-comparison",
+			Pattern: regexp.MustCompile(`\b(?:[Bb]etter|[Cc]heaper|[Ff]aster|[Ff]resher|[Nn]icer)\s+than\s+` +
+				`\p{Lu}[\p{L}'&]*(?:\s+\p{Lu}[\p{L}'&]*)*`),
+			Action: Remove,
+		},
+		Rule{
+			ID: "competitor-brand",
+			Pattern: regexp.MustCompile(`\b(?:Amazon|Walmart|Costco|Starbucks|Dunkin'?|McDonald'?s|Etsy|Shopify|` +
+				`Fiverr|Upwork|TurboTax|H&R Block|QuickBooks|Peloton|Sephora|Ulta)\b`),
+			Action:         Flag,
+			AllowFromInput: true,
+		},
+		Rule{
+			ID: "health-results-timeline",
+			Pattern: regexp.MustCompile(`(?i)\b(?:results?|lose|drop|transform)\w*\s+(?:\w+\s+){0,3}?` +
+				`(?:in|within)\s+\d+\s+(?:days?|weeks?)\b`),
+			Action:    Flag,
+			Verticals: []Vertical{Health},
+		},
+		Rule{
+			ID:        "finance-savings-claim",
+			Pattern:   regexp.MustCompile(`(?i)\b(?:maximum|bigger|biggest|largest)\s+refunds?\b|\bsave\s+\$\d[\d,]*\b|\bbeat\s+the\s+(?:irs|taxman)\b`),
+			Action:    Flag,
+			Verticals: []Vertical{Finance},
+		},
+		Rule{
+			ID:             "dietary-claim",
+			Pattern:        regexp.MustCompile(`(?i)\b(?:organic|gluten[\s-]free|vegan|nut[\s-]free|allergen[\s-]free|sugar[\s-]free|keto)\b`),
+			Action:         Flag,
+			Verticals:      []Vertical{Food},
+			AllowFromInput: true,
+		},
+		Rule{
+			ID: "personal-attributes",
+			Pattern: regexp.MustCompile(`(?i)\bare\s+you\s+(?:overweight|fat|depressed|anxious|in\s+debt|broke|single|divorced|` +
+				`bald(?:ing)?|struggling\s+with\s+\w+)\b`),
+			Action:    Remove,
+			Platforms: []core.Platform{core.Facebook, core.Instagram},
+		},
+		Rule{
+			ID:        "phone-number",
+			Pattern:   regexp.MustCompile(`(?:\+?1[\s.-]?)?\(?\b\d{3}\)?[\s.-]?\d{3}[\s.-]\d{4}\b`),
+			Action:    Remove,
+			Platforms: []core.Platform{core.GoogleBusiness},
+		},
+		Rule{
+			ID: "engagement-bait-hashtag",
+			Pattern: regexp.MustCompile(`(?i)^(?:follow4follow|followforfollow|f4f|like4like|likeforlike|l4l|` +
+				`instagood|tagsforlikes|followme)$`),
+			Action:    Remove,
+			Hashtags:  true,
+			Platforms: []core.Platform{core.Instagram, core.TikTok},
+		},
+		Rule{
+			ID:          "email-exclamations",
+			Pattern:     regexp.MustCompile(`!{2,}`),
+			Action:      Rewrite,
+			Replacement: "!",
+			Platforms:   []core.Platform{core.Email},
+		},
+		Rule{
+			ID:        "email-spam-words",
+			Pattern:   regexp.MustCompile(`\b(?:FREE|ACT NOW|URGENT|CASH|WINNER|CLICK HERE)\b`),
+			Action:    Flag,
+			Platforms: []core.Platform{core.Email},
+		},
+	)
+}
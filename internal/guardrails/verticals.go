@@ -0,0 +1,56 @@
+package guardrails
+
+import (
+	"regexp"
+	"strings"
+
+	"biz-flow/internal/core"
+)
+
+// Vertical is an industry with its own advertising rules, inferred from the
+// business description
+type Vertical string
+
+const (
+	Health  Vertical = "health"
+	Finance Vertical = "finance"
+	Food    Vertical = "food"
+)
+
+// verticalKeywords are patterns for the words in a description that place a
+// business in a vertical. They match at the start of a word, so most are
+// stems; short words that prefix unrelated ones end in \b.
+var verticalKeywords = map[Vertical][]string{
+	Health: {
+		"health", "wellness", "fitness", "gym", "yoga", "pilates", "clinic", "therap", "massage",
+		"supplement", "nutrition", "chiropract", "dental", "dentist", "skincare", `spas?\b`, "personal trainer",
+	},
+	Finance: {
+		"bookkeep", "accounting", "accountant", `tax(?:es)?\b`, "financ", "insurance", "mortgage", "invest",
+		"loan", "credit", "crypto", "trading",
+	},
+	Food: {
+		"bakery", "restaurant", "cafe", "café", "coffee", "food", "catering", "kitchen", "pastr",
+		"bistro", "brewery", `delis?\b`, "sourdough", "brunch", "pizza", "juice",
+	},
+}
+
+// verticalPatterns combine each vertical's keywords into one expression
+var verticalPatterns = func() map[Vertical]*regexp.Regexp {
+	patterns := make(map[Vertical]*regexp.Regexp, len(verticalKeywords))
+	for vertical, keywords := range verticalKeywords {
+		patterns[vertical] = regexp.MustCompile(`(?i)\b(?:` + strings.Join(keywords, "|") + `)`)
+	}
+	return patterns
+}()
+
+// Verticals returns every vertical the business description points to
+func Verticals(business core.BusinessInput) []Vertical {
+	var verticals []Vertical
+	for _, vertical := range []Vertical{Health, Finance, Food} {
+		if verticalPatterns[vertical].MatchString(business.Description) {
+			verticals = append(verticals, vertical)
+		}
+	}
+	return verticals
+}
@@ -1 +1,101 @@
-package handler
\ No newline at end of file
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/openapi"
+)
+
+// maxBodyBytes caps the size of a consultation request body
+const maxBodyBytes = 1 << 20
+
+// Consultant runs a consultation for a business
+type Consultant interface {
+	Consult(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error)
+}
+
+// AgentHandler exposes the consultation pipeline over HTTP
+type AgentHandler struct {
+	consultant Consultant
+}
+
+// NewAgentHandler creates a new agent handler
+func NewAgentHandler(consultant Consultant) *AgentHandler {
+	return &AgentHandler{consultant: consultant}
+}
+
+// RegisterRoutes adds the agent endpoints to the mux
+func (h *AgentHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /run-agent", h.RunAgent)
+	mux.HandleFunc("GET /health", h.Health)
+	mux.HandleFunc("GET /openapi.json", h.OpenAPI)
+}
+
+// RunAgent decodes a BusinessInput and responds with the ConsultationResult
+func (h *AgentHandler) RunAgent(w http.ResponseWriter, r *http.Request) {
+	business, err := decodeBusinessInput(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.consultant.Consult(r.Context(), business)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// Health reports that the server is up
+func (h *AgentHandler) Health(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// OpenAPI serves the OpenAPI document for this API
+func (h *AgentHandler) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, openapi.Spec())
+}
+
+// decodeBusinessInput reads a BusinessInput from the request body, accepting
+// legacy field names as aliases
+func decodeBusinessInput(w http.ResponseWriter, r *http.Request) (core.BusinessInput, error) {
+	business, err := core.DecodeBusinessInput(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		return business, errors.New("invalid request body: " + err.Error())
+	}
+	return business, nil
+}
+
+// statusFor maps a consultation error to an HTTP status code
+func statusFor(err error) int {
+	var validationErr *core.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return http.StatusBadRequest
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeJSON writes a JSON response with the given status
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("handler: writing response: %v", err)
+	}
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
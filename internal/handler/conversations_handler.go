@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"biz-flow/internal/clarify"
+)
+
+// ConversationsHandler asks follow-up questions about a business input that
+// leaves information missing or ambiguous, and consults once they are
+// answered
+type ConversationsHandler struct {
+	consultant Consultant
+	store      *clarify.Store
+}
+
+// NewConversationsHandler creates a new conversations handler
+func NewConversationsHandler(consultant Consultant, store *clarify.Store) *ConversationsHandler {
+	return &ConversationsHandler{consultant: consultant, store: store}
+}
+
+// RegisterRoutes adds the conversation endpoints to the mux
+func (h *ConversationsHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /conversations", h.Start)
+	mux.HandleFunc("GET /conversations/{id}", h.Get)
+	mux.HandleFunc("POST /conversations/{id}/answers", h.Answer)
+}
+
+// Start opens a conversation about the business. It responds with the
+// follow-up questions, or with the result when none are needed.
+func (h *ConversationsHandler) Start(w http.ResponseWriter, r *http.Request) {
+	business, err := decodeBusinessInput(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	conversation, err := clarify.Start(business)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	h.consultAndSave(w, r, conversation)
+}
+
+// Get responds with the conversation's questions or result
+func (h *ConversationsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	conversation, err := h.store.Get(r.PathValue("id"))
+	if err != nil {
+		writeError(w, conversationStatusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, conversation)
+}
+
+// Answer merges an AnswerRequest into the conversation and responds with the
+// next questions, or with the result once the business is clear
+func (h *ConversationsHandler) Answer(w http.ResponseWriter, r *http.Request) {
+	conversation, err := h.store.Get(r.PathValue("id"))
+	if err != nil {
+		writeError(w, conversationStatusFor(err), err)
+		return
+	}
+
+	var request clarify.AnswerRequest
+	if err := decodeStrict(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := conversation.Answer(request.Answers); err != nil {
+		writeError(w, conversationStatusFor(err), err)
+		return
+	}
+	h.consultAndSave(w, r, conversation)
+}
+
+// consultAndSave consults a conversation that is ready, then stores it and
+// responds with it
+func (h *ConversationsHandler) consultAndSave(w http.ResponseWriter, r *http.Request, conversation *clarify.Conversation) {
+	if conversation.Status == clarify.StatusReady {
+		result, err := h.consultant.Consult(r.Context(), conversation.Business)
+		if err != nil {
+			writeError(w, statusFor(err), err)
+			return
+		}
+		conversation.Complete(result)
+	}
+
+	if err := h.store.Save(conversation); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Location", "/conversations/"+conversation.ID)
+	writeJSON(w, http.StatusOK, conversation)
+}
+
+// conversationStatusFor maps a conversation error to an HTTP status code
+func conversationStatusFor(err error) int {
+	switch {
+	case errors.Is(err, clarify.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, clarify.ErrComplete):
+		return http.StatusConflict
+	}
+	return statusFor(err)
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"biz-flow/internal/diff"
+)
+
+// DiffHandler compares two consultations, or runs one again against the
+// current rules and compares the results
+type DiffHandler struct {
+	consultant Consultant
+}
+
+// NewDiffHandler creates a new consultation diff handler
+func NewDiffHandler(consultant Consultant) *DiffHandler {
+	return &DiffHandler{consultant: consultant}
+}
+
+// RegisterRoutes adds the diff endpoint to the mux
+func (h *DiffHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /diff", h.Compare)
+}
+
+// Compare decodes a CompareRequest and responds with the comparison
+func (h *DiffHandler) Compare(w http.ResponseWriter, r *http.Request) {
+	var request diff.CompareRequest
+	if err := decodeStrict(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	comparison, err := request.Run(r.Context(), h.consultant.Consult)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, comparison)
+}
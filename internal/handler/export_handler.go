@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"biz-flow/internal/calendar"
+	"biz-flow/internal/core"
+	"biz-flow/internal/export"
+)
+
+// Recommender ranks platforms for a business without the LLM stages
+type Recommender interface {
+	Recommend(business core.BusinessInput) ([]core.Recommendation, error)
+}
+
+// ExportHandler plans content calendars and exports them, or consultation
+// results, as .ics and CSV files
+type ExportHandler struct {
+	recommender Recommender
+	planner     *calendar.Planner
+}
+
+// NewExportHandler creates a new calendar and export handler
+func NewExportHandler(recommender Recommender) *ExportHandler {
+	return &ExportHandler{recommender: recommender, planner: calendar.NewPlanner()}
+}
+
+// RegisterRoutes adds the calendar and export endpoints to the mux
+func (h *ExportHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /calendar", h.Calendar)
+	mux.HandleFunc("POST /export/ics", h.ICS)
+	mux.HandleFunc("POST /export/csv", h.CSV)
+}
+
+// Calendar decodes a PlanRequest and responds with the content calendar
+func (h *ExportHandler) Calendar(w http.ResponseWriter, r *http.Request) {
+	var request calendar.PlanRequest
+	if err := decodeStrict(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	options, err := request.Options()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	recommendations, err := h.recommender.Recommend(request.Business)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	plan, err := h.planner.Plan(request.Business, recommendations, options)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, plan)
+}
+
+// ICS responds with the posts of a calendar or result as an iCalendar file
+// with a reminder before each post
+func (h *ExportHandler) ICS(w http.ResponseWriter, r *http.Request) {
+	request, posts, ok := h.decodeExport(w, r)
+	if !ok {
+		return
+	}
+	reminder, err := request.Reminder()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var body bytes.Buffer
+	if err := export.WriteICS(&body, posts, export.ICSOptions{Reminder: reminder, Locale: request.Language()}); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeFile(w, "text/calendar; charset=utf-8", "content-calendar.ics", body.Bytes())
+}
+
+// CSV responds with the posts of a calendar or result as CSV in the
+// requested layout
+func (h *ExportHandler) CSV(w http.ResponseWriter, r *http.Request) {
+	request, posts, ok := h.decodeExport(w, r)
+	if !ok {
+		return
+	}
+	layout, _ := export.ParseLayout(string(request.Layout))
+	var body bytes.Buffer
+	if err := export.WriteCSV(&body, posts, layout); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeFile(w, "text/csv; charset=utf-8", "content-calendar-"+string(layout)+".csv", body.Bytes())
+}
+
+// decodeExport reads an ExportRequest and lists its posts, writing the error
+// response itself when the request is invalid
+func (h *ExportHandler) decodeExport(w http.ResponseWriter, r *http.Request) (export.ExportRequest, []export.Post, bool) {
+	var request export.ExportRequest
+	if err := decodeStrict(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return request, nil, false
+	}
+	posts, err := request.Posts(time.Now())
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return request, nil, false
+	}
+	return request, posts, true
+}
+
+// decodeStrict reads a JSON body into value, rejecting unknown fields
+func decodeStrict(w http.ResponseWriter, r *http.Request, value interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil {
+		return errors.New("invalid request body: " + err.Error())
+	}
+	return nil
+}
+
+// writeFile writes a downloadable file response
+func writeFile(w http.ResponseWriter, contentType, filename string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
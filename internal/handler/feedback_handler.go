@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+
+	"biz-flow/internal/feedback"
+)
+
+// FeedbackHandler records the results businesses report so later
+// consultations rank platforms by what worked
+type FeedbackHandler struct {
+	store *feedback.Store
+}
+
+// NewFeedbackHandler creates a new results upload handler
+func NewFeedbackHandler(store *feedback.Store) *FeedbackHandler {
+	return &FeedbackHandler{store: store}
+}
+
+// RegisterRoutes adds the results endpoint to the mux
+func (h *FeedbackHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /performance", h.Upload)
+}
+
+// Upload decodes an UploadRequest, stores its results under the business's
+// profile and responds with the recalibrated platforms
+func (h *FeedbackHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	var request feedback.UploadRequest
+	if err := decodeStrict(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := request.Business.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	results, err := request.Parse()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.store.Record(r.Context(), request.Business, results); err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	summary, err := h.store.Summarize(r.Context(), request.Business)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	summary.Recorded = len(results)
+	writeJSON(w, http.StatusOK, summary)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"biz-flow/internal/jobs"
+)
+
+// JobsHandler exposes asynchronous consultations as pollable jobs
+type JobsHandler struct {
+	manager *jobs.Manager
+}
+
+// NewJobsHandler creates a new jobs handler
+func NewJobsHandler(manager *jobs.Manager) *JobsHandler {
+	return &JobsHandler{manager: manager}
+}
+
+// RegisterRoutes adds the job endpoints to the mux
+func (h *JobsHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /consultations", h.Create)
+	mux.HandleFunc("GET /consultations/{id}", h.Get)
+	mux.HandleFunc("DELETE /consultations/{id}", h.Cancel)
+}
+
+// Create queues a consultation and responds with the job, including its ID
+func (h *JobsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	business, err := decodeBusinessInput(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	job, err := h.manager.Submit(r.Context(), business)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+
+	w.Header().Set("Location", "/consultations/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// Get responds with the job's status and, once finished, its result
+func (h *JobsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	job, err := h.manager.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, jobStatusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+// Cancel stops a queued or running job
+func (h *JobsHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	job, err := h.manager.Cancel(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, jobStatusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+// jobStatusFor maps a jobs error to an HTTP status code
+func jobStatusFor(err error) int {
+	if errors.Is(err, jobs.ErrNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
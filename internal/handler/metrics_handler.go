@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"biz-flow/internal/cache"
+	"biz-flow/internal/openapi"
+)
+
+// MetricsHandler exposes operational counters such as cache hit rates
+type MetricsHandler struct {
+	cache *cache.Cache
+}
+
+// NewMetricsHandler creates a new metrics handler. A nil cache reports no
+// cache stages.
+func NewMetricsHandler(c *cache.Cache) *MetricsHandler {
+	return &MetricsHandler{cache: c}
+}
+
+// RegisterRoutes adds the metrics endpoint to the mux
+func (h *MetricsHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /metrics", h.Metrics)
+}
+
+// Metrics responds with the current cache counters per stage
+func (h *MetricsHandler) Metrics(w http.ResponseWriter, r *http.Request) {
+	stats := h.cache.Stats()
+	if stats == nil {
+		stats = map[cache.Stage]cache.Stats{}
+	}
+	writeJSON(w, http.StatusOK, openapi.MetricsResponse{Cache: stats})
+}
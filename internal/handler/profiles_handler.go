@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/diff"
+	"biz-flow/internal/profiles"
+)
+
+// ProfilesHandler stores businesses as revisioned profiles, consults them
+// again and compares their consultations
+type ProfilesHandler struct {
+	repo       profiles.Repository
+	consultant Consultant
+}
+
+// NewProfilesHandler creates a new profiles handler
+func NewProfilesHandler(repo profiles.Repository, consultant Consultant) *ProfilesHandler {
+	return &ProfilesHandler{repo: repo, consultant: consultant}
+}
+
+// RegisterRoutes adds the profile endpoints to the mux
+func (h *ProfilesHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /profiles", h.List)
+	mux.HandleFunc("PUT /profiles/{name}", h.Save)
+	mux.HandleFunc("GET /profiles/{name}", h.Get)
+	mux.HandleFunc("DELETE /profiles/{name}", h.Delete)
+	mux.HandleFunc("GET /profiles/{name}/revisions", h.History)
+	mux.HandleFunc("POST /profiles/{name}/consultations", h.Consult)
+	mux.HandleFunc("GET /profiles/{name}/diff", h.Diff)
+}
+
+// List responds with every profile and its latest revision's business
+func (h *ProfilesHandler) List(w http.ResponseWriter, r *http.Request) {
+	list, err := h.repo.List(r.Context())
+	if err != nil {
+		writeError(w, profileStatusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// Save decodes a SaveRequest and stores its business as the profile's next
+// revision, responding 201 with the new revision or 200 with the latest one
+// when the business is unchanged
+func (h *ProfilesHandler) Save(w http.ResponseWriter, r *http.Request) {
+	var request profiles.SaveRequest
+	if err := decodeStrict(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	revision, created, err := h.repo.Save(r.Context(), r.PathValue("name"), request.Business, request.Note)
+	if err != nil {
+		writeError(w, profileStatusFor(err), err)
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, revision)
+}
+
+// Get responds with the profile's latest revision, or the one named by
+// ?revision=, with its consultation
+func (h *ProfilesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	number, err := revisionParam(r.URL.Query(), "revision")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	revision, err := h.repo.Get(r.Context(), r.PathValue("name"), number)
+	if err != nil {
+		writeError(w, profileStatusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, revision)
+}
+
+// Delete removes the profile and its revisions
+func (h *ProfilesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.repo.Delete(r.Context(), r.PathValue("name")); err != nil {
+		writeError(w, profileStatusFor(err), err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// History responds with the profile's revisions, oldest first
+func (h *ProfilesHandler) History(w http.ResponseWriter, r *http.Request) {
+	history, err := h.repo.History(r.Context(), r.PathValue("name"))
+	if err != nil {
+		writeError(w, profileStatusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, history)
+}
+
+// Consult runs the profile's latest revision, or the one named by
+// ?revision=, stores the result and responds with it and what changed since
+// the last consultation
+func (h *ProfilesHandler) Consult(w http.ResponseWriter, r *http.Request) {
+	number, err := revisionParam(r.URL.Query(), "revision")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	consultation, err := profiles.Consult(r.Context(), h.repo, h.consultant.Consult, r.PathValue("name"), number)
+	if err != nil {
+		writeError(w, profileStatusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, consultation)
+}
+
+// Diff compares two consulted revisions named by ?from= and ?to=, by
+// default the latest consulted revision and the one before it. With
+// ?rerun=true it instead consults revision to again against the current
+// rules, without storing the result, and compares with its stored
+// consultation.
+func (h *ProfilesHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	from, err := revisionParam(query, "from")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	to, err := revisionParam(query, "to")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	rerun := false
+	if raw := query.Get("rerun"); raw != "" {
+		if rerun, err = strconv.ParseBool(raw); err != nil {
+			writeError(w, http.StatusBadRequest, &core.ValidationError{Field: "rerun", Message: "must be true or false"})
+			return
+		}
+	}
+	if rerun && from != 0 {
+		writeError(w, http.StatusBadRequest, &core.ValidationError{Field: "from", Message: "cannot be combined with rerun"})
+		return
+	}
+
+	var comparison *diff.Comparison
+	if rerun {
+		comparison, err = profiles.Rerun(r.Context(), h.repo, h.consultant.Consult, r.PathValue("name"), to)
+	} else {
+		comparison, err = profiles.DiffRevisions(r.Context(), h.repo, r.PathValue("name"), from, to)
+	}
+	if err != nil {
+		writeError(w, profileStatusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, comparison)
+}
+
+// revisionParam reads a revision number from the query; absent means 0
+func revisionParam(query url.Values, name string) (int, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+	number, err := strconv.Atoi(raw)
+	if err != nil || number < 1 {
+		return 0, &core.ValidationError{Field: name, Message: "must be a revision number from 1"}
+	}
+	return number, nil
+}
+
+// profileStatusFor maps a profiles error to an HTTP status code
+func profileStatusFor(err error) int {
+	switch {
+	case errors.Is(err, profiles.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, profiles.ErrNotConsulted):
+		return http.StatusConflict
+	default:
+		return statusFor(err)
+	}
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/scenario"
+)
+
+// Ranker ranks platforms without the LLM stages, keeping the best n; n below
+// 1 keeps every platform that passes the filters
+type Ranker interface {
+	RecommendTop(business core.BusinessInput, n int) ([]core.Recommendation, error)
+}
+
+// ScenariosHandler ranks what-if variations of a business side by side
+type ScenariosHandler struct {
+	ranker Ranker
+}
+
+// NewScenariosHandler creates a new scenario handler
+func NewScenariosHandler(ranker Ranker) *ScenariosHandler {
+	return &ScenariosHandler{ranker: ranker}
+}
+
+// RegisterRoutes adds the scenario endpoint to the mux
+func (h *ScenariosHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /scenarios", h.Explore)
+}
+
+// Explore decodes an ExploreRequest and responds with the ranking matrix
+// and its tipping points
+func (h *ScenariosHandler) Explore(w http.ResponseWriter, r *http.Request) {
+	var request scenario.ExploreRequest
+	if err := decodeStrict(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	rankAll := func(business core.BusinessInput) ([]core.Recommendation, error) {
+		return h.ranker.RecommendTop(business, 0)
+	}
+	matrix, err := scenario.Explore(r.Context(), rankAll, request, 0)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, matrix)
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/sensitivity"
+)
+
+// Analyst reports how stable a business's recommendations are
+type Analyst interface {
+	Sensitivity(ctx context.Context, business core.BusinessInput, options sensitivity.Options) (*sensitivity.Report, error)
+}
+
+// SensitivityHandler runs sensitivity analyses of platform scores
+type SensitivityHandler struct {
+	analyst Analyst
+}
+
+// NewSensitivityHandler creates a new sensitivity handler
+func NewSensitivityHandler(analyst Analyst) *SensitivityHandler {
+	return &SensitivityHandler{analyst: analyst}
+}
+
+// RegisterRoutes adds the sensitivity endpoint to the mux
+func (h *SensitivityHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /sensitivity", h.Analyze)
+}
+
+// Analyze decodes an AnalyzeRequest and responds with the sensitivity report
+func (h *SensitivityHandler) Analyze(w http.ResponseWriter, r *http.Request) {
+	var request sensitivity.AnalyzeRequest
+	if err := decodeStrict(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	report, err := h.analyst.Sensitivity(r.Context(), request.Business, request.Options())
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
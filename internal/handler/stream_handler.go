@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"biz-flow/internal/agent"
+	"biz-flow/internal/core"
+)
+
+// StreamingConsultant runs a consultation while reporting stage events
+type StreamingConsultant interface {
+	ConsultWithObserver(ctx context.Context, business core.BusinessInput, observe agent.Observer) (*core.ConsultationResult, error)
+}
+
+// StreamHandler streams consultation progress as Server-Sent Events
+type StreamHandler struct {
+	consultant StreamingConsultant
+}
+
+// NewStreamHandler creates a new stream handler
+func NewStreamHandler(consultant StreamingConsultant) *StreamHandler {
+	return &StreamHandler{consultant: consultant}
+}
+
+// RegisterRoutes adds the streaming endpoints to the mux. POST takes a JSON
+// body; GET takes query parameters so browsers can use EventSource.
+func (h *StreamHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /run-agent/stream", h.Stream)
+	mux.HandleFunc("GET /run-agent/stream", h.Stream)
+}
+
+// Stream runs a consultation and sends one event per completed stage,
+// followed by a "result" or "error" event. The "content" event is the top
+// recommendation's; no other recommendation gets content. Closing the connection cancels
+// the consultation.
+func (h *StreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	var business core.BusinessInput
+	var err error
+	if r.Method == http.MethodGet {
+		business, err = businessFromQuery(r.URL.Query())
+	} else {
+		business, err = decodeBusinessInput(w, r)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := business.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	stream, err := newEventStream(w)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	result, err := h.consultant.ConsultWithObserver(r.Context(), business, func(event agent.Event) {
+		stream.send(string(event.Stage), event.Data)
+	})
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return // Client went away; nobody is listening
+		}
+		stream.send("error", map[string]string{"error": err.Error()})
+		return
+	}
+
+	stream.send("result", result)
+}
+
+// eventStream writes Server-Sent Events and flushes each one immediately
+type eventStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	id      int
+	err     error
+}
+
+func newEventStream(w http.ResponseWriter) (*eventStream, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errors.New("streaming is not supported by this connection")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &eventStream{w: w, flusher: flusher}, nil
+}
+
+// send writes one event. After the first write failure it stops writing;
+// the request context will cancel the consultation.
+func (s *eventStream) send(event string, data interface{}) {
+	if s.err != nil {
+		return
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		payload, _ = json.Marshal(map[string]string{"error": err.Error()})
+		event = "error"
+	}
+
+	s.id++
+	if _, err := fmt.Fprintf(s.w, "id: %d\nevent: %s\ndata: %s\n\n", s.id, event, payload); err != nil {
+		s.err = err
+		return
+	}
+	s.flusher.Flush()
+}
+
+// businessFromQuery builds a BusinessInput from URL query parameters.
+// Channels may be repeated or comma-separated; the brand voice uses the web
+// form's field names.
+func businessFromQuery(query url.Values) (core.BusinessInput, error) {
+	business := core.BusinessInput{
+		Type:        core.BusinessType(query.Get("type")),
+		Description: strings.TrimSpace(query.Get("description")),
+		Location:    strings.TrimSpace(query.Get("location")),
+		Goal:        core.MarketingGoal(query.Get("goal")),
+		Locale:      core.Locale(query.Get("locale")),
+		Channels:    []string{},
+	}
+
+	for _, value := range query["channels"] {
+		for _, channel := range strings.Split(value, ",") {
+			if channel = strings.TrimSpace(channel); channel != "" {
+				business.Channels = append(business.Channels, channel)
+			}
+		}
+	}
+
+	voice, err := core.VoiceFromForm(query)
+	if err != nil {
+		return business, err
+	}
+	business.Voice = voice
+
+	if raw := strings.TrimSpace(query.Get("budget")); raw != "" {
+		budget, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return business, &core.ValidationError{Field: "budget", Message: "must be a number"}
+		}
+		business.Budget = budget
+	}
+
+	return business, nil
+}
@@ -0,0 +1,159 @@
+// Package i18n holds the message catalogs for the reasons, explanations and
+// advice the deterministic pipeline writes, one catalog per locale.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"biz-flow/internal/core"
+)
+
+//go:embed catalogs/*.json
+var catalogFiles embed.FS
+
+// Catalog maps message keys to fmt format strings. Translations may reorder
+// arguments with explicit indexes such as %[2]s.
+type Catalog map[string]string
+
+var (
+	loadOnce sync.Once
+	catalogs map[core.Locale]Catalog
+)
+
+// Catalogs returns the built-in catalog of every supported locale
+func Catalogs() map[core.Locale]Catalog {
+	loadOnce.Do(func() {
+		loaded, err := load()
+		if err != nil {
+			panic(fmt.Sprintf("i18n: built-in catalogs: %v", err))
+		}
+		catalogs = loaded
+	})
+	return catalogs
+}
+
+// T formats the message for the locale, falling back to English for
+// messages a catalog lacks and to the key itself for unknown messages
+func T(locale core.Locale, key string, args ...any) string {
+	format, ok := Catalogs()[locale][key]
+	if !ok {
+		if format, ok = Catalogs()[core.English][key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// List joins items the way the locale writes a list: "a, b and c"
+func List(locale core.Locale, items []string) string {
+	switch len(items) {
+	case 0:
+		return T(locale, "list.none")
+	case 1:
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " " + T(locale, "list.and") + " " + items[len(items)-1]
+}
+
+// Sentence ends text with the locale's full stop unless it already ends a
+// sentence
+func Sentence(locale core.Locale, text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.HasSuffix(text, ".") || strings.HasSuffix(text, "।") {
+		return text
+	}
+	return text + T(locale, "punctuation.full_stop")
+}
+
+// BusinessType names a business type in the locale, for use inside a sentence
+func BusinessType(locale core.Locale, businessType core.BusinessType) string {
+	key := "type." + string(businessType)
+	if _, ok := Catalogs()[core.English][key]; !ok {
+		return string(businessType)
+	}
+	return T(locale, key)
+}
+
+// load reads every supported locale's catalog and checks each translation
+// against English
+func load() (map[core.Locale]Catalog, error) {
+	loaded := make(map[core.Locale]Catalog)
+	for _, locale := range core.Locales() {
+		data, err := catalogFiles.ReadFile(path.Join("catalogs", string(locale)+".json"))
+		if err != nil {
+			return nil, err
+		}
+		var catalog Catalog
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return nil, fmt.Errorf("%s.json: %w", locale, err)
+		}
+		loaded[locale] = catalog
+	}
+
+	var problems []string
+	english := loaded[core.English]
+	for _, locale := range core.Locales()[1:] {
+		for key, format := range loaded[locale] {
+			source, ok := english[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: %s is not an English message", locale, key))
+				continue
+			}
+			if want, got := verbs(source), verbs(format); want != got {
+				problems = append(problems, fmt.Sprintf("%s: %s takes %s, English takes %s", locale, key, got, want))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+	return loaded, nil
+}
+
+// verbPattern matches a format verb and the explicit argument index in front
+// of its width or verb, if any
+var verbPattern = regexp.MustCompile(`%[-+# 0]*(?:\[(\d+)\])?\d*(?:\.\d*)?(?:\[(\d+)\])?([a-zA-Z%])`)
+
+// verbs describes which verb formats each argument, e.g. "1:s 2:f", so a
+// translation that reorders arguments still matches its source
+func verbs(format string) string {
+	byArg := make(map[int]string)
+	next := 1
+	for _, match := range verbPattern.FindAllStringSubmatch(format, -1) {
+		if match[3] == "%" {
+			continue
+		}
+		arg := next
+		for _, index := range match[1:3] {
+			if index != "" {
+				arg, _ = strconv.Atoi(index)
+			}
+		}
+		byArg[arg] = match[3]
+		next = arg + 1
+	}
+
+	args := make([]int, 0, len(byArg))
+	for arg := range byArg {
+		args = append(args, arg)
+	}
+	sort.Ints(args)
+	described := make([]string, 0, len(args))
+	for _, arg := range args {
+		described = append(described, fmt.Sprintf("%d:%s", arg, byArg[arg]))
+	}
+	return strings.Join(described, " ")
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"biz-flow/internal/core"
+)
+
+// Status is the lifecycle state of a job
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// Done reports whether the status is final
+func (s Status) Done() bool {
+	return s == StatusSucceeded || s == StatusFailed || s == StatusCancelled
+}
+
+// ErrNotFound is returned when a job ID is unknown
+var ErrNotFound = errors.New("jobs: job not found")
+
+// Job is an asynchronous consultation
+type Job struct {
+	ID        string                   `json:"id"`
+	Status    Status                   `json:"status"`
+	Input     core.BusinessInput       `json:"input"`
+	Result    *core.ConsultationResult `json:"result,omitempty"`
+	Error     string                   `json:"error,omitempty"`
+	Attempts  int                      `json:"attempts"`
+	CreatedAt time.Time                `json:"created_at"`
+	UpdatedAt time.Time                `json:"updated_at"`
+}
+
+// newJob creates a queued job for the input
+func newJob(input core.BusinessInput) (*Job, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	return &Job{
+		ID:        id,
+		Status:    StatusQueued,
+		Input:     input,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// newID returns a random 128-bit hex job ID
+func newID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,244 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"biz-flow/internal/ai"
+	"biz-flow/internal/core"
+)
+
+// ConsultFunc runs a single consultation
+type ConsultFunc func(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error)
+
+// Options configures the worker pool
+type Options struct {
+	Workers     int              // Concurrent jobs, defaults to 2
+	MaxAttempts int              // Attempts per job including the first, defaults to 3
+	RetryDelay  time.Duration    // Delay before the first retry, doubled for each later one
+	Timeout     time.Duration    // Per-attempt timeout, 0 for none
+	Retryable   func(error) bool // Defaults to ai.IsTransient
+}
+
+// DefaultOptions returns the options used by the server
+func DefaultOptions() Options {
+	return Options{
+		Workers:     2,
+		MaxAttempts: 3,
+		RetryDelay:  2 * time.Second,
+		Timeout:     2 * time.Minute,
+		Retryable:   ai.IsTransient,
+	}
+}
+
+// Manager runs queued consultations on an in-process worker pool
+type Manager struct {
+	queue   Queue
+	consult ConsultFunc
+	opts    Options
+
+	mu      sync.Mutex
+	running map[string]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewManager creates a manager. Call Start to begin processing jobs.
+func NewManager(queue Queue, consult ConsultFunc, opts Options) *Manager {
+	defaults := DefaultOptions()
+	if opts.Workers <= 0 {
+		opts.Workers = defaults.Workers
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaults.MaxAttempts
+	}
+	if opts.Retryable == nil {
+		opts.Retryable = defaults.Retryable
+	}
+
+	return &Manager{
+		queue:   queue,
+		consult: consult,
+		opts:    opts,
+		running: make(map[string]context.CancelFunc),
+	}
+}
+
+// Start launches the workers. They stop once ctx is done; use Wait to block
+// until they have finished.
+func (m *Manager) Start(ctx context.Context) {
+	for i := 0; i < m.opts.Workers; i++ {
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			m.work(ctx)
+		}()
+	}
+}
+
+// Wait blocks until all workers have stopped
+func (m *Manager) Wait() {
+	m.wg.Wait()
+}
+
+// Submit validates the input and queues a consultation job
+func (m *Manager) Submit(ctx context.Context, business core.BusinessInput) (*Job, error) {
+	if err := business.Validate(); err != nil {
+		return nil, err
+	}
+
+	job, err := newJob(business)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.queue.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Get returns the current state of a job
+func (m *Manager) Get(ctx context.Context, id string) (*Job, error) {
+	return m.queue.Get(ctx, id)
+}
+
+// Cancel stops a queued or running job. Finished jobs are returned unchanged.
+func (m *Manager) Cancel(ctx context.Context, id string) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cancel, ok := m.running[id]; ok {
+		// The worker records the cancelled status when the run unwinds
+		cancel()
+		return m.queue.Get(ctx, id)
+	}
+
+	job, err := m.queue.Get(ctx, id)
+	if err != nil || job.Status.Done() {
+		return job, err
+	}
+
+	job.Status = StatusCancelled
+	job.UpdatedAt = time.Now().UTC()
+	if err := m.queue.Update(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// work processes jobs until ctx is done
+func (m *Manager) work(ctx context.Context) {
+	for {
+		job, err := m.queue.Dequeue(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("jobs: dequeue failed: %v", err)
+				time.Sleep(time.Second)
+				continue
+			}
+			return
+		}
+		m.run(ctx, job)
+	}
+}
+
+// run executes one job, retrying transient failures
+func (m *Manager) run(ctx context.Context, job *Job) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	m.mu.Lock()
+	m.running[job.ID] = cancel
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.running, job.ID)
+		m.mu.Unlock()
+	}()
+
+	// A cancel that raced with the dequeue has already marked the job
+	if current, err := m.queue.Get(ctx, job.ID); err == nil && current.Status == StatusCancelled {
+		return
+	}
+
+	for {
+		job.Attempts++
+		m.save(job)
+
+		result, err := m.attempt(jobCtx, job.Input, job.Attempts < m.opts.MaxAttempts)
+		switch {
+		case err == nil:
+			job.Status = StatusSucceeded
+			job.Result = result
+			job.Error = ""
+		case ctx.Err() != nil:
+			// Shutting down: leave the job for the next process
+			job.Status = StatusQueued
+			job.Error = ""
+		case jobCtx.Err() != nil:
+			job.Status = StatusCancelled
+			job.Error = "cancelled"
+		case m.opts.Retryable(err) && job.Attempts < m.opts.MaxAttempts:
+			job.Error = err.Error()
+			m.save(job)
+			if sleep(jobCtx, m.opts.RetryDelay<<(job.Attempts-1)) {
+				continue
+			}
+			if ctx.Err() != nil {
+				job.Status = StatusQueued
+			} else {
+				job.Status = StatusCancelled
+				job.Error = "cancelled"
+			}
+		default:
+			job.Status = StatusFailed
+			job.Error = err.Error()
+		}
+
+		m.save(job)
+		return
+	}
+}
+
+// attempt runs the consultation once with the per-attempt timeout. While
+// attempts are left, LLM stages fail on transient errors rather than degrade,
+// so that the job is retried; the last attempt takes the fallback output.
+func (m *Manager) attempt(ctx context.Context, business core.BusinessInput, retries bool) (*core.ConsultationResult, error) {
+	if retries {
+		ctx = ai.WithRetries(ctx)
+	}
+	if m.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.opts.Timeout)
+		defer cancel()
+	}
+
+	result, err := m.consult(ctx, business)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() != nil {
+		// Treat a timed-out attempt like any other transient failure
+		return nil, errors.Join(ai.ErrTransient, err)
+	}
+	return result, err
+}
+
+// save persists the job, logging rather than failing the run on error
+func (m *Manager) save(job *Job) {
+	job.UpdatedAt = time.Now().UTC()
+	if err := m.queue.Update(context.Background(), job); err != nil {
+		log.Printf("jobs: saving job %s: %v", job.ID, err)
+	}
+}
+
+// sleep waits for d, returning false if ctx ends first
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,275 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"biz-flow/internal/ai"
+	"biz-flow/internal/core"
+)
+
+var business = core.BusinessInput{
+	Type:        core.Retail,
+	Description: "handmade ceramic mugs for coffee lovers",
+	Budget:      80,
+	Goal:        core.Awareness,
+}
+
+// queues opens a fresh queue of every backend
+func queues(t *testing.T) map[string]Queue {
+	t.Helper()
+	sqliteQueue, err := NewSQLiteQueue(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteQueue: %v", err)
+	}
+	t.Cleanup(func() { sqliteQueue.Close() })
+	return map[string]Queue{"memory": NewMemoryQueue(), "sqlite": sqliteQueue}
+}
+
+// startManager runs a manager over the queue until the test ends
+func startManager(t *testing.T, queue Queue, consult ConsultFunc) *Manager {
+	t.Helper()
+	manager := NewManager(queue, consult, Options{
+		Workers:     1,
+		MaxAttempts: 3,
+		RetryDelay:  time.Millisecond,
+		Timeout:     time.Second,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	manager.Start(ctx)
+	t.Cleanup(func() {
+		cancel()
+		manager.Wait()
+	})
+	return manager
+}
+
+// waitFor polls the job until its status is final
+func waitFor(t *testing.T, manager *Manager, id string) *Job {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := manager.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if job.Status.Done() {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return nil
+}
+
+func TestManagerRetries(t *testing.T) {
+	transient := fmt.Errorf("%w: rate limited", ai.ErrTransient)
+	permanent := errors.New("invalid prompt")
+
+	tests := []struct {
+		name         string
+		failures     []error // returned by the attempts in order; then success
+		wantStatus   Status
+		wantAttempts int
+		wantError    string
+	}{
+		{name: "succeeds first time", wantStatus: StatusSucceeded, wantAttempts: 1},
+		{name: "retries a transient failure", failures: []error{transient}, wantStatus: StatusSucceeded, wantAttempts: 2},
+		{name: "retries until the last attempt", failures: []error{transient, transient}, wantStatus: StatusSucceeded, wantAttempts: 3},
+		{
+			name:         "gives up after the last attempt",
+			failures:     []error{transient, transient, transient},
+			wantStatus:   StatusFailed,
+			wantAttempts: 3,
+			wantError:    "rate limited",
+		},
+		{name: "does not retry a permanent failure", failures: []error{permanent}, wantStatus: StatusFailed, wantAttempts: 1, wantError: "invalid prompt"},
+		{
+			name:         "treats a timed-out attempt as transient",
+			failures:     []error{context.DeadlineExceeded},
+			wantStatus:   StatusSucceeded,
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		for backend, queue := range queues(t) {
+			t.Run(tt.name+"/"+backend, func(t *testing.T) {
+				var calls atomic.Int32
+				manager := startManager(t, queue, func(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error) {
+					call := int(calls.Add(1))
+					if call <= len(tt.failures) {
+						if errors.Is(tt.failures[call-1], context.DeadlineExceeded) {
+							// Outlast the per-attempt timeout
+							<-ctx.Done()
+							return nil, ctx.Err()
+						}
+						return nil, tt.failures[call-1]
+					}
+					return &core.ConsultationResult{StrategicAdvice: "post daily"}, nil
+				})
+
+				submitted, err := manager.Submit(context.Background(), business)
+				if err != nil {
+					t.Fatalf("Submit: %v", err)
+				}
+				job := waitFor(t, manager, submitted.ID)
+
+				if job.Status != tt.wantStatus || job.Attempts != tt.wantAttempts {
+					t.Errorf("status %s after %d attempts, want %s after %d", job.Status, job.Attempts, tt.wantStatus, tt.wantAttempts)
+				}
+				if tt.wantStatus == StatusSucceeded && (job.Result == nil || job.Result.StrategicAdvice != "post daily" || job.Error != "") {
+					t.Errorf("succeeded job has result %+v and error %q", job.Result, job.Error)
+				}
+				if !strings.Contains(job.Error, tt.wantError) {
+					t.Errorf("error %q, want it to contain %q", job.Error, tt.wantError)
+				}
+			})
+		}
+	}
+}
+
+func TestManagerCancel(t *testing.T) {
+	for backend, queue := range queues(t) {
+		t.Run("running/"+backend, func(t *testing.T) {
+			started := make(chan struct{})
+			manager := startManager(t, queue, func(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error) {
+				close(started)
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+
+			submitted, err := manager.Submit(context.Background(), business)
+			if err != nil {
+				t.Fatalf("Submit: %v", err)
+			}
+			<-started
+			if _, err := manager.Cancel(context.Background(), submitted.ID); err != nil {
+				t.Fatalf("Cancel: %v", err)
+			}
+			if job := waitFor(t, manager, submitted.ID); job.Status != StatusCancelled || job.Attempts != 1 {
+				t.Errorf("status %s after %d attempts, want cancelled after 1", job.Status, job.Attempts)
+			}
+		})
+	}
+
+	for backend, queue := range queues(t) {
+		t.Run("queued/"+backend, func(t *testing.T) {
+			var calls atomic.Int32
+			consult := func(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error) {
+				calls.Add(1)
+				return &core.ConsultationResult{}, nil
+			}
+			// Not started, so the job stays queued until cancelled
+			manager := NewManager(queue, consult, Options{Workers: 1})
+			submitted, err := manager.Submit(context.Background(), business)
+			if err != nil {
+				t.Fatalf("Submit: %v", err)
+			}
+			job, err := manager.Cancel(context.Background(), submitted.ID)
+			if err != nil {
+				t.Fatalf("Cancel: %v", err)
+			}
+			if job.Status != StatusCancelled {
+				t.Fatalf("status %s, want cancelled", job.Status)
+			}
+
+			// A later job still runs, and the cancelled one never does
+			later, err := manager.Submit(context.Background(), business)
+			if err != nil {
+				t.Fatalf("Submit: %v", err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			manager.Start(ctx)
+			defer func() {
+				cancel()
+				manager.Wait()
+			}()
+			if job := waitFor(t, manager, later.ID); job.Status != StatusSucceeded {
+				t.Errorf("later job status %s, want succeeded", job.Status)
+			}
+			if calls.Load() != 1 {
+				t.Errorf("consulted %d times, want once", calls.Load())
+			}
+			if job, _ := manager.Get(context.Background(), submitted.ID); job.Status != StatusCancelled || job.Attempts != 0 {
+				t.Errorf("cancelled job is %s after %d attempts", job.Status, job.Attempts)
+			}
+		})
+	}
+
+	for backend, queue := range queues(t) {
+		t.Run("finished/"+backend, func(t *testing.T) {
+			manager := startManager(t, queue, func(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error) {
+				return &core.ConsultationResult{}, nil
+			})
+			submitted, err := manager.Submit(context.Background(), business)
+			if err != nil {
+				t.Fatalf("Submit: %v", err)
+			}
+			waitFor(t, manager, submitted.ID)
+			job, err := manager.Cancel(context.Background(), submitted.ID)
+			if err != nil || job.Status != StatusSucceeded {
+				t.Errorf("Cancel = %v, %v; want the succeeded job unchanged", job, err)
+			}
+		})
+	}
+}
+
+func TestManagerErrors(t *testing.T) {
+	for backend, queue := range queues(t) {
+		t.Run(backend, func(t *testing.T) {
+			manager := NewManager(queue, nil, Options{})
+			if _, err := manager.Get(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get error = %v, want ErrNotFound", err)
+			}
+			if _, err := manager.Cancel(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Cancel error = %v, want ErrNotFound", err)
+			}
+			var validationErr *core.ValidationError
+			if _, err := manager.Submit(context.Background(), core.BusinessInput{Type: core.Retail}); !errors.As(err, &validationErr) {
+				t.Errorf("Submit error = %v, want a ValidationError", err)
+			}
+		})
+	}
+}
+
+func TestSQLiteQueueRequeuesInterruptedJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	queue, err := NewSQLiteQueue(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteQueue: %v", err)
+	}
+	job, err := newJob(business)
+	if err != nil {
+		t.Fatalf("newJob: %v", err)
+	}
+	if err := queue.Enqueue(context.Background(), job); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if _, err := queue.Dequeue(context.Background()); err != nil {
+		t.Fatalf("Dequeue: %v", err)
+	}
+	queue.Close()
+
+	reopened, err := NewSQLiteQueue(path)
+	if err != nil {
+		t.Fatalf("reopening: %v", err)
+	}
+	defer reopened.Close()
+	stored, err := reopened.Get(context.Background(), job.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if stored.Status != StatusQueued {
+		t.Errorf("status %s after reopening, want queued", stored.Status)
+	}
+	if stored.Input.Description != business.Description {
+		t.Errorf("input %+v did not survive the restart", stored.Input)
+	}
+}
@@ -0,0 +1,121 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryQueue keeps jobs in process memory. Jobs are lost on restart.
+type MemoryQueue struct {
+	mu      sync.Mutex
+	jobs    map[string]*Job
+	pending []string
+	ready   chan struct{}
+}
+
+// NewMemoryQueue creates an empty in-memory queue
+func NewMemoryQueue() *MemoryQueue {
+	return &MemoryQueue{
+		jobs:  make(map[string]*Job),
+		ready: make(chan struct{}, 1),
+	}
+}
+
+// Enqueue implements Queue
+func (q *MemoryQueue) Enqueue(ctx context.Context, job *Job) error {
+	q.mu.Lock()
+	stored := *job
+	q.jobs[job.ID] = &stored
+	q.pending = append(q.pending, job.ID)
+	q.mu.Unlock()
+
+	q.signal()
+	return nil
+}
+
+// Dequeue implements Queue
+func (q *MemoryQueue) Dequeue(ctx context.Context) (*Job, error) {
+	for {
+		if job := q.take(); job != nil {
+			return job, nil
+		}
+
+		select {
+		case <-q.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// take pops the oldest job that is still queued
+func (q *MemoryQueue) take() *Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.pending) > 0 {
+		id := q.pending[0]
+		q.pending = q.pending[1:]
+
+		job, ok := q.jobs[id]
+		if !ok || job.Status != StatusQueued {
+			continue // Cancelled while waiting
+		}
+
+		job.Status = StatusRunning
+		job.UpdatedAt = time.Now().UTC()
+		taken := *job
+
+		// Wake another worker if more work is waiting
+		if len(q.pending) > 0 {
+			q.signalLocked()
+		}
+		return &taken
+	}
+	return nil
+}
+
+// Get implements Queue
+func (q *MemoryQueue) Get(ctx context.Context, id string) (*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	found := *job
+	return &found, nil
+}
+
+// Update implements Queue
+func (q *MemoryQueue) Update(ctx context.Context, job *Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.jobs[job.ID]; !ok {
+		return ErrNotFound
+	}
+	stored := *job
+	q.jobs[job.ID] = &stored
+	return nil
+}
+
+// Close implements Queue
+func (q *MemoryQueue) Close() error {
+	return nil
+}
+
+func (q *MemoryQueue) signal() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.signalLocked()
+}
+
+func (q *MemoryQueue) signalLocked() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"context"
+)
+
+// Queue stores jobs and hands queued ones to workers. Implementations must
+// be safe for concurrent use.
+type Queue interface {
+	// Enqueue stores a new queued job
+	Enqueue(ctx context.Context, job *Job) error
+
+	// Dequeue blocks until a queued job is available, marks it running and
+	// returns it. It returns ctx.Err() once ctx is done.
+	Dequeue(ctx context.Context) (*Job, error)
+
+	// Get returns a copy of the job, or ErrNotFound
+	Get(ctx context.Context, id string) (*Job, error)
+
+	// Update saves the job's status, result, error and attempt count
+	Update(ctx context.Context, job *Job) error
+
+	// Close releases the backend's resources
+	Close() error
+}
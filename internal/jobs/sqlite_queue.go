@@ -0,0 +1,185 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/sqlite"
+)
+
+// pollInterval bounds how long a worker waits before checking for jobs
+// enqueued by another process
+const pollInterval = time.Second
+
+const jobsSchema = `
+CREATE TABLE IF NOT EXISTS jobs (
+	id         TEXT PRIMARY KEY,
+	status     TEXT NOT NULL,
+	input      TEXT NOT NULL,
+	result     TEXT,
+	error      TEXT NOT NULL DEFAULT '',
+	attempts   INTEGER NOT NULL DEFAULT 0,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS jobs_status_created ON jobs (status, created_at);
+`
+
+// SQLiteQueue persists jobs in a SQLite database so they survive restarts
+type SQLiteQueue struct {
+	db    *sql.DB
+	ready chan struct{}
+}
+
+// NewSQLiteQueue opens the queue database at path. Jobs left running by a
+// previous process are put back in the queue.
+func NewSQLiteQueue(path string) (*SQLiteQueue, error) {
+	db, err := sqlite.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(jobsSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating jobs table: %w", err)
+	}
+
+	if _, err := db.Exec(
+		`UPDATE jobs SET status = ?, updated_at = ? WHERE status = ?`,
+		StatusQueued, time.Now().UTC(), StatusRunning,
+	); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("requeueing interrupted jobs: %w", err)
+	}
+
+	return &SQLiteQueue{db: db, ready: make(chan struct{}, 1)}, nil
+}
+
+// Enqueue implements Queue
+func (q *SQLiteQueue) Enqueue(ctx context.Context, job *Job) error {
+	input, err := json.Marshal(job.Input)
+	if err != nil {
+		return err
+	}
+
+	if _, err := q.db.ExecContext(ctx,
+		`INSERT INTO jobs (id, status, input, attempts, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		job.ID, job.Status, string(input), job.Attempts, job.CreatedAt, job.UpdatedAt,
+	); err != nil {
+		return fmt.Errorf("enqueueing job: %w", err)
+	}
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Dequeue implements Queue
+func (q *SQLiteQueue) Dequeue(ctx context.Context) (*Job, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := q.claim(ctx)
+		if err != nil || job != nil {
+			return job, err
+		}
+
+		select {
+		case <-q.ready:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// claim marks the oldest queued job as running and returns it, or nil if
+// the queue is empty
+func (q *SQLiteQueue) claim(ctx context.Context) (*Job, error) {
+	row := q.db.QueryRowContext(ctx,
+		`UPDATE jobs SET status = ?, updated_at = ?
+		 WHERE id = (SELECT id FROM jobs WHERE status = ? ORDER BY created_at LIMIT 1)
+		 RETURNING id`,
+		StatusRunning, time.Now().UTC(), StatusQueued,
+	)
+
+	var id string
+	if err := row.Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("claiming job: %w", err)
+	}
+
+	return q.Get(ctx, id)
+}
+
+// Get implements Queue
+func (q *SQLiteQueue) Get(ctx context.Context, id string) (*Job, error) {
+	row := q.db.QueryRowContext(ctx,
+		`SELECT id, status, input, result, error, attempts, created_at, updated_at FROM jobs WHERE id = ?`,
+		id,
+	)
+
+	var (
+		job    Job
+		input  string
+		result sql.NullString
+	)
+	if err := row.Scan(&job.ID, &job.Status, &input, &result, &job.Error, &job.Attempts, &job.CreatedAt, &job.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("loading job: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(input), &job.Input); err != nil {
+		return nil, fmt.Errorf("decoding job input: %w", err)
+	}
+	if result.Valid {
+		job.Result = &core.ConsultationResult{}
+		if err := json.Unmarshal([]byte(result.String), job.Result); err != nil {
+			return nil, fmt.Errorf("decoding job result: %w", err)
+		}
+	}
+	return &job, nil
+}
+
+// Update implements Queue
+func (q *SQLiteQueue) Update(ctx context.Context, job *Job) error {
+	var result sql.NullString
+	if job.Result != nil {
+		data, err := json.Marshal(job.Result)
+		if err != nil {
+			return err
+		}
+		result = sql.NullString{String: string(data), Valid: true}
+	}
+
+	res, err := q.db.ExecContext(ctx,
+		`UPDATE jobs SET status = ?, result = ?, error = ?, attempts = ?, updated_at = ? WHERE id = ?`,
+		job.Status, result, job.Error, job.Attempts, job.UpdatedAt, job.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("updating job: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Close implements Queue
+func (q *SQLiteQueue) Close() error {
+	return q.db.Close()
+}
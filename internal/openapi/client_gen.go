@@ -0,0 +1,406 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// GenerateClient renders a typed Go client package for the document. Only
+// operations with JSON (or no) request bodies and JSON responses are
+// generated; streaming endpoints are left to hand-written code.
+func GenerateClient(doc *Document, pkg, source string) ([]byte, error) {
+	gen := clientGenerator{doc: doc}
+	data := clientData{Package: pkg, Source: source, Version: doc.Info.Version}
+
+	names := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		schema := doc.Components.Schemas[name]
+		switch {
+		case len(schema.Enum) > 0:
+			data.Enums = append(data.Enums, gen.enumType(name, schema))
+		case schema.Type == "object":
+			decl := gen.structType(name, schema)
+			for _, field := range decl.Fields {
+				if strings.Contains(field.Type, "time.Time") {
+					data.UsesTime = true
+				}
+			}
+			data.Structs = append(data.Structs, decl)
+		}
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+		if item.Get != nil {
+			if method, ok := gen.method("GET", path, item.Get); ok {
+				data.Methods = append(data.Methods, method)
+			}
+		}
+		if item.Put != nil {
+			if method, ok := gen.method("PUT", path, item.Put); ok {
+				data.Methods = append(data.Methods, method)
+			}
+		}
+		if item.Post != nil {
+			if method, ok := gen.method("POST", path, item.Post); ok {
+				data.Methods = append(data.Methods, method)
+			}
+		}
+		if item.Delete != nil {
+			if method, ok := gen.method("DELETE", path, item.Delete); ok {
+				data.Methods = append(data.Methods, method)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := clientTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated client: %w", err)
+	}
+	return formatted, nil
+}
+
+type clientData struct {
+	Package  string
+	Source   string
+	Version  string
+	UsesTime bool
+	Enums    []enumDecl
+	Structs  []structDecl
+	Methods  []methodDecl
+}
+
+type enumDecl struct {
+	Name        string
+	Description string
+	Values      []enumValue
+}
+
+type enumValue struct {
+	Const string
+	Value string
+}
+
+type structDecl struct {
+	Name        string
+	Description string
+	Fields      []fieldDecl
+}
+
+type fieldDecl struct {
+	Name        string
+	Type        string
+	Tag         string
+	Description string
+}
+
+type methodDecl struct {
+	Name        string
+	Summary     string
+	HTTPMethod  string
+	Path        string
+	PathParams  []string // Go parameter names, in path order
+	PathExpr    string   // Go expression building the request path
+	BodyType    string
+	ResultType  string
+	ResultIsRef bool
+}
+
+// clientGenerator maps schemas to Go declarations
+type clientGenerator struct {
+	doc *Document
+}
+
+// enumType declares a named string type with one constant per value
+func (g clientGenerator) enumType(name string, schema *Schema) enumDecl {
+	decl := enumDecl{Name: name, Description: schema.Description}
+	for _, value := range schema.Enum {
+		decl.Values = append(decl.Values, enumValue{Const: name + exportedName(value), Value: value})
+	}
+	return decl
+}
+
+// structType declares a struct with one field per schema property
+func (g clientGenerator) structType(name string, schema *Schema) structDecl {
+	decl := structDecl{Name: name, Description: schema.Description}
+	required := make(map[string]bool)
+	for _, field := range schema.Required {
+		required[field] = true
+	}
+
+	for _, property := range schema.Properties {
+		if property.Schema.Deprecated {
+			continue // Legacy aliases are accepted by the server, never sent
+		}
+
+		fieldName := property.Schema.GoName
+		if fieldName == "" {
+			fieldName = exportedName(property.Name)
+		}
+
+		goType := g.goType(property.Schema)
+		tag := property.Name
+		if !required[property.Name] {
+			tag += ",omitempty"
+			if g.isStructRef(property.Schema) {
+				goType = "*" + goType
+			}
+		}
+
+		decl.Fields = append(decl.Fields, fieldDecl{
+			Name:        fieldName,
+			Type:        goType,
+			Tag:         fmt.Sprintf("`json:%q`", tag),
+			Description: property.Schema.Description,
+		})
+	}
+	return decl
+}
+
+// method declares a client method for a JSON operation
+func (g clientGenerator) method(httpMethod, path string, op *Operation) (methodDecl, bool) {
+	decl := methodDecl{
+		Name:       exportedName(op.OperationID),
+		Summary:    op.Summary,
+		HTTPMethod: httpMethod,
+		Path:       path,
+	}
+
+	decl.PathExpr = fmt.Sprintf("%q", path)
+	for _, param := range op.Parameters {
+		if param.In != "path" {
+			continue
+		}
+		placeholder := "{" + param.Name + "}"
+		decl.PathParams = append(decl.PathParams, param.Name)
+		decl.PathExpr = strings.Replace(decl.PathExpr, placeholder, `" + url.PathEscape(`+param.Name+`) + "`, 1)
+	}
+	decl.PathExpr = strings.TrimSuffix(strings.TrimPrefix(decl.PathExpr, `"" + `), ` + ""`)
+
+	if op.RequestBody != nil {
+		media, ok := op.RequestBody.Content["application/json"]
+		if !ok {
+			return decl, false
+		}
+		decl.BodyType = g.goType(media.Schema)
+	}
+
+	response, ok := op.Responses["200"]
+	if !ok {
+		response, ok = op.Responses["202"]
+	}
+	if !ok {
+		return decl, false
+	}
+	media, ok := response.Content["application/json"]
+	if !ok {
+		return decl, false
+	}
+
+	decl.ResultType = g.goType(media.Schema)
+	decl.ResultIsRef = g.isStructRef(media.Schema)
+	return decl, true
+}
+
+// goType maps a schema to a Go type expression
+func (g clientGenerator) goType(schema *Schema) string {
+	if schema.Ref != "" {
+		return refName(schema.Ref)
+	}
+
+	switch schema.Type {
+	case "string":
+		if schema.Format == "date-time" {
+			return "time.Time"
+		}
+		return "string"
+	case "boolean":
+		return "bool"
+	case "integer":
+		if schema.Format == "int64" {
+			return "int64"
+		}
+		return "int"
+	case "number":
+		return "float64"
+	case "array":
+		return "[]" + g.goType(schema.Items)
+	case "object":
+		if schema.AdditionalProperties != nil {
+			return "map[string]" + g.goType(schema.AdditionalProperties)
+		}
+		return "map[string]interface{}"
+	default:
+		return "interface{}"
+	}
+}
+
+// isStructRef reports whether a schema references an object component.
+// Optional objects become pointers; optional enums stay values because the
+// empty string already means "unset".
+func (g clientGenerator) isStructRef(schema *Schema) bool {
+	if schema.Ref == "" {
+		return false
+	}
+	target, ok := g.doc.Components.Schemas[refName(schema.Ref)]
+	return ok && target.Type == "object"
+}
+
+// refName returns the component name a reference points at
+func refName(ref string) string {
+	return strings.TrimPrefix(ref, "#/components/schemas/")
+}
+
+// exportedName turns "Google My Business" or "runAgent" into "GoogleMyBusiness"/"RunAgent"
+func exportedName(value string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by cmd/openapi from {{.Source}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"{{if .UsesTime}}
+	"time"{{end}}
+)
+
+// APIVersion is the version of the API contract this client was generated from
+const APIVersion = "{{.Version}}"
+{{range $enum := .Enums}}
+// {{$enum.Name}} mirrors the {{$enum.Name}} schema{{if $enum.Description}}: {{$enum.Description}}{{end}}
+type {{$enum.Name}} string
+
+const (
+{{- range $enum.Values}}
+	{{.Const}} {{$enum.Name}} = {{printf "%q" .Value}}
+{{- end}}
+)
+{{end}}
+{{- range .Structs}}
+// {{.Name}} mirrors the {{.Name}} schema{{if .Description}}. {{.Description}}{{end}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{if .Description}}// {{.Description}}
+	{{end}}{{.Name}} {{.Type}} {{.Tag}}
+{{- end}}
+}
+{{end}}
+// Client calls the consultation API
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// New creates a client for the API served at baseURL, e.g. "http://localhost:8080"
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/"), HTTPClient: http.DefaultClient}
+}
+
+// APIError is returned for non-2xx responses
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error (status %d): %s", e.StatusCode, e.Message)
+}
+{{range .Methods}}
+// {{.Name}} calls {{.HTTPMethod}} {{.Path}}: {{.Summary}}
+func (c *Client) {{.Name}}(ctx context.Context{{range .PathParams}}, {{.}} string{{end}}{{if .BodyType}}, body {{.BodyType}}{{end}}) ({{if .ResultIsRef}}*{{end}}{{.ResultType}}, error) {
+	var result {{.ResultType}}
+	if err := c.do(ctx, {{printf "%q" .HTTPMethod}}, {{.PathExpr}}, {{if .BodyType}}body{{else}}nil{{end}}, &result); err != nil {
+		return {{if .ResultIsRef}}nil{{else}}result{{end}}, err
+	}
+	return {{if .ResultIsRef}}&{{end}}result, nil
+}
+{{end}}
+// do sends a JSON request and decodes the JSON response into out
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Error string ` + "`json:\"error\"`" + `
+		}
+		raw, _ := io.ReadAll(resp.Body)
+		if json.Unmarshal(raw, &apiErr) != nil || apiErr.Error == "" {
+			apiErr.Error = strings.TrimSpace(string(raw))
+		}
+		return &APIError{StatusCode: resp.StatusCode, Message: apiErr.Error}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+`))
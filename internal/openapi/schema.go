@@ -0,0 +1,151 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// schemaGenerator derives component schemas from Go types by reflection, so
+// the spec cannot drift from the structs the server actually encodes
+type schemaGenerator struct {
+	components map[string]*Schema
+	enums      map[reflect.Type][]string
+	required   map[reflect.Type][]string
+	notes      map[string]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		components: make(map[string]*Schema),
+		enums:      make(map[reflect.Type][]string),
+		required:   make(map[reflect.Type][]string),
+		notes:      make(map[string]string),
+	}
+}
+
+// enum registers the allowed values of a named string type
+func (g *schemaGenerator) enum(value interface{}, values ...string) {
+	g.enums[reflect.TypeOf(value)] = values
+}
+
+// requireOnly overrides which fields of a struct are required. By default
+// every field without omitempty is required.
+func (g *schemaGenerator) requireOnly(value interface{}, fields ...string) {
+	g.required[reflect.TypeOf(value)] = fields
+}
+
+// describe attaches a description to a type ("Type") or field ("Type.field")
+func (g *schemaGenerator) describe(key, description string) {
+	g.notes[key] = description
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// ref returns a schema for t, registering named structs and enums as components
+func (g *schemaGenerator) ref(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if values, ok := g.enums[t]; ok {
+		if _, exists := g.components[t.Name()]; !exists {
+			g.components[t.Name()] = &Schema{
+				Type:        "string",
+				Enum:        values,
+				Description: g.notes[t.Name()],
+				GoName:      t.Name(),
+			}
+		}
+		return &Schema{Ref: componentRef(t.Name())}
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.ref(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.ref(t.Elem())}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Struct:
+		if _, exists := g.components[t.Name()]; !exists {
+			// Register first so recursive types terminate
+			schema := &Schema{Type: "object", GoName: t.Name()}
+			g.components[t.Name()] = schema
+			g.fillStruct(schema, t)
+		}
+		return &Schema{Ref: componentRef(t.Name())}
+	default:
+		panic(fmt.Sprintf("openapi: unsupported kind %s for %s", t.Kind(), t))
+	}
+}
+
+// fillStruct adds the JSON-visible fields of t to schema
+func (g *schemaGenerator) fillStruct(schema *Schema, t reflect.Type) {
+	schema.Description = g.notes[t.Name()]
+	required, override := g.required[t]
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty := jsonName(field)
+		if name == "-" {
+			continue
+		}
+
+		property := g.ref(field.Type)
+		property.GoName = field.Name
+		if note := g.notes[t.Name()+"."+name]; note != "" {
+			property.Description = note
+		}
+		schema.Properties = append(schema.Properties, Property{Name: name, Schema: property})
+
+		if !override && !omitEmpty {
+			required = append(required, name)
+		}
+	}
+
+	schema.Required = required
+}
+
+// jsonName returns the JSON field name and whether omitempty is set
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name, false
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			return name, true
+		}
+	}
+	return name, false
+}
+
+// componentRef returns the JSON reference to a component schema
+func componentRef(name string) string {
+	return "#/components/schemas/" + name
+}
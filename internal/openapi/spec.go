@@ -0,0 +1,616 @@
+package openapi
+
+import (
+	"reflect"
+	"sort"
+
+	"biz-flow/internal/cache"
+	"biz-flow/internal/calendar"
+	"biz-flow/internal/clarify"
+	"biz-flow/internal/core"
+	"biz-flow/internal/diff"
+	"biz-flow/internal/export"
+	"biz-flow/internal/feedback"
+	"biz-flow/internal/jobs"
+	"biz-flow/internal/profiles"
+	"biz-flow/internal/scenario"
+	"biz-flow/internal/sensitivity"
+)
+
+// APIVersion is the version of the consultation API contract. Bump the major
+// version for breaking changes to request or response shapes.
+const APIVersion = "1.0.0"
+
+// ErrorResponse is the body of every non-2xx JSON response
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// HealthResponse is the body of GET /health
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+// MetricsResponse is the body of GET /metrics
+type MetricsResponse struct {
+	Cache map[cache.Stage]cache.Stats `json:"cache"`
+}
+
+// Spec builds the OpenAPI document from the Go types in internal/core
+func Spec() *Document {
+	g := newSchemaGenerator()
+
+	g.enum(core.BusinessType(""), stringsOf(core.BusinessTypes())...)
+	g.enum(core.MarketingGoal(""), stringsOf(core.MarketingGoals())...)
+	g.enum(core.Locale(""), stringsOf(core.Locales())...)
+	g.enum(core.Platform(""), stringsOf(core.GetAllPlatformNames())...)
+
+	// Only the validated fields are required on input; the rest default to empty
+	g.requireOnly(core.BusinessInput{}, "type", "description", "goal")
+
+	g.describe("BusinessType", "Kind of business")
+	g.describe("MarketingGoal", "Primary marketing goal")
+	g.describe("Locale", "Language the consultation is written in: English, Spanish, Portuguese, Swahili or Hindi")
+	g.describe("Platform", "Marketing platform")
+	g.describe("BusinessInput", "Details of the business to consult for. The legacy field names "+
+		"business_type and monthly_budget are accepted as aliases for type and budget.")
+	g.describe("BusinessInput.description", "What the business sells and to whom")
+	g.describe("BusinessInput.location", "City or region; empty or \"online\" for online-only businesses")
+	g.describe("BusinessInput.budget", "Monthly marketing budget in US dollars")
+	g.describe("BusinessInput.channels", "Channels the business already uses")
+	g.describe("BusinessInput.locale", "Language for reasons, advice and content; defaults to en")
+	g.describe("Recommendation.score", "Fit score from 0 to 100")
+	g.describe("ConsultationResult", "Ranked platform recommendations with advice and risks")
+	g.describe("ResultMetadata", "How the result was produced: prompt versions, degraded stages, policy findings and cost")
+	g.describe("Fallback", "A stage that moved from one source to the next, and why")
+	g.describe("CostEstimate", "Estimated LLM spend for the consultation; cached stages cost nothing")
+	g.describe("ModelCall", "One request to a model with its token usage and estimated cost")
+	g.enum(core.PolicyAction(""), stringsOf([]core.PolicyAction{core.PolicyRewritten, core.PolicyRemoved, core.PolicyFlagged})...)
+	g.describe("PolicyAction", "What the content policy checker did about a finding")
+	g.describe("PolicyFinding", "A content policy rule a generated template broke; flagged findings are also listed in risks")
+	g.enum(core.EmojiPolicy(""), stringsOf(core.EmojiPolicies())...)
+	g.requireOnly(core.BrandVoice{})
+	g.requireOnly(core.Tone{})
+	g.describe("BusinessInput.voice", "Brand voice that generated content and advice should follow")
+	g.describe("BrandVoice", "How the owner wants generated content to sound")
+	g.describe("BrandVoice.banned_words", "Words generated content must never use; sentences and hashtags with them are dropped")
+	g.describe("BrandVoice.signature_phrases", "The owner's recurring lines, worked into content where they fit")
+	g.describe("BrandVoice.sample_posts", "Past posts that show the voice; sliders left at 0 take their tone from these")
+	g.describe("Tone", "Position on the brand voice sliders, each from -1 to 1; 0 leaves the axis open")
+	g.describe("Tone.formality", "Playful (-1) to formal (1)")
+	g.describe("Tone.enthusiasm", "Calm (-1) to enthusiastic (1)")
+	g.describe("EmojiPolicy", "How freely content may use emoji: none, at most one (sparing) or freely (generous)")
+	g.describe("Recommendation.voice_score", "How well the content template matches the brand voice; set only with a voice")
+	g.describe("Recommendation.confidence", "How sure the pipeline is of the recommendation")
+	g.describe("ConsultationResult.questions", "Clarifying questions, most useful first, suggested when any recommendation has low confidence")
+	g.enum(core.ConfidenceLevel(""), stringsOf([]core.ConfidenceLevel{core.ConfidenceHigh, core.ConfidenceMedium, core.ConfidenceLow})...)
+	g.describe("ConfidenceLevel", "high from a confidence score of 0.75, medium from 0.5, otherwise low")
+	g.describe("Confidence", "How sure the pipeline is of a recommendation: a score from 0 to 1 weighing the signals, each also from 0 to 1")
+	g.describe("Confidence.completeness", "How much the business input reveals: a detailed description, a location, existing channels and a budget that fits the goal")
+	g.describe("Confidence.margin", "Score gap to the platforms ranked just above and below; a gap of 10 points or more is full")
+	g.describe("Confidence.agreement", "Share of LLM review samples that picked the platform too; set only when the review stage ran")
+	g.describe("Confidence.evidence", "Reported results behind the platform's calibration; six of the business's own periods are full")
+	g.describe("Question", "A clarifying question whose answer would firm up the recommendations")
+	g.describe("Question.field", "The business input field the answer goes into")
+	g.describe("VoiceScore", "Brand voice score from 0 to 100 with the measured tone, each issue and what was adjusted")
+	g.describe("VoiceIssue", "One way a content template strays from the brand voice, and the points it cost")
+	g.enum(calendar.Pillar(""), stringsOf(calendar.Pillars())...)
+	g.enum(export.Layout(""), stringsOf(export.Layouts())...)
+	g.requireOnly(calendar.PlanRequest{}, "business")
+	g.requireOnly(export.ExportRequest{})
+	g.describe("PlanRequest", "A business and how many weeks, from when, and how many hours a week to plan for")
+	g.describe("PlanRequest.weeks", "Weeks to plan, 4 to 12; defaults to 4")
+	g.describe("PlanRequest.start", "First day, YYYY-MM-DD, moved forward to a Monday; defaults to next Monday")
+	g.describe("PlanRequest.hours_per_week", "Hours a week the owner can spend on content; defaults to 3")
+	g.describe("Calendar", "Weeks of posts across the recommended platforms, each with a content template")
+	g.describe("Calendar.id", "Identifies the business the calendar was planned for; exported events are keyed by it")
+	g.describe("Calendar.planned_hours", "Average hours a week the plan takes")
+	g.describe("Cadence", "How often and when the calendar posts to a platform")
+	g.describe("Cadence.posts_per_week", "Average posts a week; 1.5 alternates one and two")
+	g.describe("Cadence.time", "Local posting time, HH:MM")
+	g.describe("Slot", "One post in the calendar")
+	g.describe("Slot.holiday", "Holiday the post is built around, in the business's locale")
+	g.describe("Pillar", "Kind of post: educational, promotional, behind-the-scenes or user-generated content")
+	g.describe("ExportRequest", "A consultation result or content calendar to export; set exactly one")
+	g.describe("ExportRequest.start", "First posting day for a result, YYYY-MM-DD; defaults to next Monday")
+	g.describe("ExportRequest.business", "The business a result was for; recommendations without content then get a template from the offline library")
+	g.describe("ExportRequest.locale", "Language of .ics event titles; defaults to the business's, or English")
+	g.describe("ExportRequest.platform", "Export only this platform's posts")
+	g.describe("ExportRequest.reminder_minutes", "Minutes before each post an .ics reminder goes off; 0 for none, defaults to 30")
+	g.describe("Layout", "CSV columns: generic, Buffer's bulk upload or Hootsuite's bulk composer")
+	g.describe("BusinessInput.profile", "Name reported results are kept under; consultations for the profile are calibrated by them")
+	g.requireOnly(feedback.UploadRequest{}, "business")
+	g.describe("UploadRequest", "Reported results for a business profile, as the text of a CSV export or as JSON; set exactly one")
+	g.describe("UploadRequest.csv", "CSV with a header row: platform, reach and clicks columns, and optionally period, leads and sales")
+	g.describe("Result", "One platform's reported performance for a period; reporting a period again replaces it")
+	g.describe("Summary", "Each platform's catalog estimate next to its estimate calibrated by reported results")
+	g.describe("Summary.recorded", "How many results the upload stored")
+	g.describe("PlatformSummary", "One platform's reach and conversion potential before and after reported results")
+	g.describe("PlatformSummary.totals", "The profile's own reported results")
+	g.describe("Calibration", "Reach and conversion potential from 1 to 10 re-estimated from the results of the profile and its vertical")
+	g.describe("Totals", "Sums of a platform's reported results")
+	g.requireOnly(profiles.SaveRequest{}, "business")
+	g.describe("SaveRequest", "A business to store as the profile's next revision, with an optional note on what changed")
+	g.describe("Profile", "A stored business and its latest revision")
+	g.describe("Profile.revision", "Latest revision number")
+	g.describe("Revision", "One saved version of a profile's business and the consultation run against it")
+	g.describe("Revision.result", "The revision's latest consultation; omitted from revision lists")
+	g.describe("Consultation", "A revision's new consultation and what changed since the profile was last consulted")
+	g.describe("Consultation.diff", "Comparison with the closest earlier consulted revision; absent on the first consultation")
+	g.requireOnly(diff.CompareRequest{}, "before")
+	g.describe("CompareRequest", "Two consultations to compare, or one to run again against the current rules")
+	g.describe("CompareRequest.rerun", "Consult before's business again and compare the fresh result with before's; after must be empty")
+	g.describe("Snapshot", "A consultation result and, when known, the business it was run for")
+	g.describe("Snapshot.business", "Needed to run the consultation again, list edited fields and tell ads from organic posts")
+	g.describe("Comparison", "How the recommendations, risks and advice moved between two consultations")
+	g.describe("Comparison.from", "Label of the earlier consultation, e.g. \"revision 2\"")
+	g.describe("Comparison.to", "Label of the later consultation, e.g. \"current rules\" for a rerun")
+	g.describe("Comparison.changes", "Edited business fields; empty unless both businesses are known")
+	g.describe("Comparison.platforms", "Every platform recommended in either consultation, in the later rank order with dropped platforms last")
+	g.describe("Comparison.summary", "One line in the later business's locale, e.g. \"Budget rose from $80 to $250 → Facebook ads now recommended\"")
+	g.describe("Change", "One edited business field; from and to are empty for the brand voice")
+	g.describe("PlatformDelta", "One platform's recommendation in both consultations")
+	g.describe("PlatformDelta.from_rank", "Rank in the earlier consultation; 0 when not recommended")
+	g.describe("PlatformDelta.to_rank", "Rank in the later consultation; 0 when not recommended")
+	g.describe("PlatformDelta.score_delta", "Score change, for platforms both consultations recommend")
+	g.describe("PlatformDelta.to_ads", "Whether the later budget buys ads on the platform rather than only funding organic posts")
+	g.describe("PlatformDelta.reasoning_added", "Reasoning sentences only the later consultation gave")
+	g.describe("PlatformDelta.reasoning_removed", "Reasoning sentences only the earlier consultation gave")
+	g.describe("RiskChanges", "Risks only the later (added) or only the earlier (removed) consultation raised")
+	g.requireOnly(scenario.ExploreRequest{}, "business")
+	g.describe("ExploreRequest", "A base business and what-if variations of it: named variations, a grid of budget, goal and weekly hours values, or both")
+	g.describe("ExploreRequest.hours_per_week", "The base's weekly content hours; defaults to 3")
+	g.describe("ExploreRequest.budget", "Budgets to sweep; tipping points are located between them")
+	g.describe("ExploreRequest.goals", "Goals to sweep; each gets its own tipping points")
+	g.describe("ExploreRequest.hours", "Weekly content hours to sweep; they change the posting cadence, not the ranking")
+	g.describe("ExploreRequest.top", "How many places tipping points track; defaults to 3")
+	g.describe("Range", "An inclusive sweep from from to to in steps of step; to is always included")
+	g.describe("Variation", "One named what-if; fields left out keep the base's value")
+	g.describe("Variation.name", "Defaults to a description of the overrides, e.g. \"budget $150\"")
+	g.describe("Matrix", "Every scenario's ranking side by side, and the budgets where platforms enter or drop out of the top places")
+	g.describe("Matrix.platforms", "Every platform a scenario ranks, best rank first")
+	g.describe("Matrix.scenarios", "The base first, then the variations, then the grid in goal, budget and hours order")
+	g.describe("Matrix.tipping_points", "Located along the budget range for each goal; empty without a budget range")
+	g.describe("Scenario", "One variation of the business and how the deterministic pipeline ranks it")
+	g.describe("Scenario.rankings", "Every platform that passes the filters, not just the top places")
+	g.describe("Scenario.cadence", "Weekly hours shared between the platforms in the top places, as the content calendar would")
+	g.describe("Ranking", "One platform's place in a scenario")
+	g.describe("TippingPoint", "The budget at which a platform enters or drops out of the top places for one goal")
+	g.describe("TippingPoint.budget", "Lowest budget, to the cent, from which the change holds")
+	g.describe("TippingPoint.rank", "Rank at the tipping budget; 0 when the platform is not ranked")
+	g.describe("TippingPoint.from_tier", "Budget tier just below the tipping budget; it differs from to_tier at a tier boundary")
+	g.requireOnly(sensitivity.AnalyzeRequest{}, "business")
+	g.describe("AnalyzeRequest", "A business whose platform scores to perturb, and how")
+	g.describe("AnalyzeRequest.runs", "Monte Carlo runs; defaults to 1000, at most 20000")
+	g.describe("AnalyzeRequest.spread", "Largest relative perturbation of each parameter, above 0 and at most 1; defaults to 0.2 for ±20%")
+	g.describe("AnalyzeRequest.seed", "Random seed; the same seed gives the same report. Defaults to 1")
+	g.describe("Report", "How stable a business's recommendations are when the metadata numbers and constraint penalties behind their scores are perturbed")
+	g.describe("Report.top", "How many places the recommendations fill")
+	g.describe("Report.stability", "Share of runs whose top places match the unperturbed ranking in order")
+	g.describe("Report.set_stability", "Share of runs in which the same platforms fill the top places in any order")
+	g.describe("Report.platforms", "Every platform that passes the filters, in unperturbed rank order; those ranked within top are the recommendations")
+	g.describe("Report.influences", "The parameters the top places depend on most, those that reorder them first")
+	g.describe("PlatformStability", "How one platform's place held up across the runs")
+	g.describe("PlatformStability.margin", "Score lead over the next-ranked platform; 0 for the last")
+	g.describe("PlatformStability.rank_stability", "Share of runs in which the platform kept its rank")
+	g.describe("PlatformStability.top_share", "Share of runs in which the platform ranked within the top places")
+	g.describe("PlatformStability.score_low", "Lower bound of the middle 95% of the platform's perturbed scores")
+	g.describe("PlatformStability.score_high", "Upper bound of the middle 95% of the platform's perturbed scores")
+	g.describe("PlatformStability.verdict", "solid from 95% rank stability, likely from 75%, otherwise fragile")
+	g.enum(sensitivity.Parameter(""), stringsOf(sensitivity.Parameters())...)
+	g.describe("Parameter", "A number behind a platform's score: a metadata number on the 1-10 scale or a constraint penalty from 0 to 1")
+	g.describe("Influence", "What moving one parameter to either end of the spread, with the others unperturbed, does")
+	g.describe("Influence.value", "The unperturbed parameter")
+	g.describe("Influence.low", "The parameter at the low end of the spread")
+	g.describe("Influence.high", "The parameter at the high end of the spread")
+	g.describe("Influence.score_low", "The platform's score with the parameter at low")
+	g.describe("Influence.score_high", "The platform's score with the parameter at high")
+	g.describe("Influence.reorders", "Whether either end changes which platforms fill the top places or their order")
+	g.describe("Conversation", "A business input being clarified: follow-up questions while status is asking, the consultation result once it is complete")
+	g.describe("Conversation.business", "The business input with every answer so far merged in")
+	g.describe("Conversation.questions", "Follow-ups waiting for answers; empty unless status is asking")
+	g.describe("Conversation.asked", "Fields already asked about; none is asked twice")
+	g.describe("Conversation.result", "Set once status is complete")
+	g.enum(clarify.ConversationStatus(""), stringsOf([]clarify.ConversationStatus{
+		clarify.StatusAsking, clarify.StatusReady, clarify.StatusComplete,
+	})...)
+	g.describe("ConversationStatus", "Where a clarifying conversation stands")
+	g.requireOnly(clarify.AnswerRequest{}, "answers")
+	g.describe("AnswerRequest", "Answers to a conversation's pending questions")
+	g.describe("AnswerRequest.answers", "Answers keyed by question field; a pending question left out is skipped")
+	g.enum(jobs.Status(""), stringsOf([]jobs.Status{
+		jobs.StatusQueued, jobs.StatusRunning, jobs.StatusSucceeded, jobs.StatusFailed, jobs.StatusCancelled,
+	})...)
+	g.describe("Status", "Lifecycle state of an asynchronous consultation")
+	g.describe("Job", "An asynchronous consultation; result is set once status is succeeded")
+	g.describe("Stats", "Cache lookup counters for one pipeline stage")
+	g.describe("MetricsResponse.cache", "Counters keyed by cache stage: deterministic or llm")
+
+	businessInput := g.ref(reflect.TypeOf(core.BusinessInput{}))
+	result := g.ref(reflect.TypeOf(core.ConsultationResult{}))
+	errorResponse := g.ref(reflect.TypeOf(ErrorResponse{}))
+	health := g.ref(reflect.TypeOf(HealthResponse{}))
+	job := g.ref(reflect.TypeOf(jobs.Job{}))
+	metrics := g.ref(reflect.TypeOf(MetricsResponse{}))
+	planRequest := g.ref(reflect.TypeOf(calendar.PlanRequest{}))
+	plan := g.ref(reflect.TypeOf(calendar.Calendar{}))
+	exportRequest := g.ref(reflect.TypeOf(export.ExportRequest{}))
+	uploadRequest := g.ref(reflect.TypeOf(feedback.UploadRequest{}))
+	summary := g.ref(reflect.TypeOf(feedback.Summary{}))
+	saveRequest := g.ref(reflect.TypeOf(profiles.SaveRequest{}))
+	profileList := g.ref(reflect.TypeOf([]profiles.Profile{}))
+	revision := g.ref(reflect.TypeOf(profiles.Revision{}))
+	revisions := g.ref(reflect.TypeOf([]profiles.Revision{}))
+	consultation := g.ref(reflect.TypeOf(profiles.Consultation{}))
+	compareRequest := g.ref(reflect.TypeOf(diff.CompareRequest{}))
+	comparison := g.ref(reflect.TypeOf(diff.Comparison{}))
+	exploreRequest := g.ref(reflect.TypeOf(scenario.ExploreRequest{}))
+	matrix := g.ref(reflect.TypeOf(scenario.Matrix{}))
+	analyzeRequest := g.ref(reflect.TypeOf(sensitivity.AnalyzeRequest{}))
+	report := g.ref(reflect.TypeOf(sensitivity.Report{}))
+	conversation := g.ref(reflect.TypeOf(clarify.Conversation{}))
+	answerRequest := g.ref(reflect.TypeOf(clarify.AnswerRequest{}))
+
+	if budget, ok := g.components["BusinessInput"].Properties.Lookup("budget"); ok {
+		minimum := 0.0
+		budget.Minimum = &minimum
+	}
+	addLegacyAliases(g.components["BusinessInput"])
+	for _, name := range []string{"formality", "enthusiasm"} {
+		if slider, ok := g.components["Tone"].Properties.Lookup(name); ok {
+			minimum, maximum := -1.0, 1.0
+			slider.Minimum, slider.Maximum = &minimum, &maximum
+		}
+	}
+
+	jsonBody := func(schema *Schema) map[string]*MediaType {
+		return map[string]*MediaType{"application/json": {Schema: schema}}
+	}
+	profileName := Parameter{Name: "name", In: "path", Required: true, Description: "Profile name", Schema: &Schema{Type: "string"}}
+	revisionNumber := func(name, description string) Parameter {
+		return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "integer", Format: "int32"}}
+	}
+	conversationID := Parameter{Name: "id", In: "path", Required: true, Description: "Conversation ID", Schema: &Schema{Type: "string"}}
+	jobID := Parameter{Name: "id", In: "path", Required: true, Description: "Job ID", Schema: &Schema{Type: "string"}}
+	errorResponses := func(responses map[string]*Response) map[string]*Response {
+		responses["400"] = &Response{Description: "Invalid business input", Content: jsonBody(errorResponse)}
+		responses["500"] = &Response{Description: "Consultation failed", Content: jsonBody(errorResponse)}
+		return responses
+	}
+
+	return &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "BizFlow Marketing Consultant API",
+			Version:     APIVersion,
+			Description: "Platform recommendations, content and risks for micro-businesses.",
+		},
+		Paths: map[string]*PathItem{
+			"/run-agent": {
+				Post: &Operation{
+					OperationID: "runAgent",
+					Summary:     "Run a consultation",
+					RequestBody: &RequestBody{Required: true, Content: jsonBody(businessInput)},
+					Responses: errorResponses(map[string]*Response{
+						"200": {Description: "Consultation result", Content: jsonBody(result)},
+						"503": {Description: "Consultation cancelled or timed out", Content: jsonBody(errorResponse)},
+					}),
+				},
+			},
+			"/run-agent/stream": {
+				Post: &Operation{
+					OperationID: "streamAgent",
+					Summary:     "Run a consultation and stream progress as Server-Sent Events",
+					Description: "Emits filtered, scored, confidence, persona, content, risks and advice events as each " +
+						"stage completes, then a final result (a ConsultationResult) or error event. " +
+						"Content is only generated for the top recommendation, so there is a single content event, " +
+						"for rank 1, and no other recommendation has a content template in the result. " +
+						"GET with the BusinessInput fields as query parameters is also supported for EventSource.",
+					RequestBody: &RequestBody{Required: true, Content: jsonBody(businessInput)},
+					Responses: map[string]*Response{
+						"200": {
+							Description: "Stream of consultation events",
+							Content:     map[string]*MediaType{"text/event-stream": {Schema: &Schema{Type: "string"}}},
+						},
+						"400": {Description: "Invalid business input", Content: jsonBody(errorResponse)},
+					},
+				},
+			},
+			"/consultations": {
+				Post: &Operation{
+					OperationID: "createConsultation",
+					Summary:     "Queue a consultation and return its job",
+					RequestBody: &RequestBody{Required: true, Content: jsonBody(businessInput)},
+					Responses: errorResponses(map[string]*Response{
+						"202": {Description: "Job queued; poll the Location header", Content: jsonBody(job)},
+					}),
+				},
+			},
+			"/consultations/{id}": {
+				Get: &Operation{
+					OperationID: "getConsultation",
+					Summary:     "Get a consultation job's status and result",
+					Parameters:  []Parameter{jobID},
+					Responses: map[string]*Response{
+						"200": {Description: "Current job state", Content: jsonBody(job)},
+						"404": {Description: "Unknown job", Content: jsonBody(errorResponse)},
+					},
+				},
+				Delete: &Operation{
+					OperationID: "cancelConsultation",
+					Summary:     "Cancel a queued or running consultation job",
+					Parameters:  []Parameter{jobID},
+					Responses: map[string]*Response{
+						"200": {Description: "Job state after cancelling", Content: jsonBody(job)},
+						"404": {Description: "Unknown job", Content: jsonBody(errorResponse)},
+					},
+				},
+			},
+			"/conversations": {
+				Post: &Operation{
+					OperationID: "startConversation",
+					Summary:     "Ask follow-up questions about missing or ambiguous business details before consulting",
+					Description: "The description may be empty, since it is asked for. Without any questions the " +
+						"consultation runs at once and the conversation is complete.",
+					RequestBody: &RequestBody{Required: true, Content: jsonBody(businessInput)},
+					Responses: errorResponses(map[string]*Response{
+						"200": {Description: "The conversation with its questions or result", Content: jsonBody(conversation)},
+						"503": {Description: "Consultation cancelled or timed out", Content: jsonBody(errorResponse)},
+					}),
+				},
+			},
+			"/conversations/{id}": {
+				Get: &Operation{
+					OperationID: "getConversation",
+					Summary:     "Get a conversation's questions or result",
+					Parameters:  []Parameter{conversationID},
+					Responses: map[string]*Response{
+						"200": {Description: "Current conversation state", Content: jsonBody(conversation)},
+						"404": {Description: "Unknown or expired conversation", Content: jsonBody(errorResponse)},
+					},
+				},
+			},
+			"/conversations/{id}/answers": {
+				Post: &Operation{
+					OperationID: "answerConversation",
+					Summary:     "Answer a conversation's pending questions",
+					Description: "Merges the answers into the business input, then asks any follow-ups they raise " +
+						"or runs the consultation.",
+					Parameters:  []Parameter{conversationID},
+					RequestBody: &RequestBody{Required: true, Content: jsonBody(answerRequest)},
+					Responses: errorResponses(map[string]*Response{
+						"200": {Description: "The conversation with its next questions or result", Content: jsonBody(conversation)},
+						"404": {Description: "Unknown or expired conversation", Content: jsonBody(errorResponse)},
+						"409": {Description: "Conversation already complete", Content: jsonBody(errorResponse)},
+						"503": {Description: "Consultation cancelled or timed out", Content: jsonBody(errorResponse)},
+					}),
+				},
+			},
+			"/calendar": {
+				Post: &Operation{
+					OperationID: "planCalendar",
+					Summary:     "Plan weeks of posts across the recommended platforms",
+					RequestBody: &RequestBody{Required: true, Content: jsonBody(planRequest)},
+					Responses: errorResponses(map[string]*Response{
+						"200": {Description: "Content calendar", Content: jsonBody(plan)},
+					}),
+				},
+			},
+			"/scenarios": {
+				Post: &Operation{
+					OperationID: "exploreScenarios",
+					Summary:     "Rank what-if variations of a business's budget, goal and weekly hours side by side and find tipping points",
+					RequestBody: &RequestBody{Required: true, Content: jsonBody(exploreRequest)},
+					Responses: map[string]*Response{
+						"200": {Description: "Ranking matrix and tipping points", Content: jsonBody(matrix)},
+						"400": {Description: "Invalid business, variation or range", Content: jsonBody(errorResponse)},
+					},
+				},
+			},
+			"/sensitivity": {
+				Post: &Operation{
+					OperationID: "analyzeSensitivity",
+					Summary:     "Perturb the numbers behind a business's platform scores and report how stable its recommendations are",
+					RequestBody: &RequestBody{Required: true, Content: jsonBody(analyzeRequest)},
+					Responses: errorResponses(map[string]*Response{
+						"200": {Description: "Rank stability, score intervals and the most influential parameters", Content: jsonBody(report)},
+					}),
+				},
+			},
+			"/export/ics": {
+				Post: &Operation{
+					OperationID: "exportICS",
+					Summary:     "Export a consultation result or calendar as iCalendar events with reminders",
+					RequestBody: &RequestBody{Required: true, Content: jsonBody(exportRequest)},
+					Responses: map[string]*Response{
+						"200": {
+							Description: "iCalendar file with one event per post",
+							Content:     map[string]*MediaType{"text/calendar": {Schema: &Schema{Type: "string"}}},
+						},
+						"400": {Description: "Invalid export request", Content: jsonBody(errorResponse)},
+					},
+				},
+			},
+			"/export/csv": {
+				Post: &Operation{
+					OperationID: "exportCSV",
+					Summary:     "Export a consultation result or calendar as CSV for spreadsheets or scheduling tools",
+					RequestBody: &RequestBody{Required: true, Content: jsonBody(exportRequest)},
+					Responses: map[string]*Response{
+						"200": {
+							Description: "CSV file with one row per post",
+							Content:     map[string]*MediaType{"text/csv": {Schema: &Schema{Type: "string"}}},
+						},
+						"400": {Description: "Invalid export request", Content: jsonBody(errorResponse)},
+					},
+				},
+			},
+			"/performance": {
+				Post: &Operation{
+					OperationID: "reportPerformance",
+					Summary:     "Record a business profile's reported results and recalibrate its platforms",
+					Description: "Available when the server runs with a feedback database.",
+					RequestBody: &RequestBody{Required: true, Content: jsonBody(uploadRequest)},
+					Responses: map[string]*Response{
+						"200": {Description: "Recalibrated platforms", Content: jsonBody(summary)},
+						"400": {Description: "Invalid business, profile or results", Content: jsonBody(errorResponse)},
+						"500": {Description: "Results could not be stored", Content: jsonBody(errorResponse)},
+					},
+				},
+			},
+			"/profiles": {
+				Get: &Operation{
+					OperationID: "listProfiles",
+					Summary:     "List stored business profiles",
+					Description: "Profile endpoints are available when the server runs with a profiles database.",
+					Responses: map[string]*Response{
+						"200": {Description: "Profiles by name", Content: jsonBody(profileList)},
+					},
+				},
+			},
+			"/profiles/{name}": {
+				Get: &Operation{
+					OperationID: "getProfile",
+					Summary:     "Get a profile's latest revision, or the one named by ?revision=, with its consultation",
+					Parameters:  []Parameter{profileName, revisionNumber("revision", "Revision to get; defaults to the latest")},
+					Responses: map[string]*Response{
+						"200": {Description: "Profile revision", Content: jsonBody(revision)},
+						"404": {Description: "Unknown profile or revision", Content: jsonBody(errorResponse)},
+					},
+				},
+				Put: &Operation{
+					OperationID: "saveProfile",
+					Summary:     "Store a business as the profile's next revision, creating the profile on first save",
+					Parameters:  []Parameter{profileName},
+					RequestBody: &RequestBody{Required: true, Content: jsonBody(saveRequest)},
+					Responses: map[string]*Response{
+						"200": {Description: "Business unchanged; the latest revision", Content: jsonBody(revision)},
+						"201": {Description: "New revision", Content: jsonBody(revision)},
+						"400": {Description: "Invalid profile name or business input", Content: jsonBody(errorResponse)},
+					},
+				},
+				Delete: &Operation{
+					OperationID: "deleteProfile",
+					Summary:     "Delete a profile and all its revisions",
+					Parameters:  []Parameter{profileName},
+					Responses: map[string]*Response{
+						"204": {Description: "Profile deleted"},
+						"404": {Description: "Unknown profile", Content: jsonBody(errorResponse)},
+					},
+				},
+			},
+			"/profiles/{name}/revisions": {
+				Get: &Operation{
+					OperationID: "listProfileRevisions",
+					Summary:     "List a profile's revisions, oldest first, without their consultations",
+					Parameters:  []Parameter{profileName},
+					Responses: map[string]*Response{
+						"200": {Description: "Revisions", Content: jsonBody(revisions)},
+						"404": {Description: "Unknown profile", Content: jsonBody(errorResponse)},
+					},
+				},
+			},
+			"/profiles/{name}/consultations": {
+				Post: &Operation{
+					OperationID: "consultProfile",
+					Summary:     "Consult a profile's latest revision, or the one named by ?revision=, and compare with its last consultation",
+					Parameters:  []Parameter{profileName, revisionNumber("revision", "Revision to consult; defaults to the latest")},
+					Responses: errorResponses(map[string]*Response{
+						"200": {Description: "Stored consultation and its diff", Content: jsonBody(consultation)},
+						"404": {Description: "Unknown profile or revision", Content: jsonBody(errorResponse)},
+					}),
+				},
+			},
+			"/profiles/{name}/diff": {
+				Get: &Operation{
+					OperationID: "diffProfile",
+					Summary:     "Compare the consultations of two revisions of a profile, or one against the current rules",
+					Parameters: []Parameter{
+						profileName,
+						revisionNumber("from", "Earlier revision; defaults to the consulted revision before to"),
+						revisionNumber("to", "Later revision; defaults to the latest consulted, or the latest with rerun"),
+						{Name: "rerun", In: "query", Description: "Consult revision to again against the current rules, without storing the result, and compare with its stored consultation", Schema: &Schema{Type: "boolean"}},
+					},
+					Responses: map[string]*Response{
+						"200": {Description: "Revision diff", Content: jsonBody(comparison)},
+						"400": {Description: "Invalid revision number", Content: jsonBody(errorResponse)},
+						"404": {Description: "Unknown profile or revision", Content: jsonBody(errorResponse)},
+						"409": {Description: "A revision has not been consulted", Content: jsonBody(errorResponse)},
+					},
+				},
+			},
+			"/diff": {
+				Post: &Operation{
+					OperationID: "compareConsultations",
+					Summary:     "Compare two consultations, or run one again against the current rules and compare",
+					RequestBody: &RequestBody{Required: true, Content: jsonBody(compareRequest)},
+					Responses: map[string]*Response{
+						"200": {Description: "Comparison", Content: jsonBody(comparison)},
+						"400": {Description: "Missing result, or missing or invalid business for a rerun", Content: jsonBody(errorResponse)},
+						"500": {Description: "Consultation failed", Content: jsonBody(errorResponse)},
+					},
+				},
+			},
+			"/health": {
+				Get: &Operation{
+					OperationID: "getHealth",
+					Summary:     "Check that the server is up",
+					Responses: map[string]*Response{
+						"200": {Description: "Server is healthy", Content: jsonBody(health)},
+					},
+				},
+			},
+			"/metrics": {
+				Get: &Operation{
+					OperationID: "getMetrics",
+					Summary:     "Cache hit and miss counters",
+					Responses: map[string]*Response{
+						"200": {Description: "Current counters", Content: jsonBody(metrics)},
+					},
+				},
+			},
+			"/openapi.json": {
+				Get: &Operation{
+					OperationID: "getOpenAPI",
+					Summary:     "This OpenAPI document",
+					Responses: map[string]*Response{
+						"200": {Description: "OpenAPI 3 document", Content: jsonBody(&Schema{Type: "object"})},
+					},
+				},
+			},
+		},
+		Components: Components{Schemas: g.components},
+	}
+}
+
+// addLegacyAliases documents the deprecated BusinessInput field names
+func addLegacyAliases(schema *Schema) {
+	aliases := make([]string, 0, len(core.LegacyFieldAliases))
+	for alias := range core.LegacyFieldAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	for _, alias := range aliases {
+		target, ok := schema.Properties.Lookup(core.LegacyFieldAliases[alias])
+		if !ok {
+			continue
+		}
+		schema.Properties = append(schema.Properties, Property{Name: alias, Schema: &Schema{
+			Ref:         target.Ref,
+			Type:        target.Type,
+			Format:      target.Format,
+			Description: "Deprecated alias for " + core.LegacyFieldAliases[alias],
+			Deprecated:  true,
+		}})
+	}
+}
+
+// stringsOf converts a slice of named string types to plain strings
+func stringsOf[T ~string](values []T) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		out = append(out, string(value))
+	}
+	return out
+}
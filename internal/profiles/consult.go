@@ -0,0 +1,141 @@
+package profiles
+
+import (
+	"context"
+	"fmt"
+
+	"biz-flow/internal/diff"
+)
+
+// Consultation is a revision's new consultation and what changed since the
+// profile was last consulted
+type Consultation struct {
+	Revision *Revision `json:"revision"`
+	// Diff compares with the closest earlier consulted revision; it is nil
+	// for the profile's first consultation
+	Diff *diff.Comparison `json:"diff,omitempty"`
+}
+
+// Consult runs the profile's revision (its latest when number is 0), stores
+// the result on it and compares it with the closest earlier consulted
+// revision
+func Consult(ctx context.Context, repo Repository, consult diff.ConsultFunc, name string, number int) (*Consultation, error) {
+	revision, err := repo.Get(ctx, name, number)
+	if err != nil {
+		return nil, err
+	}
+	result, err := consult(ctx, revision.Business)
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.SetResult(ctx, name, revision.Number, result); err != nil {
+		return nil, err
+	}
+	if revision, err = repo.Get(ctx, name, revision.Number); err != nil {
+		return nil, err
+	}
+
+	consultation := &Consultation{Revision: revision}
+	previous, err := previous(ctx, repo, name, revision.Number)
+	if err != nil || previous == nil {
+		return consultation, err
+	}
+	consultation.Diff, err = compare(previous, revision)
+	return consultation, err
+}
+
+// DiffRevisions compares two consulted revisions of a profile. When to is 0
+// it is the latest consulted revision, and when from is 0 it is the closest
+// consulted revision before to.
+func DiffRevisions(ctx context.Context, repo Repository, name string, from, to int) (*diff.Comparison, error) {
+	var (
+		later *Revision
+		err   error
+	)
+	if to == 0 {
+		later, err = previous(ctx, repo, name, 0)
+		if err == nil && later == nil {
+			err = fmt.Errorf("profile %q: %w", name, ErrNotConsulted)
+		}
+	} else {
+		later, err = repo.Get(ctx, name, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var earlier *Revision
+	if from == 0 {
+		earlier, err = previous(ctx, repo, name, later.Number)
+		if err == nil && earlier == nil {
+			err = fmt.Errorf("profile %q has no consulted revision before %d: %w", name, later.Number, ErrNotConsulted)
+		}
+	} else {
+		earlier, err = repo.Get(ctx, name, from)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return compare(earlier, later)
+}
+
+// Rerun consults the profile's revision (its latest when number is 0)
+// against the current rules without storing the result, and compares it
+// with the revision's stored consultation
+func Rerun(ctx context.Context, repo Repository, consult diff.ConsultFunc, name string, number int) (*diff.Comparison, error) {
+	revision, err := repo.Get(ctx, name, number)
+	if err != nil {
+		return nil, err
+	}
+	if revision.Result == nil {
+		return nil, fmt.Errorf("profile %q revision %d: %w", name, revision.Number, ErrNotConsulted)
+	}
+	comparison, err := diff.Rerun(ctx, consult, snapshot(revision))
+	if err != nil {
+		return nil, err
+	}
+	comparison.From = label(revision)
+	return comparison, nil
+}
+
+// compare diffs the consultations of two revisions
+func compare(from, to *Revision) (*diff.Comparison, error) {
+	for _, revision := range []*Revision{from, to} {
+		if revision.Result == nil {
+			return nil, fmt.Errorf("profile %q revision %d: %w", revision.Profile, revision.Number, ErrNotConsulted)
+		}
+	}
+	comparison, err := diff.Compare(snapshot(from), snapshot(to))
+	if err != nil {
+		return nil, err
+	}
+	comparison.From, comparison.To = label(from), label(to)
+	return comparison, nil
+}
+
+// snapshot pairs the revision's business with its consultation
+func snapshot(revision *Revision) diff.Snapshot {
+	return diff.Snapshot{Business: &revision.Business, Result: revision.Result}
+}
+
+// label names the revision in a comparison
+func label(revision *Revision) string {
+	return fmt.Sprintf("revision %d", revision.Number)
+}
+
+// previous returns the latest consulted revision numbered below before, or
+// the latest consulted revision overall when before is 0. It returns nil
+// when there is none.
+func previous(ctx context.Context, repo Repository, name string, before int) (*Revision, error) {
+	history, err := repo.History(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		revision := history[i]
+		if revision.ConsultedAt != nil && (before == 0 || revision.Number < before) {
+			return repo.Get(ctx, name, revision.Number)
+		}
+	}
+	return nil, nil
+}
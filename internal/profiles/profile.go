@@ -0,0 +1,90 @@
+// Package profiles stores businesses so they can be saved once, edited over
+// time and consulted again. Every edit is a new revision of the profile; each
+// revision keeps the consultation run against it, so consultations can be
+// compared across revisions to show what an edit changed.
+package profiles
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"biz-flow/internal/core"
+)
+
+// ErrNotFound is returned when a profile or revision does not exist
+var ErrNotFound = errors.New("profiles: not found")
+
+// ErrNotConsulted is returned when a revision has no consultation to compare
+var ErrNotConsulted = errors.New("profiles: revision has not been consulted")
+
+// Profile is a stored business and its latest revision
+type Profile struct {
+	Name string `json:"name"`
+	// Revision is the latest revision's number
+	Revision    int                `json:"revision"`
+	Business    core.BusinessInput `json:"business"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	ConsultedAt *time.Time         `json:"consulted_at,omitempty"`
+}
+
+// Revision is one saved version of a profile's business and the
+// consultation run against it, if any
+type Revision struct {
+	Profile  string             `json:"profile"`
+	Number   int                `json:"revision"`
+	Business core.BusinessInput `json:"business"`
+	// Note says why the business was edited, e.g. "raised the ad budget"
+	Note        string                   `json:"note,omitempty"`
+	CreatedAt   time.Time                `json:"created_at"`
+	Result      *core.ConsultationResult `json:"result,omitempty"`
+	ConsultedAt *time.Time               `json:"consulted_at,omitempty"`
+}
+
+// SaveRequest is the body of PUT /profiles/{name}: the business to store as
+// the profile's next revision
+type SaveRequest struct {
+	Business core.BusinessInput `json:"business"`
+	Note     string             `json:"note,omitempty"`
+}
+
+// Repository stores profiles and their revisions. Implementations must be
+// safe for concurrent use.
+type Repository interface {
+	// Save stores business as the profile's next revision, creating the
+	// profile on its first save. Saving the latest revision's business again
+	// stores nothing and returns that revision with created false.
+	Save(ctx context.Context, name string, business core.BusinessInput, note string) (revision *Revision, created bool, err error)
+
+	// Get returns the profile's revision, or its latest when number is 0,
+	// with its consultation. It returns ErrNotFound for unknown profiles and
+	// revisions.
+	Get(ctx context.Context, name string, number int) (*Revision, error)
+
+	// History returns the profile's revisions, oldest first, without their
+	// consultations
+	History(ctx context.Context, name string) ([]Revision, error)
+
+	// List returns every profile by name
+	List(ctx context.Context) ([]Profile, error)
+
+	// SetResult stores the consultation run against a revision, replacing
+	// any earlier one
+	SetResult(ctx context.Context, name string, number int, result *core.ConsultationResult) error
+
+	// Delete removes the profile and all its revisions
+	Delete(ctx context.Context, name string) error
+
+	// Close releases the backend's resources
+	Close() error
+}
+
+// validateName checks a profile name; unlike a business's optional profile,
+// a stored profile needs one
+func validateName(name string) error {
+	if name == "" {
+		return &core.ValidationError{Field: "profile", Message: "name is required"}
+	}
+	return core.ValidateProfile(name)
+}
@@ -0,0 +1,268 @@
+package profiles
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/sqlite"
+)
+
+const profilesSchema = `
+CREATE TABLE IF NOT EXISTS profiles (
+	name       TEXT PRIMARY KEY,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+CREATE TABLE IF NOT EXISTS revisions (
+	profile      TEXT NOT NULL REFERENCES profiles (name) ON DELETE CASCADE,
+	number       INTEGER NOT NULL,
+	business     TEXT NOT NULL,
+	note         TEXT NOT NULL DEFAULT '',
+	created_at   TIMESTAMP NOT NULL,
+	result       TEXT,
+	consulted_at TIMESTAMP,
+	PRIMARY KEY (profile, number)
+);
+`
+
+// SQLiteRepository keeps profiles in a SQLite database
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRepository opens (creating if needed) the profiles database at
+// path
+func NewSQLiteRepository(path string) (*SQLiteRepository, error) {
+	db, err := sqlite.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(profilesSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating profiles tables: %w", err)
+	}
+	return &SQLiteRepository{db: db}, nil
+}
+
+// Save implements Repository
+func (r *SQLiteRepository) Save(ctx context.Context, name string, business core.BusinessInput, note string) (*Revision, bool, error) {
+	if err := validateName(name); err != nil {
+		return nil, false, err
+	}
+	business.Profile = name
+	business.Calibration = nil
+	if business.Channels == nil {
+		business.Channels = []string{}
+	}
+	if err := business.Validate(); err != nil {
+		return nil, false, err
+	}
+	data, err := json.Marshal(business)
+	if err != nil {
+		return nil, false, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	var (
+		latest int
+		stored sql.NullString
+	)
+	if err := tx.QueryRowContext(ctx,
+		`SELECT number, business FROM revisions WHERE profile = ? ORDER BY number DESC LIMIT 1`,
+		name,
+	).Scan(&latest, &stored); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, fmt.Errorf("saving profile: %w", err)
+	}
+	if stored.Valid && stored.String == string(data) {
+		// The database has one connection, which the transaction holds
+		tx.Rollback()
+		revision, err := r.Get(ctx, name, latest)
+		return revision, false, err
+	}
+
+	now := time.Now().UTC()
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO profiles (name, created_at, updated_at) VALUES (?, ?, ?)
+		 ON CONFLICT (name) DO UPDATE SET updated_at = excluded.updated_at`,
+		name, now, now,
+	); err != nil {
+		return nil, false, fmt.Errorf("saving profile: %w", err)
+	}
+	revision := &Revision{Profile: name, Number: latest + 1, Business: business, Note: note, CreatedAt: now}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO revisions (profile, number, business, note, created_at) VALUES (?, ?, ?, ?, ?)`,
+		name, revision.Number, string(data), note, now,
+	); err != nil {
+		return nil, false, fmt.Errorf("saving profile: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("saving profile: %w", err)
+	}
+	return revision, true, nil
+}
+
+// Get implements Repository
+func (r *SQLiteRepository) Get(ctx context.Context, name string, number int) (*Revision, error) {
+	query := `SELECT profile, number, business, note, created_at, result, consulted_at FROM revisions WHERE profile = ? AND number = ?`
+	args := []any{name, number}
+	if number == 0 {
+		query = `SELECT profile, number, business, note, created_at, result, consulted_at FROM revisions WHERE profile = ? ORDER BY number DESC LIMIT 1`
+		args = args[:1]
+	}
+
+	revision, err := scanRevision(r.db.QueryRowContext(ctx, query, args...), true)
+	if errors.Is(err, sql.ErrNoRows) {
+		if number == 0 {
+			return nil, fmt.Errorf("profile %q: %w", name, ErrNotFound)
+		}
+		return nil, fmt.Errorf("profile %q revision %d: %w", name, number, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading profile: %w", err)
+	}
+	return revision, nil
+}
+
+// History implements Repository
+func (r *SQLiteRepository) History(ctx context.Context, name string) ([]Revision, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT profile, number, business, note, created_at, NULL, consulted_at FROM revisions WHERE profile = ? ORDER BY number`,
+		name,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("loading profile history: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []Revision
+	for rows.Next() {
+		revision, err := scanRevision(rows, false)
+		if err != nil {
+			return nil, fmt.Errorf("loading profile history: %w", err)
+		}
+		revisions = append(revisions, *revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("loading profile history: %w", err)
+	}
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("profile %q: %w", name, ErrNotFound)
+	}
+	return revisions, nil
+}
+
+// List implements Repository
+func (r *SQLiteRepository) List(ctx context.Context) ([]Profile, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT p.name, v.number, v.business, p.created_at, p.updated_at, c.consulted_at
+		 FROM profiles p
+		 JOIN revisions v ON v.profile = p.name
+		      AND v.number = (SELECT MAX(number) FROM revisions WHERE profile = p.name)
+		 LEFT JOIN revisions c ON c.profile = p.name
+		      AND c.number = (SELECT number FROM revisions WHERE profile = p.name AND consulted_at IS NOT NULL
+		                      ORDER BY consulted_at DESC LIMIT 1)
+		 ORDER BY p.name`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing profiles: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := []Profile{}
+	for rows.Next() {
+		var (
+			profile   Profile
+			business  string
+			consulted sql.NullTime
+		)
+		if err := rows.Scan(&profile.Name, &profile.Revision, &business, &profile.CreatedAt, &profile.UpdatedAt, &consulted); err != nil {
+			return nil, fmt.Errorf("listing profiles: %w", err)
+		}
+		if err := json.Unmarshal([]byte(business), &profile.Business); err != nil {
+			return nil, fmt.Errorf("decoding profile %q: %w", profile.Name, err)
+		}
+		if consulted.Valid {
+			profile.ConsultedAt = &consulted.Time
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, rows.Err()
+}
+
+// SetResult implements Repository
+func (r *SQLiteRepository) SetResult(ctx context.Context, name string, number int, result *core.ConsultationResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE revisions SET result = ?, consulted_at = ? WHERE profile = ? AND number = ?`,
+		string(data), time.Now().UTC(), name, number,
+	)
+	if err != nil {
+		return fmt.Errorf("storing consultation: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("profile %q revision %d: %w", name, number, ErrNotFound)
+	}
+	return nil
+}
+
+// Delete implements Repository
+func (r *SQLiteRepository) Delete(ctx context.Context, name string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM profiles WHERE name = ?`, name)
+	if err != nil {
+		return fmt.Errorf("deleting profile: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("profile %q: %w", name, ErrNotFound)
+	}
+	return nil
+}
+
+// Close implements Repository
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
+
+// scanner is a *sql.Row or *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanRevision reads a revisions row, decoding its consultation when
+// withResult is set
+func scanRevision(row scanner, withResult bool) (*Revision, error) {
+	var (
+		revision  Revision
+		business  string
+		result    sql.NullString
+		consulted sql.NullTime
+	)
+	if err := row.Scan(&revision.Profile, &revision.Number, &business, &revision.Note, &revision.CreatedAt, &result, &consulted); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(business), &revision.Business); err != nil {
+		return nil, fmt.Errorf("decoding business: %w", err)
+	}
+	if consulted.Valid {
+		revision.ConsultedAt = &consulted.Time
+	}
+	if withResult && result.Valid {
+		revision.Result = &core.ConsultationResult{}
+		if err := json.Unmarshal([]byte(result.String), revision.Result); err != nil {
+			return nil, fmt.Errorf("decoding consultation: %w", err)
+		}
+	}
+	return &revision, nil
+}
@@ -0,0 +1,211 @@
+package profiles
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"biz-flow/internal/core"
+)
+
+var bakery = core.BusinessInput{
+	Type:        core.Retail,
+	Description: "Sourdough bakery",
+	Location:    "Austin, TX",
+	Budget:      80,
+	Goal:        core.Awareness,
+}
+
+// openRepository opens a fresh SQLite repository for the test
+func openRepository(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	repo, err := NewSQLiteRepository(filepath.Join(t.TempDir(), "profiles.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+// consulted returns a consultation recommending platforms in order
+func consulted(platforms ...core.Platform) *core.ConsultationResult {
+	result := &core.ConsultationResult{StrategicAdvice: "Post weekly."}
+	for i, platform := range platforms {
+		result.Recommendations = append(result.Recommendations, core.Recommendation{Platform: platform, Rank: i + 1, Score: float64(90 - 10*i)})
+	}
+	return result
+}
+
+func TestSQLiteRepositoryRevisions(t *testing.T) {
+	ctx := context.Background()
+	repo := openRepository(t)
+
+	business := bakery
+	for want := 1; want <= 3; want++ {
+		business.Budget = float64(100 * want)
+		revision, created, err := repo.Save(ctx, "bakery", business, "budget change")
+		if err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if !created || revision.Number != want {
+			t.Errorf("Save = revision %d, created %v, want revision %d created", revision.Number, created, want)
+		}
+	}
+
+	revision, created, err := repo.Save(ctx, "bakery", business, "no change")
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if created || revision.Number != 3 || revision.Note != "budget change" {
+		t.Errorf("saving the same business = revision %d %q, created %v, want revision 3 unchanged", revision.Number, revision.Note, created)
+	}
+
+	latest, err := repo.Get(ctx, "bakery", 0)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if latest.Number != 3 || latest.Business.Budget != 300 || latest.Business.Profile != "bakery" {
+		t.Errorf("Get latest = revision %d, budget %v, profile %q, want revision 3 of bakery with budget 300",
+			latest.Number, latest.Business.Budget, latest.Business.Profile)
+	}
+	first, err := repo.Get(ctx, "bakery", 1)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if first.Business.Budget != 100 {
+		t.Errorf("revision 1 budget = %v, want 100", first.Business.Budget)
+	}
+
+	history, err := repo.History(ctx, "bakery")
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("History has %d revisions, want 3", len(history))
+	}
+	for i, revision := range history {
+		if revision.Number != i+1 {
+			t.Errorf("History[%d] is revision %d, want %d", i, revision.Number, i+1)
+		}
+	}
+
+	if _, _, err := repo.Save(ctx, "cafe", bakery, ""); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	profiles, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].Name != "bakery" || profiles[0].Revision != 3 || profiles[1].Name != "cafe" || profiles[1].Revision != 1 {
+		t.Errorf("List = %+v, want bakery at revision 3 and cafe at revision 1", profiles)
+	}
+}
+
+func TestSQLiteRepositoryNotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := openRepository(t)
+	if _, _, err := repo.Save(ctx, "bakery", bakery, ""); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"missing revision", func() error { _, err := repo.Get(ctx, "bakery", 2); return err }},
+		{"unknown profile", func() error { _, err := repo.Get(ctx, "cafe", 0); return err }},
+		{"unknown profile revision", func() error { _, err := repo.Get(ctx, "cafe", 1); return err }},
+		{"unknown profile history", func() error { _, err := repo.History(ctx, "cafe"); return err }},
+		{"result for a missing revision", func() error { return repo.SetResult(ctx, "bakery", 2, consulted(core.Instagram)) }},
+		{"delete an unknown profile", func() error { return repo.Delete(ctx, "cafe") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, ErrNotFound) {
+				t.Errorf("error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+
+	if err := repo.Delete(ctx, "bakery"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.Get(ctx, "bakery", 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrNotFound", err)
+	}
+	if _, err := repo.History(ctx, "bakery"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("History after Delete = %v, want the revisions deleted too", err)
+	}
+}
+
+func TestSQLiteRepositorySaveValidates(t *testing.T) {
+	repo := openRepository(t)
+	invalid := bakery
+	invalid.Budget = -1
+
+	for name, business := range map[string]core.BusinessInput{"": bakery, "bad name!": bakery, "bakery": invalid} {
+		var validation *core.ValidationError
+		if _, _, err := repo.Save(context.Background(), name, business, ""); !errors.As(err, &validation) {
+			t.Errorf("Save(%q) error = %v, want a validation error", name, err)
+		}
+	}
+}
+
+func TestConsult(t *testing.T) {
+	ctx := context.Background()
+	repo := openRepository(t)
+
+	results := []*core.ConsultationResult{consulted(core.Instagram), consulted(core.Instagram, core.Facebook)}
+	consult := func(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error) {
+		result := results[0]
+		results = results[1:]
+		return result, nil
+	}
+
+	business := bakery
+	if _, _, err := repo.Save(ctx, "bakery", business, ""); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := DiffRevisions(ctx, repo, "bakery", 0, 0); !errors.Is(err, ErrNotConsulted) {
+		t.Errorf("DiffRevisions before consulting = %v, want ErrNotConsulted", err)
+	}
+
+	first, err := Consult(ctx, repo, consult, "bakery", 0)
+	if err != nil {
+		t.Fatalf("Consult: %v", err)
+	}
+	if first.Diff != nil || first.Revision.Result == nil || first.Revision.ConsultedAt == nil {
+		t.Errorf("first Consult = %+v, want a stored result and no diff", first)
+	}
+
+	business.Budget = 250
+	if _, _, err := repo.Save(ctx, "bakery", business, "raised the budget"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	second, err := Consult(ctx, repo, consult, "bakery", 0)
+	if err != nil {
+		t.Fatalf("Consult: %v", err)
+	}
+	if second.Diff == nil || second.Diff.From != "revision 1" || second.Diff.To != "revision 2" {
+		t.Fatalf("second Consult diff = %+v, want revision 1 to revision 2", second.Diff)
+	}
+	if len(second.Diff.Added) != 1 || second.Diff.Added[0] != core.Facebook {
+		t.Errorf("Added = %v, want Facebook", second.Diff.Added)
+	}
+
+	comparison, err := DiffRevisions(ctx, repo, "bakery", 0, 0)
+	if err != nil {
+		t.Fatalf("DiffRevisions: %v", err)
+	}
+	if comparison.From != "revision 1" || comparison.To != "revision 2" {
+		t.Errorf("DiffRevisions = %s to %s, want revision 1 to revision 2", comparison.From, comparison.To)
+	}
+	if _, err := DiffRevisions(ctx, repo, "bakery", 0, 1); !errors.Is(err, ErrNotConsulted) {
+		t.Errorf("DiffRevisions before the first revision = %v, want ErrNotConsulted", err)
+	}
+	if _, err := DiffRevisions(ctx, repo, "bakery", 3, 0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DiffRevisions from a missing revision = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,296 @@
+package prompts
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+//go:embed library
+var builtin embed.FS
+
+// Files a prompt directory may contain besides <name>/<version>.tmpl
+const (
+	partialsFile = "partials.tmpl"
+	trafficFile  = "traffic.json"
+)
+
+// versionPattern is the accepted version naming: v1, v2, ...
+var versionPattern = regexp.MustCompile(`^v[1-9][0-9]*$`)
+
+// Library holds every version of every prompt and how traffic is split
+// between them
+type Library struct {
+	versions map[string][]*Template // Sorted oldest first
+	// traffic maps a prompt name to the weight of each version; a prompt
+	// without an entry sends everything to its newest version
+	traffic map[string]map[string]int
+}
+
+var (
+	defaultOnce    sync.Once
+	defaultLibrary *Library
+)
+
+// Default returns the built-in prompts
+func Default() *Library {
+	defaultOnce.Do(func() {
+		library, err := load(nil)
+		if err != nil {
+			panic(fmt.Sprintf("prompts: built-in library: %v", err))
+		}
+		defaultLibrary = library
+	})
+	return defaultLibrary
+}
+
+// Load returns the built-in prompts overlaid with the prompts in dir. Files in
+// dir add versions or replace built-in ones with the same name; its
+// partials.tmpl may redefine shared blocks and its traffic.json replaces the
+// split for the prompts it lists.
+func Load(dir string) (*Library, error) {
+	if dir == "" {
+		return Default(), nil
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	library, err := load(os.DirFS(dir))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", dir, err)
+	}
+	return library, nil
+}
+
+// loader gathers prompt sources from the built-in library and an overlay
+type loader struct {
+	partials []string
+	sources  map[string]map[string]string // name -> version -> text
+	traffic  map[string]map[string]int
+}
+
+// load compiles the built-in library and the optional overlay
+func load(overlay fs.FS) (*Library, error) {
+	l := &loader{
+		sources: make(map[string]map[string]string),
+		traffic: make(map[string]map[string]int),
+	}
+	root, err := fs.Sub(builtin, "library")
+	if err != nil {
+		return nil, err
+	}
+	if err := l.read(root); err != nil {
+		return nil, err
+	}
+	if overlay != nil {
+		if err := l.read(overlay); err != nil {
+			return nil, err
+		}
+	}
+	return l.compile()
+}
+
+// read adds the partials, prompt versions and traffic split found in fsys
+func (l *loader) read(fsys fs.FS) error {
+	partials, err := fs.ReadFile(fsys, partialsFile)
+	switch {
+	case err == nil:
+		l.partials = append(l.partials, string(partials))
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	files, err := fs.Glob(fsys, "*/*.tmpl")
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		name, version := path.Dir(file), strings.TrimSuffix(path.Base(file), ".tmpl")
+		if !versionPattern.MatchString(version) {
+			return fmt.Errorf("%s: version must look like v1, v2, ...", file)
+		}
+		text, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		if l.sources[name] == nil {
+			l.sources[name] = make(map[string]string)
+		}
+		l.sources[name][version] = string(text)
+	}
+
+	data, err := fs.ReadFile(fsys, trafficFile)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	case err != nil:
+		return err
+	}
+	var traffic map[string]map[string]int
+	if err := json.Unmarshal(data, &traffic); err != nil {
+		return fmt.Errorf("%s: %w", trafficFile, err)
+	}
+	for name, weights := range traffic {
+		l.traffic[name] = weights
+	}
+	return nil
+}
+
+// compile parses every version on top of the shared partials and checks that
+// each renders and that the traffic split only names known versions
+func (l *loader) compile() (*Library, error) {
+	base := template.New("").Funcs(funcs).Option("missingkey=error")
+	for _, partials := range l.partials {
+		if _, err := base.Parse(partials); err != nil {
+			return nil, fmt.Errorf("%s: %w", partialsFile, err)
+		}
+	}
+
+	library := &Library{versions: make(map[string][]*Template), traffic: l.traffic}
+	var errs []error
+	for name, versions := range l.sources {
+		for version, text := range versions {
+			prompt, err := compileVersion(base, name, version, text)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			library.versions[name] = append(library.versions[name], prompt)
+		}
+		sort.Slice(library.versions[name], func(i, j int) bool {
+			return versionNumber(library.versions[name][i].Version) < versionNumber(library.versions[name][j].Version)
+		})
+	}
+
+	for name, weights := range l.traffic {
+		total := 0
+		for version, weight := range weights {
+			if _, ok := library.Lookup(name, version); !ok {
+				errs = append(errs, fmt.Errorf("%s: unknown prompt version %s@%s", trafficFile, name, version))
+			}
+			if weight < 0 {
+				errs = append(errs, fmt.Errorf("%s: weight for %s@%s must not be negative", trafficFile, name, version))
+			}
+			total += weight
+		}
+		if total <= 0 {
+			errs = append(errs, fmt.Errorf("%s: %s needs at least one version with a positive weight", trafficFile, name))
+		}
+	}
+
+	sortErrors(errs)
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return library, nil
+}
+
+// compileVersion parses one prompt version and renders it once with sample
+// values
+func compileVersion(base *template.Template, name, version, text string) (*Template, error) {
+	tmpl, err := template.Must(base.Clone()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s/%s.tmpl: %w", name, version, err)
+	}
+	for _, block := range []string{"system", "user"} {
+		if tmpl.Lookup(block) == nil {
+			return nil, fmt.Errorf("%s/%s.tmpl: missing {{define %q}}", name, version, block)
+		}
+	}
+
+	prompt := &Template{Name: name, Version: version, tmpl: tmpl}
+	if _, _, err := prompt.Render(sampleVars); err != nil {
+		return nil, err
+	}
+	return prompt, nil
+}
+
+// Names lists the prompts in the library
+func (l *Library) Names() []string {
+	names := make([]string, 0, len(l.versions))
+	for name := range l.versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Versions returns every version of a prompt, oldest first
+func (l *Library) Versions(name string) []*Template {
+	return l.versions[name]
+}
+
+// Lookup returns a specific prompt version
+func (l *Library) Lookup(name, version string) (*Template, bool) {
+	for _, prompt := range l.versions[name] {
+		if prompt.Version == version {
+			return prompt, true
+		}
+	}
+	return nil, false
+}
+
+// Traffic returns the share of traffic each version of a prompt receives
+func (l *Library) Traffic(name string) map[string]int {
+	if weights, ok := l.traffic[name]; ok {
+		return weights
+	}
+	versions := l.versions[name]
+	if len(versions) == 0 {
+		return nil
+	}
+	return map[string]int{versions[len(versions)-1].Version: 100}
+}
+
+// Select picks the prompt version for a request according to the traffic
+// split. The same seed always gets the same version, so a business sees
+// consistent output and cached results stay valid. It returns nil for an
+// unknown prompt.
+func (l *Library) Select(name, seed string) *Template {
+	weights := l.Traffic(name)
+	versions := make([]string, 0, len(weights))
+	total := 0
+	for version, weight := range weights {
+		if weight > 0 {
+			versions = append(versions, version)
+			total += weight
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	sort.Slice(versions, func(i, j int) bool { return versionNumber(versions[i]) < versionNumber(versions[j]) })
+
+	h := fnv.New32a()
+	h.Write([]byte(name + "/" + seed))
+	point := int(h.Sum32() % uint32(total))
+	for _, version := range versions {
+		if point -= weights[version]; point < 0 {
+			prompt, _ := l.Lookup(name, version)
+			return prompt
+		}
+	}
+	return nil
+}
+
+// versionNumber orders versions numerically so v10 sorts after v9
+func versionNumber(version string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(version, "v"))
+	return n
+}
+
+// sortErrors orders load errors so they are reported deterministically
+func sortErrors(errs []error) {
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+}
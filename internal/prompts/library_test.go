@@ -0,0 +1,146 @@
+package prompts
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"biz-flow/internal/core"
+)
+
+// overlay writes files, keyed by path, into a prompt directory
+func overlay(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+const personaV2 = `{{define "system"}}{{template "consultant" .}}{{end}}
+{{define "user"}}Who buys {{.Business.Description}}?{{end}}`
+
+func TestSelect(t *testing.T) {
+	library, err := Load(overlay(t, map[string]string{
+		"persona/v2.tmpl": personaV2,
+		"persona/v3.tmpl": personaV2,
+		"traffic.json":    `{"persona": {"v1": 30, "v2": 70, "v3": 0}}`,
+	}))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		prompt string
+		want   map[string]int
+	}{
+		{name: "overlay split", prompt: "persona", want: map[string]int{"v1": 30, "v2": 70}},
+		{name: "built-in split", prompt: "content", want: map[string]int{"v1": 50, "v2": 50}},
+		{name: "no split sends everything to the newest version", prompt: "advice", want: map[string]int{"v1": 100}},
+	}
+	const seeds = 20000
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts := make(map[string]int)
+			for i := 0; i < seeds; i++ {
+				seed := fmt.Sprintf("business-%d", i)
+				prompt := library.Select(tt.prompt, seed)
+				if prompt == nil {
+					t.Fatalf("Select(%q) = nil", seed)
+				}
+				if again := library.Select(tt.prompt, seed); again != prompt {
+					t.Fatalf("seed %q got %s, then %s", seed, prompt.ID(), again.ID())
+				}
+				counts[prompt.Version]++
+			}
+
+			total := 0
+			for _, weight := range tt.want {
+				total += weight
+			}
+			for version, count := range counts {
+				if tt.want[version] == 0 {
+					t.Errorf("%s@%s got %d requests without any weight", tt.prompt, version, count)
+				}
+			}
+			for version, weight := range tt.want {
+				share, want := float64(counts[version])/seeds, float64(weight)/float64(total)
+				if math.Abs(share-want) > 0.02 {
+					t.Errorf("%s@%s got %.3f of the traffic, want %.2f", tt.prompt, version, share, want)
+				}
+			}
+		})
+	}
+
+	if library.Select("summary", "seed") != nil {
+		t.Error("selected a version of an unknown prompt")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{name: "bad version name", files: map[string]string{"persona/latest.tmpl": personaV2}, wantErr: "version must look like v1, v2"},
+		{name: "missing block", files: map[string]string{"persona/v2.tmpl": `{{define "user"}}Hi{{end}}`}, wantErr: `missing {{define "system"}}`},
+		{
+			name:    "unknown variable",
+			files:   map[string]string{"persona/v2.tmpl": `{{define "system"}}x{{end}}{{define "user"}}{{.Budget}}{{end}}`},
+			wantErr: "rendering persona@v2",
+		},
+		{name: "unknown version in the split", files: map[string]string{"traffic.json": `{"persona": {"v9": 100}}`}, wantErr: "unknown prompt version persona@v9"},
+		{name: "negative weight", files: map[string]string{"traffic.json": `{"content": {"v1": -10, "v2": 50}}`}, wantErr: "weight for content@v1 must not be negative"},
+		{name: "no positive weight", files: map[string]string{"traffic.json": `{"content": {"v1": 0}}`}, wantErr: "content needs at least one version with a positive weight"},
+		{name: "split that is not JSON", files: map[string]string{"traffic.json": `persona: v1`}, wantErr: "traffic.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(overlay(t, tt.files))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	business := core.BusinessInput{Type: core.Retail, Description: "handmade mugs", Location: "Austin", Goal: core.Sales, Locale: core.Spanish}
+	vars := Vars{
+		Business:        business,
+		Platform:        core.Instagram,
+		Recommendations: []core.Recommendation{{Platform: core.Instagram, Rank: 1}},
+		Candidates:      []core.Platform{core.Instagram, core.Facebook},
+		Top:             1,
+		Draft:           "Post daily.",
+	}
+	library := Default()
+	for _, name := range library.Names() {
+		for _, prompt := range library.Versions(name) {
+			system, user, err := prompt.Render(vars)
+			if err != nil {
+				t.Fatalf("%s: %v", prompt.ID(), err)
+			}
+			if system == "" || !strings.Contains(user, "handmade mugs") {
+				t.Errorf("%s does not describe the business:\n%s", prompt.ID(), user)
+			}
+			// Review replies are platform names, in no language
+			if name != "review" && !strings.Contains(system+user, "Spanish") {
+				t.Errorf("%s does not ask for Spanish", prompt.ID())
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"text/template"
+
+	"biz-flow/internal/core"
+)
+
+// Template is one version of a named prompt. Its text defines a "system" and
+// a "user" template rendered against Vars.
+type Template struct {
+	Name    string
+	Version string
+	tmpl    *template.Template
+}
+
+// Vars are the values a prompt can reference: the business input and, for
+// later stages, the recommendation context
+type Vars struct {
+	Business core.BusinessInput
+	// Platform is the platform content is written for
+	Platform core.Platform
+	// Recommendations are the ranked platforms, best first
+	Recommendations []core.Recommendation
+	// Draft is the rule-based strategy the advice prompt improves on
+	Draft string
+	// Candidates are the platforms that pass the filters, which the review
+	// prompt picks the best Top of
+	Candidates []core.Platform
+	Top        int
+}
+
+// SupportsHashtags reports whether the content platform uses hashtags
+func (v Vars) SupportsHashtags() bool {
+	metadata, ok := core.GetPlatformMetadata(v.Platform)
+	return ok && metadata.SupportsHashtags
+}
+
+// languageNames are how prompts name the language of each non-English locale
+var languageNames = map[core.Locale]string{
+	core.Spanish:    "Spanish",
+	core.Portuguese: "Brazilian Portuguese",
+	core.Swahili:    "Swahili",
+	core.Hindi:      "Hindi, in Devanagari script",
+}
+
+// Language names the language replies must be written in, or "" for
+// English, which prompts need not ask for
+func (v Vars) Language() string {
+	return languageNames[v.Business.Language()]
+}
+
+// VoiceTone describes the brand voice's tone sliders in words, e.g. "very
+// playful and calm", or "" when the business has no voice or leaves the
+// sliders at 0
+func (v Vars) VoiceTone() string {
+	if v.Business.Voice == nil {
+		return ""
+	}
+	tone := v.Business.Voice.Tone
+	var words []string
+	for _, slider := range []struct {
+		value     float64
+		low, high string
+	}{
+		{tone.Formality, "playful", "formal"},
+		{tone.Enthusiasm, "calm", "enthusiastic"},
+	} {
+		word := slider.high
+		if slider.value < 0 {
+			word = slider.low
+		}
+		switch magnitude := math.Abs(slider.value); {
+		case magnitude == 0:
+			continue
+		case magnitude < 0.4:
+			word = "slightly " + word
+		case magnitude >= 0.75:
+			word = "very " + word
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, " and ")
+}
+
+// ID identifies the prompt version, e.g. "content@v2"
+func (t *Template) ID() string {
+	return t.Name + "@" + t.Version
+}
+
+// Render executes the system and user templates
+func (t *Template) Render(vars Vars) (system, user string, err error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.ExecuteTemplate(&buf, "system", vars); err != nil {
+		return "", "", fmt.Errorf("rendering %s: %w", t.ID(), err)
+	}
+	system = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err := t.tmpl.ExecuteTemplate(&buf, "user", vars); err != nil {
+		return "", "", fmt.Errorf("rendering %s: %w", t.ID(), err)
+	}
+	return system, strings.TrimSpace(buf.String()), nil
+}
+
+// funcs are available to every prompt template
+var funcs = template.FuncMap{
+	"join": strings.Join,
+	"trim": strings.TrimSpace,
+	// item indents text as a nested list item, keeping its line breaks
+	"item": func(text string) string {
+		lines := strings.Split(strings.TrimSpace(text), "\n")
+		for i, line := range lines {
+			prefix := "    "
+			if i == 0 {
+				prefix = "  - "
+			}
+			lines[i] = prefix + strings.TrimSpace(line)
+		}
+		return strings.Join(lines, "\n")
+	},
+}
+
+// sampleVars exercise every field a prompt may reference so broken templates
+// fail when they are loaded rather than mid-consultation
+var sampleVars = Vars{
+	Business: core.BusinessInput{
+		Type:        core.Retail,
+		Description: "Handmade ceramic mugs",
+		Location:    "Austin, TX",
+		Budget:      100,
+		Goal:        core.Awareness,
+		Channels:    []string{"Instagram"},
+		Locale:      core.Spanish,
+		Voice: &core.BrandVoice{
+			Tone:             core.Tone{Formality: -0.5, Enthusiasm: 0.8},
+			BannedWords:      []string{"cheap"},
+			Emoji:            core.EmojiSparing,
+			SignaturePhrases: []string{"Stay cozy"},
+			SamplePosts:      []string{"Fresh batch out of the kiln! Which glaze is your fave?"},
+		},
+	},
+	Platform: core.Instagram,
+	Recommendations: []core.Recommendation{
+		{Rank: 1, Platform: core.Instagram, Score: 8.5, Reasoning: "Visual products do well here."},
+	},
+	Draft:      "Post three times a week.",
+	Candidates: []core.Platform{core.Instagram, core.Facebook},
+	Top:        1,
+}
@@ -1 +1,110 @@
-package reasoning
\ No newline at end of file
+package reasoning
+
+import (
+	"strings"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/filters"
+	"biz-flow/internal/i18n"
+	"biz-flow/internal/scoring"
+)
+
+// Explainer turns scores and constraint checks into human-readable reasoning
+type Explainer struct {
+	filter      *filters.PlatformFilter
+	constraints *filters.ConstraintValidator
+	scorer      *scoring.Scorer
+}
+
+// NewExplainer creates a new explainer
+func NewExplainer() *Explainer {
+	return &Explainer{
+		filter:      filters.NewPlatformFilter(),
+		constraints: filters.NewConstraintValidator(),
+		scorer:      scoring.NewScorer(),
+	}
+}
+
+// ConstraintCheck is the outcome of a single constraint for a platform
+type ConstraintCheck struct {
+	Name    string  `json:"name"`
+	IsValid bool    `json:"is_valid"`
+	Reason  string  `json:"reason"`
+	Penalty float64 `json:"penalty"`
+}
+
+// PlatformExplanation explains how one platform fares for a business
+type PlatformExplanation struct {
+	Platform        core.Platform          `json:"platform"`
+	PassedFilters   bool                   `json:"passed_filters"`
+	Rank            int                    `json:"rank,omitempty"` // Rank among filtered platforms, 0 if filtered out
+	Scored          scoring.ScoredPlatform `json:"scored"`
+	Constraints     []ConstraintCheck      `json:"constraints"`
+	CombinedPenalty float64                `json:"combined_penalty"`
+	Filtering       map[string]string      `json:"filtering"`
+}
+
+// ExplainPlatform walks through the filters, constraints and score for a
+// single platform, whether or not it would be recommended
+func (e *Explainer) ExplainPlatform(business core.BusinessInput, platform core.Platform) PlatformExplanation {
+	constraints := e.constraints.ForLocale(business.Language())
+	explanation := PlatformExplanation{
+		Platform:        platform,
+		Scored:          e.scorer.ScorePlatform(business, platform),
+		CombinedPenalty: e.constraints.GetCombinedPenalty(business, platform),
+		Filtering:       e.filter.ExplainFiltering(business),
+	}
+
+	filtered := e.filter.ApplyAllFilters(business)
+	for i, scored := range e.scorer.Rank(business, filtered) {
+		if scored.Platform == platform {
+			explanation.PassedFilters = true
+			explanation.Rank = i + 1
+			break
+		}
+	}
+
+	budget := constraints.ValidateBudgetConstraints(business.Budget, platform)
+	effort := constraints.ValidateEffortConstraints(business, platform)
+	visual := constraints.ValidateVisualRequirements(business, platform)
+	goal := constraints.ValidateGoalAlignment(business.Goal, platform)
+
+	explanation.Constraints = []ConstraintCheck{
+		{Name: "budget", IsValid: budget.IsValid, Reason: budget.Reason, Penalty: budget.Penalty},
+		{Name: "effort", IsValid: effort.IsValid, Reason: effort.Reason, Penalty: effort.Penalty},
+		{Name: "visuals", IsValid: visual.IsValid, Reason: visual.Reason, Penalty: visual.Penalty},
+		{Name: "goal", IsValid: goal.IsValid, Reason: goal.Reason, Penalty: goal.Penalty},
+	}
+
+	return explanation
+}
+
+// ExplainRecommendation builds the reasoning shown next to a recommended
+// platform, in the business's locale
+func (e *Explainer) ExplainRecommendation(business core.BusinessInput, scored scoring.ScoredPlatform) string {
+	locale := business.Language()
+	constraints := e.constraints.ForLocale(locale)
+	platform := scored.Platform
+	parts := []string{
+		i18n.T(locale, "explain.score", platform, scored.Score, i18n.BusinessType(locale, business.Type)),
+	}
+	if calibration, ok := business.Calibration[platform]; ok {
+		metadata, _ := core.GetPlatformMetadata(platform)
+		parts = append(parts, i18n.T(locale, "explain.calibrated",
+			calibration.ReachPotential, calibration.ConversionFocus, metadata.ReachPotential, metadata.ConversionFocus))
+	}
+
+	goal := constraints.ValidateGoalAlignment(business.Goal, platform)
+	budget := constraints.ValidateBudgetConstraints(business.Budget, platform)
+	effort := constraints.ValidateEffortConstraints(business, platform)
+	visual := constraints.ValidateVisualRequirements(business, platform)
+
+	parts = append(parts,
+		i18n.Sentence(locale, goal.Reason),
+		i18n.Sentence(locale, budget.Reason),
+		i18n.Sentence(locale, effort.Reason),
+		i18n.Sentence(locale, visual.Reason),
+	)
+
+	return strings.Join(parts, " ")
+}
@@ -1 +1,97 @@
 package reasoning
+
+import (
+	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
+)
+
+// RiskAssessor identifies risks in a set of recommendations
+type RiskAssessor struct{}
+
+// NewRiskAssessor creates a new risk assessor
+func NewRiskAssessor() *RiskAssessor {
+	return &RiskAssessor{}
+}
+
+// Risk is a risk paired with what the owner can do about it
+type Risk struct {
+	Risk       string `json:"risk"`
+	Mitigation string `json:"mitigation"`
+}
+
+// Assess returns the risks the business should be aware of, in its locale
+func (ra *RiskAssessor) Assess(business core.BusinessInput, recommendations []core.Recommendation) []string {
+	assessed := ra.assess(business, recommendations)
+	risks := make([]string, 0, len(assessed))
+	for _, risk := range assessed {
+		risks = append(risks, risk.Risk)
+	}
+	return risks
+}
+
+// Mitigate pairs each risk with a mitigation in the business's locale. Risks
+// the assessor raised get their own advice; any others, such as policy
+// findings, get general advice.
+func (ra *RiskAssessor) Mitigate(business core.BusinessInput, recommendations []core.Recommendation, risks []string) []Risk {
+	known := make(map[string]string)
+	for _, risk := range ra.assess(business, recommendations) {
+		known[risk.Risk] = risk.Mitigation
+	}
+	mitigated := make([]Risk, 0, len(risks))
+	for _, risk := range risks {
+		mitigation, ok := known[risk]
+		if !ok {
+			mitigation = i18n.T(business.Language(), "mitigation.general")
+		}
+		mitigated = append(mitigated, Risk{Risk: risk, Mitigation: mitigation})
+	}
+	return mitigated
+}
+
+// assess finds the risks along with their mitigations
+func (ra *RiskAssessor) assess(business core.BusinessInput, recommendations []core.Recommendation) []Risk {
+	locale := business.Language()
+	risks := make([]Risk, 0)
+	add := func(key string, args ...any) {
+		risks = append(risks, Risk{Risk: i18n.T(locale, "risk."+key, args...), Mitigation: i18n.T(locale, "mitigation."+key)})
+	}
+
+	if len(recommendations) == 0 {
+		add("no_platform")
+		return risks
+	}
+
+	highEffort := 0
+	for _, rec := range recommendations {
+		metadata, exists := core.GetPlatformMetadata(rec.Platform)
+		if !exists {
+			continue
+		}
+
+		if metadata.RequiresVideo {
+			add("video", rec.Platform)
+		}
+		if metadata.EffortLevel == core.HighEffort {
+			highEffort++
+		}
+		if metadata.SupportsHashtags && metadata.ReachPotential >= 9 {
+			add("competition", rec.Platform)
+		}
+	}
+
+	if highEffort >= 2 {
+		add("high_effort")
+	}
+
+	if business.HasLowBudget() {
+		add("low_budget")
+	}
+
+	if business.Goal == core.Sales && business.IsOnlineOnly() {
+		add("online_sales")
+	}
+
+	add("consistency")
+
+	return risks
+}
@@ -1 +1,107 @@
 package reasoning
+
+import (
+	"strings"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
+	"biz-flow/internal/voice"
+)
+
+// StrategyAdvisor produces the overall strategic advice for a consultation
+type StrategyAdvisor struct{}
+
+// NewStrategyAdvisor creates a new strategy advisor
+func NewStrategyAdvisor() *StrategyAdvisor {
+	return &StrategyAdvisor{}
+}
+
+// Advise summarizes how the business should approach the recommended
+// platforms, in its locale
+func (sa *StrategyAdvisor) Advise(business core.BusinessInput, recommendations []core.Recommendation) string {
+	locale := business.Language()
+	if len(recommendations) == 0 {
+		return i18n.T(locale, "advice.no_platform")
+	}
+
+	var advice []string
+	if len(recommendations) > 1 {
+		others := make([]string, 0, len(recommendations)-1)
+		for _, rec := range recommendations[1:] {
+			others = append(others, string(rec.Platform))
+		}
+		advice = append(advice, i18n.T(locale, "advice.lead_and_support", recommendations[0].Platform, i18n.List(locale, others)))
+	} else {
+		advice = append(advice, i18n.T(locale, "advice.lead", recommendations[0].Platform))
+	}
+
+	switch business.BudgetTier() {
+	case "low":
+		advice = append(advice, i18n.T(locale, "advice.budget.low"))
+	case "medium":
+		advice = append(advice, i18n.T(locale, "advice.budget.medium"))
+	default:
+		advice = append(advice, i18n.T(locale, "advice.budget.high"))
+	}
+
+	switch business.Goal {
+	case core.Sales:
+		advice = append(advice, i18n.T(locale, "advice.goal.sales"))
+	default:
+		advice = append(advice, i18n.T(locale, "advice.goal.awareness"))
+	}
+
+	if business.IsLocal() {
+		advice = append(advice, i18n.T(locale, "advice.local", business.Location))
+	}
+
+	advice = append(advice, sa.adviseVoice(business, recommendations[0].Platform)...)
+
+	return strings.Join(advice, " ")
+}
+
+// Platforms where a brand voice at either end of the formality slider reads
+// out of place
+var (
+	casualPlatforms       = map[core.Platform]bool{core.TikTok: true, core.Instagram: true}
+	professionalPlatforms = map[core.Platform]bool{core.LinkedIn: true, core.Email: true}
+)
+
+// adviseVoice suggests how to keep the brand voice consistent on the lead
+// platform; nothing without a voice
+func (sa *StrategyAdvisor) adviseVoice(business core.BusinessInput, lead core.Platform) []string {
+	if business.Voice == nil {
+		return nil
+	}
+	locale := business.Language()
+	target := voice.TargetOf(business.Voice)
+
+	var tone []string
+	if target.HasFormality && target.Formality <= -0.25 {
+		tone = append(tone, i18n.T(locale, "voice.playful"))
+	} else if target.HasFormality && target.Formality >= 0.25 {
+		tone = append(tone, i18n.T(locale, "voice.formal"))
+	}
+	if target.HasEnthusiasm && target.Enthusiasm <= -0.25 {
+		tone = append(tone, i18n.T(locale, "voice.calm"))
+	} else if target.HasEnthusiasm && target.Enthusiasm >= 0.25 {
+		tone = append(tone, i18n.T(locale, "voice.enthusiastic"))
+	}
+
+	var advice []string
+	if len(tone) > 0 {
+		advice = append(advice, i18n.T(locale, "advice.voice", i18n.List(locale, tone)))
+	} else {
+		advice = append(advice, i18n.T(locale, "advice.voice.consistent"))
+	}
+	if len(business.Voice.SignaturePhrases) > 0 {
+		advice = append(advice, i18n.T(locale, "advice.voice.signature", strings.TrimSpace(business.Voice.SignaturePhrases[0])))
+	}
+	switch {
+	case target.HasFormality && target.Formality >= 0.5 && casualPlatforms[lead]:
+		advice = append(advice, i18n.T(locale, "advice.voice.loosen", lead))
+	case target.HasFormality && target.Formality <= -0.5 && professionalPlatforms[lead]:
+		advice = append(advice, i18n.T(locale, "advice.voice.tighten", lead))
+	}
+	return advice
+}
@@ -0,0 +1,333 @@
+// Package report turns a consultation into a client-ready report: Markdown to
+// edit or paste, and standalone HTML that prints to PDF from a browser. The
+// layout comes from a theme an agency can override.
+package report
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"biz-flow/internal/calendar"
+	"biz-flow/internal/core"
+	"biz-flow/internal/filters"
+	"biz-flow/internal/i18n"
+	"biz-flow/internal/reasoning"
+	"biz-flow/internal/scoring"
+	"biz-flow/internal/templates"
+	"biz-flow/internal/voice"
+)
+
+// PlanWeeks is the length of the report's plan: 90 days in whole weeks
+const PlanWeeks = 12
+
+// phaseWeeks is how many weeks each phase of the plan covers
+const phaseWeeks = 4
+
+// Report is everything a rendered report shows
+type Report struct {
+	Locale core.Locale `json:"locale"`
+	// Agency is who prepared the report; empty takes the theme's agency
+	Agency   string             `json:"agency,omitempty"`
+	Date     string             `json:"date"`
+	Business core.BusinessInput `json:"business"`
+	// Summary is the executive summary, one sentence per entry
+	Summary   []string         `json:"summary"`
+	Persona   string           `json:"persona,omitempty"`
+	Platforms []Platform       `json:"platforms"`
+	Filters   []string         `json:"filters"`
+	Risks     []reasoning.Risk `json:"risks"`
+	Budget    Budget           `json:"budget"`
+	Samples   []Sample         `json:"samples"`
+	Plan      *Plan            `json:"plan,omitempty"`
+	Advice    string           `json:"advice"`
+}
+
+// Platform is a recommended platform with the reasoning behind its rank
+type Platform struct {
+	Rank      int               `json:"rank"`
+	Platform  core.Platform     `json:"platform"`
+	Score     float64           `json:"score"`
+	Reasoning string            `json:"reasoning"`
+	Breakdown scoring.Breakdown `json:"breakdown"`
+	// Constraints are the checks the explainer ran for the platform
+	Constraints []reasoning.ConstraintCheck `json:"constraints"`
+}
+
+// Bar is the score as a whole percentage, for drawing score bars
+func (p Platform) Bar() int {
+	return int(math.Round(math.Max(0, math.Min(100, p.Score))))
+}
+
+// Budget is how the monthly budget splits across the platforms
+type Budget struct {
+	Monthly float64      `json:"monthly"`
+	Lines   []BudgetLine `json:"lines"`
+}
+
+// BudgetLine is one platform's share of the budget
+type BudgetLine struct {
+	Platform core.Platform `json:"platform"`
+	Amount   float64       `json:"amount"`
+	// Share is a percentage of the monthly budget
+	Share float64 `json:"share"`
+	// Paid is true when the money buys ads rather than content production
+	Paid bool `json:"paid"`
+}
+
+// Sample is a content sample for one platform
+type Sample struct {
+	Platform   core.Platform         `json:"platform"`
+	Template   *core.ContentTemplate `json:"template"`
+	VoiceScore *core.VoiceScore      `json:"voice_score,omitempty"`
+}
+
+// Plan is the 90-day plan, in three phases
+type Plan struct {
+	Start        string             `json:"start"`
+	End          string             `json:"end"`
+	PlannedHours float64            `json:"planned_hours"`
+	Cadence      []calendar.Cadence `json:"cadence"`
+	Phases       []Phase            `json:"phases"`
+}
+
+// Phase is a month of the plan
+type Phase struct {
+	Number int `json:"number"`
+	// Posts counts the phase's posts per platform, in rank order
+	Posts    []Count  `json:"posts"`
+	Pillars  []Count  `json:"pillars"`
+	Holidays []string `json:"holidays,omitempty"`
+}
+
+// Count is how many posts a platform or pillar gets in a phase
+type Count struct {
+	Name  string `json:"name"`
+	Posts int    `json:"posts"`
+}
+
+// Total is the number of posts in the phase
+func (p Phase) Total() int {
+	total := 0
+	for _, count := range p.Posts {
+		total += count.Posts
+	}
+	return total
+}
+
+// Options shape a report. Zero values take the defaults.
+type Options struct {
+	Agency string
+	// Date is the report date; zero means today
+	Date time.Time
+	// Start is the first day of the plan; zero means next Monday
+	Start time.Time
+	// HoursPerWeek is the time the owner can spend on content each week
+	HoursPerWeek float64
+}
+
+// Builder gathers a report from a consultation result
+type Builder struct {
+	explainer *reasoning.Explainer
+	filter    *filters.PlatformFilter
+	risks     *reasoning.RiskAssessor
+	planner   *calendar.Planner
+	library   *templates.Library
+	voice     *voice.Checker
+}
+
+// NewBuilder creates a report builder
+func NewBuilder() *Builder {
+	return &Builder{
+		explainer: reasoning.NewExplainer(),
+		filter:    filters.NewPlatformFilter(),
+		risks:     reasoning.NewRiskAssessor(),
+		planner:   calendar.NewPlanner(),
+		library:   templates.NewLibrary(),
+		voice:     voice.NewChecker(),
+	}
+}
+
+// Build assembles the report for a business and its consultation result
+func (b *Builder) Build(business core.BusinessInput, result *core.ConsultationResult, options Options) (*Report, error) {
+	if err := business.Validate(); err != nil {
+		return nil, err
+	}
+	date := options.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	locale := business.Language()
+	recommendations := append([]core.Recommendation{}, result.Recommendations...)
+	sort.SliceStable(recommendations, func(i, j int) bool { return recommendations[i].Rank < recommendations[j].Rank })
+
+	report := &Report{
+		Locale:   locale,
+		Agency:   options.Agency,
+		Date:     date.Format(calendar.DateLayout),
+		Business: business,
+		Persona:  result.Persona,
+		Risks:    b.risks.Mitigate(business, recommendations, result.Risks),
+		Budget:   splitBudget(business, recommendations),
+		Advice:   result.StrategicAdvice,
+	}
+
+	for _, rec := range recommendations {
+		explanation := b.explainer.ExplainPlatform(business, rec.Platform)
+		report.Platforms = append(report.Platforms, Platform{
+			Rank:        rec.Rank,
+			Platform:    rec.Platform,
+			Score:       rec.Score,
+			Reasoning:   rec.Reasoning,
+			Breakdown:   explanation.Scored.Breakdown,
+			Constraints: explanation.Constraints,
+		})
+
+		sample := Sample{Platform: rec.Platform, Template: rec.ContentTemplate, VoiceScore: rec.VoiceScore}
+		if sample.Template == nil {
+			sample.Template, sample.VoiceScore = b.voice.Check(business, b.library.Generate(business, rec.Platform))
+		}
+		report.Samples = append(report.Samples, sample)
+	}
+
+	filtering := b.filter.ExplainFiltering(business)
+	for _, key := range []string{"business_type", "budget", "location"} {
+		if explanation, ok := filtering[key]; ok {
+			report.Filters = append(report.Filters, explanation)
+		}
+	}
+
+	if len(recommendations) > 0 {
+		plan, err := b.planner.Plan(business, recommendations, calendar.Options{
+			Weeks:        PlanWeeks,
+			Start:        options.Start,
+			HoursPerWeek: options.HoursPerWeek,
+		})
+		if err != nil {
+			return nil, err
+		}
+		report.Plan = phases(plan, locale)
+	}
+
+	report.Summary = summarize(report)
+	return report, nil
+}
+
+// splitBudget divides the budget in proportion to each platform's score, in
+// whole dollars; rounding leftovers go to the top platform. A low budget is
+// too small for ads, so it all goes to content.
+func splitBudget(business core.BusinessInput, recommendations []core.Recommendation) Budget {
+	monthly := business.Budget
+	budget := Budget{Monthly: monthly}
+	total := 0.0
+	for _, rec := range recommendations {
+		total += math.Max(rec.Score, 0)
+	}
+	if monthly <= 0 || total == 0 {
+		return budget
+	}
+
+	whole := math.Floor(monthly)
+	assigned := 0.0
+	for _, rec := range recommendations {
+		metadata, _ := core.GetPlatformMetadata(rec.Platform)
+		amount := math.Floor(whole * math.Max(rec.Score, 0) / total)
+		assigned += amount
+		budget.Lines = append(budget.Lines, BudgetLine{Platform: rec.Platform, Amount: amount, Paid: metadata.IsPaid && !business.HasLowBudget()})
+	}
+	budget.Lines[0].Amount += monthly - assigned
+	for i := range budget.Lines {
+		line := &budget.Lines[i]
+		line.Amount = math.Round(line.Amount*100) / 100
+		line.Share = math.Round(line.Amount/monthly*1000) / 10
+	}
+	return budget
+}
+
+// phases groups a 90-day calendar into months
+func phases(plan *calendar.Calendar, locale core.Locale) *Plan {
+	summary := &Plan{Start: plan.Start, End: plan.End, PlannedHours: plan.PlannedHours, Cadence: plan.Cadence}
+	for number := 1; (number-1)*phaseWeeks < plan.Weeks; number++ {
+		phase := Phase{Number: number}
+		posts := make(map[core.Platform]int)
+		pillars := make(map[calendar.Pillar]int)
+		seen := make(map[string]bool)
+		for _, slot := range plan.Slots {
+			if (slot.Week-1)/phaseWeeks+1 != number {
+				continue
+			}
+			posts[slot.Platform]++
+			pillars[slot.Pillar]++
+			if slot.Holiday != "" && !seen[slot.Holiday] {
+				seen[slot.Holiday] = true
+				phase.Holidays = append(phase.Holidays, slot.Holiday)
+			}
+		}
+		for _, cadence := range plan.Cadence {
+			if posts[cadence.Platform] > 0 {
+				phase.Posts = append(phase.Posts, Count{Name: string(cadence.Platform), Posts: posts[cadence.Platform]})
+			}
+		}
+		for _, pillar := range calendar.Pillars() {
+			if pillars[pillar] > 0 {
+				phase.Pillars = append(phase.Pillars, Count{Name: PillarName(locale, pillar), Posts: pillars[pillar]})
+			}
+		}
+		summary.Phases = append(summary.Phases, phase)
+	}
+	return summary
+}
+
+// PillarName names a content pillar in the locale
+func PillarName(locale core.Locale, pillar calendar.Pillar) string {
+	return i18n.T(locale, "report.pillar."+string(pillar))
+}
+
+// summarize writes the executive summary from the rest of the report
+func summarize(report *Report) []string {
+	locale := report.Locale
+	var summary []string
+	if len(report.Platforms) == 0 {
+		summary = append(summary, i18n.T(locale, "risk.no_platform"))
+	} else {
+		top := report.Platforms[0]
+		if len(report.Platforms) == 1 {
+			summary = append(summary, i18n.T(locale, "report.summary.focus_only", top.Platform, top.Score))
+		} else {
+			rest := make([]string, 0, len(report.Platforms)-1)
+			for _, platform := range report.Platforms[1:] {
+				rest = append(rest, string(platform.Platform))
+			}
+			summary = append(summary, i18n.T(locale, "report.summary.focus", top.Platform, top.Score, i18n.List(locale, rest)))
+		}
+	}
+
+	if len(report.Budget.Lines) > 0 {
+		largest := report.Budget.Lines[0]
+		for _, line := range report.Budget.Lines[1:] {
+			if line.Amount > largest.Amount {
+				largest = line
+			}
+		}
+		summary = append(summary, i18n.T(locale, "report.summary.budget", report.Budget.Monthly, largest.Amount, largest.Platform))
+	} else {
+		summary = append(summary, i18n.T(locale, "report.summary.organic"))
+	}
+
+	if report.Plan != nil {
+		posts := 0
+		for _, phase := range report.Plan.Phases {
+			posts += phase.Total()
+		}
+		summary = append(summary, i18n.T(locale, "report.summary.plan", posts, report.Plan.PlannedHours))
+	}
+
+	if len(report.Risks) > 0 {
+		summary = append(summary, i18n.T(locale, "report.summary.risk", report.Risks[0].Risk))
+	}
+
+	for i, sentence := range summary {
+		summary[i] = i18n.Sentence(locale, sentence)
+	}
+	return summary
+}
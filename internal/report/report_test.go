@@ -0,0 +1,245 @@
+package report
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
+)
+
+var shop = core.BusinessInput{
+	Type:        core.Retail,
+	Description: `Mugs & bowls <script>alert("hi")</script>`,
+	Location:    "Austin, TX",
+	Budget:      300,
+	Goal:        core.Sales,
+}
+
+var result = &core.ConsultationResult{
+	Recommendations: []core.Recommendation{
+		{Platform: core.Facebook, Rank: 2, Score: 60, Reasoning: "Local groups"},
+		{Platform: core.Instagram, Rank: 1, Score: 90, Reasoning: "Visual <products>",
+			ContentTemplate: &core.ContentTemplate{Hook: "New mugs", Caption: "Fresh from the kiln.", CTA: "Shop now", Hashtags: []string{"mugs"}}},
+	},
+	StrategicAdvice: "Post <b>daily</b>.",
+	Risks:           []string{"Instagram reach depends on consistent posting"},
+	Persona:         "Gift buyers | 25-40",
+}
+
+// build makes the report for shop in the locale with fixed dates
+func build(t *testing.T, locale core.Locale) *Report {
+	t.Helper()
+	business := shop
+	business.Locale = locale
+	report, err := NewBuilder().Build(business, result, Options{
+		Agency:       "Acme & Co",
+		Date:         time.Date(2025, 2, 20, 0, 0, 0, 0, time.UTC),
+		Start:        time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+		HoursPerWeek: 5,
+	})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return report
+}
+
+func TestBuild(t *testing.T) {
+	report := build(t, core.English)
+
+	if report.Date != "2025-02-20" || report.Agency != "Acme & Co" {
+		t.Errorf("Date, Agency = %q, %q", report.Date, report.Agency)
+	}
+	if len(report.Platforms) != 2 || report.Platforms[0].Platform != core.Instagram || report.Platforms[1].Platform != core.Facebook {
+		t.Fatalf("Platforms = %+v, want Instagram then Facebook", report.Platforms)
+	}
+	if report.Samples[0].Template != result.Recommendations[1].ContentTemplate {
+		t.Error("the top platform's sample is not its content template")
+	}
+	if sample := report.Samples[1].Template; sample == nil || sample.Hook == "" {
+		t.Error("a platform without content has no library sample")
+	}
+
+	total := 0.0
+	for _, line := range report.Budget.Lines {
+		total += line.Amount
+	}
+	if total != shop.Budget || report.Budget.Lines[0].Amount <= report.Budget.Lines[1].Amount {
+		t.Errorf("Budget = %+v, want the $%.0f split by score", report.Budget, shop.Budget)
+	}
+
+	plan := report.Plan
+	if plan == nil || plan.Start != "2025-03-03" || len(plan.Phases) != PlanWeeks/phaseWeeks {
+		t.Fatalf("Plan = %+v, want %d phases from 2025-03-03", plan, PlanWeeks/phaseWeeks)
+	}
+	for _, phase := range plan.Phases {
+		if phase.Total() == 0 || len(phase.Pillars) == 0 {
+			t.Errorf("phase %d is empty: %+v", phase.Number, phase)
+		}
+	}
+
+	if len(report.Summary) != 4 {
+		t.Errorf("Summary = %q, want focus, budget, plan and risk", report.Summary)
+	}
+	for _, sentence := range report.Summary {
+		if !strings.HasSuffix(sentence, ".") {
+			t.Errorf("summary sentence %q does not end in a full stop", sentence)
+		}
+	}
+
+	invalid := shop
+	invalid.Budget = math.Inf(1)
+	var validation *core.ValidationError
+	if _, err := NewBuilder().Build(invalid, result, Options{}); !errors.As(err, &validation) {
+		t.Errorf("Build with an invalid business = %v, want a validation error", err)
+	}
+}
+
+func TestSplitBudget(t *testing.T) {
+	recommendations := []core.Recommendation{
+		{Platform: core.Instagram, Score: 70},
+		{Platform: core.Facebook, Score: 20},
+		{Platform: core.Email, Score: 10},
+	}
+	tests := []struct {
+		name    string
+		budget  float64
+		amounts []float64
+		paid    bool
+	}{
+		{name: "proportional", budget: 1000, amounts: []float64{700, 200, 100}, paid: true},
+		{name: "leftovers to the top", budget: 101.5, amounts: []float64{71.5, 20, 10}, paid: true},
+		{name: "low budget buys content", budget: 30, amounts: []float64{21, 6, 3}},
+		{name: "no budget", budget: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			business := shop
+			business.Budget = tt.budget
+			budget := splitBudget(business, recommendations)
+			if len(budget.Lines) != len(tt.amounts) {
+				t.Fatalf("Lines = %+v, want %d", budget.Lines, len(tt.amounts))
+			}
+			for i, line := range budget.Lines {
+				if line.Amount != tt.amounts[i] {
+					t.Errorf("%s gets %v, want %v", line.Platform, line.Amount, tt.amounts[i])
+				}
+			}
+			if len(budget.Lines) > 0 && budget.Lines[0].Paid != tt.paid {
+				t.Errorf("Instagram Paid = %v, want %v", budget.Lines[0].Paid, tt.paid)
+			}
+		})
+	}
+}
+
+// sections are the catalog keys of the report's section titles, in order
+var sections = []string{
+	"report.title", "report.summary", "report.platforms", "report.trace", "report.risks",
+	"report.budget", "report.samples", "report.plan", "report.advice",
+}
+
+func TestMarkdown(t *testing.T) {
+	for _, locale := range []core.Locale{core.English, core.Spanish} {
+		t.Run(string(locale), func(t *testing.T) {
+			var out bytes.Buffer
+			if err := Default().Markdown(&out, build(t, locale)); err != nil {
+				t.Fatalf("Markdown: %v", err)
+			}
+			text := out.String()
+
+			last := -1
+			for _, key := range sections {
+				heading := "# " + i18n.T(locale, key) + "\n"
+				at := strings.Index(text, heading)
+				if at < 0 {
+					t.Errorf("no %q heading", heading)
+					continue
+				}
+				if at < last {
+					t.Errorf("%q heading is out of order", heading)
+				}
+				last = at
+			}
+			for _, want := range []string{"| 1 | Instagram |", "| 2 | Facebook |", "Acme & Co", "Gift buyers | 25-40", i18n.T(locale, "report.footer")} {
+				if !strings.Contains(text, want) {
+					t.Errorf("Markdown has no %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestHTML(t *testing.T) {
+	var out bytes.Buffer
+	if err := Default().HTML(&out, build(t, core.English)); err != nil {
+		t.Fatalf("HTML: %v", err)
+	}
+	page := out.String()
+
+	for _, key := range sections[1:] {
+		if want := "<h2>" + i18n.T(core.English, key) + "</h2>"; !strings.Contains(page, want) {
+			t.Errorf("HTML has no %s", want)
+		}
+	}
+	for _, want := range []string{
+		`<html lang="en">`,
+		"<h1>" + i18n.T(core.English, "report.title") + "</h1>",
+		"--primary: #1f4e79;",
+		// Business fields, the agency and LLM text are escaped
+		"Mugs &amp; bowls &lt;script&gt;alert(&#34;hi&#34;)&lt;/script&gt;",
+		"Acme &amp; Co",
+		"Post &lt;b&gt;daily&lt;/b&gt;.",
+		"Visual &lt;products&gt;",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("HTML has no %q", want)
+		}
+	}
+	for _, unsafe := range []string{"<script>", "<b>daily", "<products>"} {
+		if strings.Contains(page, unsafe) {
+			t.Errorf("HTML contains unescaped %q", unsafe)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(themeFile, `{"agency": "Studio North", "colors": {"primary": "#abc"}, "footer": "Confidential"}`)
+	write(markdownFile, `{{define "footer"}}-- {{.Footer}} --{{end}}`)
+
+	theme, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	report := build(t, core.English)
+	report.Agency = ""
+	var out bytes.Buffer
+	if err := theme.Markdown(&out, report); err != nil {
+		t.Fatalf("Markdown: %v", err)
+	}
+	for _, want := range []string{"Studio North", "-- Confidential --", "## " + i18n.T(core.English, "report.platforms")} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("overlaid Markdown has no %q", want)
+		}
+	}
+	if theme.Colors.Primary != "#abc" || theme.Colors.Accent != Default().Colors.Accent {
+		t.Errorf("Colors = %+v, want the overlay's primary and the built-in accent", theme.Colors)
+	}
+
+	write(themeFile, `{"colors": {"primary": "blue"}}`)
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "colors.primary") {
+		t.Errorf("Load with a bad color = %v, want an error naming colors.primary", err)
+	}
+}
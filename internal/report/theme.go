@@ -0,0 +1,341 @@
+package report
+
+import (
+	"bytes"
+	"embed"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+
+	"biz-flow/internal/calendar"
+	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
+)
+
+//go:embed themes
+var builtin embed.FS
+
+// Files a theme directory may contain. Every file is optional in an overlay.
+const (
+	themeFile    = "theme.json"
+	styleFile    = "style.css"
+	htmlFile     = "report.html.tmpl"
+	markdownFile = "report.md.tmpl"
+)
+
+// colorPattern is the accepted color syntax: #rgb or #rrggbb
+var colorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Colors are the theme's brand colors
+type Colors struct {
+	Primary string `json:"primary,omitempty"`
+	Accent  string `json:"accent,omitempty"`
+	Text    string `json:"text,omitempty"`
+}
+
+// Theme is how reports look. Agencies override the built-in theme with a
+// directory of their own files.
+type Theme struct {
+	// Agency is the name shown as the report's author
+	Agency string `json:"agency,omitempty"`
+	// Logo is an image file in the theme directory or an https URL
+	Logo   string `json:"logo,omitempty"`
+	Colors Colors `json:"colors"`
+	// Footer replaces the standard footer line
+	Footer string `json:"footer,omitempty"`
+
+	logo     htmltemplate.URL
+	css      []string
+	html     *htmltemplate.Template
+	markdown *texttemplate.Template
+}
+
+var (
+	defaultOnce  sync.Once
+	defaultTheme *Theme
+)
+
+// Default returns the built-in theme
+func Default() *Theme {
+	defaultOnce.Do(func() {
+		theme, err := load(nil)
+		if err != nil {
+			panic(fmt.Sprintf("report: built-in theme: %v", err))
+		}
+		defaultTheme = theme
+	})
+	return defaultTheme
+}
+
+// Load returns the built-in theme overlaid with the files in dir. Its
+// theme.json replaces the settings it sets, its style.css is added after the
+// built-in styles, and its templates may redefine any named block of the
+// built-in ones.
+func Load(dir string) (*Theme, error) {
+	if dir == "" {
+		return Default(), nil
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	theme, err := load(os.DirFS(dir))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", dir, err)
+	}
+	return theme, nil
+}
+
+// load reads the built-in theme and the optional overlay
+func load(overlay fs.FS) (*Theme, error) {
+	root, err := fs.Sub(builtin, "themes/default")
+	if err != nil {
+		return nil, err
+	}
+	theme := &Theme{
+		html:     htmltemplate.New(htmlFile).Funcs(htmltemplate.FuncMap(funcs(core.English))),
+		markdown: texttemplate.New(markdownFile).Funcs(texttemplate.FuncMap(funcs(core.English))),
+	}
+	layers := []fs.FS{root}
+	if overlay != nil {
+		layers = append(layers, overlay)
+	}
+	for _, layer := range layers {
+		if err := theme.read(layer); err != nil {
+			return nil, err
+		}
+	}
+	return theme, nil
+}
+
+// read applies one layer of theme files
+func (t *Theme) read(fsys fs.FS) error {
+	settings, err := readOptional(fsys, themeFile)
+	if err != nil {
+		return err
+	}
+	if settings != nil {
+		var layer Theme
+		decoder := json.NewDecoder(bytes.NewReader(settings))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&layer); err != nil {
+			return fmt.Errorf("%s: %w", themeFile, err)
+		}
+		if err := t.merge(fsys, layer); err != nil {
+			return fmt.Errorf("%s: %w", themeFile, err)
+		}
+	}
+
+	style, err := readOptional(fsys, styleFile)
+	if err != nil {
+		return err
+	}
+	if style != nil {
+		t.css = append(t.css, string(style))
+	}
+
+	html, err := readOptional(fsys, htmlFile)
+	if err != nil {
+		return err
+	}
+	if html != nil {
+		if _, err := t.html.Parse(string(html)); err != nil {
+			return err
+		}
+	}
+	markdown, err := readOptional(fsys, markdownFile)
+	if err != nil {
+		return err
+	}
+	if markdown != nil {
+		if _, err := t.markdown.Parse(string(markdown)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// merge takes the settings a layer sets, reading its logo from the layer
+func (t *Theme) merge(fsys fs.FS, layer Theme) error {
+	for _, color := range []struct {
+		name  string
+		value string
+		into  *string
+	}{
+		{"colors.primary", layer.Colors.Primary, &t.Colors.Primary},
+		{"colors.accent", layer.Colors.Accent, &t.Colors.Accent},
+		{"colors.text", layer.Colors.Text, &t.Colors.Text},
+	} {
+		if color.value == "" {
+			continue
+		}
+		if !colorPattern.MatchString(color.value) {
+			return fmt.Errorf("%s: %q is not a color like #1f4e79", color.name, color.value)
+		}
+		*color.into = color.value
+	}
+	if layer.Agency != "" {
+		t.Agency = layer.Agency
+	}
+	if layer.Footer != "" {
+		t.Footer = layer.Footer
+	}
+	if layer.Logo != "" {
+		logo, err := readLogo(fsys, layer.Logo)
+		if err != nil {
+			return fmt.Errorf("logo: %w", err)
+		}
+		t.Logo, t.logo = layer.Logo, logo
+	}
+	return nil
+}
+
+// readLogo inlines a logo file as a data URL so the report stays standalone;
+// https URLs are kept as they are
+func readLogo(fsys fs.FS, logo string) (htmltemplate.URL, error) {
+	if strings.HasPrefix(logo, "https://") {
+		return htmltemplate.URL(logo), nil
+	}
+	kind := mime.TypeByExtension(path.Ext(logo))
+	if !strings.HasPrefix(kind, "image/") {
+		return "", fmt.Errorf("%s is not an image", logo)
+	}
+	data, err := fs.ReadFile(fsys, logo)
+	if err != nil {
+		return "", err
+	}
+	return htmltemplate.URL("data:" + kind + ";base64," + base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// readOptional reads a file that may be missing, returning nil if it is
+func readOptional(fsys fs.FS, name string) ([]byte, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// page is what the templates render
+type page struct {
+	*Report
+	Theme  *Theme
+	Agency string
+	Footer string
+	Logo   htmltemplate.URL
+	Style  htmltemplate.CSS
+}
+
+// newPage fills in the report's agency and footer from the theme
+func (t *Theme) newPage(report *Report) page {
+	p := page{
+		Report: report,
+		Theme:  t,
+		Agency: report.Agency,
+		Footer: t.Footer,
+		Logo:   t.logo,
+		// Theme styles are files the agency controls, not user input
+		Style: htmltemplate.CSS(t.style()),
+	}
+	if p.Agency == "" {
+		p.Agency = t.Agency
+	}
+	if p.Footer == "" {
+		p.Footer = i18n.T(report.Locale, "report.footer")
+	}
+	return p
+}
+
+// style is the theme's stylesheet with its colors set as CSS variables
+func (t *Theme) style() string {
+	var b strings.Builder
+	b.WriteString(":root {\n")
+	for _, color := range []struct{ name, value string }{
+		{"primary", t.Colors.Primary},
+		{"accent", t.Colors.Accent},
+		{"text", t.Colors.Text},
+	} {
+		if color.value != "" {
+			fmt.Fprintf(&b, "  --%s: %s;\n", color.name, color.value)
+		}
+	}
+	b.WriteString("}\n")
+	for _, css := range t.css {
+		b.WriteString(css)
+	}
+	return b.String()
+}
+
+// HTML writes the report as a standalone HTML page with its styles and logo
+// inlined, ready to print to PDF
+func (t *Theme) HTML(w io.Writer, report *Report) error {
+	tmpl, err := t.html.Clone()
+	if err != nil {
+		return err
+	}
+	return tmpl.Funcs(htmltemplate.FuncMap(funcs(report.Locale))).Execute(w, t.newPage(report))
+}
+
+// Markdown writes the report as Markdown
+func (t *Theme) Markdown(w io.Writer, report *Report) error {
+	tmpl, err := t.markdown.Clone()
+	if err != nil {
+		return err
+	}
+	return tmpl.Funcs(texttemplate.FuncMap(funcs(report.Locale))).Execute(w, t.newPage(report))
+}
+
+// funcs are the helpers the templates share, bound to a locale
+func funcs(locale core.Locale) map[string]any {
+	return map[string]any{
+		"t": func(key string, args ...any) string {
+			return i18n.T(locale, key, args...)
+		},
+		"money": func(amount float64) string {
+			if amount == float64(int64(amount)) {
+				return fmt.Sprintf("$%.0f", amount)
+			}
+			return fmt.Sprintf("$%.2f", amount)
+		},
+		"hashtags": func(tags []string) string {
+			formatted := make([]string, 0, len(tags))
+			for _, tag := range tags {
+				formatted = append(formatted, "#"+strings.TrimPrefix(tag, "#"))
+			}
+			return strings.Join(formatted, " ")
+		},
+		"counts": func(counts []Count) string {
+			items := make([]string, 0, len(counts))
+			for _, count := range counts {
+				items = append(items, fmt.Sprintf("%s %d", count.Name, count.Posts))
+			}
+			return strings.Join(items, ", ")
+		},
+		"list": func(items []string) string {
+			return i18n.List(locale, items)
+		},
+		"cadence": func(cadence calendar.Cadence) string {
+			return i18n.T(locale, "report.cadence.line", cadence.Platform, cadence.PostsPerWeek, cadence.Time)
+		},
+		"bar": func(percent int) string {
+			filled := (percent + 5) / 10
+			return strings.Repeat("█", filled) + strings.Repeat("░", 10-filled)
+		},
+		"cell": func(text string) string {
+			return strings.ReplaceAll(strings.ReplaceAll(text, "|", `\|`), "\n", " ")
+		},
+		"phase": func(number int, suffix string) string {
+			return i18n.T(locale, fmt.Sprintf("report.phase.%d%s", number, suffix))
+		},
+	}
+}
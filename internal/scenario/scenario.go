@@ -0,0 +1,398 @@
+// Package scenario answers what-if questions about a business. It runs the
+// deterministic pipeline over variations of the business's budget, goal and
+// weekly content hours, concurrently, and returns the rankings side by side
+// with the budgets at which platforms enter or drop out of the top places.
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"biz-flow/internal/calendar"
+	"biz-flow/internal/core"
+)
+
+const (
+	// DefaultTop is how many of the best-ranked places tipping points track
+	DefaultTop = 3
+	// MaxScenarios caps the variations and grid points one request runs
+	MaxScenarios = 500
+)
+
+// RecommendFunc ranks platforms with the deterministic stages only. It should
+// rank every platform that passes the filters, not just the usual top few,
+// so scenarios can show platforms moving into the top places.
+type RecommendFunc func(business core.BusinessInput) ([]core.Recommendation, error)
+
+// Range is an inclusive sweep from From to To in steps of Step; To is always
+// included even when the steps overshoot it
+type Range struct {
+	From float64 `json:"from"`
+	To   float64 `json:"to"`
+	Step float64 `json:"step"`
+}
+
+// ExploreRequest is the body of POST /scenarios: a base business and the
+// variations of it to explore
+type ExploreRequest struct {
+	Business core.BusinessInput `json:"business"`
+	// HoursPerWeek is the base's weekly content time; defaults to the
+	// calendar's
+	HoursPerWeek float64     `json:"hours_per_week,omitempty"`
+	Variations   []Variation `json:"variations,omitempty"`
+	// Budget, Goals and Hours span a grid of every budget with every goal and
+	// every weekly hours value; an axis left out keeps the base's value
+	Budget *Range               `json:"budget,omitempty"`
+	Goals  []core.MarketingGoal `json:"goals,omitempty"`
+	Hours  *Range               `json:"hours,omitempty"`
+	// Top is how many places tipping points track; defaults to DefaultTop
+	Top int `json:"top,omitempty"`
+}
+
+// Variation is one named what-if; fields left out keep the base's value
+type Variation struct {
+	// Name defaults to a description of the overrides, e.g. "budget $150"
+	Name         string             `json:"name,omitempty"`
+	Budget       *float64           `json:"budget,omitempty"`
+	Goal         core.MarketingGoal `json:"goal,omitempty"`
+	HoursPerWeek *float64           `json:"hours_per_week,omitempty"`
+}
+
+// Matrix is every scenario's ranking and the tipping points between them
+type Matrix struct {
+	Top int `json:"top"`
+	// Platforms lists every platform a scenario recommends, best rank first
+	Platforms []core.Platform `json:"platforms"`
+	// Scenarios starts with the base, then the variations, then the grid in
+	// goal, budget and hours order
+	Scenarios []Scenario `json:"scenarios"`
+	// TippingPoints are located along the budget range, for each goal; they
+	// are empty without a budget range
+	TippingPoints []TippingPoint `json:"tipping_points"`
+}
+
+// Scenario is one variation of the business and how the pipeline ranks it
+type Scenario struct {
+	Name         string             `json:"name"`
+	Budget       float64            `json:"budget"`
+	BudgetTier   string             `json:"budget_tier"`
+	Goal         core.MarketingGoal `json:"goal"`
+	HoursPerWeek float64            `json:"hours_per_week"`
+	Rankings     []Ranking          `json:"rankings"`
+	// Cadence shares the weekly hours between the platforms in the top
+	// places the way the content calendar does
+	Cadence      []calendar.Cadence `json:"cadence"`
+	PlannedHours float64            `json:"planned_hours"`
+
+	business core.BusinessInput
+}
+
+// Ranking is one platform's place in a scenario
+type Ranking struct {
+	Platform core.Platform `json:"platform"`
+	Rank     int           `json:"rank"`
+	Score    float64       `json:"score"`
+}
+
+// Rank returns the platform's rank in the scenario, 0 when not recommended
+func (s Scenario) Rank(platform core.Platform) int {
+	for _, ranking := range s.Rankings {
+		if ranking.Platform == platform {
+			return ranking.Rank
+		}
+	}
+	return 0
+}
+
+// Explore runs every scenario of the request through recommend with a bounded
+// worker pool, defaulting to the number of CPUs, and locates the tipping
+// points along the budget range
+func Explore(ctx context.Context, recommend RecommendFunc, request ExploreRequest, workers int) (*Matrix, error) {
+	top := request.Top
+	if top == 0 {
+		top = DefaultTop
+	}
+	if top < 1 || top > len(core.AllPlatforms()) {
+		return nil, &core.ValidationError{Field: "top", Message: fmt.Sprintf("must be between 1 and %d", len(core.AllPlatforms()))}
+	}
+	scenarios, budgets, goals, err := request.expand()
+	if err != nil {
+		return nil, err
+	}
+	if err := run(ctx, recommend, scenarios, top, workers); err != nil {
+		return nil, err
+	}
+
+	matrix := &Matrix{Top: top, Platforms: platforms(scenarios), Scenarios: scenarios, TippingPoints: []TippingPoint{}}
+	if len(budgets) > 1 {
+		explorer := newExplorer(recommend, top, scenarios)
+		if matrix.TippingPoints, err = explorer.tippingPoints(ctx, request.Business, budgets, goals); err != nil {
+			return nil, err
+		}
+	}
+	return matrix, nil
+}
+
+// expand validates the request and lists its scenarios, along with the grid's
+// budgets and goals
+func (r ExploreRequest) expand() ([]Scenario, []float64, []core.MarketingGoal, error) {
+	if err := r.Business.Validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	if len(r.Variations) == 0 && r.Budget == nil && len(r.Goals) == 0 && r.Hours == nil {
+		return nil, nil, nil, &core.ValidationError{Field: "variations", Message: "give variations or a budget, goals or hours range to explore"}
+	}
+	hours := r.HoursPerWeek
+	if hours == 0 {
+		hours = calendar.DefaultHoursPerWeek
+	}
+	if err := validateHours("hours_per_week", hours); err != nil {
+		return nil, nil, nil, err
+	}
+
+	scenarios := []Scenario{newScenario("base", r.Business, r.Business.Budget, r.Business.Goal, hours)}
+	for i, variation := range r.Variations {
+		field := fmt.Sprintf("variations[%d]", i)
+		budget, goal, hoursPerWeek := r.Business.Budget, r.Business.Goal, hours
+		var named []string
+		if variation.Budget != nil {
+			if err := core.ValidateBudget(*variation.Budget); err != nil {
+				return nil, nil, nil, &core.ValidationError{Field: field + ".budget", Message: "must not be negative"}
+			}
+			budget = *variation.Budget
+			named = append(named, budgetName(budget))
+		}
+		if variation.Goal != "" {
+			if err := core.ValidateGoal(variation.Goal); err != nil {
+				return nil, nil, nil, &core.ValidationError{Field: field + ".goal", Message: "must be awareness or sales"}
+			}
+			goal = variation.Goal
+			named = append(named, goalName(goal))
+		}
+		if variation.HoursPerWeek != nil {
+			if err := validateHours(field+".hours_per_week", *variation.HoursPerWeek); err != nil {
+				return nil, nil, nil, err
+			}
+			hoursPerWeek = *variation.HoursPerWeek
+			named = append(named, hoursName(hoursPerWeek))
+		}
+		name := variation.Name
+		if name == "" {
+			name = strings.Join(named, ", ")
+		}
+		if name == "" {
+			return nil, nil, nil, &core.ValidationError{Field: field, Message: "changes nothing; set a budget, goal or hours_per_week"}
+		}
+		scenarios = append(scenarios, newScenario(name, r.Business, budget, goal, hoursPerWeek))
+	}
+
+	if r.Budget == nil && len(r.Goals) == 0 && r.Hours == nil {
+		return scenarios, nil, nil, nil
+	}
+	budgets := []float64{r.Business.Budget}
+	if r.Budget != nil {
+		var err error
+		if budgets, err = r.Budget.values("budget"); err != nil {
+			return nil, nil, nil, err
+		}
+		if budgets[0] < 0 {
+			return nil, nil, nil, &core.ValidationError{Field: "budget.from", Message: "must not be negative"}
+		}
+	}
+	goals := []core.MarketingGoal{r.Business.Goal}
+	if len(r.Goals) > 0 {
+		goals = r.Goals
+		for i, goal := range goals {
+			if err := core.ValidateGoal(goal); err != nil {
+				return nil, nil, nil, &core.ValidationError{Field: fmt.Sprintf("goals[%d]", i), Message: "must be awareness or sales"}
+			}
+		}
+	}
+	hoursValues := []float64{hours}
+	if r.Hours != nil {
+		var err error
+		if hoursValues, err = r.Hours.values("hours"); err != nil {
+			return nil, nil, nil, err
+		}
+		for _, value := range []float64{hoursValues[0], hoursValues[len(hoursValues)-1]} {
+			if err := validateHours("hours", value); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+	}
+
+	if total := len(scenarios) + len(budgets)*len(goals)*len(hoursValues); total > MaxScenarios {
+		return nil, nil, nil, &core.ValidationError{Field: "budget", Message: fmt.Sprintf("the request spans %d scenarios; at most %d can run at once", total, MaxScenarios)}
+	}
+	for _, goal := range goals {
+		for _, budget := range budgets {
+			for _, hoursPerWeek := range hoursValues {
+				var named []string
+				if r.Budget != nil {
+					named = append(named, budgetName(budget))
+				}
+				if len(r.Goals) > 0 {
+					named = append(named, goalName(goal))
+				}
+				if r.Hours != nil {
+					named = append(named, hoursName(hoursPerWeek))
+				}
+				scenarios = append(scenarios, newScenario(strings.Join(named, ", "), r.Business, budget, goal, hoursPerWeek))
+			}
+		}
+	}
+	return scenarios, budgets, goals, nil
+}
+
+// values lists the range's values, rounded to the cent
+func (r Range) values(field string) ([]float64, error) {
+	switch {
+	case r.Step <= 0:
+		return nil, &core.ValidationError{Field: field + ".step", Message: "must be positive"}
+	case r.To < r.From:
+		return nil, &core.ValidationError{Field: field + ".to", Message: "must not be below from"}
+	}
+	count := math.Floor((r.To-r.From)/r.Step+1e-9) + 1
+	if count > MaxScenarios {
+		return nil, &core.ValidationError{Field: field + ".step", Message: fmt.Sprintf("spans more than %d values", MaxScenarios)}
+	}
+	values := make([]float64, 0, int(count)+1)
+	for i := 0; i < int(count); i++ {
+		values = append(values, cents(r.From+float64(i)*r.Step))
+	}
+	if last := values[len(values)-1]; cents(r.To) > last {
+		values = append(values, cents(r.To))
+	}
+	return values, nil
+}
+
+// validateHours checks a weekly hours value against the calendar's limits
+func validateHours(field string, hours float64) error {
+	if math.IsNaN(hours) || hours <= 0 || hours > calendar.MaxHoursPerWeek {
+		return &core.ValidationError{Field: field, Message: fmt.Sprintf("must be more than 0 and at most %d", calendar.MaxHoursPerWeek)}
+	}
+	return nil
+}
+
+// newScenario applies a budget and goal to the base business
+func newScenario(name string, base core.BusinessInput, budget float64, goal core.MarketingGoal, hours float64) Scenario {
+	business := base
+	business.Budget, business.Goal = budget, goal
+	return Scenario{
+		Name:         name,
+		Budget:       budget,
+		BudgetTier:   business.BudgetTier(),
+		Goal:         goal,
+		HoursPerWeek: hours,
+		business:     business,
+	}
+}
+
+// run ranks the scenario and shares its hours between the platforms in the
+// top places
+func (s *Scenario) run(recommend RecommendFunc, top int) error {
+	recommendations, err := recommend(s.business)
+	if err != nil {
+		return err
+	}
+	s.Rankings = make([]Ranking, len(recommendations))
+	var leading []core.Platform
+	for i, recommendation := range recommendations {
+		s.Rankings[i] = Ranking{Platform: recommendation.Platform, Rank: recommendation.Rank, Score: recommendation.Score}
+		if recommendation.Rank <= top {
+			leading = append(leading, recommendation.Platform)
+		}
+	}
+	s.Cadence = calendar.Cadences(leading, s.HoursPerWeek)
+	for _, cadence := range s.Cadence {
+		s.PlannedHours += cadence.PostsPerWeek * cadence.HoursPerPost
+	}
+	s.PlannedHours = cents(s.PlannedHours)
+	return nil
+}
+
+// run ranks the scenarios with a bounded worker pool, stopping early once
+// ctx is done
+func run(ctx context.Context, recommend RecommendFunc, scenarios []Scenario, top, workers int) error {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	indexes := make(chan int)
+	errs := make([]error, len(scenarios))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				errs[index] = scenarios[index].run(recommend, top)
+			}
+		}()
+	}
+feed:
+	for i := range scenarios {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("scenario %q: %w", scenarios[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// platforms lists every recommended platform by its best rank in any
+// scenario, then by first appearance
+func platforms(scenarios []Scenario) []core.Platform {
+	best := make(map[core.Platform]int)
+	var order []core.Platform
+	for _, scenario := range scenarios {
+		for _, ranking := range scenario.Rankings {
+			rank, seen := best[ranking.Platform]
+			if !seen {
+				order = append(order, ranking.Platform)
+			}
+			if !seen || ranking.Rank < rank {
+				best[ranking.Platform] = ranking.Rank
+			}
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool { return best[order[i]] < best[order[j]] })
+	return order
+}
+
+// budgetName names a scenario's budget
+func budgetName(budget float64) string {
+	return fmt.Sprintf("budget $%g", budget)
+}
+
+// goalName names a scenario's goal
+func goalName(goal core.MarketingGoal) string {
+	return "goal " + string(goal)
+}
+
+// hoursName names a scenario's weekly content hours
+func hoursName(hours float64) string {
+	return fmt.Sprintf("%g h/week", hours)
+}
+
+// cents rounds to two decimal places
+func cents(x float64) float64 {
+	return math.Round(x*100) / 100
+}
@@ -0,0 +1,175 @@
+package scenario
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"biz-flow/internal/core"
+)
+
+// precision is how finely tipping budgets are located, in dollars
+const precision = 0.01
+
+// TippingPoint is the budget at which a platform enters or drops out of the
+// top places for one goal
+type TippingPoint struct {
+	Platform core.Platform      `json:"platform"`
+	Goal     core.MarketingGoal `json:"goal"`
+	// Budget is the lowest budget, to the cent, from which the change holds
+	Budget float64 `json:"budget"`
+	// Enters is true when the platform moves into the top places at Budget
+	// and false when it drops out
+	Enters bool `json:"enters"`
+	// Rank is the platform's rank at Budget; 0 when it is not recommended
+	Rank int `json:"rank"`
+	// FromTier and ToTier are the budget tiers just below Budget and at it;
+	// they differ when the tipping point is a tier boundary
+	FromTier string `json:"from_tier"`
+	ToTier   string `json:"to_tier"`
+}
+
+// rankKey identifies a ranking along the budget range; hours do not change
+// rankings, so they are left out
+type rankKey struct {
+	goal   core.MarketingGoal
+	budget float64
+}
+
+// explorer ranks the business at budgets between the grid's, remembering
+// every ranking it has seen
+type explorer struct {
+	recommend RecommendFunc
+	top       int
+	ranks     map[rankKey]map[core.Platform]int
+}
+
+// newExplorer seeds the remembered rankings with the scenarios'
+func newExplorer(recommend RecommendFunc, top int, scenarios []Scenario) *explorer {
+	e := &explorer{recommend: recommend, top: top, ranks: make(map[rankKey]map[core.Platform]int)}
+	for _, scenario := range scenarios {
+		key := rankKey{goal: scenario.Goal, budget: scenario.Budget}
+		if _, ok := e.ranks[key]; ok {
+			continue
+		}
+		ranks := make(map[core.Platform]int, len(scenario.Rankings))
+		for _, ranking := range scenario.Rankings {
+			ranks[ranking.Platform] = ranking.Rank
+		}
+		e.ranks[key] = ranks
+	}
+	return e
+}
+
+// tippingPoints walks each goal's budgets in order. Wherever a platform is in
+// the top places at one budget and not the next, it bisects between the two
+// for the budget where that changes, assuming it changes once in between.
+func (e *explorer) tippingPoints(ctx context.Context, base core.BusinessInput, budgets []float64, goals []core.MarketingGoal) ([]TippingPoint, error) {
+	points := []TippingPoint{}
+	for _, goal := range goals {
+		business := base
+		business.Goal = goal
+		for i := 1; i < len(budgets); i++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			lower, err := e.rank(business, budgets[i-1])
+			if err != nil {
+				return nil, err
+			}
+			upper, err := e.rank(business, budgets[i])
+			if err != nil {
+				return nil, err
+			}
+			for _, platform := range candidates(upper, lower) {
+				enters := e.inTop(upper[platform])
+				if e.inTop(lower[platform]) == enters {
+					continue
+				}
+				point, err := e.bisect(business, platform, budgets[i-1], budgets[i], enters)
+				if err != nil {
+					return nil, err
+				}
+				points = append(points, point)
+			}
+		}
+	}
+	return points, nil
+}
+
+// bisect narrows the budgets between below, where the platform's place is
+// still the old one, and above, where it has changed, down to a cent
+func (e *explorer) bisect(business core.BusinessInput, platform core.Platform, below, above float64, enters bool) (TippingPoint, error) {
+	for above-below > precision+1e-9 {
+		middle := cents((below + above) / 2)
+		if middle <= below || middle >= above {
+			break
+		}
+		ranks, err := e.rank(business, middle)
+		if err != nil {
+			return TippingPoint{}, err
+		}
+		if e.inTop(ranks[platform]) == enters {
+			above = middle
+		} else {
+			below = middle
+		}
+	}
+
+	ranks, err := e.rank(business, above)
+	if err != nil {
+		return TippingPoint{}, err
+	}
+	from, to := business, business
+	from.Budget, to.Budget = below, above
+	return TippingPoint{
+		Platform: platform,
+		Goal:     business.Goal,
+		Budget:   above,
+		Enters:   enters,
+		Rank:     ranks[platform],
+		FromTier: from.BudgetTier(),
+		ToTier:   to.BudgetTier(),
+	}, nil
+}
+
+// rank returns each recommended platform's rank at the budget
+func (e *explorer) rank(business core.BusinessInput, budget float64) (map[core.Platform]int, error) {
+	key := rankKey{goal: business.Goal, budget: budget}
+	if ranks, ok := e.ranks[key]; ok {
+		return ranks, nil
+	}
+	business.Budget = budget
+	recommendations, err := e.recommend(business)
+	if err != nil {
+		return nil, err
+	}
+	ranks := make(map[core.Platform]int, len(recommendations))
+	for _, recommendation := range recommendations {
+		ranks[recommendation.Platform] = recommendation.Rank
+	}
+	e.ranks[key] = ranks
+	return ranks, nil
+}
+
+// candidates lists the platforms of either ranking, those of the first in
+// rank order, then the rest of the second's
+func candidates(first, second map[core.Platform]int) []core.Platform {
+	var platforms []core.Platform
+	for _, ranks := range []map[core.Platform]int{first, second} {
+		start := len(platforms)
+		for platform := range ranks {
+			if !slices.Contains(platforms, platform) {
+				platforms = append(platforms, platform)
+			}
+		}
+		added := platforms[start:]
+		sort.Slice(added, func(i, j int) bool { return ranks[added[i]] < ranks[added[j]] })
+	}
+	return platforms
+}
+
+// inTop reports whether a rank is one of the top places
+func (e *explorer) inTop(rank int) bool {
+	return rank > 0 && rank <= e.top
+}
@@ -0,0 +1,98 @@
+package scenario
+
+import (
+	"context"
+	"testing"
+
+	"biz-flow/internal/core"
+)
+
+// thresholdRecommend ranks Instagram first, then each platform of joins once
+// the budget reaches its threshold, then Facebook
+func thresholdRecommend(joins map[core.Platform]float64, calls *int) RecommendFunc {
+	return func(business core.BusinessInput) ([]core.Recommendation, error) {
+		*calls++
+		platforms := []core.Platform{core.Instagram}
+		for _, platform := range []core.Platform{core.GoogleBusiness, core.TikTok} {
+			if threshold, ok := joins[platform]; ok && business.Budget >= threshold {
+				platforms = append(platforms, platform)
+			}
+		}
+		platforms = append(platforms, core.Facebook)
+
+		recommendations := make([]core.Recommendation, len(platforms))
+		for i, platform := range platforms {
+			recommendations[i] = core.Recommendation{Platform: platform, Rank: i + 1, Score: float64(100 - i)}
+		}
+		return recommendations, nil
+	}
+}
+
+func TestTippingPoints(t *testing.T) {
+	tests := []struct {
+		name  string
+		joins map[core.Platform]float64
+		want  []TippingPoint
+	}{
+		{name: "rankings never change", want: []TippingPoint{}},
+		{
+			name:  "a platform enters between grid budgets",
+			joins: map[core.Platform]float64{core.GoogleBusiness: 57.25},
+			want: []TippingPoint{
+				{Platform: core.GoogleBusiness, Budget: 57.25, Enters: true, Rank: 2},
+				{Platform: core.Facebook, Budget: 57.25, Enters: false, Rank: 3},
+			},
+		},
+		{
+			name:  "a platform enters on a grid budget",
+			joins: map[core.Platform]float64{core.GoogleBusiness: 100},
+			want: []TippingPoint{
+				{Platform: core.GoogleBusiness, Budget: 100, Enters: true, Rank: 2},
+				{Platform: core.Facebook, Budget: 100, Enters: false, Rank: 3},
+			},
+		},
+		{
+			name:  "a platform enters a cent above the first budget",
+			joins: map[core.Platform]float64{core.GoogleBusiness: 0.01},
+			want: []TippingPoint{
+				{Platform: core.GoogleBusiness, Budget: 0.01, Enters: true, Rank: 2},
+				{Platform: core.Facebook, Budget: 0.01, Enters: false, Rank: 3},
+			},
+		},
+		{
+			name:  "a platform below the top places is not tracked",
+			joins: map[core.Platform]float64{core.GoogleBusiness: 20, core.TikTok: 150.5},
+			want: []TippingPoint{
+				{Platform: core.GoogleBusiness, Budget: 20, Enters: true, Rank: 2},
+				{Platform: core.Facebook, Budget: 20, Enters: false, Rank: 3},
+			},
+		},
+	}
+
+	base := core.BusinessInput{Type: core.Retail, Description: "handmade mugs", Location: "Austin", Goal: core.Sales}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			request := ExploreRequest{Business: base, Budget: &Range{From: 0, To: 200, Step: 100}, Top: 2}
+			matrix, err := Explore(context.Background(), thresholdRecommend(tt.joins, &calls), request, 1)
+			if err != nil {
+				t.Fatalf("Explore: %v", err)
+			}
+
+			if len(matrix.TippingPoints) != len(tt.want) {
+				t.Fatalf("tipping points = %+v, want %+v", matrix.TippingPoints, tt.want)
+			}
+			for i, got := range matrix.TippingPoints {
+				want := tt.want[i]
+				if got.Platform != want.Platform || got.Goal != core.Sales || got.Budget != want.Budget ||
+					got.Enters != want.Enters || got.Rank != want.Rank {
+					t.Errorf("tipping point %d = %+v, want %+v", i, got, want)
+				}
+			}
+			// A cent-wide bisection of a $100 gap takes about 14 rankings
+			if calls > len(matrix.Scenarios)+3+2*15 {
+				t.Errorf("ranked %d times, want the bisection to reuse rankings", calls)
+			}
+		})
+	}
+}
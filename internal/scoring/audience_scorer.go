@@ -1 +1,53 @@
-package scoring
\ No newline at end of file
+package scoring
+
+import (
+	"strings"
+
+	"biz-flow/internal/core"
+)
+
+// AudienceScorer rates how well a platform's audience matches the business
+type AudienceScorer struct{}
+
+// NewAudienceScorer creates a new audience scorer
+func NewAudienceScorer() *AudienceScorer {
+	return &AudienceScorer{}
+}
+
+// Score returns a 0.0 (wrong audience) to 1.0 (ideal audience) score
+func (as *AudienceScorer) Score(business core.BusinessInput, metadata core.PlatformMetadata) float64 {
+	score := 0.4
+	for _, businessType := range metadata.BestFor {
+		if businessType == business.Type {
+			score = 0.8
+			break
+		}
+	}
+
+	// Local customers search Google before anything else
+	if business.IsLocal() && metadata.Name == core.GoogleBusiness {
+		score += 0.2
+	}
+
+	// An existing presence means the audience is already there
+	if usesChannel(business.Channels, metadata.Name) {
+		score += 0.1
+	}
+
+	return clamp(score)
+}
+
+// usesChannel reports whether one of the business's existing channels is the platform
+func usesChannel(channels []string, platform core.Platform) bool {
+	name := strings.ToLower(string(platform))
+	for _, channel := range channels {
+		channel = strings.ToLower(strings.TrimSpace(channel))
+		if channel == "" {
+			continue
+		}
+		if channel == name || strings.HasPrefix(name, channel) {
+			return true
+		}
+	}
+	return false
+}
@@ -1 +1,44 @@
 package scoring
+
+import (
+	"biz-flow/internal/core"
+)
+
+// BudgetScorer rates how well a platform fits the monthly budget
+type BudgetScorer struct{}
+
+// NewBudgetScorer creates a new budget scorer
+func NewBudgetScorer() *BudgetScorer {
+	return &BudgetScorer{}
+}
+
+// Score returns a 0.0 (poor fit) to 1.0 (ideal fit) budget score
+func (bs *BudgetScorer) Score(business core.BusinessInput, metadata core.PlatformMetadata) float64 {
+	if business.Budget < metadata.MinBudget {
+		return 0.0
+	}
+
+	switch {
+	case business.HasLowBudget():
+		// Low budget: only organic reach is realistic
+		if metadata.IsOrganic {
+			return 1.0
+		}
+		return 0.2
+	case business.HasMediumBudget():
+		// Medium budget: organic first, paid boosts are a bonus
+		if metadata.IsOrganic && metadata.IsPaid {
+			return 1.0
+		}
+		if metadata.IsOrganic {
+			return 0.9
+		}
+		return 0.6
+	default:
+		// High budget: platforms with paid options can put the money to work
+		if metadata.IsPaid {
+			return 1.0
+		}
+		return 0.7
+	}
+}
@@ -1 +1,35 @@
 package scoring
+
+import (
+	"biz-flow/internal/core"
+)
+
+// EffortScorer rates how manageable a platform is for a micro-business
+type EffortScorer struct{}
+
+// NewEffortScorer creates a new effort scorer
+func NewEffortScorer() *EffortScorer {
+	return &EffortScorer{}
+}
+
+// Score returns a 0.0 (unmanageable) to 1.0 (effortless) effort score
+func (es *EffortScorer) Score(business core.BusinessInput, metadata core.PlatformMetadata) float64 {
+	var score float64
+	switch metadata.EffortLevel {
+	case core.LowEffort:
+		score = 1.0
+	case core.MediumEffort:
+		score = 0.7
+	case core.HighEffort:
+		score = 0.4
+	default:
+		score = 0.5
+	}
+
+	// Video production is easier when the product itself is the subject
+	if metadata.RequiresVideo && business.Type != core.Retail {
+		score -= 0.2
+	}
+
+	return clamp(score)
+}
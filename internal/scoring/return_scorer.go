@@ -1 +1,28 @@
 package scoring
+
+import (
+	"biz-flow/internal/core"
+)
+
+// ReturnScorer rates the expected return of a platform for the marketing goal
+type ReturnScorer struct{}
+
+// NewReturnScorer creates a new return scorer
+func NewReturnScorer() *ReturnScorer {
+	return &ReturnScorer{}
+}
+
+// Score returns a 0.0 (no return) to 1.0 (best return) score for the goal,
+// using the business's calibrated potential when it has reported results
+func (rs *ReturnScorer) Score(business core.BusinessInput, metadata core.PlatformMetadata) float64 {
+	reach, conversion := business.Potential(metadata)
+	switch business.Goal {
+	case core.Awareness:
+		// Awareness is mostly about reach, conversions still matter a little
+		return clamp((0.8*reach + 0.2*conversion) / 10.0)
+	case core.Sales:
+		return clamp((0.8*conversion + 0.2*reach) / 10.0)
+	default:
+		return clamp((reach + conversion) / 20.0)
+	}
+}
@@ -1 +1,134 @@
 package scoring
+
+import (
+	"math"
+	"sort"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/filters"
+)
+
+// Weights controls how much each component contributes to the final score
+type Weights struct {
+	Audience float64
+	Budget   float64
+	Effort   float64
+	Return   float64
+}
+
+// DefaultWeights returns the weights used for standard consultations
+func DefaultWeights() Weights {
+	return Weights{
+		Audience: 0.3,
+		Budget:   0.2,
+		Effort:   0.2,
+		Return:   0.3,
+	}
+}
+
+// Breakdown holds the individual component scores behind a platform score
+type Breakdown struct {
+	Audience float64 `json:"audience"`
+	Budget   float64 `json:"budget"`
+	Effort   float64 `json:"effort"`
+	Return   float64 `json:"return"`
+	Penalty  float64 `json:"penalty"`
+}
+
+// ScoredPlatform is a platform with its final 0-100 score
+type ScoredPlatform struct {
+	Platform  core.Platform `json:"platform"`
+	Score     float64       `json:"score"`
+	Breakdown Breakdown     `json:"breakdown"`
+}
+
+// Scorer combines the component scorers and constraint penalties into a ranking
+type Scorer struct {
+	weights     Weights
+	audience    *AudienceScorer
+	budget      *BudgetScorer
+	effort      *EffortScorer
+	returns     *ReturnScorer
+	constraints *filters.ConstraintValidator
+}
+
+// NewScorer creates a new scorer with the default weights
+func NewScorer() *Scorer {
+	return NewScorerWithWeights(DefaultWeights())
+}
+
+// NewScorerWithWeights creates a new scorer with custom weights
+func NewScorerWithWeights(weights Weights) *Scorer {
+	return &Scorer{
+		weights:     weights,
+		audience:    NewAudienceScorer(),
+		budget:      NewBudgetScorer(),
+		effort:      NewEffortScorer(),
+		returns:     NewReturnScorer(),
+		constraints: filters.NewConstraintValidator(),
+	}
+}
+
+// ScorePlatform scores a single platform for the business
+func (s *Scorer) ScorePlatform(business core.BusinessInput, platform core.Platform) ScoredPlatform {
+	metadata, exists := core.GetPlatformMetadata(platform)
+	if !exists {
+		return ScoredPlatform{Platform: platform}
+	}
+
+	breakdown := Breakdown{
+		Audience: s.audience.Score(business, metadata),
+		Budget:   s.budget.Score(business, metadata),
+		Effort:   s.effort.Score(business, metadata),
+		Return:   s.returns.Score(business, metadata),
+		Penalty:  s.constraints.GetCombinedPenalty(business, platform),
+	}
+
+	return ScoredPlatform{
+		Platform:  platform,
+		Score:     round(s.Combine(breakdown)),
+		Breakdown: breakdown,
+	}
+}
+
+// Combine weighs the component scores and softens the result by the penalty,
+// giving the 0-100 score before rounding
+func (s *Scorer) Combine(breakdown Breakdown) float64 {
+	totalWeight := s.weights.Audience + s.weights.Budget + s.weights.Effort + s.weights.Return
+	if totalWeight <= 0 {
+		return 0
+	}
+
+	weighted := (breakdown.Audience*s.weights.Audience +
+		breakdown.Budget*s.weights.Budget +
+		breakdown.Effort*s.weights.Effort +
+		breakdown.Return*s.weights.Return) / totalWeight
+
+	// Penalties soften the score rather than zero it out
+	return weighted * (1.0 - 0.5*breakdown.Penalty) * 100.0
+}
+
+// Rank scores the platforms and returns them from best to worst
+func (s *Scorer) Rank(business core.BusinessInput, platforms []core.Platform) []ScoredPlatform {
+	ranked := make([]ScoredPlatform, 0, len(platforms))
+	for _, platform := range platforms {
+		ranked = append(ranked, s.ScorePlatform(business, platform))
+	}
+
+	// Stable sort keeps the filter's priority order for ties
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+
+	return ranked
+}
+
+// clamp limits a component score to the 0.0-1.0 range
+func clamp(value float64) float64 {
+	return math.Max(0.0, math.Min(1.0, value))
+}
+
+// round rounds a score to one decimal place
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
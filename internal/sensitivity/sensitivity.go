@@ -0,0 +1,423 @@
+// Package sensitivity measures how fragile a ranking is. It perturbs the
+// platform metadata numbers and constraint penalties behind each score, ranks
+// the platforms again many times over (Monte Carlo) and reports how often each
+// recommendation keeps its place, an interval on its score and which
+// parameters the ranking depends on most.
+package sensitivity
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/filters"
+	"biz-flow/internal/scoring"
+)
+
+const (
+	DefaultRuns   = 1000
+	MaxRuns       = 20000
+	DefaultSpread = 0.2
+	DefaultSeed   = 1
+	// maxInfluences caps how many parameters a report lists
+	maxInfluences = 10
+)
+
+// Parameter names one of the numbers behind a platform's score
+type Parameter string
+
+const (
+	ReachPotential  Parameter = "reach_potential"
+	ConversionFocus Parameter = "conversion_focus"
+	BudgetPenalty   Parameter = "budget_penalty"
+	EffortPenalty   Parameter = "effort_penalty"
+	VisualPenalty   Parameter = "visual_penalty"
+	GoalPenalty     Parameter = "goal_penalty"
+)
+
+// Parameters returns every perturbed parameter, metadata numbers first
+func Parameters() []Parameter {
+	return []Parameter{ReachPotential, ConversionFocus, BudgetPenalty, EffortPenalty, VisualPenalty, GoalPenalty}
+}
+
+// limit is the largest value a parameter can take: metadata numbers are on a
+// 1-10 scale and penalties run from 0 to 1
+func (p Parameter) limit() float64 {
+	if p == ReachPotential || p == ConversionFocus {
+		return 10
+	}
+	return 1
+}
+
+// Options shape an analysis. Zero values take the defaults.
+type Options struct {
+	Runs int
+	// Spread is the largest relative perturbation of each parameter: 0.2
+	// moves it by up to ±20%. A penalty that does not apply stays off.
+	Spread float64
+	// Seed makes the runs reproducible; the same seed gives the same report
+	Seed int64
+}
+
+// AnalyzeRequest is the body of POST /sensitivity
+type AnalyzeRequest struct {
+	Business core.BusinessInput `json:"business"`
+	Runs     int                `json:"runs,omitempty"`
+	Spread   float64            `json:"spread,omitempty"`
+	Seed     int64              `json:"seed,omitempty"`
+}
+
+// Options returns the request's analysis options
+func (r AnalyzeRequest) Options() Options {
+	return Options{Runs: r.Runs, Spread: r.Spread, Seed: r.Seed}
+}
+
+// Report is how stable a business's ranking is under perturbation
+type Report struct {
+	Runs   int     `json:"runs"`
+	Spread float64 `json:"spread"`
+	Seed   int64   `json:"seed"`
+	// Top is how many places the recommendations fill
+	Top int `json:"top"`
+	// Stability is the share of runs whose top places match the unperturbed
+	// ranking in order, and SetStability the share where the same platforms
+	// fill them in any order
+	Stability    float64 `json:"stability"`
+	SetStability float64 `json:"set_stability"`
+	// Platforms covers every platform that passes the filters, in the
+	// unperturbed rank order; those ranked within Top are the recommendations
+	Platforms []PlatformStability `json:"platforms"`
+	// Influences are the parameters the top places depend on most, those that
+	// reorder them first
+	Influences []Influence `json:"influences"`
+}
+
+// PlatformStability is how one platform's place held up across the runs
+type PlatformStability struct {
+	Platform core.Platform `json:"platform"`
+	Rank     int           `json:"rank"`
+	Score    float64       `json:"score"`
+	// Margin is the score lead over the next-ranked platform; 0 for the last
+	Margin float64 `json:"margin"`
+	// RankStability is the share of runs in which the platform kept its rank
+	RankStability float64 `json:"rank_stability"`
+	// TopShare is the share of runs in which it ranked within the top places
+	TopShare float64 `json:"top_share"`
+	// ScoreLow and ScoreHigh bound the middle 95% of its perturbed scores
+	ScoreLow  float64 `json:"score_low"`
+	ScoreHigh float64 `json:"score_high"`
+	// Verdict reads RankStability: solid from 95%, likely from 75%, otherwise
+	// fragile
+	Verdict string `json:"verdict"`
+}
+
+// Influence is what moving one parameter to either end of the spread does
+type Influence struct {
+	Platform  core.Platform `json:"platform"`
+	Parameter Parameter     `json:"parameter"`
+	Value     float64       `json:"value"`
+	// Low and High are the parameter at either end of the spread, and
+	// ScoreLow and ScoreHigh the platform's score at each
+	Low       float64 `json:"low"`
+	High      float64 `json:"high"`
+	ScoreLow  float64 `json:"score_low"`
+	ScoreHigh float64 `json:"score_high"`
+	// Reorders is set when either end changes which platforms fill the top
+	// places or their order
+	Reorders bool `json:"reorders"`
+}
+
+// Analyzer reruns the scorer on perturbed parameters
+type Analyzer struct {
+	scorer      *scoring.Scorer
+	returns     *scoring.ReturnScorer
+	constraints *filters.ConstraintValidator
+}
+
+// NewAnalyzer creates an analyzer around the scorer that ranked the business
+func NewAnalyzer(scorer *scoring.Scorer) *Analyzer {
+	return &Analyzer{scorer: scorer, returns: scoring.NewReturnScorer(), constraints: filters.NewConstraintValidator()}
+}
+
+// Analyze perturbs the scores of the platforms that passed the filters, in
+// the filters' order, and reports how stable the top places are
+func (a *Analyzer) Analyze(business core.BusinessInput, platforms []core.Platform, top int, options Options) (*Report, error) {
+	options, err := normalize(options)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]model, 0, len(platforms))
+	for _, platform := range platforms {
+		if m, ok := a.model(business, platform); ok {
+			models = append(models, m)
+		}
+	}
+	top = min(top, len(models))
+	report := &Report{
+		Runs:       options.Runs,
+		Spread:     options.Spread,
+		Seed:       options.Seed,
+		Top:        top,
+		Platforms:  []PlatformStability{},
+		Influences: []Influence{},
+	}
+	if len(models) == 0 {
+		return report, nil
+	}
+
+	unit := make([][]float64, len(models))
+	for i := range models {
+		unit[i] = ones()
+	}
+	baseScores := a.scores(business, models, unit)
+	baseOrder := order(baseScores)
+
+	random := rand.New(rand.NewPCG(uint64(options.Seed), uint64(options.Seed)))
+	samples := make([][]float64, len(models))
+	keptRank := make([]int, len(models))
+	inTop := make([]int, len(models))
+	factors := make([][]float64, len(models))
+	for run := 0; run < options.Runs; run++ {
+		for i := range models {
+			factors[i] = make([]float64, len(Parameters()))
+			for j := range factors[i] {
+				factors[i][j] = 1 + options.Spread*(2*random.Float64()-1)
+			}
+		}
+		scores := a.scores(business, models, factors)
+		runOrder := order(scores)
+		if slices.Equal(runOrder[:top], baseOrder[:top]) {
+			report.Stability++
+		}
+		if sameSet(runOrder[:top], baseOrder[:top]) {
+			report.SetStability++
+		}
+		for rank, i := range runOrder {
+			samples[i] = append(samples[i], scores[i])
+			if baseOrder[rank] == i {
+				keptRank[i]++
+			}
+			if rank < top {
+				inTop[i]++
+			}
+		}
+	}
+	runs := float64(options.Runs)
+	report.Stability = share(report.Stability, runs)
+	report.SetStability = share(report.SetStability, runs)
+
+	for rank, i := range baseOrder {
+		low, high := interval(samples[i])
+		stability := PlatformStability{
+			Platform:      models[i].metadata.Name,
+			Rank:          rank + 1,
+			Score:         baseScores[i],
+			RankStability: share(float64(keptRank[i]), runs),
+			TopShare:      share(float64(inTop[i]), runs),
+			ScoreLow:      low,
+			ScoreHigh:     high,
+		}
+		if rank+1 < len(baseOrder) {
+			stability.Margin = round(baseScores[i] - baseScores[baseOrder[rank+1]])
+		}
+		stability.Verdict = verdict(stability.RankStability)
+		report.Platforms = append(report.Platforms, stability)
+	}
+
+	report.Influences = a.influences(business, models, baseOrder[:top], options.Spread)
+	return report, nil
+}
+
+// influences moves each parameter to either end of the spread with the others
+// unperturbed, keeping those that move a score; the ones that reorder the top
+// places come first, then the largest score swings
+func (a *Analyzer) influences(business core.BusinessInput, models []model, baseTop []int, spread float64) []Influence {
+	var influences []Influence
+	for i, m := range models {
+		for j, parameter := range Parameters() {
+			if m.values[j] == 0 {
+				continue
+			}
+			influence := Influence{Platform: m.metadata.Name, Parameter: parameter, Value: m.values[j]}
+			for _, factor := range []float64{1 - spread, 1 + spread} {
+				factors := make([][]float64, len(models))
+				for k := range models {
+					factors[k] = ones()
+				}
+				factors[i][j] = factor
+				scores := a.scores(business, models, factors)
+				runOrder := order(scores)
+				if !slices.Equal(runOrder[:len(baseTop)], baseTop) {
+					influence.Reorders = true
+				}
+				value := perturb(m.values[j], factor, parameter)
+				if factor < 1 {
+					influence.Low, influence.ScoreLow = value, scores[i]
+				} else {
+					influence.High, influence.ScoreHigh = value, scores[i]
+				}
+			}
+			if influence.ScoreLow != influence.ScoreHigh {
+				influences = append(influences, influence)
+			}
+		}
+	}
+
+	sort.SliceStable(influences, func(i, j int) bool {
+		if influences[i].Reorders != influences[j].Reorders {
+			return influences[i].Reorders
+		}
+		return swing(influences[i]) > swing(influences[j])
+	})
+	if len(influences) > maxInfluences {
+		influences = influences[:maxInfluences]
+	}
+	if influences == nil {
+		influences = []Influence{}
+	}
+	return influences
+}
+
+// model is what one platform's score is made of
+type model struct {
+	metadata  core.PlatformMetadata
+	breakdown scoring.Breakdown
+	// values holds the unperturbed parameters in Parameters() order
+	values []float64
+}
+
+// model breaks the platform's score into its unperturbed parameters
+func (a *Analyzer) model(business core.BusinessInput, platform core.Platform) (model, bool) {
+	metadata, ok := core.GetPlatformMetadata(platform)
+	if !ok {
+		return model{}, false
+	}
+	reach, conversion := business.Potential(metadata)
+	return model{
+		metadata:  metadata,
+		breakdown: a.scorer.ScorePlatform(business, platform).Breakdown,
+		values: []float64{
+			reach,
+			conversion,
+			a.constraints.ValidateBudgetConstraints(business.Budget, platform).Penalty,
+			a.constraints.ValidateEffortConstraints(business, platform).Penalty,
+			a.constraints.ValidateVisualRequirements(business, platform).Penalty,
+			a.constraints.ValidateGoalAlignment(business.Goal, platform).Penalty,
+		},
+	}, true
+}
+
+// scores rescores every platform with its parameters scaled by factors
+func (a *Analyzer) scores(business core.BusinessInput, models []model, factors [][]float64) []float64 {
+	scores := make([]float64, len(models))
+	for i, m := range models {
+		values := make([]float64, len(m.values))
+		for j, parameter := range Parameters() {
+			values[j] = perturb(m.values[j], factors[i][j], parameter)
+		}
+
+		perturbed := business
+		perturbed.Calibration = map[core.Platform]core.Calibration{
+			m.metadata.Name: {ReachPotential: values[0], ConversionFocus: values[1]},
+		}
+		breakdown := m.breakdown
+		breakdown.Return = a.returns.Score(perturbed, m.metadata)
+		breakdown.Penalty = (values[2] + values[3] + values[4] + values[5]) / 4
+		scores[i] = round(a.scorer.Combine(breakdown))
+	}
+	return scores
+}
+
+// normalize applies the defaults and checks the limits
+func normalize(options Options) (Options, error) {
+	if options.Runs == 0 {
+		options.Runs = DefaultRuns
+	}
+	if options.Runs < 1 || options.Runs > MaxRuns {
+		return options, &core.ValidationError{Field: "runs", Message: fmt.Sprintf("must be between 1 and %d", MaxRuns)}
+	}
+	if options.Spread == 0 {
+		options.Spread = DefaultSpread
+	}
+	if math.IsNaN(options.Spread) || options.Spread <= 0 || options.Spread > 1 {
+		return options, &core.ValidationError{Field: "spread", Message: "must be more than 0 and at most 1"}
+	}
+	if options.Seed == 0 {
+		options.Seed = DefaultSeed
+	}
+	return options, nil
+}
+
+// perturb scales a parameter, keeping it within its range
+func perturb(value, factor float64, parameter Parameter) float64 {
+	return math.Max(0, math.Min(parameter.limit(), value*factor))
+}
+
+// order returns the indexes of the scores from best to worst, keeping the
+// filters' order for ties as the scorer does
+func order(scores []float64) []int {
+	indexes := make([]int, len(scores))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool { return scores[indexes[i]] > scores[indexes[j]] })
+	return indexes
+}
+
+// sameSet reports whether two rankings hold the same platforms
+func sameSet(a, b []int) bool {
+	for _, i := range a {
+		if !slices.Contains(b, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// interval bounds the middle 95% of the samples
+func interval(samples []float64) (low, high float64) {
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	last := float64(len(sorted) - 1)
+	return sorted[int(math.Floor(0.025*last))], sorted[int(math.Ceil(0.975*last))]
+}
+
+// verdict names how stable a rank is
+func verdict(rankStability float64) string {
+	switch {
+	case rankStability >= 0.95:
+		return "solid"
+	case rankStability >= 0.75:
+		return "likely"
+	default:
+		return "fragile"
+	}
+}
+
+// swing is how far a parameter moves its platform's score
+func swing(influence Influence) float64 {
+	return math.Abs(influence.ScoreHigh - influence.ScoreLow)
+}
+
+// ones is a platform's factors when nothing is perturbed
+func ones() []float64 {
+	factors := make([]float64, len(Parameters()))
+	for i := range factors {
+		factors[i] = 1
+	}
+	return factors
+}
+
+// share is count out of total, to three decimal places
+func share(count, total float64) float64 {
+	return math.Round(count/total*1000) / 1000
+}
+
+// round rounds a score to one decimal place, as the scorer does
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,237 @@
+package sensitivity
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/scoring"
+)
+
+var shop = core.BusinessInput{
+	Type:        core.Retail,
+	Description: "Handmade ceramic mugs",
+	Location:    "Austin, TX",
+	Budget:      150,
+	Goal:        core.Awareness,
+}
+
+var platforms = []core.Platform{core.Instagram, core.Facebook, core.TikTok, core.GoogleBusiness, core.Email}
+
+func TestAnalyzeSeed(t *testing.T) {
+	analyzer := NewAnalyzer(scoring.NewScorer())
+	analyze := func(seed int64) *Report {
+		t.Helper()
+		report, err := analyzer.Analyze(shop, platforms, 3, Options{Runs: 300, Seed: seed})
+		if err != nil {
+			t.Fatalf("Analyze: %v", err)
+		}
+		return report
+	}
+
+	first, again := analyze(7), analyze(7)
+	if !reflect.DeepEqual(first, again) {
+		t.Errorf("the same seed gave different reports:\n%+v\n%+v", first, again)
+	}
+	if defaulted := analyze(0); defaulted.Seed != DefaultSeed || !reflect.DeepEqual(defaulted, analyze(DefaultSeed)) {
+		t.Errorf("seed 0 = %+v, want the default seed's report", defaulted)
+	}
+	if first.Runs != 300 || first.Spread != DefaultSpread {
+		t.Errorf("Runs, Spread = %d, %v, want 300 and the default spread", first.Runs, first.Spread)
+	}
+}
+
+func TestAnalyzeReport(t *testing.T) {
+	report, err := NewAnalyzer(scoring.NewScorer()).Analyze(shop, platforms, 3, Options{Runs: 200})
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	if report.Top != 3 || len(report.Platforms) != len(platforms) {
+		t.Fatalf("Top = %d with %d platforms, want 3 of %d", report.Top, len(report.Platforms), len(platforms))
+	}
+
+	for i, platform := range report.Platforms {
+		if platform.Rank != i+1 {
+			t.Errorf("%s Rank = %d, want %d", platform.Platform, platform.Rank, i+1)
+		}
+		if platform.ScoreLow > platform.ScoreHigh {
+			t.Errorf("%s interval = [%v, %v], want low at most high", platform.Platform, platform.ScoreLow, platform.ScoreHigh)
+		}
+		if platform.Verdict != verdict(platform.RankStability) {
+			t.Errorf("%s Verdict = %q for rank stability %v", platform.Platform, platform.Verdict, platform.RankStability)
+		}
+		if i+1 == len(report.Platforms) {
+			if platform.Margin != 0 {
+				t.Errorf("last platform Margin = %v, want 0", platform.Margin)
+			}
+			continue
+		}
+		next := report.Platforms[i+1]
+		if platform.Score < next.Score || platform.Margin != round(platform.Score-next.Score) {
+			t.Errorf("%s score %v, margin %v over %s at %v", platform.Platform, platform.Score, platform.Margin, next.Platform, next.Score)
+		}
+	}
+
+	if len(report.Influences) == 0 || len(report.Influences) > maxInfluences {
+		t.Fatalf("%d influences, want between 1 and %d", len(report.Influences), maxInfluences)
+	}
+	for i, influence := range report.Influences {
+		if influence.ScoreLow == influence.ScoreHigh || influence.Low > influence.High {
+			t.Errorf("influence %+v does not move its score", influence)
+		}
+		if i > 0 {
+			before := report.Influences[i-1]
+			if influence.Reorders && !before.Reorders {
+				t.Errorf("%s %s reorders but comes after one that does not", influence.Platform, influence.Parameter)
+			}
+			if influence.Reorders == before.Reorders && swing(influence) > swing(before) {
+				t.Errorf("%s %s swings more than the influence before it", influence.Platform, influence.Parameter)
+			}
+		}
+	}
+}
+
+func TestAnalyzeTop(t *testing.T) {
+	analyzer := NewAnalyzer(scoring.NewScorer())
+	tests := []struct {
+		name      string
+		platforms []core.Platform
+		top       int
+		want      int
+	}{
+		{"top within the platforms", platforms, 2, 2},
+		{"top over the platforms", platforms[:2], 5, 2},
+		{"unknown platforms skipped", []core.Platform{core.Instagram, "Myspace"}, 3, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := analyzer.Analyze(shop, tt.platforms, tt.top, Options{Runs: 50})
+			if err != nil {
+				t.Fatalf("Analyze: %v", err)
+			}
+			if report.Top != tt.want {
+				t.Errorf("Top = %d, want %d", report.Top, tt.want)
+			}
+			if tt.top >= len(report.Platforms) && report.SetStability != 1 {
+				t.Errorf("SetStability = %v with every platform in the top places, want 1", report.SetStability)
+			}
+		})
+	}
+}
+
+func TestAnalyzeNoModels(t *testing.T) {
+	for name, platforms := range map[string][]core.Platform{"none": nil, "unknown": {"Myspace"}} {
+		report, err := NewAnalyzer(scoring.NewScorer()).Analyze(shop, platforms, 3, Options{})
+		if err != nil {
+			t.Fatalf("%s: Analyze: %v", name, err)
+		}
+		want := &Report{Runs: DefaultRuns, Spread: DefaultSpread, Seed: DefaultSeed, Platforms: []PlatformStability{}, Influences: []Influence{}}
+		if !reflect.DeepEqual(report, want) {
+			t.Errorf("%s: report = %+v, want %+v", name, report, want)
+		}
+	}
+}
+
+func TestStability(t *testing.T) {
+	analyzer := NewAnalyzer(scoring.NewScorer())
+	tests := []struct {
+		name   string
+		spread float64
+		top    int
+		check  func(t *testing.T, report *Report)
+	}{
+		{
+			name: "tiny spread keeps the ranking", spread: 1e-6, top: 3,
+			check: func(t *testing.T, report *Report) {
+				if report.Stability != 1 || report.SetStability != 1 {
+					t.Errorf("Stability, SetStability = %v, %v, want 1", report.Stability, report.SetStability)
+				}
+				for _, platform := range report.Platforms {
+					if platform.RankStability != 1 || platform.Verdict != "solid" {
+						t.Errorf("%s = %+v, want a solid rank", platform.Platform, platform)
+					}
+				}
+			},
+		},
+		{
+			name: "order can change when the set cannot", spread: 1, top: len(platforms),
+			check: func(t *testing.T, report *Report) {
+				if report.Stability >= 1 || report.SetStability != 1 {
+					t.Errorf("Stability, SetStability = %v, %v, want below 1 and 1", report.Stability, report.SetStability)
+				}
+			},
+		},
+		{
+			name: "set stability bounds stability", spread: 0.5, top: 2,
+			check: func(t *testing.T, report *Report) {
+				if report.Stability > report.SetStability {
+					t.Errorf("Stability = %v over SetStability %v", report.Stability, report.SetStability)
+				}
+				top := 0.0
+				for _, platform := range report.Platforms {
+					if platform.TopShare < 0 || platform.TopShare > 1 {
+						t.Errorf("%s TopShare = %v", platform.Platform, platform.TopShare)
+					}
+					top += platform.TopShare
+				}
+				if math.Abs(top-2) > 0.01 {
+					t.Errorf("TopShares sum to %v, want the 2 top places", top)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := analyzer.Analyze(shop, platforms, tt.top, Options{Runs: 500, Spread: tt.spread})
+			if err != nil {
+				t.Fatalf("Analyze: %v", err)
+			}
+			tt.check(t, report)
+		})
+	}
+}
+
+func TestAnalyzeOptions(t *testing.T) {
+	tests := []struct {
+		options Options
+		field   string
+	}{
+		{Options{Runs: -1}, "runs"},
+		{Options{Runs: MaxRuns + 1}, "runs"},
+		{Options{Spread: -0.1}, "spread"},
+		{Options{Spread: 1.5}, "spread"},
+		{Options{Spread: math.NaN()}, "spread"},
+	}
+
+	for _, tt := range tests {
+		var validation *core.ValidationError
+		if _, err := NewAnalyzer(scoring.NewScorer()).Analyze(shop, platforms, 3, tt.options); !errors.As(err, &validation) || validation.Field != tt.field {
+			t.Errorf("Analyze(%+v) error = %v, want a validation error on %s", tt.options, err, tt.field)
+		}
+	}
+}
+
+func TestVerdict(t *testing.T) {
+	for stability, want := range map[float64]string{1: "solid", 0.95: "solid", 0.949: "likely", 0.75: "likely", 0.5: "fragile", 0: "fragile"} {
+		if got := verdict(stability); got != want {
+			t.Errorf("verdict(%v) = %q, want %q", stability, got, want)
+		}
+	}
+}
+
+func TestInterval(t *testing.T) {
+	samples := make([]float64, 201)
+	for i := range samples {
+		samples[len(samples)-1-i] = float64(i)
+	}
+	if low, high := interval(samples); low != 5 || high != 195 {
+		t.Errorf("interval = [%v, %v], want [5, 195]", low, high)
+	}
+	if low, high := interval([]float64{42}); low != 42 || high != 42 {
+		t.Errorf("interval of one sample = [%v, %v], want [42, 42]", low, high)
+	}
+}
@@ -0,0 +1,37 @@
+// Package sqlite opens the SQLite databases used by the persistent stores.
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	_ "modernc.org/sqlite" // Registers the pure-Go "sqlite" driver
+)
+
+// Open opens (creating if needed) the SQLite database at path with settings
+// suited to a single server process: WAL journaling, a busy timeout and
+// foreign keys enforced. Use ":memory:" for a throwaway database.
+func Open(path string) (*sql.DB, error) {
+	params := url.Values{}
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "foreign_keys(1)")
+	if path != ":memory:" {
+		params.Add("_pragma", "journal_mode(WAL)")
+	}
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+
+	// SQLite allows one writer at a time; a single connection avoids
+	// SQLITE_BUSY errors and keeps :memory: databases shared
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	return db, nil
+}
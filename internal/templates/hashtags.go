@@ -0,0 +1,90 @@
+package templates
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"biz-flow/internal/core"
+)
+
+// maxHashtags caps the hashtags on a generated template
+const maxHashtags = 6
+
+// maxKeywordTags caps the hashtags taken from individual description words
+const maxKeywordTags = 3
+
+// stopwords are English words never turned into hashtags
+var stopwords = map[string]bool{
+	"with": true, "that": true, "from": true, "your": true, "their": true,
+	"this": true, "than": true, "into": true, "over": true, "near": true,
+	"also": true, "just": true, "very": true, "more": true, "most": true,
+	"have": true, "offer": true, "offers": true, "sell": true, "sells": true,
+	"small": true, "business": true, "local": true, "online": true,
+}
+
+// Hashtags derives hashtags from the product phrase, description keywords
+// and location, with the locale's community tags. Platforms without hashtag
+// support get none.
+func Hashtags(business core.BusinessInput, platform core.Platform) []string {
+	tags := []string{}
+	if metadata, ok := core.GetPlatformMetadata(platform); !ok || !metadata.SupportsHashtags {
+		return tags
+	}
+
+	seen := make(map[string]bool)
+	add := func(tag string) {
+		if tag == "" || len(tags) == maxHashtags || seen[strings.ToLower(tag)] {
+			return
+		}
+		seen[strings.ToLower(tag)] = true
+		tags = append(tags, tag)
+	}
+
+	lang := languageFor(business)
+	product := strings.Fields(productPhrase(lang, business.Description))
+	if len(product) > 1 && len(product) <= 3 {
+		add(camelCase(product))
+	}
+
+	keywords := 0
+	for _, word := range strings.Fields(strings.ToLower(business.Description)) {
+		word = strings.TrimFunc(word, notWordRune)
+		if utf8.RuneCountInString(word) <= 3 || lang.stopwords[word] || keywords == maxKeywordTags {
+			continue
+		}
+		if !seen[word] {
+			keywords++
+		}
+		add(word)
+	}
+
+	if city := City(business); city != "" {
+		add(camelCase(strings.Fields(city)))
+		if business.Type == core.Retail {
+			add(lang.shopLocal)
+		}
+	}
+	add(lang.smallBusiness)
+	return tags
+}
+
+// camelCase joins words into a single CamelCase hashtag
+func camelCase(words []string) string {
+	var b strings.Builder
+	for _, word := range words {
+		runes := []rune(strings.TrimFunc(word, notWordRune))
+		if len(runes) == 0 {
+			continue
+		}
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	return b.String()
+}
+
+// notWordRune reports whether r is trimmed from a hashtag word. Combining
+// marks are kept: Devanagari vowel signs are marks, not letters.
+func notWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+}
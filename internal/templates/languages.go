@@ -0,0 +1,160 @@
+package templates
+
+import (
+	"regexp"
+	"strings"
+
+	"biz-flow/internal/core"
+)
+
+// language holds the words the library needs to fill patterns and derive
+// hashtags in one locale
+type language struct {
+	patterns func() map[Key][]Pattern
+	// leadingFillers are dropped from the start of the product phrase,
+	// phraseBreaks end it, phraseRestarts start it over (for languages that
+	// put the place before the product) and trailingFillers are dropped
+	// from its end (for languages that end the clause with the verb)
+	leadingFillers  map[string]bool
+	phraseBreaks    map[string]bool
+	phraseRestarts  map[string]bool
+	trailingFillers map[string]bool
+	stopwords       map[string]bool
+	offerPattern    *regexp.Regexp
+	// place formats the city into the {place} slot
+	place  string
+	online string
+	// product stands in when the description yields no product phrase
+	product string
+	// offers are the default {offer} per goal
+	offers        map[core.MarketingGoal]string
+	shopLocal     string
+	smallBusiness string
+	// formal spells out contractions for formal brand voices; nil leaves
+	// the text alone
+	formal *strings.Replacer
+}
+
+// languages maps each supported locale to its words
+var languages = map[core.Locale]*language{
+	core.English: {
+		patterns:       defaultPatterns,
+		leadingFillers: leadingFillers,
+		phraseBreaks:   phraseBreaks,
+		stopwords:      stopwords,
+		offerPattern:   offerPattern,
+		place:          "in %s",
+		online:         "online",
+		product:        "what we do",
+		offers: map[core.MarketingGoal]string{
+			core.Sales:     "a special introductory price",
+			core.Awareness: "a behind-the-scenes look at how we work",
+		},
+		shopLocal:     "ShopLocal",
+		smallBusiness: "SmallBusiness",
+		formal: strings.NewReplacer(
+			"Here's", "Here is", "here's", "here is", "It's", "It is", "it's", "it is",
+			"We'd", "We would", "we'd", "we would", "We'll", "We will", "we'll", "we will",
+			"We're", "We are", "we're", "we are", "We've", "We have", "we've", "we have",
+			"You've", "You have", "you've", "you have", "You'd", "You would", "you'd", "you would",
+			"Who'd", "Who would", "who'd", "who would", "Don't", "Do not", "don't", "do not",
+			"Isn't", "Is not", "isn't", "is not", "Won't", "Will not", "won't", "will not",
+		),
+	},
+	core.Spanish: {
+		patterns: spanishPatterns,
+		leadingFillers: words("un", "una", "unos", "unas", "el", "la", "los", "las", "nuestro", "nuestra", "nuestros",
+			"nuestras", "mi", "mis", "vendemos", "vendo", "vende", "ofrecemos", "ofrezco", "ofrece", "hacemos", "hago",
+			"hace", "somos", "soy", "es", "tenemos", "tengo", "damos", "doy"),
+		phraseBreaks: words("para", "en", "con", "al", "cerca", "que", "quien", "quienes", "y", "por", "desde"),
+		stopwords: words("para", "como", "desde", "sobre", "nuestro", "nuestra", "nuestros", "nuestras", "vendemos",
+			"ofrecemos", "hacemos", "tenemos", "también", "negocio", "pequeño", "pequeña", "local", "línea", "online",
+			"todos", "todas", "muy", "más", "entre", "hasta", "cada"),
+		offerPattern: regexp.MustCompile(`(?i)(\d+\s?% de descuento[^.,;!]*|env[ií]o gratis[^.,;!]*|primer[ao]? [^.,;!]* gratis|2x1[^.,;!]*)`),
+		place:        "en %s",
+		online:       "en línea",
+		product:      "lo que hacemos",
+		offers: map[core.MarketingGoal]string{
+			core.Sales:     "un precio especial de lanzamiento",
+			core.Awareness: "un vistazo detrás de cámaras a cómo trabajamos",
+		},
+		shopLocal:     "CompraLocal",
+		smallBusiness: "PequeñosNegocios",
+	},
+	core.Portuguese: {
+		patterns: portuguesePatterns,
+		leadingFillers: words("um", "uma", "uns", "umas", "o", "a", "os", "as", "nosso", "nossa", "nossos", "nossas",
+			"meu", "minha", "vendemos", "vendo", "vende", "oferecemos", "ofereço", "oferece", "fazemos", "faço", "faz",
+			"somos", "sou", "é", "temos", "tenho"),
+		phraseBreaks: words("para", "em", "no", "na", "nos", "nas", "com", "perto", "que", "quem", "e", "por", "pelo",
+			"pela", "desde"),
+		stopwords: words("para", "como", "desde", "sobre", "nosso", "nossa", "nossos", "nossas", "vendemos",
+			"oferecemos", "fazemos", "temos", "também", "negócio", "pequeno", "pequena", "local", "online", "todos",
+			"todas", "muito", "mais", "entre", "até", "cada"),
+		offerPattern: regexp.MustCompile(`(?i)(\d+\s?% de desconto[^.,;!]*|frete gr[áa]tis[^.,;!]*|primeir[ao] [^.,;!]* gr[áa]tis|leve 2,? pague 1[^.,;!]*)`),
+		place:        "em %s",
+		online:       "online",
+		product:      "o que fazemos",
+		offers: map[core.MarketingGoal]string{
+			core.Sales:     "um preço especial de lançamento",
+			core.Awareness: "um olhar nos bastidores de como trabalhamos",
+		},
+		shopLocal:     "CompreLocal",
+		smallBusiness: "PequenosNegocios",
+	},
+	core.Swahili: {
+		patterns: swahiliPatterns,
+		leadingFillers: words("sisi", "mimi", "tunauza", "ninauza", "anauza", "tunatoa", "ninatoa", "tunatengeneza",
+			"ninatengeneza", "tunaendesha", "ninaendesha", "tuna", "nina", "ni", "biashara", "ya"),
+		phraseBreaks: words("kwa", "katika", "huko", "mjini", "karibu", "na", "ambazo", "ambayo", "ambao", "kutoka", "kwenye"),
+		stopwords: words("kwa", "katika", "kutoka", "kwenye", "ambazo", "ambayo", "ambao", "tunauza", "tunatoa",
+			"tunatengeneza", "pia", "sana", "zaidi", "biashara", "ndogo", "mtandaoni", "yetu", "wetu", "zetu", "kila"),
+		offerPattern: regexp.MustCompile(`(?i)(punguzo la [^.,;!]+|[^.,;!\s]+ bure\b[^.,;!]*)`),
+		place:        "mjini %s",
+		online:       "mtandaoni",
+		product:      "kazi yetu",
+		offers: map[core.MarketingGoal]string{
+			core.Sales:     "bei maalum ya utangulizi",
+			core.Awareness: "mwonekano wa nyuma ya pazia wa jinsi tunavyofanya kazi",
+		},
+		shopLocal:     "NunuaBidhaaZaNdani",
+		smallBusiness: "BiasharaNdogo",
+	},
+	core.Hindi: {
+		patterns:       hindiPatterns,
+		leadingFillers: words("हम", "मैं", "हमारे", "हमारी", "हमारा", "मेरे", "मेरी", "मेरा", "एक"),
+		phraseRestarts: words("में", "पर", "लिए"),
+		trailingFillers: words("बेचते", "बेचती", "बेचता", "बनाते", "बनाती", "बनाता", "करते", "करती", "करता", "देते",
+			"देती", "देता", "सिखाते", "सिखाती", "सिखाता", "चलाते", "चलाती", "चलाता", "प्रदान", "हैं", "है", "हूँ", "हूं"),
+		stopwords: words("हम", "हमारे", "हमारी", "हमारा", "लिए", "बेचते", "बेचती", "बनाते", "बनाती", "करते", "करती",
+			"सिखाते", "सिखाती", "प्रदान", "वाले", "वाली", "व्यवसाय", "छोटा", "स्थानीय", "ऑनलाइन", "सबसे", "बहुत"),
+		offerPattern: regexp.MustCompile(`(\d+\s?% (?:की )?छूट|मुफ़?्त [^.,;!।]+)`),
+		place:        "%s में",
+		online:       "ऑनलाइन",
+		product:      "हमारा काम",
+		offers: map[core.MarketingGoal]string{
+			core.Sales:     "खास शुरुआती कीमत",
+			core.Awareness: "हमारे काम की पर्दे के पीछे की झलक",
+		},
+		shopLocal:     "VocalForLocal",
+		smallBusiness: "छोटाव्यवसाय",
+	},
+}
+
+// languageFor returns the words for the business's locale, English for
+// locales without their own
+func languageFor(business core.BusinessInput) *language {
+	if lang, ok := languages[business.Language()]; ok {
+		return lang
+	}
+	return languages[core.English]
+}
+
+// words builds a lookup set
+func words(list ...string) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, word := range list {
+		set[word] = true
+	}
+	return set
+}
@@ -0,0 +1,140 @@
+package templates
+
+import (
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"biz-flow/internal/core"
+)
+
+// Pattern is a content template with {product}, {Product}, {place} and
+// {offer} slots
+type Pattern struct {
+	Hook    string
+	Caption string
+	CTA     string
+}
+
+// Key selects patterns by platform, business type and goal. An empty
+// Platform or Type matches any value.
+type Key struct {
+	Platform core.Platform
+	Type     core.BusinessType
+	Goal     core.MarketingGoal
+}
+
+// Library generates content templates from curated patterns without an LLM
+type Library struct {
+	patterns map[core.Locale]map[Key][]Pattern
+}
+
+// NewLibrary creates a library over the curated default patterns of every
+// supported locale
+func NewLibrary() *Library {
+	patterns := make(map[core.Locale]map[Key][]Pattern, len(languages))
+	for locale, lang := range languages {
+		patterns[locale] = lang.patterns()
+	}
+	return &Library{patterns: patterns}
+}
+
+// Lookup returns the most specific patterns in the locale for the
+// combination, falling back from platform × type × goal to platform × goal,
+// type × goal and finally goal alone
+func (l *Library) Lookup(
+	locale core.Locale,
+	platform core.Platform,
+	businessType core.BusinessType,
+	goal core.MarketingGoal,
+) ([]Pattern, bool) {
+	for _, key := range []Key{
+		{Platform: platform, Type: businessType, Goal: goal},
+		{Platform: platform, Goal: goal},
+		{Type: businessType, Goal: goal},
+		{Goal: goal},
+	} {
+		if patterns, ok := l.patterns[locale][key]; ok && len(patterns) > 0 {
+			return patterns, true
+		}
+	}
+	return nil, false
+}
+
+// Generate fills the best matching pattern for the business, in its locale
+// and brand voice. The same input always yields the same template; different
+// descriptions rotate through the available patterns.
+func (l *Library) Generate(business core.BusinessInput, platform core.Platform) *core.ContentTemplate {
+	locale := business.Language()
+	patterns, ok := l.Lookup(locale, platform, business.Type, business.Goal)
+	if !ok {
+		patterns, _ = l.Lookup(locale, platform, business.Type, core.Awareness)
+	}
+	if len(patterns) == 0 {
+		patterns = []Pattern{fallbackPattern}
+	}
+
+	fill := slotReplacer(ExtractSlots(business))
+	return fillPattern(business, platform, choose(patterns, business, fill), fill)
+}
+
+// Fill fills a pattern that is not in the library, such as a calendar post,
+// for the business on the platform, with its hashtags and brand voice
+func Fill(business core.BusinessInput, platform core.Platform, pattern Pattern) *core.ContentTemplate {
+	return fillPattern(business, platform, pattern, slotReplacer(ExtractSlots(business)))
+}
+
+func fillPattern(business core.BusinessInput, platform core.Platform, pattern Pattern, fill *strings.Replacer) *core.ContentTemplate {
+	template := &core.ContentTemplate{
+		Hook:     tidy(fill.Replace(pattern.Hook)),
+		Caption:  tidy(fill.Replace(pattern.Caption)),
+		CTA:      tidy(fill.Replace(pattern.CTA)),
+		Hashtags: Hashtags(business, platform),
+	}
+	applyVoice(template, business, languageFor(business))
+	return template
+}
+
+// fallbackPattern is used only if a goal has no patterns at all
+var fallbackPattern = Pattern{
+	Hook:    "Meet {product} {place}.",
+	Caption: "{Product}, made for people like you. Here's {offer}.",
+	CTA:     "Get in touch to learn more.",
+}
+
+// slotReplacer substitutes the slot values into a pattern
+func slotReplacer(slots Slots) *strings.Replacer {
+	return strings.NewReplacer(
+		"{product}", slots.Product,
+		"{Product}", capitalize(slots.Product),
+		"{place}", slots.Place,
+		"{offer}", slots.Offer,
+		"{Offer}", capitalize(slots.Offer),
+	)
+}
+
+// spaceBeforePunctuation matches the gap an empty slot leaves before punctuation
+var spaceBeforePunctuation = regexp.MustCompile(`\s+([.,!?:;।])`)
+
+// tidy collapses the whitespace left behind by empty slots
+func tidy(s string) string {
+	return spaceBeforePunctuation.ReplaceAllString(strings.Join(strings.Fields(s), " "), "$1")
+}
+
+// pick deterministically chooses one of n patterns for a description
+func pick(description string, n int) int {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(strings.Join(strings.Fields(description), " "))))
+	return int(h.Sum32() % uint32(n))
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
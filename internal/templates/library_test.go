@@ -0,0 +1,112 @@
+package templates
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"biz-flow/internal/core"
+)
+
+func TestExtractSlots(t *testing.T) {
+	tests := []struct {
+		name     string
+		business core.BusinessInput
+		want     Slots
+	}{
+		{
+			name:     "local business",
+			business: core.BusinessInput{Description: "We sell handmade ceramic mugs for coffee lovers", Location: "Austin, TX", Goal: core.Sales},
+			want:     Slots{Product: "handmade ceramic mugs", Place: "in Austin", Offer: "a special introductory price"},
+		},
+		{
+			name:     "online product with a discount",
+			business: core.BusinessInput{Description: "Online Spanish lessons for adults. 20% off your first month", Location: "online", Goal: core.Sales},
+			want:     Slots{Product: "online Spanish lessons", Place: "", Offer: "20% off your first month"},
+		},
+		{
+			name:     "free offer",
+			business: core.BusinessInput{Description: "Dog grooming. Free nail trim with every bath", Goal: core.Awareness},
+			want:     Slots{Product: "dog grooming", Place: "online", Offer: "free nail trim with every bath"},
+		},
+		{
+			name:     "Spanish",
+			business: core.BusinessInput{Description: "Vasos de cerámica hechos a mano", Location: "Lima", Goal: core.Sales, Locale: core.Spanish},
+			want:     Slots{Product: "vasos de cerámica hechos a mano", Place: "en Lima", Offer: "un precio especial de lanzamiento"},
+		},
+		{
+			name:     "Swahili without a description",
+			business: core.BusinessInput{Location: "Nairobi", Goal: core.Awareness, Locale: core.Swahili},
+			want:     Slots{Product: "kazi yetu", Place: "mjini Nairobi", Offer: "mwonekano wa nyuma ya pazia wa jinsi tunavyofanya kazi"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractSlots(tt.business); got != tt.want {
+				t.Errorf("ExtractSlots = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	library := NewLibrary()
+	mugs := core.BusinessInput{Type: core.Retail, Description: "We sell handmade ceramic mugs for coffee lovers", Location: "Austin, TX", Goal: core.Sales}
+
+	for _, locale := range core.Locales() {
+		for _, platform := range core.GetAllPlatformNames() {
+			business := mugs
+			business.Locale = locale
+			template := library.Generate(business, platform)
+			name := string(locale) + "/" + string(platform)
+
+			if template.Hook == "" || template.Caption == "" || template.CTA == "" {
+				t.Errorf("%s: incomplete template %+v", name, template)
+			}
+			for _, text := range []string{template.Hook, template.Caption, template.CTA} {
+				if strings.ContainsAny(text, "{}") || strings.Contains(text, "  ") || strings.Contains(text, " .") {
+					t.Errorf("%s: unfilled or untidy text %q", name, text)
+				}
+			}
+			metadata, _ := core.GetPlatformMetadata(platform)
+			if !metadata.SupportsHashtags && len(template.Hashtags) > 0 || len(template.Hashtags) > maxHashtags {
+				t.Errorf("%s: hashtags %v", name, template.Hashtags)
+			}
+			if again := library.Generate(business, platform); again.Hook != template.Hook || again.Caption != template.Caption {
+				t.Errorf("%s: the same input gave a different template", name)
+			}
+		}
+	}
+
+	english := library.Generate(mugs, core.Instagram)
+	if !strings.Contains(english.Caption, "Handmade ceramic mugs in Austin") {
+		t.Errorf("caption %q does not fill the product and place", english.Caption)
+	}
+	if want := []string{"HandmadeCeramicMugs", "handmade", "ceramic", "mugs", "Austin", "ShopLocal"}; !slices.Equal(english.Hashtags, want) {
+		t.Errorf("hashtags = %v, want %v", english.Hashtags, want)
+	}
+	spanish := mugs
+	spanish.Locale = core.Spanish
+	if library.Generate(spanish, core.Instagram).CTA == english.CTA {
+		t.Error("the Spanish template is in English")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	library := NewLibrary()
+	for _, locale := range core.Locales() {
+		for _, goal := range core.MarketingGoals() {
+			for _, businessType := range core.BusinessTypes() {
+				for _, platform := range core.GetAllPlatformNames() {
+					if _, ok := library.Lookup(locale, platform, businessType, goal); !ok {
+						t.Errorf("no patterns for %s %s %s %s", locale, platform, businessType, goal)
+					}
+				}
+			}
+		}
+	}
+	if _, ok := library.Lookup(core.English, core.Instagram, core.Retail, "growth"); ok {
+		t.Error("found patterns for an unknown goal")
+	}
+}
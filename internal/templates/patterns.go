@@ -0,0 +1,209 @@
+package templates
+
+import "biz-flow/internal/core"
+
+// defaultPatterns is the curated pattern set. Platform × goal entries carry
+// each platform's format; type × goal entries cover platforms without their
+// own; a few platform × type × goal entries cover combinations that read
+// badly otherwise.
+func defaultPatterns() map[Key][]Pattern {
+	return map[Key][]Pattern{
+		// Platform × goal
+		{Platform: core.Instagram, Goal: core.Awareness}: {
+			{
+				Hook:    "POV: you just found the best {product} {place} ✨",
+				Caption: "Swipe through to see what goes into our {product}. We share {offer} every week, so save this post for later.",
+				CTA:     "Follow for more and tag a friend who'd love this.",
+			},
+			{
+				Hook:    "3 things nobody tells you about {product}",
+				Caption: "We've spent years perfecting our {product} {place}. This carousel shares what we've learned, plus {offer}.",
+				CTA:     "Save this post and follow along.",
+			},
+		},
+		{Platform: core.Instagram, Goal: core.Sales}: {
+			{
+				Hook:    "Your new favorite {product} is one tap away",
+				Caption: "{Product} {place}, ready when you are. For this week only: {offer}.",
+				CTA:     "Tap the link in our bio to order before it's gone.",
+			},
+		},
+		{Platform: core.Facebook, Goal: core.Awareness}: {
+			{
+				Hook:    "Have you met your neighbors behind the best {product} {place}?",
+				Caption: "We're a small team who care about {product}. Here's {offer}. Share this with someone who should know about us!",
+				CTA:     "Like our page to see more.",
+			},
+		},
+		{Platform: core.Facebook, Goal: core.Sales}: {
+			{
+				Hook:    "Looking for {product} {place}?",
+				Caption: "We'd love to help. Right now we have {offer}, and we answer every message personally.",
+				CTA:     "Send us a message or click Shop Now to get started.",
+			},
+		},
+		{Platform: core.TikTok, Goal: core.Awareness}: {
+			{
+				Hook:    "Wait until you see how we make our {product} 👀",
+				Caption: "A day in the life of a small {product} business {place}. Follow for {offer}.",
+				CTA:     "Follow for part 2!",
+			},
+			{
+				Hook:    "Things I wish I knew before starting a {product} business",
+				Caption: "Real talk from a small business {place}. Stick around for {offer}.",
+				CTA:     "Comment your questions and follow for more.",
+			},
+		},
+		{Platform: core.TikTok, Goal: core.Sales}: {
+			{
+				Hook:    "This is your sign to try {product} {place}",
+				Caption: "Watch till the end for {offer}. Limited spots, so don't wait.",
+				CTA:     "Hit the link in our bio to grab it.",
+			},
+		},
+		{Platform: core.GoogleBusiness, Goal: core.Awareness}: {
+			{
+				Hook:    "Your local spot for {product} {place}",
+				Caption: "Stop by and see us. We're proud to serve the neighborhood and always happy to share {offer}.",
+				CTA:     "Get directions and save us to your maps.",
+			},
+		},
+		{Platform: core.GoogleBusiness, Goal: core.Sales}: {
+			{
+				Hook:    "{Offer} on {product} {place}",
+				Caption: "Mention this post when you visit or call to claim it. Check our hours and reviews to see why neighbors keep coming back.",
+				CTA:     "Call now or get directions.",
+			},
+		},
+		{Platform: core.WhatsApp, Goal: core.Awareness}: {
+			{
+				Hook:    "Hi! Thanks for connecting with us 👋",
+				Caption: "We'll send you the occasional update about our {product} {place}, including {offer}. No spam, promise.",
+				CTA:     "Reply with any question and we'll get back to you today.",
+			},
+		},
+		{Platform: core.WhatsApp, Goal: core.Sales}: {
+			{
+				Hook:    "Quick heads-up for our favorite customers",
+				Caption: "Our {product} {place} is available now, with {offer} for people on this list.",
+				CTA:     "Reply YES to reserve yours.",
+			},
+		},
+		{Platform: core.Email, Goal: core.Awareness}: {
+			{
+				Hook:    "Subject: The story behind our {product}",
+				Caption: "Thanks for subscribing! This month we're sharing how our {product} {place} comes together, plus {offer}.",
+				CTA:     "Hit reply and tell us what you'd like to hear about next.",
+			},
+		},
+		{Platform: core.Email, Goal: core.Sales}: {
+			{
+				Hook:    "Subject: Just for subscribers: {offer}",
+				Caption: "As a thank-you for being on our list, here's {offer} on our {product}. It won't last long.",
+				CTA:     "Click here to claim your offer.",
+			},
+		},
+		{Platform: core.LinkedIn, Goal: core.Awareness}: {
+			{
+				Hook:    "What running a {product} business taught me about customers",
+				Caption: "After working with clients {place}, one lesson stands out: people buy from those they trust. Here's {offer}.",
+				CTA:     "Follow for more lessons from the front line of small business.",
+			},
+		},
+		{Platform: core.LinkedIn, Goal: core.Sales}: {
+			{
+				Hook:    "Is your team still struggling without {product}?",
+				Caption: "We help businesses {place} get results with {product}. This quarter we're offering {offer}.",
+				CTA:     "Send me a message to book a 15-minute call.",
+			},
+		},
+		{Platform: core.YouTube, Goal: core.Awareness}: {
+			{
+				Hook:    "Behind the scenes: a week of {product} {place}",
+				Caption: "In this video we walk through how we work and answer your most common questions. Stay to the end for {offer}.",
+				CTA:     "Subscribe and turn on notifications for the next episode.",
+			},
+		},
+		{Platform: core.YouTube, Goal: core.Sales}: {
+			{
+				Hook:    "Is our {product} worth it? An honest walkthrough",
+				Caption: "We show exactly what you get, who it's for and who it isn't for. Details on {offer} are in the description.",
+				CTA:     "Use the link in the description to order.",
+			},
+		},
+
+		// Business type × goal, for platforms added by configuration
+		{Type: core.Retail, Goal: core.Awareness}: {
+			{
+				Hook:    "Meet the makers behind our {product}",
+				Caption: "Every piece of our {product} {place} is picked with care. Follow along for {offer}.",
+				CTA:     "Follow us to see new arrivals first.",
+			},
+		},
+		{Type: core.Retail, Goal: core.Sales}: {
+			{
+				Hook:    "New in: {product} {place}",
+				Caption: "Fresh stock just landed, and for a short time we have {offer}.",
+				CTA:     "Shop now before it sells out.",
+			},
+		},
+		{Type: core.Service, Goal: core.Awareness}: {
+			{
+				Hook:    "What a day of {product} {place} really looks like",
+				Caption: "We love what we do and the people we do it for. Here's {offer}.",
+				CTA:     "Follow us for tips and stories.",
+			},
+		},
+		{Type: core.Service, Goal: core.Sales}: {
+			{
+				Hook:    "Need {product} {place}? We have openings this week",
+				Caption: "Book with a team that shows up on time and does it right. New customers get {offer}.",
+				CTA:     "Book your appointment today.",
+			},
+		},
+		{Type: core.Digital, Goal: core.Awareness}: {
+			{
+				Hook:    "Here's how {product} saves you hours every week",
+				Caption: "We built our {product} to solve a problem we had ourselves. Take {offer}.",
+				CTA:     "Follow along for tips and updates.",
+			},
+		},
+		{Type: core.Digital, Goal: core.Sales}: {
+			{
+				Hook:    "Stop wasting time: try our {product}",
+				Caption: "Instant access, no setup headaches. Right now you can get {offer}.",
+				CTA:     "Sign up today and start in minutes.",
+			},
+		},
+
+		// Platform × type × goal overrides
+		{Platform: core.GoogleBusiness, Type: core.Service, Goal: core.Sales}: {
+			{
+				Hook:    "Now booking: {product} {place}",
+				Caption: "Trusted by your neighbors, with reviews to prove it. New customers get {offer}.",
+				CTA:     "Call now or book online.",
+			},
+		},
+		{Platform: core.Instagram, Type: core.Service, Goal: core.Sales}: {
+			{
+				Hook:    "Before and after: what our {product} {place} can do",
+				Caption: "Real results for real customers. Book this week and get {offer}.",
+				CTA:     "Tap the link in our bio to book your spot.",
+			},
+		},
+		{Platform: core.Instagram, Type: core.Digital, Goal: core.Sales}: {
+			{
+				Hook:    "Swipe to see what our {product} can do for you",
+				Caption: "Everything you need, delivered instantly. Right now new customers get {offer}.",
+				CTA:     "Tap the link in our bio to get instant access.",
+			},
+		},
+		{Platform: core.Email, Type: core.Digital, Goal: core.Sales}: {
+			{
+				Hook:    "Subject: Your {product} upgrade is waiting",
+				Caption: "You've seen what the basics can do. Unlock the rest of our {product} today with {offer}.",
+				CTA:     "Upgrade now.",
+			},
+		},
+	}
+}
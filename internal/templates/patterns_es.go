@@ -0,0 +1,178 @@
+package templates
+
+import "biz-flow/internal/core"
+
+// spanishPatterns is the curated Spanish pattern set. It has the platform ×
+// goal and type × goal entries; combinations with an English override use
+// the platform × goal entry instead.
+func spanishPatterns() map[Key][]Pattern {
+	return map[Key][]Pattern{
+		// Platform × goal
+		{Platform: core.Instagram, Goal: core.Awareness}: {
+			{
+				Hook:    "POV: encontraste tu nuevo lugar favorito de {product} {place} ✨",
+				Caption: "Desliza para ver lo que hay detrás de nuestro trabajo con {product}. Cada semana compartimos {offer}, así que guarda esta publicación.",
+				CTA:     "Síguenos para ver más y etiqueta a alguien a quien le encantaría.",
+			},
+			{
+				Hook:    "3 cosas que nadie te cuenta sobre {product}",
+				Caption: "Llevamos años perfeccionando {product} {place}. En este carrusel compartimos lo que hemos aprendido y, además, {offer}.",
+				CTA:     "Guarda esta publicación y síguenos.",
+			},
+		},
+		{Platform: core.Instagram, Goal: core.Sales}: {
+			{
+				Hook:    "Tu nuevo favorito en {product} está a un toque",
+				Caption: "{Product} {place}, cuando tú quieras. Solo esta semana: {offer}.",
+				CTA:     "Toca el enlace de nuestra bio y pide antes de que se agote.",
+			},
+		},
+		{Platform: core.Facebook, Goal: core.Awareness}: {
+			{
+				Hook:    "¿Ya conoces a los vecinos detrás de {product} {place}?",
+				Caption: "Somos un equipo pequeño al que le apasiona {product}. Te compartimos {offer}. ¡Comparte esto con alguien que debería conocernos!",
+				CTA:     "Dale me gusta a nuestra página para ver más.",
+			},
+		},
+		{Platform: core.Facebook, Goal: core.Sales}: {
+			{
+				Hook:    "¿Buscas {product} {place}?",
+				Caption: "Nos encantaría ayudarte. Ahora mismo tenemos {offer} y respondemos cada mensaje personalmente.",
+				CTA:     "Envíanos un mensaje o haz clic en Comprar para empezar.",
+			},
+		},
+		{Platform: core.TikTok, Goal: core.Awareness}: {
+			{
+				Hook:    "Espera a ver cómo hacemos {product} 👀",
+				Caption: "Un día en la vida de un pequeño negocio de {product} {place}. Síguenos para ver {offer}.",
+				CTA:     "¡Síguenos para la parte 2!",
+			},
+			{
+				Hook:    "Lo que me habría gustado saber antes de empezar un negocio de {product}",
+				Caption: "Hablando claro desde un pequeño negocio {place}. Quédate para ver {offer}.",
+				CTA:     "Comenta tus preguntas y síguenos para ver más.",
+			},
+		},
+		{Platform: core.TikTok, Goal: core.Sales}: {
+			{
+				Hook:    "Esta es tu señal para probar {product} {place}",
+				Caption: "Mira hasta el final para descubrir {offer}. Los cupos son limitados, así que no esperes.",
+				CTA:     "Toca el enlace de nuestra bio para conseguirlo.",
+			},
+		},
+		{Platform: core.GoogleBusiness, Goal: core.Awareness}: {
+			{
+				Hook:    "Tu lugar de confianza para {product} {place}",
+				Caption: "Ven a visitarnos. Nos encanta atender al barrio y siempre compartimos {offer} con gusto.",
+				CTA:     "Pide indicaciones y guárdanos en tus mapas.",
+			},
+		},
+		{Platform: core.GoogleBusiness, Goal: core.Sales}: {
+			{
+				Hook:    "{Offer} en {product} {place}",
+				Caption: "Menciona esta publicación cuando nos visites o llames para aprovecharlo. Revisa nuestro horario y reseñas para ver por qué los vecinos siempre vuelven.",
+				CTA:     "Llama ahora o pide indicaciones.",
+			},
+		},
+		{Platform: core.WhatsApp, Goal: core.Awareness}: {
+			{
+				Hook:    "¡Hola! Gracias por escribirnos 👋",
+				Caption: "De vez en cuando te enviaremos novedades sobre {product} {place}, incluido {offer}. Nada de spam, lo prometemos.",
+				CTA:     "Responde con cualquier pregunta y te contestamos hoy mismo.",
+			},
+		},
+		{Platform: core.WhatsApp, Goal: core.Sales}: {
+			{
+				Hook:    "Aviso rápido para nuestros clientes favoritos",
+				Caption: "Ya está disponible {product} {place}, con {offer} para quienes están en esta lista.",
+				CTA:     "Responde SÍ para apartar el tuyo.",
+			},
+		},
+		{Platform: core.Email, Goal: core.Awareness}: {
+			{
+				Hook:    "Asunto: La historia detrás de {product}",
+				Caption: "¡Gracias por suscribirte! Este mes te contamos cómo preparamos {product} {place} y te compartimos {offer}.",
+				CTA:     "Responde a este correo y cuéntanos de qué te gustaría saber más.",
+			},
+		},
+		{Platform: core.Email, Goal: core.Sales}: {
+			{
+				Hook:    "Asunto: Solo para suscriptores: {offer}",
+				Caption: "Como agradecimiento por estar en nuestra lista, aquí tienes {offer} en {product}. No durará mucho.",
+				CTA:     "Haz clic aquí para aprovechar tu oferta.",
+			},
+		},
+		{Platform: core.LinkedIn, Goal: core.Awareness}: {
+			{
+				Hook:    "Lo que dirigir un negocio de {product} me enseñó sobre los clientes",
+				Caption: "Después de trabajar con clientes {place}, hay una lección que destaca: la gente le compra a quien le tiene confianza. Aquí va {offer}.",
+				CTA:     "Sígueme para más lecciones desde la primera línea de los pequeños negocios.",
+			},
+		},
+		{Platform: core.LinkedIn, Goal: core.Sales}: {
+			{
+				Hook:    "¿Tu equipo sigue trabajando sin {product}?",
+				Caption: "Ayudamos a empresas {place} a obtener resultados con {product}. Este trimestre ofrecemos {offer}.",
+				CTA:     "Escríbeme para agendar una llamada de 15 minutos.",
+			},
+		},
+		{Platform: core.YouTube, Goal: core.Awareness}: {
+			{
+				Hook:    "Detrás de cámaras: una semana de {product} {place}",
+				Caption: "En este video te mostramos cómo trabajamos y respondemos tus preguntas más frecuentes. Quédate hasta el final para ver {offer}.",
+				CTA:     "Suscríbete y activa las notificaciones para el próximo episodio.",
+			},
+		},
+		{Platform: core.YouTube, Goal: core.Sales}: {
+			{
+				Hook:    "¿Vale la pena {product}? Un recorrido honesto",
+				Caption: "Te mostramos exactamente lo que recibes, para quién es y para quién no. Los detalles de {offer} están en la descripción.",
+				CTA:     "Usa el enlace de la descripción para hacer tu pedido.",
+			},
+		},
+
+		// Business type × goal, for platforms added by configuration
+		{Type: core.Retail, Goal: core.Awareness}: {
+			{
+				Hook:    "Conoce a quienes están detrás de {product}",
+				Caption: "Cada pieza de {product} {place} se elige con cuidado. Síguenos para ver {offer}.",
+				CTA:     "Síguenos para ver las novedades antes que nadie.",
+			},
+		},
+		{Type: core.Retail, Goal: core.Sales}: {
+			{
+				Hook:    "Novedad: {product} {place}",
+				Caption: "Acaba de llegar mercancía nueva y, por poco tiempo, tenemos {offer}.",
+				CTA:     "Compra ahora antes de que se agote.",
+			},
+		},
+		{Type: core.Service, Goal: core.Awareness}: {
+			{
+				Hook:    "Así es de verdad un día de {product} {place}",
+				Caption: "Nos encanta lo que hacemos y las personas para quienes lo hacemos. Te compartimos {offer}.",
+				CTA:     "Síguenos para ver consejos e historias.",
+			},
+		},
+		{Type: core.Service, Goal: core.Sales}: {
+			{
+				Hook:    "¿Necesitas {product} {place}? Tenemos citas esta semana",
+				Caption: "Reserva con un equipo que llega a tiempo y hace bien el trabajo. Los clientes nuevos reciben {offer}.",
+				CTA:     "Reserva tu cita hoy.",
+			},
+		},
+		{Type: core.Digital, Goal: core.Awareness}: {
+			{
+				Hook:    "Así es como {product} te ahorra horas cada semana",
+				Caption: "Creamos {product} para resolver un problema que teníamos nosotros mismos. Te compartimos {offer}.",
+				CTA:     "Síguenos para ver consejos y novedades.",
+			},
+		},
+		{Type: core.Digital, Goal: core.Sales}: {
+			{
+				Hook:    "Deja de perder tiempo: prueba {product}",
+				Caption: "Acceso inmediato, sin complicaciones de configuración. Ahora mismo puedes obtener {offer}.",
+				CTA:     "Regístrate hoy y empieza en minutos.",
+			},
+		},
+	}
+}
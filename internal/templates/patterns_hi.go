@@ -0,0 +1,179 @@
+package templates
+
+import "biz-flow/internal/core"
+
+// hindiPatterns is the curated Hindi pattern set. It has the platform × goal
+// and type × goal entries; combinations with an English override use the
+// platform × goal entry instead. {place} comes before {product} because the
+// place slot ends in a postposition ("जयपुर में").
+func hindiPatterns() map[Key][]Pattern {
+	return map[Key][]Pattern{
+		// Platform × goal
+		{Platform: core.Instagram, Goal: core.Awareness}: {
+			{
+				Hook:    "POV: आपको {place} {product} की सबसे पसंदीदा जगह मिल गई ✨",
+				Caption: "स्वाइप करें और देखें कि हम {product} कैसे तैयार करते हैं। हम हर हफ़्ते {offer} शेयर करते हैं, इसलिए इस पोस्ट को सेव कर लें।",
+				CTA:     "और देखने के लिए फ़ॉलो करें और उस दोस्त को टैग करें जिसे यह पसंद आएगा।",
+			},
+			{
+				Hook:    "{product} के बारे में 3 बातें जो कोई नहीं बताता",
+				Caption: "हमने {place} {product} को बेहतर बनाने में सालों लगाए हैं। इस कैरसेल में हम अपनी सीख शेयर कर रहे हैं, साथ में {offer} भी।",
+				CTA:     "इस पोस्ट को सेव करें और हमें फ़ॉलो करें।",
+			},
+		},
+		{Platform: core.Instagram, Goal: core.Sales}: {
+			{
+				Hook:    "{product} अब बस एक टैप दूर",
+				Caption: "{place} {product}, जब चाहें तब। सिर्फ़ इस हफ़्ते: {offer}।",
+				CTA:     "खत्म होने से पहले ऑर्डर करने के लिए बायो में दिए लिंक पर टैप करें।",
+			},
+		},
+		{Platform: core.Facebook, Goal: core.Awareness}: {
+			{
+				Hook:    "क्या आप {place} {product} के पीछे के अपने पड़ोसियों से मिले हैं?",
+				Caption: "हम एक छोटी टीम हैं जिसे {product} से लगाव है। आपके लिए {offer}। इसे किसी ऐसे व्यक्ति के साथ शेयर करें जिसे हमारे बारे में जानना चाहिए!",
+				CTA:     "और देखने के लिए हमारा पेज लाइक करें।",
+			},
+		},
+		{Platform: core.Facebook, Goal: core.Sales}: {
+			{
+				Hook:    "क्या आप {place} {product} ढूँढ रहे हैं?",
+				Caption: "हमें आपकी मदद करके खुशी होगी। अभी हमारे पास {offer} है, और हम हर मैसेज का जवाब खुद देते हैं।",
+				CTA:     "शुरू करने के लिए हमें मैसेज भेजें या Shop Now पर क्लिक करें।",
+			},
+		},
+		{Platform: core.TikTok, Goal: core.Awareness}: {
+			{
+				Hook:    "देखिए हम {product} कैसे बनाते हैं 👀",
+				Caption: "{place} {product} के एक छोटे व्यवसाय का एक दिन। {offer} के लिए फ़ॉलो करें।",
+				CTA:     "पार्ट 2 के लिए फ़ॉलो करें!",
+			},
+			{
+				Hook:    "{product} का व्यवसाय शुरू करने से पहले काश मुझे ये बातें पता होतीं",
+				Caption: "{place} एक छोटे व्यवसाय की सच्ची बातें। {offer} के लिए आख़िर तक देखें।",
+				CTA:     "अपने सवाल कमेंट करें और ज़्यादा के लिए फ़ॉलो करें।",
+			},
+		},
+		{Platform: core.TikTok, Goal: core.Sales}: {
+			{
+				Hook:    "{place} {product} आज़माने का यही सही मौका है",
+				Caption: "{offer} के लिए आख़िर तक देखें। जगहें सीमित हैं, इसलिए देर न करें।",
+				CTA:     "पाने के लिए बायो में दिए लिंक पर टैप करें।",
+			},
+		},
+		{Platform: core.GoogleBusiness, Goal: core.Awareness}: {
+			{
+				Hook:    "{place} {product} के लिए आपकी अपनी जगह",
+				Caption: "हमसे मिलने आइए। हमें अपने मोहल्ले की सेवा करने पर गर्व है और हम हमेशा खुशी से {offer} शेयर करते हैं।",
+				CTA:     "रास्ता देखें और हमें अपने मैप्स में सेव करें।",
+			},
+		},
+		{Platform: core.GoogleBusiness, Goal: core.Sales}: {
+			{
+				Hook:    "{place} {product} पर {offer}",
+				Caption: "इसका फ़ायदा उठाने के लिए आते या कॉल करते समय इस पोस्ट का ज़िक्र करें। हमारा समय और रिव्यू देखें और जानें कि पड़ोसी बार-बार क्यों आते हैं।",
+				CTA:     "अभी कॉल करें या रास्ता देखें।",
+			},
+		},
+		{Platform: core.WhatsApp, Goal: core.Awareness}: {
+			{
+				Hook:    "नमस्ते! हमसे जुड़ने के लिए धन्यवाद 👋",
+				Caption: "हम आपको कभी-कभी {place} {product} के बारे में अपडेट भेजेंगे, जिसमें {offer} भी शामिल है। कोई स्पैम नहीं, वादा।",
+				CTA:     "कोई भी सवाल हो तो जवाब दें, हम आज ही आपसे बात करेंगे।",
+			},
+		},
+		{Platform: core.WhatsApp, Goal: core.Sales}: {
+			{
+				Hook:    "हमारे खास ग्राहकों के लिए एक छोटी-सी सूचना",
+				Caption: "{place} {product} अब उपलब्ध है, और इस लिस्ट के लोगों के लिए {offer} भी।",
+				CTA:     "अपने लिए बुक करने के लिए हाँ लिखकर जवाब दें।",
+			},
+		},
+		{Platform: core.Email, Goal: core.Awareness}: {
+			{
+				Hook:    "विषय: {product} के पीछे की कहानी",
+				Caption: "सब्सक्राइब करने के लिए धन्यवाद! इस महीने हम बता रहे हैं कि हम {place} {product} कैसे तैयार करते हैं, साथ में {offer} भी।",
+				CTA:     "इस ईमेल का जवाब दें और बताएँ कि आगे आप किस बारे में सुनना चाहेंगे।",
+			},
+		},
+		{Platform: core.Email, Goal: core.Sales}: {
+			{
+				Hook:    "विषय: सिर्फ़ सब्सक्राइबर्स के लिए: {offer}",
+				Caption: "हमारी लिस्ट में होने के लिए धन्यवाद के तौर पर, {product} पर आपके लिए {offer}। यह ऑफ़र ज़्यादा समय तक नहीं रहेगा।",
+				CTA:     "अपना ऑफ़र पाने के लिए यहाँ क्लिक करें।",
+			},
+		},
+		{Platform: core.LinkedIn, Goal: core.Awareness}: {
+			{
+				Hook:    "{product} का व्यवसाय चलाकर मैंने ग्राहकों के बारे में क्या सीखा",
+				Caption: "{place} ग्राहकों के साथ काम करने के बाद एक सबक सबसे अलग है: लोग उन्हीं से खरीदते हैं जिन पर उन्हें भरोसा होता है। आपके लिए {offer}।",
+				CTA:     "छोटे व्यवसाय की ज़मीनी सीख के लिए फ़ॉलो करें।",
+			},
+		},
+		{Platform: core.LinkedIn, Goal: core.Sales}: {
+			{
+				Hook:    "क्या आपकी टीम अब भी {product} के बिना जूझ रही है?",
+				Caption: "हम {place} व्यवसायों को {product} से नतीजे पाने में मदद करते हैं। इस तिमाही हम {offer} दे रहे हैं।",
+				CTA:     "15 मिनट की कॉल बुक करने के लिए मुझे मैसेज करें।",
+			},
+		},
+		{Platform: core.YouTube, Goal: core.Awareness}: {
+			{
+				Hook:    "पर्दे के पीछे: {place} {product} का एक हफ़्ता",
+				Caption: "इस वीडियो में हम दिखाते हैं कि हम कैसे काम करते हैं और आपके आम सवालों के जवाब देते हैं। {offer} के लिए आख़िर तक देखें।",
+				CTA:     "अगले एपिसोड के लिए सब्सक्राइब करें और नोटिफ़िकेशन चालू करें।",
+			},
+		},
+		{Platform: core.YouTube, Goal: core.Sales}: {
+			{
+				Hook:    "{product}: क्या यह पैसे के लायक है? एक ईमानदार झलक",
+				Caption: "हम दिखाते हैं कि आपको ठीक-ठीक क्या मिलता है, यह किसके लिए है और किसके लिए नहीं। {offer} की जानकारी डिस्क्रिप्शन में है।",
+				CTA:     "ऑर्डर करने के लिए डिस्क्रिप्शन में दिए लिंक का इस्तेमाल करें।",
+			},
+		},
+
+		// Business type × goal, for platforms added by configuration
+		{Type: core.Retail, Goal: core.Awareness}: {
+			{
+				Hook:    "{product} के पीछे के लोगों से मिलिए",
+				Caption: "{place} {product} का हर पीस ध्यान से चुना जाता है। {offer} के लिए हमें फ़ॉलो करें।",
+				CTA:     "नई चीज़ें सबसे पहले देखने के लिए हमें फ़ॉलो करें।",
+			},
+		},
+		{Type: core.Retail, Goal: core.Sales}: {
+			{
+				Hook:    "नया आया: {place} {product}",
+				Caption: "नया स्टॉक अभी-अभी आया है, और कुछ ही समय के लिए {offer}।",
+				CTA:     "खत्म होने से पहले अभी खरीदें।",
+			},
+		},
+		{Type: core.Service, Goal: core.Awareness}: {
+			{
+				Hook:    "{place} {product} का एक दिन असल में कैसा होता है",
+				Caption: "हमें अपना काम और वे लोग पसंद हैं जिनके लिए हम यह करते हैं। आपके लिए {offer}।",
+				CTA:     "टिप्स और कहानियों के लिए हमें फ़ॉलो करें।",
+			},
+		},
+		{Type: core.Service, Goal: core.Sales}: {
+			{
+				Hook:    "{place} {product} चाहिए? इस हफ़्ते हमारे पास समय है",
+				Caption: "ऐसी टीम के साथ बुक करें जो समय पर आती है और काम सही करती है। नए ग्राहकों के लिए {offer}।",
+				CTA:     "आज ही अपना अपॉइंटमेंट बुक करें।",
+			},
+		},
+		{Type: core.Digital, Goal: core.Awareness}: {
+			{
+				Hook:    "जानिए {product} से हर हफ़्ते घंटों की बचत कैसे होती है",
+				Caption: "हमने {product} अपनी ही एक समस्या हल करने के लिए बनाया। आपके लिए {offer}।",
+				CTA:     "टिप्स और अपडेट के लिए फ़ॉलो करें।",
+			},
+		},
+		{Type: core.Digital, Goal: core.Sales}: {
+			{
+				Hook:    "समय बर्बाद करना बंद करें: {product} आज़माएँ",
+				Caption: "तुरंत एक्सेस, सेटअप की कोई झंझट नहीं। अभी आप {offer} पा सकते हैं।",
+				CTA:     "आज ही साइन अप करें और मिनटों में शुरू करें।",
+			},
+		},
+	}
+}
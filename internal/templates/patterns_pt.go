@@ -0,0 +1,178 @@
+package templates
+
+import "biz-flow/internal/core"
+
+// portuguesePatterns is the curated Brazilian Portuguese pattern set. It has
+// the platform × goal and type × goal entries; combinations with an English
+// override use the platform × goal entry instead.
+func portuguesePatterns() map[Key][]Pattern {
+	return map[Key][]Pattern{
+		// Platform × goal
+		{Platform: core.Instagram, Goal: core.Awareness}: {
+			{
+				Hook:    "POV: você acabou de achar seu novo lugar favorito de {product} {place} ✨",
+				Caption: "Arraste para ver o que existe por trás do nosso trabalho com {product}. Toda semana compartilhamos {offer}, então salve este post.",
+				CTA:     "Siga para ver mais e marque um amigo que ia amar isso.",
+			},
+			{
+				Hook:    "3 coisas que ninguém te conta sobre {product}",
+				Caption: "Passamos anos aperfeiçoando {product} {place}. Neste carrossel mostramos o que aprendemos e ainda compartilhamos {offer}.",
+				CTA:     "Salve este post e siga a gente.",
+			},
+		},
+		{Platform: core.Instagram, Goal: core.Sales}: {
+			{
+				Hook:    "Seu novo favorito em {product} está a um toque",
+				Caption: "{Product} {place}, quando você quiser. Só nesta semana: {offer}.",
+				CTA:     "Toque no link da bio e peça antes que acabe.",
+			},
+		},
+		{Platform: core.Facebook, Goal: core.Awareness}: {
+			{
+				Hook:    "Já conhece os vizinhos por trás de {product} {place}?",
+				Caption: "Somos uma equipe pequena e apaixonada por {product}. Aqui vai {offer}. Compartilhe com alguém que precisa conhecer a gente!",
+				CTA:     "Curta nossa página para ver mais.",
+			},
+		},
+		{Platform: core.Facebook, Goal: core.Sales}: {
+			{
+				Hook:    "Procurando {product} {place}?",
+				Caption: "Adoraríamos ajudar. Agora temos {offer} e respondemos cada mensagem pessoalmente.",
+				CTA:     "Mande uma mensagem ou clique em Comprar agora para começar.",
+			},
+		},
+		{Platform: core.TikTok, Goal: core.Awareness}: {
+			{
+				Hook:    "Espera só pra ver como a gente faz {product} 👀",
+				Caption: "Um dia na vida de um pequeno negócio de {product} {place}. Siga para ver {offer}.",
+				CTA:     "Siga para a parte 2!",
+			},
+			{
+				Hook:    "O que eu queria saber antes de abrir um negócio de {product}",
+				Caption: "Papo reto de um pequeno negócio {place}. Fique até o fim para ver {offer}.",
+				CTA:     "Comente suas dúvidas e siga para ver mais.",
+			},
+		},
+		{Platform: core.TikTok, Goal: core.Sales}: {
+			{
+				Hook:    "Este é o seu sinal para experimentar {product} {place}",
+				Caption: "Assista até o fim para descobrir {offer}. As vagas são limitadas, então não espere.",
+				CTA:     "Toque no link da bio para garantir o seu.",
+			},
+		},
+		{Platform: core.GoogleBusiness, Goal: core.Awareness}: {
+			{
+				Hook:    "Seu lugar de confiança para {product} {place}",
+				Caption: "Venha nos visitar. Temos orgulho de atender o bairro e sempre compartilhamos {offer} com prazer.",
+				CTA:     "Veja como chegar e salve a gente no mapa.",
+			},
+		},
+		{Platform: core.GoogleBusiness, Goal: core.Sales}: {
+			{
+				Hook:    "{Offer} em {product} {place}",
+				Caption: "Mencione este post quando vier ou ligar para aproveitar. Confira nosso horário e avaliações para ver por que os vizinhos sempre voltam.",
+				CTA:     "Ligue agora ou veja como chegar.",
+			},
+		},
+		{Platform: core.WhatsApp, Goal: core.Awareness}: {
+			{
+				Hook:    "Oi! Obrigado por falar com a gente 👋",
+				Caption: "De vez em quando vamos mandar novidades sobre {product} {place}, incluindo {offer}. Sem spam, prometemos.",
+				CTA:     "Responda com qualquer dúvida e a gente retorna hoje mesmo.",
+			},
+		},
+		{Platform: core.WhatsApp, Goal: core.Sales}: {
+			{
+				Hook:    "Aviso rápido para nossos clientes favoritos",
+				Caption: "{Product} {place} já está disponível, com {offer} para quem está nesta lista.",
+				CTA:     "Responda SIM para reservar o seu.",
+			},
+		},
+		{Platform: core.Email, Goal: core.Awareness}: {
+			{
+				Hook:    "Assunto: A história por trás de {product}",
+				Caption: "Obrigado por assinar! Este mês contamos como preparamos {product} {place} e ainda compartilhamos {offer}.",
+				CTA:     "Responda este e-mail e conte o que você quer ver na próxima.",
+			},
+		},
+		{Platform: core.Email, Goal: core.Sales}: {
+			{
+				Hook:    "Assunto: Só para assinantes: {offer}",
+				Caption: "Como agradecimento por estar na nossa lista, aqui está {offer} em {product}. Não vai durar muito.",
+				CTA:     "Clique aqui para garantir sua oferta.",
+			},
+		},
+		{Platform: core.LinkedIn, Goal: core.Awareness}: {
+			{
+				Hook:    "O que tocar um negócio de {product} me ensinou sobre clientes",
+				Caption: "Depois de trabalhar com clientes {place}, uma lição se destaca: as pessoas compram de quem confiam. Aqui vai {offer}.",
+				CTA:     "Siga para mais lições da linha de frente dos pequenos negócios.",
+			},
+		},
+		{Platform: core.LinkedIn, Goal: core.Sales}: {
+			{
+				Hook:    "Sua equipe ainda trabalha sem {product}?",
+				Caption: "Ajudamos empresas {place} a ter resultados com {product}. Neste trimestre oferecemos {offer}.",
+				CTA:     "Me mande uma mensagem para agendar uma conversa de 15 minutos.",
+			},
+		},
+		{Platform: core.YouTube, Goal: core.Awareness}: {
+			{
+				Hook:    "Bastidores: uma semana de {product} {place}",
+				Caption: "Neste vídeo mostramos como trabalhamos e respondemos às suas dúvidas mais comuns. Fique até o fim para ver {offer}.",
+				CTA:     "Inscreva-se e ative as notificações para o próximo episódio.",
+			},
+		},
+		{Platform: core.YouTube, Goal: core.Sales}: {
+			{
+				Hook:    "{Product} vale a pena? Uma análise honesta",
+				Caption: "Mostramos exatamente o que você recebe, para quem é e para quem não é. Os detalhes de {offer} estão na descrição.",
+				CTA:     "Use o link da descrição para fazer seu pedido.",
+			},
+		},
+
+		// Business type × goal, for platforms added by configuration
+		{Type: core.Retail, Goal: core.Awareness}: {
+			{
+				Hook:    "Conheça quem está por trás de {product}",
+				Caption: "Cada peça de {product} {place} é escolhida com carinho. Siga a gente para ver {offer}.",
+				CTA:     "Siga a gente para ver as novidades primeiro.",
+			},
+		},
+		{Type: core.Retail, Goal: core.Sales}: {
+			{
+				Hook:    "Novidade: {product} {place}",
+				Caption: "Acabou de chegar estoque novo e, por pouco tempo, temos {offer}.",
+				CTA:     "Compre agora antes que acabe.",
+			},
+		},
+		{Type: core.Service, Goal: core.Awareness}: {
+			{
+				Hook:    "Como é de verdade um dia de {product} {place}",
+				Caption: "Amamos o que fazemos e as pessoas para quem fazemos. Aqui vai {offer}.",
+				CTA:     "Siga a gente para dicas e histórias.",
+			},
+		},
+		{Type: core.Service, Goal: core.Sales}: {
+			{
+				Hook:    "Precisa de {product} {place}? Temos horários esta semana",
+				Caption: "Agende com uma equipe pontual que faz o trabalho direito. Clientes novos ganham {offer}.",
+				CTA:     "Agende seu horário hoje.",
+			},
+		},
+		{Type: core.Digital, Goal: core.Awareness}: {
+			{
+				Hook:    "Veja como {product} economiza horas da sua semana",
+				Caption: "Criamos {product} para resolver um problema que nós mesmos tínhamos. Aproveite {offer}.",
+				CTA:     "Siga a gente para dicas e novidades.",
+			},
+		},
+		{Type: core.Digital, Goal: core.Sales}: {
+			{
+				Hook:    "Pare de perder tempo: experimente {product}",
+				Caption: "Acesso imediato, sem dor de cabeça com configuração. Agora você pode ganhar {offer}.",
+				CTA:     "Cadastre-se hoje e comece em minutos.",
+			},
+		},
+	}
+}
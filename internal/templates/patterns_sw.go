@@ -0,0 +1,179 @@
+package templates
+
+import "biz-flow/internal/core"
+
+// swahiliPatterns is the curated Swahili pattern set. It has the platform ×
+// goal and type × goal entries; combinations with an English override use
+// the platform × goal entry instead. Sentences avoid agreeing with {product}
+// because its noun class is unknown.
+func swahiliPatterns() map[Key][]Pattern {
+	return map[Key][]Pattern{
+		// Platform × goal
+		{Platform: core.Instagram, Goal: core.Awareness}: {
+			{
+				Hook:    "Umepata sehemu yako mpya ya {product} {place} ✨",
+				Caption: "Telezesha uone jinsi tunavyoandaa {product}. Kila wiki tunashiriki {offer}, kwa hivyo hifadhi chapisho hili.",
+				CTA:     "Tufuate kwa zaidi na umtaje rafiki atakayependa hili.",
+			},
+			{
+				Hook:    "Mambo 3 ambayo hakuna anayekuambia kuhusu {product}",
+				Caption: "Tumetumia miaka kuboresha {product} {place}. Katika picha hizi tunashiriki tulichojifunza, pamoja na {offer}.",
+				CTA:     "Hifadhi chapisho hili na utufuate.",
+			},
+		},
+		{Platform: core.Instagram, Goal: core.Sales}: {
+			{
+				Hook:    "Pata {product} kwa mguso mmoja tu",
+				Caption: "{Product} {place}, wakati wowote unapotaka. Wiki hii pekee: {offer}.",
+				CTA:     "Gusa kiungo kwenye wasifu wetu uagize kabla ofa haijaisha.",
+			},
+		},
+		{Platform: core.Facebook, Goal: core.Awareness}: {
+			{
+				Hook:    "Je, umewafahamu majirani walio nyuma ya {product} {place}?",
+				Caption: "Sisi ni timu ndogo inayojali {product}. Tunakuletea {offer}. Shiriki hili na mtu anayepaswa kutufahamu!",
+				CTA:     "Penda ukurasa wetu uone zaidi.",
+			},
+		},
+		{Platform: core.Facebook, Goal: core.Sales}: {
+			{
+				Hook:    "Unatafuta {product} {place}?",
+				Caption: "Tungependa kukusaidia. Kwa sasa tuna {offer}, na tunajibu kila ujumbe sisi wenyewe.",
+				CTA:     "Tutumie ujumbe au bofya Nunua Sasa uanze.",
+			},
+		},
+		{Platform: core.TikTok, Goal: core.Awareness}: {
+			{
+				Hook:    "Subiri uone jinsi tunavyotengeneza {product} 👀",
+				Caption: "Siku moja katika maisha ya biashara ndogo ya {product} {place}. Tufuate upate {offer}.",
+				CTA:     "Tufuate kwa sehemu ya 2!",
+			},
+			{
+				Hook:    "Mambo ningependa kujua kabla ya kuanzisha biashara ya {product}",
+				Caption: "Ukweli mtupu kutoka kwa biashara ndogo {place}. Baki nasi upate {offer}.",
+				CTA:     "Andika maswali yako kwenye maoni na utufuate kwa zaidi.",
+			},
+		},
+		{Platform: core.TikTok, Goal: core.Sales}: {
+			{
+				Hook:    "Hii ni ishara yako ya kujaribu {product} {place}",
+				Caption: "Tazama hadi mwisho upate {offer}. Nafasi ni chache, kwa hivyo usisubiri.",
+				CTA:     "Gusa kiungo kwenye wasifu wetu ujipatie sasa.",
+			},
+		},
+		{Platform: core.GoogleBusiness, Goal: core.Awareness}: {
+			{
+				Hook:    "Mahali pako pa karibu pa {product} {place}",
+				Caption: "Karibu utembelee. Tunajivunia kuhudumia mtaa wetu na daima tunafurahi kushiriki {offer}.",
+				CTA:     "Pata maelekezo na utuhifadhi kwenye ramani zako.",
+			},
+		},
+		{Platform: core.GoogleBusiness, Goal: core.Sales}: {
+			{
+				Hook:    "{Offer} kwa {product} {place}",
+				Caption: "Taja chapisho hili unapotutembelea au kupiga simu ili kunufaika. Angalia saa zetu za kazi na maoni ya wateja uone kwa nini majirani hurudi.",
+				CTA:     "Piga simu sasa au pata maelekezo.",
+			},
+		},
+		{Platform: core.WhatsApp, Goal: core.Awareness}: {
+			{
+				Hook:    "Habari! Asante kwa kuwasiliana nasi 👋",
+				Caption: "Tutakutumia habari mara kwa mara kuhusu {product} {place}, ikiwemo {offer}. Hatutakusumbua, tunaahidi.",
+				CTA:     "Tuma swali lolote nasi tutakujibu leo.",
+			},
+		},
+		{Platform: core.WhatsApp, Goal: core.Sales}: {
+			{
+				Hook:    "Taarifa fupi kwa wateja wetu tuwapendao",
+				Caption: "Sasa unaweza kupata {product} {place}, pamoja na {offer} kwa walio kwenye orodha hii.",
+				CTA:     "Jibu NDIYO ili tukuwekee.",
+			},
+		},
+		{Platform: core.Email, Goal: core.Awareness}: {
+			{
+				Hook:    "Mada: Hadithi nyuma ya {product}",
+				Caption: "Asante kwa kujiunga! Mwezi huu tunakusimulia jinsi tunavyoandaa {product} {place}, pamoja na {offer}.",
+				CTA:     "Jibu barua hii utuambie ungependa kusikia nini baadaye.",
+			},
+		},
+		{Platform: core.Email, Goal: core.Sales}: {
+			{
+				Hook:    "Mada: Kwa wanachama pekee: {offer}",
+				Caption: "Kama shukrani kwa kuwa kwenye orodha yetu, tunakupa {offer} kwa {product}. Ofa hii haitadumu muda mrefu.",
+				CTA:     "Bofya hapa kupata ofa yako.",
+			},
+		},
+		{Platform: core.LinkedIn, Goal: core.Awareness}: {
+			{
+				Hook:    "Kile ambacho kuendesha biashara ya {product} kilinifundisha kuhusu wateja",
+				Caption: "Baada ya kufanya kazi na wateja {place}, somo moja linajitokeza: watu hununua kutoka kwa wale wanaowaamini. Tunakuletea {offer}.",
+				CTA:     "Nifuate upate mafunzo zaidi kutoka mstari wa mbele wa biashara ndogo.",
+			},
+		},
+		{Platform: core.LinkedIn, Goal: core.Sales}: {
+			{
+				Hook:    "Je, timu yako bado inahangaika bila {product}?",
+				Caption: "Tunasaidia biashara {place} kupata matokeo kwa {product}. Robo hii ya mwaka tunatoa {offer}.",
+				CTA:     "Nitumie ujumbe tupange simu ya dakika 15.",
+			},
+		},
+		{Platform: core.YouTube, Goal: core.Awareness}: {
+			{
+				Hook:    "Nyuma ya pazia: wiki moja ya {product} {place}",
+				Caption: "Katika video hii tunaonyesha jinsi tunavyofanya kazi na kujibu maswali yenu ya kawaida. Baki hadi mwisho upate {offer}.",
+				CTA:     "Jiunge na chaneli na uwashe arifa kwa kipindi kijacho.",
+			},
+		},
+		{Platform: core.YouTube, Goal: core.Sales}: {
+			{
+				Hook:    "Je, {product} ni thamani ya pesa yako? Tathmini ya wazi",
+				Caption: "Tunaonyesha hasa utakachopata, ni kwa ajili ya nani na si kwa ajili ya nani. Maelezo ya {offer} yako chini ya video.",
+				CTA:     "Tumia kiungo kilicho chini ya video kuagiza.",
+			},
+		},
+
+		// Business type × goal, for platforms added by configuration
+		{Type: core.Retail, Goal: core.Awareness}: {
+			{
+				Hook:    "Wafahamu watu walio nyuma ya {product}",
+				Caption: "Kila kipande cha {product} {place} huchaguliwa kwa uangalifu. Tufuate upate {offer}.",
+				CTA:     "Tufuate uone bidhaa mpya kwanza.",
+			},
+		},
+		{Type: core.Retail, Goal: core.Sales}: {
+			{
+				Hook:    "Mpya: {product} {place}",
+				Caption: "Mzigo mpya umewasili, na kwa muda mfupi tuna {offer}.",
+				CTA:     "Nunua sasa kabla bidhaa hazijaisha.",
+			},
+		},
+		{Type: core.Service, Goal: core.Awareness}: {
+			{
+				Hook:    "Siku ya {product} {place} ilivyo kwa kweli",
+				Caption: "Tunapenda tunachofanya na watu tunaowahudumia. Tunakuletea {offer}.",
+				CTA:     "Tufuate upate vidokezo na simulizi.",
+			},
+		},
+		{Type: core.Service, Goal: core.Sales}: {
+			{
+				Hook:    "Unahitaji {product} {place}? Tuna nafasi wiki hii",
+				Caption: "Weka miadi na timu inayofika kwa wakati na kufanya kazi ipasavyo. Wateja wapya wanapata {offer}.",
+				CTA:     "Weka miadi yako leo.",
+			},
+		},
+		{Type: core.Digital, Goal: core.Awareness}: {
+			{
+				Hook:    "Jinsi ya kuokoa saa kila wiki kwa {product}",
+				Caption: "Tulitengeneza {product} ili kutatua tatizo tulilokuwa nalo sisi wenyewe. Tunakuletea {offer}.",
+				CTA:     "Tufuate upate vidokezo na habari mpya.",
+			},
+		},
+		{Type: core.Digital, Goal: core.Sales}: {
+			{
+				Hook:    "Acha kupoteza muda: jaribu {product}",
+				Caption: "Ufikiaji wa papo hapo, bila usumbufu wa kusanidi. Sasa hivi unaweza kupata {offer}.",
+				CTA:     "Jisajili leo na uanze kwa dakika chache.",
+			},
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package templates
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"biz-flow/internal/core"
+)
+
+// Slots are the values substituted into a pattern, extracted from the
+// business input
+type Slots struct {
+	// Product is the main noun phrase of the description, e.g. "handmade jewelry"
+	Product string
+	// Place is "in <city>" for local businesses and "online" otherwise, or
+	// empty when the product already says it is online
+	Place string
+	// Offer is a promotion found in the description, or a goal-based default
+	Offer string
+}
+
+// leadingFillers are dropped from the start of an English description before
+// the product phrase is taken
+var leadingFillers = map[string]bool{
+	"a": true, "an": true, "the": true, "our": true, "my": true,
+	"we": true, "i": true, "sell": true, "sells": true, "selling": true,
+	"offer": true, "offers": true, "offering": true, "make": true, "makes": true,
+	"provide": true, "provides": true, "run": true, "runs": true,
+}
+
+// phraseBreaks end the product phrase: what follows describes audience or place
+var phraseBreaks = map[string]bool{
+	"for": true, "in": true, "at": true, "with": true, "to": true, "near": true,
+	"that": true, "who": true, "which": true, "from": true, "and": true, "by": true,
+}
+
+// offerPattern finds English promotions worth repeating verbatim
+var offerPattern = regexp.MustCompile(`(?i)\b(\d+\s?% off[^.,;!]*|free [^.,;!]+|buy one,? get one[^.,;!]*|bogo\b[^.,;!]*|first [^.,;!]* free)`)
+
+// ExtractSlots derives the slot values from the business input, in its locale
+func ExtractSlots(business core.BusinessInput) Slots {
+	lang := languageFor(business)
+	slots := Slots{
+		Product: productPhrase(lang, business.Description),
+		Place:   lang.online,
+		Offer:   lang.offers[business.Goal],
+	}
+	if slots.Offer == "" {
+		slots.Offer = lang.offers[core.Awareness]
+	}
+	if city := City(business); city != "" {
+		slots.Place = fmt.Sprintf(lang.place, city)
+	} else if strings.Contains(" "+slots.Product+" ", " "+lang.online+" ") {
+		// "online Spanish lessons online" reads badly
+		slots.Place = ""
+	}
+	if offer := lang.offerPattern.FindString(business.Description); offer != "" {
+		slots.Offer = strings.ToLower(strings.TrimSpace(offer))
+	}
+	return slots
+}
+
+// City returns the city part of a local business's location, or "" for
+// online-only businesses
+func City(business core.BusinessInput) string {
+	if !business.IsLocal() {
+		return ""
+	}
+	city, _, _ := strings.Cut(business.Location, ",")
+	return strings.TrimSpace(city)
+}
+
+// productPhrase takes the first clause of the description, without leading
+// filler words, up to the first preposition
+func productPhrase(lang *language, description string) string {
+	clause := strings.FieldsFunc(description, func(r rune) bool {
+		return r == '.' || r == ',' || r == ';' || r == ':' || r == '!' || r == '?' || r == '(' || r == '।'
+	})
+	if len(clause) == 0 {
+		return lang.product
+	}
+
+	var words []string
+	for _, word := range strings.Fields(clause[0]) {
+		lower := strings.ToLower(word)
+		if len(words) == 0 && lang.leadingFillers[lower] {
+			continue
+		}
+		if len(words) > 0 && lang.phraseBreaks[lower] {
+			break
+		}
+		if lang.phraseRestarts[lower] {
+			// The place came first; the product follows it
+			words = nil
+			continue
+		}
+		if len(words) == 0 {
+			// Only the sentence-initial capital goes; proper nouns stay
+			word = lowerUnlessAcronym(word)
+		}
+		words = append(words, word)
+	}
+	for len(words) > 0 && lang.trailingFillers[strings.ToLower(words[len(words)-1])] {
+		words = words[:len(words)-1]
+	}
+	if len(words) == 0 {
+		return lang.product
+	}
+	return strings.Join(words, " ")
+}
+
+// lowerUnlessAcronym lowercases a word unless it is written in capitals
+func lowerUnlessAcronym(word string) string {
+	letters, upper := 0, 0
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	if letters > 1 && upper == letters {
+		return word
+	}
+	return strings.ToLower(word)
+}
@@ -0,0 +1,81 @@
+package templates
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/voice"
+)
+
+// typeEmoji is added to the hook when the brand voice welcomes emoji and the
+// pattern has none
+var typeEmoji = map[core.BusinessType]string{
+	core.Retail:  "🛍️",
+	core.Service: "🤝",
+	core.Digital: "💻",
+}
+
+// choose picks the pattern whose filled text best fits the brand voice's
+// tone. Without a tone target it rotates by description; ties go to the
+// pattern the rotation would have picked.
+func choose(patterns []Pattern, business core.BusinessInput, fill *strings.Replacer) Pattern {
+	first := pick(business.Description, len(patterns))
+	target := voice.TargetOf(business.Voice)
+	if !target.HasFormality && !target.HasEnthusiasm {
+		return patterns[first]
+	}
+
+	best, bestDistance := patterns[first], -1.0
+	for i := range patterns {
+		pattern := patterns[(first+i)%len(patterns)]
+		text := fill.Replace(pattern.Hook + "\n" + pattern.Caption + "\n" + pattern.CTA)
+		if distance := target.Distance(voice.Measure(text)); bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = pattern, distance
+		}
+	}
+	return best
+}
+
+// applyVoice adjusts a filled template toward the brand voice: formal and
+// calm voices lose contractions and exclamation marks, enthusiastic and
+// playful ones gain one in the call to action, welcomed emoji are added and a
+// signature phrase closes the caption
+func applyVoice(template *core.ContentTemplate, business core.BusinessInput, lang *language) {
+	profile := business.Voice
+	if profile == nil {
+		return
+	}
+	target := voice.TargetOf(profile)
+	fields := []*string{&template.Hook, &template.Caption, &template.CTA}
+
+	if target.HasFormality && target.Formality >= 0.5 && lang.formal != nil {
+		for _, field := range fields {
+			*field = lang.formal.Replace(*field)
+		}
+	}
+	calm := (target.HasFormality && target.Formality >= 0.5) || (target.HasEnthusiasm && target.Enthusiasm <= -0.5)
+	switch {
+	case calm:
+		for _, field := range fields {
+			*field = strings.ReplaceAll(*field, "!", ".")
+		}
+	case ((target.HasEnthusiasm && target.Enthusiasm >= 0.3) || (target.HasFormality && target.Formality <= -0.5)) &&
+		strings.HasSuffix(template.CTA, "."):
+		template.CTA = strings.TrimSuffix(template.CTA, ".") + "!"
+	}
+
+	if profile.Emoji == core.EmojiGenerous && voice.CountEmoji(template.Hook+template.Caption+template.CTA) == 0 {
+		if emoji, ok := typeEmoji[business.Type]; ok {
+			template.Hook += " " + emoji
+		}
+	}
+
+	if len(profile.SignaturePhrases) > 0 && !voice.UsesSignature(profile, template.Caption+" "+template.CTA) {
+		phrase := strings.TrimSpace(profile.SignaturePhrases[pick(business.Description, len(profile.SignaturePhrases))])
+		if last, _ := utf8.DecodeLastRuneInString(phrase); !strings.ContainsRune(".!?।", last) {
+			phrase += "."
+		}
+		template.Caption = strings.TrimSpace(template.Caption + " " + capitalize(phrase))
+	}
+}
@@ -0,0 +1,278 @@
+package voice
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/i18n"
+)
+
+// Penalties subtracted from a perfect score of 100
+const (
+	bannedWordPenalty   = 20
+	emojiPenalty        = 15
+	missingEmojiPenalty = 5
+	signaturePenalty    = 10
+	stylePenalty        = 5
+	// Tone penalties are per unit of distance from the target, once the
+	// distance exceeds toneTolerance
+	formalityPenalty  = 30
+	enthusiasmPenalty = 15
+	toneTolerance     = 0.3
+)
+
+// Checker holds generated templates to the owner's brand voice
+type Checker struct{}
+
+// NewChecker creates a brand voice checker
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// Check applies the business's brand voice hard rules to a copy of the
+// template, dropping sentences and hashtags with banned words and emoji the
+// policy does not allow, then scores what is left, describing each change and
+// issue in the business's language. Without a voice the template is left alone.
+func (c *Checker) Check(business core.BusinessInput, template *core.ContentTemplate) (*core.ContentTemplate, *core.VoiceScore) {
+	voice := business.Voice
+	if voice == nil || template == nil {
+		return template, nil
+	}
+	checked := *template
+	checked.Hashtags = append([]string{}, template.Hashtags...)
+	score := &core.VoiceScore{}
+	locale := business.Language()
+
+	for _, word := range voice.BannedWords {
+		for _, field := range fields(&checked) {
+			kept := dropSentences(*field.text, word)
+			if kept != *field.text && kept != "" {
+				*field.text = kept
+				score.Adjustments = append(score.Adjustments, i18n.T(locale, "voice.adjust.sentence", i18n.T(locale, "policy.field."+field.name), word))
+			}
+		}
+		tag := strings.ToLower(strings.Join(strings.Fields(word), ""))
+		kept := checked.Hashtags[:0]
+		for _, hashtag := range checked.Hashtags {
+			if strings.Contains(strings.ToLower(hashtag), tag) {
+				score.Adjustments = append(score.Adjustments, i18n.T(locale, "voice.adjust.hashtag", strings.TrimPrefix(hashtag, "#")))
+				continue
+			}
+			kept = append(kept, hashtag)
+		}
+		checked.Hashtags = kept
+	}
+
+	allowed := -1
+	switch voice.Emoji {
+	case core.EmojiNone:
+		allowed = 0
+	case core.EmojiSparing:
+		allowed = 1
+	}
+	if allowed >= 0 {
+		removed := 0
+		for _, field := range fields(&checked) {
+			var n int
+			*field.text, n = stripEmoji(*field.text, &allowed)
+			removed += n
+		}
+		if removed > 0 {
+			score.Adjustments = append(score.Adjustments, i18n.T(locale, "voice.adjust.emoji", removed))
+		}
+	}
+
+	c.score(locale, voice, &checked, score)
+	return &checked, score
+}
+
+// score rates the template against the voice and records each problem
+func (c *Checker) score(locale core.Locale, voice *core.BrandVoice, template *core.ContentTemplate, score *core.VoiceScore) {
+	issue := func(check, field, detail string, penalty float64) {
+		score.Issues = append(score.Issues, core.VoiceIssue{Check: check, Field: field, Detail: detail, Penalty: round(penalty)})
+	}
+
+	for _, word := range voice.BannedWords {
+		for _, field := range fields(template) {
+			if containsWord(*field.text, word) {
+				issue("banned-word", field.name, i18n.T(locale, "voice.issue.banned_word", word), bannedWordPenalty)
+			}
+		}
+	}
+
+	text := template.Hook + "\n" + template.Caption + "\n" + template.CTA
+	switch emoji := CountEmoji(text); {
+	case voice.Emoji == core.EmojiNone && emoji > 0:
+		issue("emoji", "", i18n.T(locale, "voice.issue.emoji_none", emoji), emojiPenalty)
+	case voice.Emoji == core.EmojiSparing && emoji > 1:
+		issue("emoji", "", i18n.T(locale, "voice.issue.emoji_sparing", emoji), emojiPenalty)
+	case voice.Emoji == core.EmojiGenerous && emoji == 0:
+		issue("emoji", "", i18n.T(locale, "voice.issue.emoji_missing"), missingEmojiPenalty)
+	}
+
+	score.Tone = Measure(text)
+	target := TargetOf(voice)
+	if target.HasFormality {
+		if distance := math.Abs(score.Tone.Formality - target.Formality); distance > toneTolerance {
+			issue("formality", "", i18n.T(locale, "voice.issue.tone",
+				describe(locale, score.Tone.Formality, "playful", "formal"), score.Tone.Formality,
+				describe(locale, target.Formality, "playful", "formal"), target.Formality), formalityPenalty*distance)
+		}
+	}
+	if target.HasEnthusiasm {
+		if distance := math.Abs(score.Tone.Enthusiasm - target.Enthusiasm); distance > toneTolerance {
+			issue("enthusiasm", "", i18n.T(locale, "voice.issue.tone",
+				describe(locale, score.Tone.Enthusiasm, "calm", "enthusiastic"), score.Tone.Enthusiasm,
+				describe(locale, target.Enthusiasm, "calm", "enthusiastic"), target.Enthusiasm), enthusiasmPenalty*distance)
+		}
+	}
+
+	if len(voice.SignaturePhrases) > 0 && !UsesSignature(voice, text) {
+		issue("signature", "", i18n.T(locale, "voice.issue.signature"), signaturePenalty)
+	}
+
+	if len(voice.SamplePosts) > 0 {
+		want := averageSentenceLength(strings.Join(voice.SamplePosts, "\n"))
+		got := averageSentenceLength(text)
+		if want > 0 && (got > 2*want || got < want/2) {
+			issue("style", "", i18n.T(locale, "voice.issue.style", got, want), stylePenalty)
+		}
+	}
+
+	total := 100.0
+	for _, found := range score.Issues {
+		total -= found.Penalty
+	}
+	score.Score = math.Round(math.Max(0, total)*10) / 10
+}
+
+// UsesSignature reports whether text contains any of the voice's signature
+// phrases, ignoring case and punctuation
+func UsesSignature(voice *core.BrandVoice, text string) bool {
+	if voice == nil {
+		return false
+	}
+	normalized := normalize(text)
+	for _, phrase := range voice.SignaturePhrases {
+		if phrase = normalize(phrase); phrase != "" && strings.Contains(normalized, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// field is a text field of a content template
+type field struct {
+	name string
+	text *string
+}
+
+func fields(template *core.ContentTemplate) []field {
+	return []field{{"hook", &template.Hook}, {"caption", &template.Caption}, {"cta", &template.CTA}}
+}
+
+// containsWord reports whether text contains word as a whole word or phrase,
+// ignoring case
+func containsWord(text, word string) bool {
+	return wordPattern(word).MatchString(text)
+}
+
+// wordPattern matches word between non-letters. RE2 has no Unicode-aware \b,
+// so the boundaries are spelled out.
+func wordPattern(word string) *regexp.Regexp {
+	quoted := strings.Join(strings.Fields(regexp.QuoteMeta(strings.TrimSpace(word))), `\s+`)
+	return regexp.MustCompile(`(?i)(?:^|[^\pL\pN\pM])` + quoted + `(?:$|[^\pL\pN\pM])`)
+}
+
+// dropSentences removes every sentence that contains word
+func dropSentences(text, word string) string {
+	pattern := wordPattern(word)
+	if !pattern.MatchString(text) {
+		return text
+	}
+	var kept []string
+	for _, sentence := range Sentences(text) {
+		if !pattern.MatchString(sentence) {
+			kept = append(kept, sentence)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// stripEmoji removes emoji beyond the first *allowed, along with the
+// joiners and variation selectors that belonged to them
+func stripEmoji(text string, allowed *int) (string, int) {
+	var b strings.Builder
+	removed := 0
+	dropping := false
+	for _, r := range text {
+		switch {
+		case IsEmoji(r):
+			if *allowed > 0 {
+				*allowed--
+				dropping = false
+				b.WriteRune(r)
+				continue
+			}
+			removed++
+			dropping = true
+		case dropping && (r == 0x200D || r == 0xFE0F || (r >= 0x1F3FB && r <= 0x1F3FF)):
+		default:
+			dropping = false
+			b.WriteRune(r)
+		}
+	}
+	if removed == 0 {
+		return text, 0
+	}
+	return tidy(b.String()), removed
+}
+
+// spaceBeforePunctuation matches the gap a removed emoji leaves before
+// punctuation
+var spaceBeforePunctuation = regexp.MustCompile(` +([.,!?:;।])`)
+
+// tidy collapses the spaces removals leave behind, keeping line breaks
+func tidy(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = spaceBeforePunctuation.ReplaceAllString(strings.Join(strings.Fields(line), " "), "$1")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// normalize lowercases text and reduces it to words separated by spaces
+func normalize(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsMark(r)
+	}), " ")
+}
+
+// averageSentenceLength is the mean number of words per sentence
+func averageSentenceLength(text string) float64 {
+	sentences := Sentences(text)
+	if len(sentences) == 0 {
+		return 0
+	}
+	return float64(len(strings.Fields(text))) / float64(len(sentences))
+}
+
+// describe names where a slider value sits between its two ends, given as
+// the voice.* catalog keys' suffixes
+func describe(locale core.Locale, value float64, low, high string) string {
+	switch {
+	case value <= -0.6:
+		return i18n.T(locale, "voice.very", i18n.T(locale, "voice."+low))
+	case value < -0.2:
+		return i18n.T(locale, "voice."+low)
+	case value >= 0.6:
+		return i18n.T(locale, "voice.very", i18n.T(locale, "voice."+high))
+	case value > 0.2:
+		return i18n.T(locale, "voice."+high)
+	default:
+		return i18n.T(locale, "voice.neutral")
+	}
+}
@@ -0,0 +1,258 @@
+package voice
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"biz-flow/internal/core"
+)
+
+func TestCheck(t *testing.T) {
+	template := core.ContentTemplate{
+		Hook:     "New mugs this week.",
+		Caption:  "Each one is thrown by hand in our studio. Stay cozy.",
+		CTA:      "Visit the shop.",
+		Hashtags: []string{"#mugs", "#pottery"},
+	}
+	tone := Measure(template.Hook + "\n" + template.Caption + "\n" + template.CTA)
+
+	tests := []struct {
+		name        string
+		locale      core.Locale
+		voice       core.BrandVoice
+		edit        func(t *core.ContentTemplate)
+		want        core.ContentTemplate
+		checks      []string // the issues' checks, in order
+		adjustments []string
+		score       float64
+	}{
+		{
+			name:  "on voice",
+			voice: core.BrandVoice{BannedWords: []string{"cheap"}, Emoji: core.EmojiNone, SignaturePhrases: []string{"stay cozy!"}},
+			want:  template,
+			score: 100,
+		},
+		{
+			name:  "banned word sentence and hashtag removed",
+			voice: core.BrandVoice{BannedWords: []string{"studio"}},
+			edit:  func(t *core.ContentTemplate) { t.Hashtags = append(t.Hashtags, "#StudioLife") },
+			want: core.ContentTemplate{
+				Hook: template.Hook, Caption: "Stay cozy.", CTA: template.CTA, Hashtags: []string{"#mugs", "#pottery"},
+			},
+			adjustments: []string{`removed a caption sentence with "studio"`, "removed hashtag #StudioLife"},
+			score:       100,
+		},
+		{
+			name:   "banned word kept when it is the whole field",
+			voice:  core.BrandVoice{BannedWords: []string{"visit"}},
+			want:   template,
+			checks: []string{"banned-word"},
+			score:  80,
+		},
+		{
+			name:  "banned word inside another word",
+			voice: core.BrandVoice{BannedWords: []string{"hand"}},
+			edit:  func(t *core.ContentTemplate) { t.Caption = "Handy, handsome mugs. Stay cozy." },
+			want: core.ContentTemplate{
+				Hook: template.Hook, Caption: "Handy, handsome mugs. Stay cozy.", CTA: template.CTA, Hashtags: template.Hashtags,
+			},
+			score: 100,
+		},
+		{
+			name:  "emoji over the policy removed",
+			voice: core.BrandVoice{Emoji: core.EmojiSparing},
+			edit:  func(t *core.ContentTemplate) { t.Hook = "New mugs ☕ this week 👍🏽!" },
+			want: core.ContentTemplate{
+				Hook: "New mugs ☕ this week!", Caption: template.Caption, CTA: template.CTA, Hashtags: template.Hashtags,
+			},
+			adjustments: []string{"removed 1 emoji"},
+			score:       100,
+		},
+		{
+			name:   "emoji missing",
+			voice:  core.BrandVoice{Emoji: core.EmojiGenerous},
+			want:   template,
+			checks: []string{"emoji"},
+			score:  95,
+		},
+		{
+			name:   "signature missing",
+			voice:  core.BrandVoice{SignaturePhrases: []string{"See you soon"}},
+			want:   template,
+			checks: []string{"signature"},
+			score:  90,
+		},
+		{
+			name:  "tone off target",
+			voice: core.BrandVoice{Tone: core.Tone{Formality: 1, Enthusiasm: -1}},
+			edit: func(t *core.ContentTemplate) {
+				t.Hook, t.Caption, t.CTA = "OMG y'all!!!", "These mugs are SO cute 😍 gonna sell out!", "Grab one now!"
+			},
+			want: core.ContentTemplate{
+				Hook: "OMG y'all!!!", Caption: "These mugs are SO cute 😍 gonna sell out!", CTA: "Grab one now!", Hashtags: template.Hashtags,
+			},
+			checks: []string{"formality", "enthusiasm"},
+			score:  10,
+		},
+		{
+			name:   "sentences much longer than the samples",
+			voice:  core.BrandVoice{Tone: tone, SamplePosts: []string{"Hi. New mugs. Come by. Stay cozy."}},
+			want:   template,
+			checks: []string{"style"},
+			score:  95,
+		},
+		{
+			name:   "Spanish",
+			locale: core.Spanish,
+			voice:  core.BrandVoice{BannedWords: []string{"barato"}, Emoji: core.EmojiNone},
+			edit: func(t *core.ContentTemplate) {
+				t.Hook, t.Caption, t.CTA = "¡Hola! 😍", "Tazas hechas a mano. Nada barato aquí.", "Compra barato"
+				t.Hashtags = []string{"#tazas", "#barato"}
+			},
+			want: core.ContentTemplate{
+				Hook: "¡Hola!", Caption: "Tazas hechas a mano.", CTA: "Compra barato", Hashtags: []string{"#tazas"},
+			},
+			checks: []string{"banned-word"},
+			adjustments: []string{
+				`el texto: se quitó una frase con "barato"`,
+				"se quitó el hashtag #barato",
+				"se quitaron 1 emoji",
+			},
+			score: 80,
+		},
+	}
+
+	checker := NewChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := template
+			input.Hashtags = append([]string{}, template.Hashtags...)
+			if tt.edit != nil {
+				tt.edit(&input)
+			}
+			original := input
+			voice := tt.voice
+			business := core.BusinessInput{Type: core.Retail, Description: "Handmade mugs", Locale: tt.locale, Voice: &voice}
+
+			checked, score := checker.Check(business, &input)
+			if !reflect.DeepEqual(*checked, tt.want) {
+				t.Errorf("Check = %+v, want %+v", *checked, tt.want)
+			}
+			if input.Hook != original.Hook || input.Caption != original.Caption || input.CTA != original.CTA {
+				t.Errorf("template changed to %+v, want a copy checked", input)
+			}
+
+			var checks []string
+			for _, issue := range score.Issues {
+				checks = append(checks, issue.Check)
+				if issue.Detail == "" || issue.Penalty <= 0 {
+					t.Errorf("issue = %+v, want a detail and a penalty", issue)
+				}
+			}
+			if !reflect.DeepEqual(checks, tt.checks) {
+				t.Errorf("issues = %q, want %q", checks, tt.checks)
+			}
+			if !reflect.DeepEqual(score.Adjustments, tt.adjustments) {
+				t.Errorf("Adjustments = %q, want %q", score.Adjustments, tt.adjustments)
+			}
+			if score.Score != tt.score {
+				t.Errorf("Score = %v, want %v", score.Score, tt.score)
+			}
+		})
+	}
+
+	t.Run("no voice", func(t *testing.T) {
+		input := template
+		checked, score := checker.Check(core.BusinessInput{}, &input)
+		if checked != &input || score != nil {
+			t.Errorf("Check = %v, %v, want the template unchanged and no score", checked, score)
+		}
+	})
+}
+
+func TestMeasure(t *testing.T) {
+	tests := []struct {
+		text                  string
+		formality, enthusiasm int // the sign of each slider
+	}{
+		{"", 0, 0},
+		{"OMG y'all!!! These mugs are SO cute 😍🔥 gonna sell out!", -1, 1},
+		{"We are pleased to offer professional consultation services to our clients. Please schedule an appointment.", 1, -1},
+	}
+
+	for _, tt := range tests {
+		tone := Measure(tt.text)
+		if sign(tone.Formality) != tt.formality || sign(tone.Enthusiasm) != tt.enthusiasm {
+			t.Errorf("Measure(%q) = %+v, want signs %d, %d", tt.text, tone, tt.formality, tt.enthusiasm)
+		}
+		if tone.Formality < -1 || tone.Formality > 1 || tone.Enthusiasm < -1 || tone.Enthusiasm > 1 {
+			t.Errorf("Measure(%q) = %+v, want sliders within -1 and 1", tt.text, tone)
+		}
+	}
+}
+
+func TestTargetOf(t *testing.T) {
+	if target := TargetOf(nil); target.HasFormality || target.HasEnthusiasm {
+		t.Errorf("TargetOf(nil) = %+v, want no target", target)
+	}
+
+	voice := &core.BrandVoice{Tone: core.Tone{Formality: 0.5}, SamplePosts: []string{"OMG so excited!!! 🎉"}}
+	target := TargetOf(voice)
+	if !target.HasFormality || target.Formality != 0.5 {
+		t.Errorf("Formality = %v, want the slider's 0.5", target.Formality)
+	}
+	if !target.HasEnthusiasm || target.Enthusiasm <= 0 {
+		t.Errorf("Enthusiasm = %v, want the sample posts' enthusiasm", target.Enthusiasm)
+	}
+	if distance := target.Distance(target.Tone); distance != 0 {
+		t.Errorf("Distance to itself = %v, want 0", distance)
+	}
+}
+
+func TestSentences(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"One. Two!\nThree", []string{"One.", "Two!", "Three"}},
+		{"Prices from $4.50 today.", []string{"Prices from $4.50 today."}},
+		{"ताज़ा ब्रेड। आज ही आएं।", []string{"ताज़ा ब्रेड।", "आज ही आएं।"}},
+	}
+
+	for _, tt := range tests {
+		if got := Sentences(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Sentences(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestUsesSignature(t *testing.T) {
+	voice := &core.BrandVoice{SignaturePhrases: []string{"Stay cozy!"}}
+	for text, want := range map[string]bool{
+		"See you soon. STAY, cozy.": true,
+		"Stay warm.":                false,
+		"":                          false,
+	} {
+		if got := UsesSignature(voice, text); got != want {
+			t.Errorf("UsesSignature(%q) = %v, want %v", text, got, want)
+		}
+	}
+	if UsesSignature(nil, "Stay cozy") {
+		t.Error("UsesSignature(nil) = true, want false")
+	}
+	if strings.Contains(normalize("Stay, cozy!"), ",") {
+		t.Error("normalize kept punctuation")
+	}
+}
+
+func sign(x float64) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,190 @@
+// Package voice measures how generated content sounds and checks it against
+// the owner's brand voice profile. It runs offline on word lists and
+// punctuation; the word lists are English, so in other locales tone comes
+// from punctuation, emoji and sentence length alone.
+package voice
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"biz-flow/internal/core"
+)
+
+// Word lists that mark copy as playful, formal or enthusiastic
+var (
+	casualWords = set("hey", "hi", "yay", "omg", "lol", "gonna", "wanna", "gotta", "y'all", "awesome", "super",
+		"totally", "vibes", "vibe", "pov", "obsessed", "bestie", "fam", "cute", "yum", "yummy", "woohoo", "tbh",
+		"btw", "literally", "stuff", "guys", "folks", "cool", "epic", "fave", "psst", "oops", "ooh", "whoa")
+	formalWords = set("please", "kindly", "ensure", "provide", "provides", "providing", "professional",
+		"services", "clients", "furthermore", "therefore", "accordingly", "regarding", "expertise", "assist",
+		"dedicated", "committed", "inquire", "enquire", "consultation", "appointment", "pleased", "welcome",
+		"offer", "offers", "experienced", "qualified", "reliable", "trusted", "delighted", "invite", "invites")
+	intensifiers = set("so", "super", "really", "amazing", "love", "best", "incredible", "excited", "thrilled",
+		"wow", "can't", "favorite", "favourite", "obsessed", "huge", "absolutely", "unbelievable", "perfect",
+		"delicious", "gorgeous", "stunning", "new", "now", "finally")
+	contractionSuffixes = []string{"'s", "'re", "'ll", "'ve", "'d", "n't", "'m"}
+)
+
+// Measure places text on the brand voice sliders
+func Measure(text string) core.Tone {
+	text = strings.ReplaceAll(text, "’", "'")
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsMark(r) && r != '\''
+	})
+	if len(words) == 0 {
+		return core.Tone{}
+	}
+
+	var contractions, casual, formal, intense, shouted, letters int
+	for _, word := range words {
+		lower := strings.ToLower(strings.Trim(word, "'"))
+		letters += len([]rune(lower))
+		for _, suffix := range contractionSuffixes {
+			if len(lower) > len(suffix) && strings.HasSuffix(lower, suffix) {
+				contractions++
+				break
+			}
+		}
+		if casualWords[lower] {
+			casual++
+		}
+		if formalWords[lower] {
+			formal++
+		}
+		if intensifiers[lower] {
+			intense++
+		}
+		if len([]rune(word)) >= 3 && strings.ToUpper(word) == word && strings.ToLower(word) != word {
+			shouted++
+		}
+	}
+	exclaims := strings.Count(text, "!")
+	emoji := CountEmoji(text)
+
+	n := float64(len(words))
+	averageWord := float64(letters) / n
+	averageSentence := n / float64(max(len(Sentences(text)), 1))
+
+	playful := float64(exclaims+emoji+contractions+2*casual+shouted) / n
+	formality := 3*(1.5*float64(formal)/n-playful) + 0.25*(averageWord-4.5) + 0.03*(averageSentence-12)
+	enthusiasm := 5*float64(2*exclaims+emoji+intense+shouted)/n - 0.3
+	return core.Tone{Formality: round(clamp(formality)), Enthusiasm: round(clamp(enthusiasm))}
+}
+
+// Target is the tone a voice asks for. Sliders left at 0 take the average
+// tone of the sample posts; an axis with neither has no target.
+type Target struct {
+	core.Tone
+	HasFormality  bool
+	HasEnthusiasm bool
+}
+
+// TargetOf returns the tone the voice asks for
+func TargetOf(voice *core.BrandVoice) Target {
+	var target Target
+	if voice == nil {
+		return target
+	}
+	target.Tone = voice.Tone
+	target.HasFormality = voice.Tone.Formality != 0
+	target.HasEnthusiasm = voice.Tone.Enthusiasm != 0
+
+	if len(voice.SamplePosts) > 0 && (!target.HasFormality || !target.HasEnthusiasm) {
+		var sampled core.Tone
+		for _, post := range voice.SamplePosts {
+			tone := Measure(post)
+			sampled.Formality += tone.Formality / float64(len(voice.SamplePosts))
+			sampled.Enthusiasm += tone.Enthusiasm / float64(len(voice.SamplePosts))
+		}
+		if !target.HasFormality {
+			target.Formality, target.HasFormality = round(sampled.Formality), true
+		}
+		if !target.HasEnthusiasm {
+			target.Enthusiasm, target.HasEnthusiasm = round(sampled.Enthusiasm), true
+		}
+	}
+	return target
+}
+
+// Distance is how far tone is from the target on the axes it sets. Formality
+// counts for more because readers notice it first.
+func (t Target) Distance(tone core.Tone) float64 {
+	var distance float64
+	if t.HasFormality {
+		distance += math.Abs(tone.Formality - t.Formality)
+	}
+	if t.HasEnthusiasm {
+		distance += 0.6 * math.Abs(tone.Enthusiasm-t.Enthusiasm)
+	}
+	return distance
+}
+
+// Sentences splits text after sentence-ending punctuation and at line breaks
+func Sentences(text string) []string {
+	var sentences []string
+	start := 0
+	runes := []rune(text)
+	for i, r := range runes {
+		end := r == '\n'
+		if (r == '.' || r == '!' || r == '?' || r == '।') && (i+1 == len(runes) || unicode.IsSpace(runes[i+1])) {
+			end = true
+		}
+		if !end {
+			continue
+		}
+		if sentence := strings.TrimSpace(string(runes[start : i+1])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = i + 1
+	}
+	if rest := strings.TrimSpace(string(runes[start:])); rest != "" {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
+
+// IsEmoji reports whether r is a pictographic emoji. Joiners, variation
+// selectors and skin tone modifiers are not emoji on their own.
+func IsEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F3FB && r <= 0x1F3FF:
+		return false
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return true
+	case r >= 0x2600 && r <= 0x27BF:
+		return true
+	case r == 0x2B50 || r == 0x2B55 || r == 0x231A || r == 0x231B || r == 0x23F0 || r == 0x23F3:
+		return true
+	}
+	return false
+}
+
+// CountEmoji counts the emoji in text
+func CountEmoji(text string) int {
+	count := 0
+	for _, r := range text {
+		if IsEmoji(r) {
+			count++
+		}
+	}
+	return count
+}
+
+func set(words ...string) map[string]bool {
+	m := make(map[string]bool, len(words))
+	for _, word := range words {
+		m[word] = true
+	}
+	return m
+}
+
+func clamp(x float64) float64 {
+	return math.Max(-1, math.Min(1, x))
+}
+
+// round keeps two decimals, enough for a slider
+func round(x float64) float64 {
+	return math.Round(x*100) / 100
+}
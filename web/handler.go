@@ -1 +1,227 @@
 package web
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/filters"
+	"biz-flow/internal/reasoning"
+)
+
+//go:embed templates/*.html static/*
+var assets embed.FS
+
+// Consultant runs a consultation for a business, and calibrates it from
+// reported results the way the consultation does
+type Consultant interface {
+	Consult(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error)
+	Calibrate(ctx context.Context, business core.BusinessInput) (core.BusinessInput, error)
+}
+
+// Handler serves the HTML form and results pages
+type Handler struct {
+	consultant Consultant
+	filter     *filters.PlatformFilter
+	explainer  *reasoning.Explainer
+	form       *template.Template
+	result     *template.Template
+}
+
+// NewHandler parses the embedded templates and creates a web handler
+func NewHandler(consultant Consultant) (*Handler, error) {
+	funcs := template.FuncMap{
+		"percent":  percent,
+		"hashtags": hashtags,
+		"join":     strings.Join,
+	}
+
+	form, err := template.New("index.html").Funcs(funcs).ParseFS(assets, "templates/layout.html", "templates/index.html")
+	if err != nil {
+		return nil, fmt.Errorf("parsing form template: %w", err)
+	}
+	result, err := template.New("result.html").Funcs(funcs).ParseFS(assets, "templates/layout.html", "templates/result.html")
+	if err != nil {
+		return nil, fmt.Errorf("parsing result template: %w", err)
+	}
+
+	return &Handler{
+		consultant: consultant,
+		filter:     filters.NewPlatformFilter(),
+		explainer:  reasoning.NewExplainer(),
+		form:       form,
+		result:     result,
+	}, nil
+}
+
+// RegisterRoutes adds the web pages and static assets to the mux
+func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+	static, _ := fs.Sub(assets, "static")
+	mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServer(http.FS(static))))
+	mux.HandleFunc("GET /{$}", h.Form)
+	mux.HandleFunc("POST /consult", h.Consult)
+}
+
+// formPage is the data behind the input form
+type formPage struct {
+	Title         string
+	Error         string
+	Input         core.BusinessInput
+	BusinessTypes []core.BusinessType
+	Goals         []core.MarketingGoal
+	Locales       []core.Locale
+	EmojiPolicies []core.EmojiPolicy
+	Platforms     []core.Platform
+}
+
+// UsesChannel reports whether a channel checkbox should be ticked
+func (p formPage) UsesChannel(platform core.Platform) bool {
+	for _, channel := range p.Input.Channels {
+		if channel == string(platform) {
+			return true
+		}
+	}
+	return false
+}
+
+// Voice returns the brand voice to prefill the form with, empty when none
+func (p formPage) Voice() core.BrandVoice {
+	if p.Input.Voice == nil {
+		return core.BrandVoice{}
+	}
+	return *p.Input.Voice
+}
+
+// resultPage is the data behind the results page
+type resultPage struct {
+	Title     string
+	Input     core.BusinessInput
+	Result    *core.ConsultationResult
+	Filtering map[string]string
+	Trace     []reasoning.PlatformExplanation
+}
+
+// Form renders the empty input form
+func (h *Handler) Form(w http.ResponseWriter, r *http.Request) {
+	h.renderForm(w, http.StatusOK, core.BusinessInput{Type: core.Retail, Goal: core.Awareness}, "")
+}
+
+// Consult runs the pipeline for a submitted form and renders the results
+func (h *Handler) Consult(w http.ResponseWriter, r *http.Request) {
+	business, err := parseForm(r)
+	if err != nil {
+		h.renderForm(w, http.StatusBadRequest, business, err.Error())
+		return
+	}
+
+	// The trace explains the scores of the calibrated business the
+	// consultation ranks, so calibrate once and consult that
+	calibrated, err := h.consultant.Calibrate(r.Context(), business)
+	var result *core.ConsultationResult
+	if err == nil {
+		result, err = h.consultant.Consult(r.Context(), calibrated)
+	}
+	if err != nil {
+		var validationErr *core.ValidationError
+		if errors.As(err, &validationErr) {
+			h.renderForm(w, http.StatusBadRequest, business, err.Error())
+			return
+		}
+		log.Printf("web: consultation failed: %v", err)
+		h.renderForm(w, http.StatusInternalServerError, business, "Something went wrong while preparing your plan. Please try again.")
+		return
+	}
+
+	page := resultPage{
+		Title:     "Your marketing plan",
+		Input:     business,
+		Result:    result,
+		Filtering: h.filter.ExplainFiltering(calibrated),
+		Trace:     make([]reasoning.PlatformExplanation, 0, len(result.Recommendations)),
+	}
+	for _, rec := range result.Recommendations {
+		page.Trace = append(page.Trace, h.explainer.ExplainPlatform(calibrated, rec.Platform))
+	}
+
+	render(w, h.result, http.StatusOK, page)
+}
+
+// renderForm renders the form with the given values and error message
+func (h *Handler) renderForm(w http.ResponseWriter, status int, business core.BusinessInput, message string) {
+	render(w, h.form, status, formPage{
+		Title:         "Get a marketing plan",
+		Error:         message,
+		Input:         business,
+		BusinessTypes: core.BusinessTypes(),
+		Goals:         core.MarketingGoals(),
+		Locales:       core.Locales(),
+		EmojiPolicies: core.EmojiPolicies(),
+		Platforms:     core.GetAllPlatformNames(),
+	})
+}
+
+// parseForm converts the submitted form into a BusinessInput
+func parseForm(r *http.Request) (core.BusinessInput, error) {
+	business := core.BusinessInput{Channels: []string{}}
+	if err := r.ParseForm(); err != nil {
+		return business, errors.New("could not read the form")
+	}
+
+	business.Type = core.BusinessType(r.PostForm.Get("type"))
+	business.Description = strings.TrimSpace(r.PostForm.Get("description"))
+	business.Location = strings.TrimSpace(r.PostForm.Get("location"))
+	business.Goal = core.MarketingGoal(r.PostForm.Get("goal"))
+	business.Locale = core.Locale(r.PostForm.Get("locale"))
+	voice, err := core.VoiceFromForm(r.PostForm)
+	if err != nil {
+		return business, err
+	}
+	business.Voice = voice
+	for _, channel := range r.PostForm["channels"] {
+		if channel = strings.TrimSpace(channel); channel != "" {
+			business.Channels = append(business.Channels, channel)
+		}
+	}
+
+	if raw := strings.TrimSpace(r.PostForm.Get("budget")); raw != "" {
+		budget, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return business, &core.ValidationError{Field: "budget", Message: "must be a number"}
+		}
+		business.Budget = budget
+	}
+
+	return business, business.Validate()
+}
+
+// render executes a page template, logging failures that happen mid-write
+func render(w http.ResponseWriter, tmpl *template.Template, status int, data interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := tmpl.Execute(w, data); err != nil {
+		log.Printf("web: rendering %s: %v", tmpl.Name(), err)
+	}
+}
+
+// percent clamps a 0-100 score for use as a CSS width
+func percent(score float64) int {
+	return int(math.Round(math.Max(0, math.Min(100, score))))
+}
+
+// hashtags renders hashtags with a leading # each
+func hashtags(tags []string) string {
+	formatted := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		formatted = append(formatted, "#"+strings.TrimPrefix(tag, "#"))
+	}
+	return strings.Join(formatted, " ")
+}
@@ -0,0 +1,65 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"biz-flow/internal/core"
+	"biz-flow/internal/reasoning"
+)
+
+// calibratingConsultant marks Instagram as a weak reach platform and records
+// the business it is asked to consult
+type calibratingConsultant struct {
+	consulted core.BusinessInput
+}
+
+func (c *calibratingConsultant) Calibrate(ctx context.Context, business core.BusinessInput) (core.BusinessInput, error) {
+	business.Calibration = map[core.Platform]core.Calibration{
+		core.Instagram: {ReachPotential: 1, ConversionFocus: 1, Results: 4},
+	}
+	return business, nil
+}
+
+func (c *calibratingConsultant) Consult(ctx context.Context, business core.BusinessInput) (*core.ConsultationResult, error) {
+	c.consulted = business
+	return &core.ConsultationResult{
+		Recommendations: []core.Recommendation{{Platform: core.Instagram, Rank: 1, Score: 50}},
+	}, nil
+}
+
+func TestConsultTracesTheCalibratedBusiness(t *testing.T) {
+	consultant := &calibratingConsultant{}
+	handler, err := NewHandler(consultant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	form := url.Values{"type": {"retail"}, "description": {"Handmade ceramic mugs"}, "goal": {"sales"}, "budget": {"100"}}
+	request := httptest.NewRequest(http.MethodPost, "/consult", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder := httptest.NewRecorder()
+	handler.Consult(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200:\n%s", recorder.Code, recorder.Body)
+	}
+	if consultant.consulted.Calibration == nil {
+		t.Fatal("the consultation did not get the calibrated business")
+	}
+	calibrated := reasoning.NewExplainer().ExplainPlatform(consultant.consulted, core.Instagram)
+	uncalibrated := reasoning.NewExplainer().ExplainPlatform(core.BusinessInput{
+		Type: core.Retail, Description: "Handmade ceramic mugs", Goal: core.Sales, Budget: 100, Channels: []string{},
+	}, core.Instagram)
+	want := fmt.Sprintf("Return %.2f", calibrated.Scored.Breakdown.Return)
+	if want == fmt.Sprintf("Return %.2f", uncalibrated.Scored.Breakdown.Return) {
+		t.Fatal("the calibration does not change the return score")
+	}
+	if !strings.Contains(recorder.Body.String(), want) {
+		t.Errorf("the trace does not show %q", want)
+	}
+}
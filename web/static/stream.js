@@ -0,0 +1,148 @@
+// Progressive consultation: streams stage events from /run-agent/stream and
+// renders them as they arrive. Without JavaScript the form posts normally.
+(function () {
+  "use strict";
+
+  var form = document.querySelector("form[data-stream]");
+  var panel = document.getElementById("progress");
+  if (!form || !panel || !window.EventSource) {
+    return;
+  }
+
+  var list = panel.querySelector(".progress-steps");
+  var output = panel.querySelector(".progress-output");
+  var cancel = panel.querySelector(".progress-cancel");
+  var source = null;
+
+  function el(tag, text, className) {
+    var node = document.createElement(tag);
+    if (text !== undefined) {
+      node.textContent = text;
+    }
+    if (className) {
+      node.className = className;
+    }
+    return node;
+  }
+
+  function step(text) {
+    list.appendChild(el("li", text));
+  }
+
+  function section(title) {
+    var card = el("div", undefined, "progress-section");
+    card.appendChild(el("h3", title));
+    output.appendChild(card);
+    return card;
+  }
+
+  function hashtags(tags) {
+    return (tags || []).map(function (tag) { return "#" + tag.replace(/^#/, ""); }).join(" ");
+  }
+
+  function finish(message) {
+    if (source) {
+      source.close();
+      source = null;
+    }
+    cancel.hidden = true;
+    if (message) {
+      step(message);
+    }
+  }
+
+  var handlers = {
+    filtered: function (platforms) {
+      step("Shortlisted " + platforms.length + " platforms: " + platforms.join(", "));
+    },
+    scored: function (ranked) {
+      var card = section("Top platforms");
+      var ol = el("ol", undefined, "recommendations");
+      ranked.forEach(function (item) {
+        var li = el("li");
+        var head = el("div", undefined, "rec-head");
+        head.appendChild(el("span", item.platform, "rec-name"));
+        head.appendChild(el("span", item.score.toFixed(1), "score"));
+        var bar = el("div", undefined, "bar");
+        var fill = el("span");
+        fill.style.width = Math.max(0, Math.min(100, item.score)) + "%";
+        bar.appendChild(fill);
+        li.appendChild(head);
+        li.appendChild(bar);
+        ol.appendChild(li);
+      });
+      card.appendChild(ol);
+      step("Scored the shortlist");
+    },
+    confidence: function (event) {
+      event.recommendations.forEach(function (item) {
+        step(item.platform + ": " + item.confidence.level + " confidence (" + item.confidence.score.toFixed(2) + ")");
+      });
+      if (event.questions && event.questions.length) {
+        var ul = el("ul");
+        event.questions.forEach(function (question) { ul.appendChild(el("li", question.text)); });
+        section("A few questions").appendChild(ul);
+      }
+    },
+    persona: function (persona) {
+      section("Target customer").appendChild(el("p", persona));
+      step("Inferred your target customer");
+    },
+    content: function (event) {
+      var card = section("Content for " + event.platform);
+      card.appendChild(el("p", "Hook: " + event.template.hook));
+      card.appendChild(el("p", "Caption: " + event.template.caption));
+      card.appendChild(el("p", "Call to action: " + event.template.cta));
+      if (event.template.hashtags && event.template.hashtags.length) {
+        card.appendChild(el("p", hashtags(event.template.hashtags), "hashtags"));
+      }
+      step("Drafted content for " + event.platform);
+    },
+    risks: function (risks) {
+      var ul = el("ul", undefined, "risks");
+      risks.forEach(function (risk) { ul.appendChild(el("li", risk)); });
+      section("Risks to watch").appendChild(ul);
+      step("Assessed risks");
+    },
+    advice: function (advice) {
+      section("Strategy").appendChild(el("p", advice));
+    },
+    result: function () {
+      finish("Done.");
+    }
+  };
+
+  form.addEventListener("submit", function (event) {
+    event.preventDefault();
+    finish();
+
+    list.textContent = "";
+    output.textContent = "";
+    panel.hidden = false;
+    cancel.hidden = false;
+    step("Analyzing your business…");
+
+    var params = new URLSearchParams(new FormData(form));
+    source = new EventSource("/run-agent/stream?" + params.toString());
+
+    Object.keys(handlers).forEach(function (name) {
+      source.addEventListener(name, function (message) {
+        handlers[name](JSON.parse(message.data));
+      });
+    });
+
+    source.addEventListener("error", function (message) {
+      if (message.data) {
+        finish("Error: " + JSON.parse(message.data).error);
+        return;
+      }
+      if (source) {
+        finish("Connection lost. Please check your answers and try again.");
+      }
+    });
+  });
+
+  cancel.addEventListener("click", function () {
+    finish("Cancelled.");
+  });
+})();